@@ -0,0 +1,59 @@
+// Command promptreplay replays the recorded AI inputs and responses under
+// testdata/replay through the AI adapters and diffs the prompts and
+// transactions against the golden files, to catch extraction regressions
+// when prompts or parsers change.
+//
+//	go run ./cmd/promptreplay                 # compare with the default prompt version
+//	go run ./cmd/promptreplay -version v2     # compare another prompt version
+//	go run ./cmd/promptreplay -update         # accept the current output as golden
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"money-tracker-bot/internal/adapters/replay"
+	"money-tracker-bot/internal/common"
+	"os"
+	"strings"
+)
+
+func run(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("promptreplay", flag.ContinueOnError)
+	flags.SetOutput(out)
+	dir := flags.String("dir", "testdata/replay", "corpus directory holding fixtures/ and golden/")
+	version := flags.String("version", common.PromptVersion, "prompt version to replay, one of "+strings.Join(common.PromptVersions(), ", "))
+	update := flags.Bool("update", false, "rewrite the golden files with the current output")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	results, err := replay.Replay(replay.Corpus{Dir: *dir}, *version, *update)
+	if err != nil {
+		fmt.Fprintln(out, "replay failed:", err)
+		return 1
+	}
+	changed := 0
+	for _, r := range results {
+		fmt.Fprintf(out, "%-8s %s\n", r.Status, r.Fixture)
+		if r.Diff != "" {
+			changed++
+			fmt.Fprint(out, indent(r.Diff))
+		}
+	}
+	fmt.Fprintf(out, "\n%d fixture(s) replayed with prompt %s, %d changed\n", len(results), *version, changed)
+	if changed > 0 {
+		fmt.Fprintln(out, "Review the diff and run with -update to accept it.")
+		return 1
+	}
+	return 0
+}
+
+func indent(diff string) string {
+	lines := strings.SplitAfter(strings.TrimSuffix(diff, "\n"), "\n")
+	return "    " + strings.Join(lines, "    ") + "\n"
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout))
+}
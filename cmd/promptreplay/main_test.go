@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"money-tracker-bot/internal/common"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	if code := run([]string{"-dir", filepath.Join("..", "..", "testdata", "replay")}, &out); code != 0 {
+		t.Fatalf("expected the recorded corpus to replay cleanly, got %d:\n%s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "replayed with prompt "+common.PromptVersion+", 0 changed") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestRun_Changed(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "fixtures"), 0o755)
+	os.WriteFile(filepath.Join(dir, "fixtures", "kopi.json"), []byte(`{"provider": "offline", "text": "kopi 18k", "date": "2025-07-10"}`), 0o644)
+
+	var out bytes.Buffer
+	if code := run([]string{"-dir", dir}, &out); code != 1 || !strings.Contains(out.String(), "changed  kopi") {
+		t.Errorf("a fixture without golden files should fail, got %d:\n%s", code, out.String())
+	}
+	out.Reset()
+	if code := run([]string{"-dir", dir, "-update"}, &out); code != 0 {
+		t.Errorf("expected the update to succeed, got %d:\n%s", code, out.String())
+	}
+	if code := run([]string{"-dir", dir}, &out); code != 0 {
+		t.Errorf("expected a clean replay after the update, got %d", code)
+	}
+	if code := run([]string{"-version", "v0"}, &out); code != 1 {
+		t.Errorf("expected an unknown version to fail, got %d", code)
+	}
+}
@@ -1,13 +1,46 @@
 package main
 
 import (
+	"context"
+	"io"
 	"log"
+	"log/slog"
+	"money-tracker-bot/internal/adapters/aicache"
+	"money-tracker-bot/internal/adapters/fallback"
+	"money-tracker-bot/internal/adapters/filestore"
 	"money-tracker-bot/internal/adapters/gemini"
 	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/adapters/offline"
+	"money-tracker-bot/internal/adapters/openai"
 	"money-tracker-bot/internal/adapters/telegram"
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/config"
+	category_domain "money-tracker-bot/internal/domain/category"
+	usage_domain "money-tracker-bot/internal/domain/usage"
 	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/health"
+	"money-tracker-bot/internal/logging"
+	"money-tracker-bot/internal/metrics"
+	aiport "money-tracker-bot/internal/port/out/ai"
+	"money-tracker-bot/internal/resilience"
+	"money-tracker-bot/internal/service/advice"
+	"money-tracker-bot/internal/service/categories"
+	"money-tracker-bot/internal/service/currency"
+	"money-tracker-bot/internal/service/digest"
+	"money-tracker-bot/internal/service/goals"
+	"money-tracker-bot/internal/service/learning"
+	"money-tracker-bot/internal/service/recurring"
+	"money-tracker-bot/internal/service/review"
+	"money-tracker-bot/internal/service/rules"
+	"money-tracker-bot/internal/service/settings"
+	"money-tracker-bot/internal/service/split"
 	"money-tracker-bot/internal/service/transactions"
+	"money-tracker-bot/internal/service/usage"
+	"money-tracker-bot/internal/tracing"
+	"net/http"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -19,21 +52,177 @@ import (
 type SpreadsheetService interface{}
 type GeminiClient interface{}
 
-func startBotWithDeps(telegramToken, apiKey string, spreadsheetService SpreadsheetService, geminiClient GeminiClient) error {
-	if telegramToken == "" {
-		return ErrEnvVarMissing("TELEGRAM_BOT_TOKEN")
+func startBotWithDeps(cfg *config.Config, spreadsheetService SpreadsheetService, geminiClient GeminiClient) error {
+	if err := cfg.Validate(); err != nil {
+		return err
 	}
-	if apiKey == "" {
-		return ErrEnvVarMissing("GEMINI_API_KEY")
+	var breakers []*resilience.Breaker
+	newBreaker := func(name string) *resilience.Breaker {
+		b := resilience.NewBreaker(name, cfg.Breaker.FailureThreshold, cfg.Breaker.Cooldown, common.SystemClock{})
+		breakers = append(breakers, b)
+		return b
 	}
+	loc := cfg.Location
+	spreadsheetID := cfg.Sheets.SpreadsheetID
+	dataFile := func(name string) string { return filepath.Join(cfg.Storage.DataDir, name) }
 	// Only run the real bot if using real implementations
-	if s, ok := spreadsheetService.(*spreadsheet.SpreadsheetService); ok {
+	if sheet, ok := spreadsheetService.(*spreadsheet.SpreadsheetService); ok {
+		m := metrics.New()
+		errors.SetObserver(m)
+		s := tracing.TraceSheets(spreadsheet.NewGuarded(m.InstrumentSheets(sheet), newBreaker("sheets")))
+		usageService := usage.NewUsageService(
+			filestore.NewUsageRepository(dataFile("usage.json")),
+			common.SystemClock{},
+			loc,
+			usage_domain.Pricing{PromptPerMillion: cfg.AI.PromptPricePerMTok, ResponsePerMillion: cfg.AI.ResponsePricePerMTok},
+		)
+		usageService.DailyQuota = cfg.AI.DailyTokenQuota
+		usageService.Quotas = cfg.AI.TenantQuotas()
+		settingsService := settings.NewSettingsService(
+			filestore.NewSettingsRepository(dataFile("settings.json")),
+			loc,
+			cfg.Locale,
+		)
+		settingsService.Currency = cfg.Currency
+		sheet.Zones = settingsService
+		sheet.LegacyChat = cfg.Sheets.LegacyChat
 		if g, ok := geminiClient.(*gemini.GeminiClient); ok {
-			transactionService := transactions.NewTransactionService(g, s)
-			telegramHandler, err := telegram.NewTelegramHandler(telegramToken, transactionService)
+			g.Usage = usageService
+			g.Location = loc
+			g.Zones = settingsService
+		}
+		if ai, ok := newAiPort(cfg, geminiClient, settingsService, newBreaker, m); ok {
+			if cfg.AI.CacheTTL > 0 {
+				cache := aicache.New(ai, filestore.New(dataFile("ai_cache.json")), common.SystemClock{}, loc, cfg.AI.CacheTTL, cfg.AI.CacheMaxEntries)
+				cache.Zones = settingsService
+				ai = cache
+			}
+			transactionService := transactions.NewTransactionService(ai, s, spreadsheetID)
+			transactionService.ReviewThreshold = cfg.AI.ReviewThreshold
+			telegramHandler, err := telegram.NewTelegramHandler(cfg.Telegram.Token, transactionService)
+			if err != nil {
+				return err
+			}
+			reviewRepository := filestore.NewReviewRepository(dataFile("review.json"))
+			telegramHandler.Review = review.NewReviewService(
+				reviewRepository,
+				transactionService,
+				common.SystemClock{},
+			)
+			telegramHandler.Usage = usageService
+			telegramHandler.Admins = adminChats(cfg.Telegram.AdminChats)
+			telegramHandler.Debug = cfg.Telegram.Debug
+			telegramHandler.Breakers = breakers
+			telegramHandler.SpreadsheetID = spreadsheetID
+			telegramHandler.DownloadsDir = cfg.Storage.DownloadsDir
+			telegramHandler.Settings = settingsService
+			digestService := digest.NewDigestService(
+				filestore.NewDigestScheduleRepository(dataFile("digest_schedules.json")),
+				s,
+				telegramHandler,
+				common.SystemClock{},
+				spreadsheetID,
+			)
+			digestService.Zones = settingsService
+			digestService.Locales = settingsService
+			digestService.Languages = settingsService
+			telegramHandler.Digests = digestService
+			go digestService.Run(context.Background(), time.Minute)
+
+			recurringService := recurring.NewRecurringService(
+				filestore.NewRecurringRepository(dataFile("recurring.json")),
+				transactionService,
+				s,
+				telegramHandler,
+				common.SystemClock{},
+				loc,
+				spreadsheetID,
+			)
+			recurringService.Zones = settingsService
+			recurringService.Locales = settingsService
+			recurringService.Languages = settingsService
+			telegramHandler.Recurring = recurringService
+			go recurringService.Run(context.Background(), time.Minute)
+
+			goalService := goals.NewGoalService(
+				filestore.NewGoalRepository(dataFile("goals.json")),
+				s,
+				transactionService,
+				common.SystemClock{},
+				loc,
+				spreadsheetID,
+			)
+			goalService.Zones = settingsService
+			telegramHandler.Goals = goalService
+			defaults, err := defaultCategories(cfg.Storage.CategoriesFile)
 			if err != nil {
 				return err
 			}
+			categoryService := categories.NewCategoryService(
+				filestore.NewCategoryRepository(dataFile("categories.json")),
+				s,
+				defaults,
+				spreadsheetID,
+			)
+			transactionService.Categories = categoryService
+			telegramHandler.Categories = categoryService
+
+			ruleService := rules.NewRuleService(
+				filestore.NewRuleRepository(dataFile("rules.json")),
+				common.SystemClock{},
+				loc,
+			)
+			ruleService.Zones = settingsService
+			transactionService.Rules = ruleService
+			telegramHandler.Rules = ruleService
+
+			currencyService := currency.NewCurrencyService(
+				filestore.NewRateRepository(dataFile("rates.json")),
+				common.SystemClock{},
+				loc,
+				cfg.Currency,
+			)
+			currencyService.Zones = settingsService
+			transactionService.Currencies = currencyService
+			telegramHandler.Currency = currencyService
+
+			learningService := learning.NewLearningService(
+				filestore.NewLearningRepository(dataFile("learning.json")),
+				s,
+				common.SystemClock{},
+				loc,
+				spreadsheetID,
+			)
+			learningService.Zones = settingsService
+			transactionService.Learner = learningService
+			telegramHandler.Learning = learningService
+
+			adviceService := advice.NewAdviceService(ai, s, common.SystemClock{}, loc, spreadsheetID)
+			adviceService.Zones = settingsService
+			adviceService.Locales = settingsService
+			telegramHandler.Advice = adviceService
+
+			splitService := split.NewSplitService(
+				filestore.NewSplitRepository(dataFile("splits.json")),
+				transactionService,
+				common.SystemClock{},
+				loc,
+			)
+			splitService.Zones = settingsService
+			telegramHandler.Splits = splitService
+			m.RegisterReviewQueue(reviewRepository.Len)
+			m.RegisterBreakers(breakers...)
+			telegramHandler.Metrics = m
+			telegramHandler.Heartbeat = health.NewHeartbeat(common.SystemClock{})
+			checks := []health.Check{
+				{Name: "telegram", Probe: telegramHandler.Ping},
+				{Name: "sheets", Probe: func(ctx context.Context) error { return sheet.Ping(ctx, spreadsheetID) }},
+			}
+			checks = append(checks, aiChecks(cfg, geminiClient)...)
+			checker := health.NewChecker(common.SystemClock{}, cfg.Health.CheckTimeout, cfg.Health.CheckTTL, checks...)
+			if cfg.HTTP.Addr != "" {
+				go serveHTTP(cfg.HTTP.Addr, newHTTPMux(m, health.Live(telegramHandler.Heartbeat, cfg.Health.StallAfter), health.Ready(checker)))
+			}
 			log.Println("Telegram bot started")
 			if err := telegramHandler.Start(); err != nil {
 				return err
@@ -43,6 +232,126 @@ func startBotWithDeps(telegramToken, apiKey string, spreadsheetService Spreadshe
 	return nil
 }
 
+// newAiPort builds the transaction parser asking the configured providers in
+// order, each traced and instrumented with m and dating messages in the
+// timezone zones gives each chat. It reports false when Gemini is listed but
+// geminiClient is not a real one.
+func newAiPort(cfg *config.Config, geminiClient GeminiClient, zones common.Zones, newBreaker func(name string) *resilience.Breaker, m *metrics.Metrics) (aiport.AiPort, bool) {
+	chain := fallback.NewChain()
+	for _, name := range cfg.AI.Providers {
+		port, ok := providerPort(name, cfg, geminiClient, zones)
+		if !ok {
+			return nil, false
+		}
+		provider := fallback.Provider{Name: name, Port: m.InstrumentAI(name, tracing.TraceAI(name, port))}
+		if name != config.ProviderOffline && newBreaker != nil {
+			provider.Breaker = newBreaker(name)
+		}
+		chain.Providers = append(chain.Providers, provider)
+	}
+	if len(chain.Providers) == 1 && chain.Providers[0].Breaker == nil {
+		return chain.Providers[0].Port, true
+	}
+	return chain, true
+}
+
+// providerPort builds the client of one AI provider. It reports false for
+// Gemini when geminiClient is not a real one, which is used as it is.
+func providerPort(name string, cfg *config.Config, geminiClient GeminiClient, zones common.Zones) (aiport.AiPort, bool) {
+	switch name {
+	case config.ProviderGemini:
+		g, ok := geminiClient.(*gemini.GeminiClient)
+		if !ok {
+			return nil, false
+		}
+		return g, true
+	case config.ProviderOpenAI, config.ProviderOllama:
+		var client *openai.Client
+		if name == config.ProviderOllama {
+			client = openai.NewOllamaClient(cfg.Ollama.BaseURL, cfg.Ollama.Model)
+		} else {
+			client = openai.NewClient(cfg.OpenAI.BaseURL, cfg.OpenAI.APIKey, cfg.OpenAI.Model)
+		}
+		client.Location = cfg.Location
+		client.Zones = zones
+		return client, true
+	default:
+		parser := offline.NewParser(common.SystemClock{}, cfg.Location)
+		parser.Zones = zones
+		return parser, true
+	}
+}
+
+// aiChecks are the readiness checks of the AI providers that can check
+// their API key or server
+func aiChecks(cfg *config.Config, geminiClient GeminiClient) []health.Check {
+	var checks []health.Check
+	for _, name := range cfg.AI.Providers {
+		port, _ := providerPort(name, cfg, geminiClient, nil)
+		if p, ok := port.(health.Pinger); ok {
+			checks = append(checks, health.Check{Name: name, Probe: p.Ping})
+		}
+	}
+	return checks
+}
+
+// adminChats returns the chats allowed to see the AI usage of every chat as a set
+func adminChats(chats []int64) map[int64]bool {
+	admins := make(map[int64]bool, len(chats))
+	for _, chatID := range chats {
+		admins[chatID] = true
+	}
+	return admins
+}
+
+// newHTTPMux routes the operational endpoints of the HTTP server
+func newHTTPMux(m *metrics.Metrics, live, ready http.Handler) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	mux.Handle("/healthz", live)
+	mux.Handle("/readyz", ready)
+	return mux
+}
+
+// serveHTTP runs the HTTP server until it fails. A failure is logged but
+// does not stop the bot.
+func serveHTTP(addr string, handler http.Handler) {
+	server := &http.Server{Addr: addr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	if err := server.ListenAndServe(); err != nil {
+		errors.HandleError(errors.NewNetworkError("HTTP server stopped", err).
+			WithContext("addr", addr).
+			WithComponent("main"), "serving HTTP endpoints")
+	}
+}
+
+// defaultCategories returns the categories of chats that have not changed
+// theirs: the JSON file at path when set, the built-in list otherwise
+func defaultCategories(path string) (category_domain.Set, error) {
+	defaults := category_domain.DefaultSet(common.TransactionCategoryList)
+	if path == "" {
+		return defaults, nil
+	}
+	var file category_domain.Set
+	if err := filestore.New(path).Load(&file); err != nil {
+		return defaults, err
+	}
+	if len(file.Categories) == 0 {
+		return defaults, errors.NewConfigError("categories file has no categories", nil).
+			WithContext("path", path).
+			WithComponent("main")
+	}
+	// Re-add every entry so the file gets the same checks as /categories add
+	var set category_domain.Set
+	for _, c := range file.Categories {
+		if err := set.Add(c); err != nil {
+			return defaults, errors.NewConfigError("invalid categories file: "+err.Error(), err).
+				WithContext("path", path).
+				WithComponent("main")
+		}
+	}
+	return set, nil
+}
+
 var testBotDeps struct {
 	SpreadsheetService SpreadsheetService
 	GeminiClient       GeminiClient
@@ -50,31 +359,63 @@ var testBotDeps struct {
 }
 
 func startBot() error {
-	if err := godotenv.Load(); err != nil {
+	envErr := godotenv.Load()
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"), os.Getenv)
+	if err != nil {
+		return err
+	}
+	if err := setupLogging(os.Stderr, cfg); err != nil {
+		return err
+	}
+	if envErr != nil {
 		log.Println("No .env file found or failed to load, proceeding with system env")
 	}
+	shutdownTracing, err := setupTracing(os.Stdout, cfg)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing(context.Background())
 
-	telegramToken := os.Getenv("TELEGRAM_BOT_TOKEN")
-	apiKey := os.Getenv("GEMINI_API_KEY")
 	if testBotDeps.Override {
-		return startBotWithDeps(telegramToken, apiKey, testBotDeps.SpreadsheetService, testBotDeps.GeminiClient)
+		return startBotWithDeps(cfg, testBotDeps.SpreadsheetService, testBotDeps.GeminiClient)
 	}
-	googleSpreadsheet, err := spreadsheet.NewSpreadsheetService()
+	googleSpreadsheet, err := spreadsheet.NewSpreadsheetService(cfg.Sheets.CredentialsFile, cfg.Location)
 	if err != nil {
 		return err
 	}
-	geminiClient, err := gemini.NewClient(apiKey)
+	if !cfg.AI.Uses(config.ProviderGemini) {
+		return startBotWithDeps(cfg, googleSpreadsheet, nil)
+	}
+	geminiClient, err := gemini.NewClient(cfg.Gemini.APIKey, cfg.Gemini.Model)
 	if err != nil {
 		return err
 	}
-	return startBotWithDeps(telegramToken, apiKey, googleSpreadsheet, geminiClient)
+	return startBotWithDeps(cfg, googleSpreadsheet, geminiClient)
 }
 
-// ErrEnvVarMissing is returned when a required environment variable is missing.
-type ErrEnvVarMissing string
+// setupLogging makes the slog logger configured by cfg.Log (json records at
+// info level by default) the default logger, for the log package and for
+// errors.HandleError. Records never contain the secrets of cfg.
+func setupLogging(w io.Writer, cfg *config.Config) error {
+	level, err := logging.ParseLevel(cfg.Log.Level)
+	if err != nil {
+		return err
+	}
+	logger, err := logging.New(w, cfg.Log.Format, level, logging.NewRedactor(cfg.Secrets()...))
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger.Logger)
+	errors.SetLogger(logger)
+	return nil
+}
 
-func (e ErrEnvVarMissing) Error() string {
-	return "required environment variable not set: " + string(e)
+// setupTracing makes the tracer provider exporting spans to cfg.Trace.Exporter
+// the global one: none (the default), stdout, which writes them to w, or
+// otlp, configured by the standard OTEL_EXPORTER_OTLP_* variables. It
+// returns a function flushing the spans not exported yet.
+func setupTracing(w io.Writer, cfg *config.Config) (func(context.Context) error, error) {
+	return tracing.Setup(context.Background(), cfg.Trace.Exporter, w)
 }
 
 func main() {
@@ -1,79 +1,319 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"money-tracker-bot/internal/adapters/fallback"
+	"money-tracker-bot/internal/adapters/gemini"
+	"money-tracker-bot/internal/adapters/offline"
+	"money-tracker-bot/internal/adapters/openai"
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/config"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/metrics"
+	"money-tracker-bot/internal/resilience"
+	"money-tracker-bot/internal/tracing"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
 )
 
-func TestStartBotWithDeps_MissingEnvVars(t *testing.T) {
-	err := startBotWithDeps("", "", nil, nil)
-	if err == nil {
-		t.Error("expected error when env vars are missing, got nil")
+// testConfig returns a valid configuration for the bot
+func testConfig() *config.Config {
+	cfg := config.Default()
+	cfg.Telegram.Token = "dummy-token"
+	cfg.Sheets.SpreadsheetID = "dummy-sheet"
+	cfg.Gemini.APIKey = "dummy-key"
+	return cfg
+}
+
+func TestStartBotWithDeps_MissingConfig(t *testing.T) {
+	err := startBotWithDeps(config.Default(), nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "TELEGRAM_BOT_TOKEN") || !strings.Contains(err.Error(), "GEMINI_API_KEY") {
+		t.Errorf("expected every missing setting to be reported, got: %v", err)
 	}
 }
 
-func TestStartBotWithDeps_AllEnvVarsPresent(t *testing.T) {
-	telegramToken := "dummy-token"
-	apiKey := "dummy-key"
+func TestStartBotWithDeps_ValidConfig(t *testing.T) {
 	// Use empty struct for mocks, as interfaces are now empty
 	mockSpreadsheet := struct{}{}
 	mockGemini := struct{}{}
-	err := startBotWithDeps(telegramToken, apiKey, mockSpreadsheet, mockGemini)
+	err := startBotWithDeps(testConfig(), mockSpreadsheet, mockGemini)
 	if err != nil {
 		t.Errorf("expected no error, got: %v", err)
 	}
 }
 
-func TestErrEnvVarMissing_Error(t *testing.T) {
-	err := ErrEnvVarMissing("TELEGRAM_BOT_TOKEN")
-	got := err.Error()
-	want := "required environment variable not set: TELEGRAM_BOT_TOKEN"
-	if got != want {
-		t.Errorf("expected '%s', got '%s'", want, got)
-	}
+// setBotEnv sets the variables the bot needs, unsetting any config file
+func setBotEnv(t *testing.T, token string) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("TELEGRAM_BOT_TOKEN", token)
+	t.Setenv("GEMINI_API_KEY", "dummy-key")
+	t.Setenv("GOOGLE_SPREADSHEET_ID", "dummy-sheet")
+	t.Setenv("LOG_FORMAT", "")
+	t.Setenv("LOG_LEVEL", "")
+	t.Setenv("TRACE_EXPORTER", "")
 }
 
-func TestStartBot_MissingEnvVars(t *testing.T) {
-	// Save and restore env
-	origToken := getenv("TELEGRAM_BOT_TOKEN")
-	origKey := getenv("GEMINI_API_KEY")
-	os.Setenv("TELEGRAM_BOT_TOKEN", "")
-	os.Setenv("GEMINI_API_KEY", "")
-	defer func() {
-		os.Setenv("TELEGRAM_BOT_TOKEN", origToken)
-		os.Setenv("GEMINI_API_KEY", origKey)
-	}()
+// overrideDeps makes startBot use empty dependencies
+func overrideDeps(t *testing.T) {
+	previous := slog.Default()
 	testBotDeps.Override = true
 	testBotDeps.SpreadsheetService = struct{}{}
 	testBotDeps.GeminiClient = struct{}{}
-	err := startBot()
-	testBotDeps.Override = false
-	if err == nil {
+	t.Cleanup(func() {
+		testBotDeps.Override = false
+		slog.SetDefault(previous)
+		errors.SetLogger(errors.DefaultLogger{})
+	})
+}
+
+func TestStartBot_MissingEnvVars(t *testing.T) {
+	setBotEnv(t, "")
+	overrideDeps(t)
+	if err := startBot(); err == nil {
 		t.Error("expected error when env vars are missing, got nil")
 	}
 }
 
 func TestStartBot_AllEnvVarsPresent(t *testing.T) {
-	origToken := getenv("TELEGRAM_BOT_TOKEN")
-	origKey := getenv("GEMINI_API_KEY")
-	os.Setenv("TELEGRAM_BOT_TOKEN", "dummy-token")
-	os.Setenv("GEMINI_API_KEY", "dummy-key")
-	defer func() {
-		os.Setenv("TELEGRAM_BOT_TOKEN", origToken)
-		os.Setenv("GEMINI_API_KEY", origKey)
-	}()
-	testBotDeps.Override = true
-	testBotDeps.SpreadsheetService = struct{}{}
-	testBotDeps.GeminiClient = struct{}{}
-	err := startBot()
-	testBotDeps.Override = false
-	if err != nil {
+	setBotEnv(t, "dummy-token")
+	overrideDeps(t)
+	if err := startBot(); err != nil {
 		t.Errorf("expected no error, got: %v", err)
 	}
 }
 
-// getenv is a helper to avoid panic if env is not set
-func getenv(key string) string {
-	v := os.Getenv(key)
-	return v
+func TestStartBot_ConfigFile(t *testing.T) {
+	setBotEnv(t, "")
+	overrideDeps(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("telegram:\n  token: file-token\nlog:\n  level: warn\n"), 0o600)
+	t.Setenv("CONFIG_FILE", path)
+	if err := startBot(); err != nil {
+		t.Errorf("expected the token from the config file, got: %v", err)
+	}
+}
+
+func TestDefaultCategories(t *testing.T) {
+	set, err := defaultCategories("")
+	if err != nil || len(set.Categories) != 11 {
+		t.Errorf("expected the built-in categories, got %d, %v", len(set.Categories), err)
+	}
+
+	path := filepath.Join(t.TempDir(), "categories.json")
+	os.WriteFile(path, []byte(`{"categories": [{"name": "Food"}, {"name": "Coffee", "parent": "Food", "aliases": ["kopi"]}, {"name": "Pets"}]}`), 0o600)
+	set, err = defaultCategories(path)
+	if err != nil || len(set.Categories) != 3 {
+		t.Fatalf("expected categories from the file, got %+v, %v", set, err)
+	}
+	if ref, ok := set.Match("kopi"); !ok || ref.Category != "Food" {
+		t.Errorf("unexpected match: %+v", ref)
+	}
+
+	os.WriteFile(path, []byte(`{"categories": [{"name": "Coffee", "parent": "Food"}]}`), 0o600)
+	if _, err := defaultCategories(path); err == nil {
+		t.Error("expected error for a subcategory of an unknown parent")
+	}
+}
+
+func TestStartBotWithDeps_ProviderKeys(t *testing.T) {
+	cfg := testConfig()
+	cfg.Gemini.APIKey = ""
+	cfg.AI.Providers = []string{config.ProviderOffline}
+	if err := startBotWithDeps(cfg, struct{}{}, nil); err != nil {
+		t.Errorf("the offline parser should not need GEMINI_API_KEY, got: %v", err)
+	}
+	cfg.AI.Providers = []string{config.ProviderOpenAI, config.ProviderOffline}
+	if err := startBotWithDeps(cfg, struct{}{}, nil); err == nil {
+		t.Error("expected error without OPENAI_API_KEY")
+	}
+	cfg.AI.Providers = []string{config.ProviderOllama}
+	if err := startBotWithDeps(cfg, struct{}{}, nil); err != nil {
+		t.Errorf("Ollama should not need an API key, got: %v", err)
+	}
+}
+
+func TestNewAiPort(t *testing.T) {
+	cfg := testConfig()
+	cfg.AI.Providers = []string{config.ProviderOffline}
+	if ai, ok := newAiPort(cfg, nil, nil, nil, nil); !ok || ai == nil {
+		t.Error("the offline parser should not need a Gemini client")
+	}
+	if _, ok := newAiPort(testConfig(), struct{}{}, nil, nil, nil); ok {
+		t.Error("gemini needs a real Gemini client")
+	}
+	var breakers []string
+	newBreaker := func(name string) *resilience.Breaker {
+		breakers = append(breakers, name)
+		return resilience.NewBreaker(name, 0, 0, common.SystemClock{})
+	}
+	cfg.AI.Providers = []string{config.ProviderGemini, config.ProviderOpenAI, config.ProviderOllama, config.ProviderOffline}
+	ai, ok := newAiPort(cfg, &gemini.GeminiClient{}, nil, newBreaker, nil)
+	chain, isChain := ai.(*fallback.Chain)
+	if !ok || !isChain || len(chain.Providers) != 4 || chain.Providers[2].Name != config.ProviderOllama {
+		t.Errorf("expected a fallback chain in the configured order, got %#v", ai)
+	}
+	if strings.Join(breakers, ",") != "gemini,openai,ollama" || chain.Providers[3].Breaker != nil {
+		t.Errorf("expected a breaker for every remote provider, got %v", breakers)
+	}
+	cfg.AI.Providers = []string{config.ProviderGemini}
+	if ai, _ := newAiPort(cfg, &gemini.GeminiClient{}, nil, newBreaker, nil); ai == nil {
+		t.Error("a single provider should still be guarded")
+	} else if _, isChain := ai.(*fallback.Chain); !isChain {
+		t.Errorf("expected a chain holding the breaker, got %T", ai)
+	}
+}
+
+type zonesStub struct{}
+
+func (zonesStub) Location(chatID int64) *time.Location { return time.UTC }
+
+func TestProviderPort_ChatTimezones(t *testing.T) {
+	cfg := testConfig()
+	cfg.Validate()
+	for _, name := range []string{config.ProviderOpenAI, config.ProviderOllama} {
+		port, _ := providerPort(name, cfg, nil, zonesStub{})
+		if c, ok := port.(*openai.Client); !ok || c.Zones == nil || c.Location != cfg.Location {
+			t.Errorf("expected %s to date messages in each chat's timezone, got %#v", name, port)
+		}
+	}
+	port, _ := providerPort(config.ProviderOffline, cfg, nil, zonesStub{})
+	if p, ok := port.(*offline.Parser); !ok || p.Zones == nil || p.Location != cfg.Location {
+		t.Errorf("expected the offline parser to use each chat's timezone, got %#v", port)
+	}
+}
+
+func TestAdminChats(t *testing.T) {
+	admins := adminChats([]int64{12345, -1001234})
+	if !admins[12345] || !admins[-1001234] || len(admins) != 2 {
+		t.Errorf("unexpected admins %v", admins)
+	}
+}
+
+func TestSetupLogging(t *testing.T) {
+	previous := slog.Default()
+	t.Cleanup(func() {
+		slog.SetDefault(previous)
+		errors.SetLogger(errors.DefaultLogger{})
+	})
+
+	cfg := testConfig()
+	cfg.Log = config.LogConfig{Format: "text", Level: "warn"}
+	var buf bytes.Buffer
+	if err := setupLogging(&buf, cfg); err != nil {
+		t.Fatal(err)
+	}
+	log.Println("below the level")
+	errors.HandleError(errors.NewValidationError("bad amount", nil), "parsing")
+	out := buf.String()
+	if strings.Contains(out, "below the level") {
+		t.Errorf("info records should be dropped at warn level: %s", out)
+	}
+	if !strings.Contains(out, "level=ERROR") || !strings.Contains(out, "code=VALIDATION_ERROR") {
+		t.Errorf("expected a structured text record, got %s", out)
+	}
+
+	cfg.Log = config.LogConfig{Format: "xml", Level: "info"}
+	if err := setupLogging(&buf, cfg); err == nil {
+		t.Error("expected error for an unknown format")
+	}
+	cfg.Log = config.LogConfig{Level: "loud"}
+	if err := setupLogging(&buf, cfg); err == nil {
+		t.Error("expected error for an unknown level")
+	}
+}
+
+func TestSetupLogging_RedactsSecrets(t *testing.T) {
+	previous := slog.Default()
+	t.Cleanup(func() {
+		slog.SetDefault(previous)
+		errors.SetLogger(errors.DefaultLogger{})
+	})
+
+	const token = "bot-token-from-env"
+	cfg := testConfig()
+	cfg.Telegram.Token = token
+	cfg.Gemini.APIKey = "gemini-key-from-env"
+	cfg.Log.Level = "debug"
+	var buf bytes.Buffer
+	if err := setupLogging(&buf, cfg); err != nil {
+		t.Fatal(err)
+	}
+	log.Printf("Endpoint: https://api.telegram.org/bot%s/getUpdates", token)
+	slog.Debug("request", "url", "https://generativelanguage.googleapis.com/v1?key=gemini-key-from-env")
+	errors.HandleError(errors.NewTelegramError("failed to send message", fmt.Errorf("Post bot%s: EOF", token)), "sending reply")
+
+	out := buf.String()
+	if strings.Contains(out, token) || strings.Contains(out, "gemini-key-from-env") {
+		t.Errorf("a secret leaked into the log:\n%s", out)
+	}
+	if strings.Count(out, "[REDACTED]") != 3 {
+		t.Errorf("expected three redactions, got:\n%s", out)
+	}
+}
+
+func TestNewHTTPMux(t *testing.T) {
+	m := metrics.New()
+	m.UpdateReceived(metrics.UpdateText)
+	live := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("live")) })
+	ready := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ready")) })
+	mux := newHTTPMux(m, live, ready)
+
+	for path, want := range map[string]string{
+		"/metrics": `moneybot_updates_received_total{type="text"} 1`,
+		"/healthz": "live",
+		"/readyz":  "ready",
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("unexpected %s response %d: %s", path, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestAiChecks(t *testing.T) {
+	cfg := testConfig()
+	cfg.AI.Providers = []string{config.ProviderGemini, config.ProviderOllama, config.ProviderOffline}
+	checks := aiChecks(cfg, &gemini.GeminiClient{})
+	if len(checks) != 2 || checks[0].Name != config.ProviderGemini || checks[1].Name != config.ProviderOllama {
+		t.Errorf("expected checks for the remote providers only, got %+v", checks)
+	}
+	cfg.AI.Providers = []string{config.ProviderGemini}
+	if checks := aiChecks(cfg, nil); len(checks) != 0 {
+		t.Errorf("expected no check without a Gemini client, got %+v", checks)
+	}
+}
+
+func TestSetupTracing(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	cfg := testConfig()
+	cfg.Trace.Exporter = "jaeger"
+	if _, err := setupTracing(io.Discard, cfg); err == nil {
+		t.Error("expected error for an unknown exporter")
+	}
+	cfg.Trace.Exporter = "stdout"
+	buf := &bytes.Buffer{}
+	shutdown, err := setupTracing(buf, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := tracing.Start(context.Background(), "telegram.update")
+	span.End()
+	if err := shutdown(context.Background()); err != nil || !strings.Contains(buf.String(), "telegram.update") {
+		t.Errorf("expected the span on stdout, got %v: %s", err, buf.String())
+	}
 }
@@ -0,0 +1,235 @@
+// Package aicache puts a persistent response cache in front of an AI port, so
+// re-sending the same receipt or message does not call the AI again.
+package aicache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"money-tracker-bot/internal/common"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	aiport "money-tracker-bot/internal/port/out/ai"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Store persists the cache as a single document, e.g. a *filestore.Store
+type Store interface {
+	Load(v interface{}) error
+	Save(v interface{}) error
+}
+
+type entry struct {
+	Transaction transaction_domain.Transaction `json:"transaction"`
+	CreatedAt   time.Time                      `json:"created_at"`
+	UsedAt      time.Time                      `json:"used_at"`
+}
+
+// Cache is an aiport.AiPort that answers repeated extractions from disk.
+// Entries are keyed by a hash of the image bytes or the normalized text, the
+// prompt version and the tenant's prompt categories and examples. Text keys
+// also hold the current date, since the prompt resolves relative dates.
+type Cache struct {
+	Next     aiport.AiPort
+	Store    Store
+	Clock    common.Clock
+	Location *time.Location
+	// Zones is optional; it dates text keys in each chat's timezone instead of Location
+	Zones common.Zones
+	// TTL is how long an entry is reused
+	TTL time.Duration
+	// MaxEntries caps the cache; the least recently used entries go first
+	MaxEntries int
+
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+func New(next aiport.AiPort, store Store, clock common.Clock, loc *time.Location, ttl time.Duration, maxEntries int) *Cache {
+	return &Cache{
+		Next:       next,
+		Store:      store,
+		Clock:      clock,
+		Location:   loc,
+		TTL:        ttl,
+		MaxEntries: maxEntries,
+	}
+}
+
+// GenerateContent is not cached; free-form answers depend on the data in the prompt
+func (c *Cache) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return c.Next.GenerateContent(ctx, prompt)
+}
+
+func (c *Cache) ReadImageToTransaction(ctx context.Context, imgPath string) (*transaction_domain.Transaction, error) {
+	data, err := os.ReadFile(imgPath)
+	if err != nil {
+		// Let the provider report the unreadable image
+		return c.Next.ReadImageToTransaction(ctx, imgPath)
+	}
+	key := c.key(ctx, "image", hashBytes(data))
+	if trx, ok := c.get(ctx, key); ok {
+		// The answer echoes the file ID of the first upload
+		trx.FileID = filepath.Base(imgPath)
+		// Providers consume the image on success; so does a cache hit
+		os.Remove(imgPath)
+		return trx, nil
+	}
+	trx, err := c.Next.ReadImageToTransaction(ctx, imgPath)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, *trx)
+	return trx, nil
+}
+
+func (c *Cache) TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error) {
+	today := c.Clock.Now().In(common.ContextLocation(ctx, c.Zones, c.Location))
+	key := c.key(ctx, "text", today.Format("2006-01-02"), NormalizeText(message))
+	if trx, ok := c.get(ctx, key); ok {
+		return trx, nil
+	}
+	trx, err := c.Next.TextToTransaction(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, *trx)
+	return trx, nil
+}
+
+// Len returns the number of cached answers
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	return len(c.entries)
+}
+
+// NormalizeText folds case and whitespace so retyped messages share a key.
+// Input: "  Makan  Siang 35rb "
+// Output: "makan siang 35rb"
+func NormalizeText(message string) string {
+	return strings.ToLower(strings.Join(strings.Fields(message), " "))
+}
+
+func (c *Cache) key(ctx context.Context, parts ...string) string {
+	parts = append(parts, common.PromptVersion)
+	parts = append(parts, common.PromptCategories(ctx)...)
+	parts = append(parts, "--")
+	parts = append(parts, common.PromptExamples(ctx)...)
+	return hashBytes([]byte(strings.Join(parts, "\x00")))
+}
+
+func (c *Cache) get(ctx context.Context, key string) (*transaction_domain.Transaction, bool) {
+	if common.CacheBypassed(ctx) {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	now := c.now()
+	if now.Sub(e.CreatedAt) > c.TTL {
+		delete(c.entries, key)
+		c.save()
+		return nil, false
+	}
+	e.UsedAt = now
+	c.entries[key] = e
+	c.save()
+	trx := clone(e.Transaction)
+	return &trx, true
+}
+
+// put stores trx under key. Only answers of the current prompt are stored:
+// the key names that prompt, and an answer of a fallback such as the offline
+// parser would otherwise be served in its place once the AI recovers.
+func (c *Cache) put(key string, trx transaction_domain.Transaction) {
+	if trx.PromptVersion != common.PromptVersion {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.load()
+	now := c.now()
+	c.entries[key] = entry{Transaction: clone(trx), CreatedAt: now, UsedAt: now}
+	c.evict(now)
+	c.save()
+}
+
+// evict drops expired entries, then the least recently used ones above MaxEntries
+func (c *Cache) evict(now time.Time) {
+	for k, e := range c.entries {
+		if now.Sub(e.CreatedAt) > c.TTL {
+			delete(c.entries, k)
+		}
+	}
+	if c.MaxEntries <= 0 || len(c.entries) <= c.MaxEntries {
+		return
+	}
+	keys := make([]string, 0, len(c.entries))
+	for k := range c.entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return c.entries[keys[i]].UsedAt.Before(c.entries[keys[j]].UsedAt) })
+	for _, k := range keys[:len(keys)-c.MaxEntries] {
+		delete(c.entries, k)
+	}
+}
+
+// load reads the entries from the store on first use. Callers hold mu.
+func (c *Cache) load() {
+	if c.entries != nil {
+		return
+	}
+	c.entries = make(map[string]entry)
+	if err := c.Store.Load(&c.entries); err != nil {
+		// Start empty; the next save replaces the unreadable document
+		errors.HandleError(err, "loading AI response cache")
+		c.entries = make(map[string]entry)
+	}
+}
+
+// save writes the entries to the store. Callers hold mu.
+func (c *Cache) save() {
+	if err := c.Store.Save(c.entries); err != nil {
+		// The answer is still served; only the cache is not persisted
+		errors.HandleError(err, "saving AI response cache")
+	}
+}
+
+func (c *Cache) now() time.Time {
+	loc := c.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return c.Clock.Now().In(loc)
+}
+
+// clone copies the slices and maps of trx, so a served answer that is changed
+// later, for example by the review flow, leaves the cached one alone
+func clone(trx transaction_domain.Transaction) transaction_domain.Transaction {
+	trx.Tags = append([]string(nil), trx.Tags...)
+	trx.LowConfidence = append([]string(nil), trx.LowConfidence...)
+	if trx.Confidence != nil {
+		confidence := make(map[string]float64, len(trx.Confidence))
+		for field, score := range trx.Confidence {
+			confidence[field] = score
+		}
+		trx.Confidence = confidence
+	}
+	return trx
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
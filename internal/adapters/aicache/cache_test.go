@@ -0,0 +1,203 @@
+package aicache
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/adapters/filestore"
+	"money-tracker-bot/internal/common"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	aiport "money-tracker-bot/internal/port/out/ai"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type stubPort struct {
+	err   error
+	calls int
+	// version is the prompt version of answers, common.PromptVersion when empty
+	version string
+}
+
+func (s *stubPort) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	s.calls++
+	return "tip", s.err
+}
+
+func (s *stubPort) ReadImageToTransaction(ctx context.Context, imgPath string) (*transaction_domain.Transaction, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	os.Remove(imgPath)
+	return &transaction_domain.Transaction{Title: "receipt", FileID: filepath.Base(imgPath), Tags: []string{"shop"}, PromptVersion: common.PromptVersion}, nil
+}
+
+func (s *stubPort) TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &transaction_domain.Transaction{Title: message, Amount: fmt.Sprintf("%d", s.calls), PromptVersion: s.promptVersion(), Confidence: map[string]float64{"amount": 0.4}}, nil
+}
+
+func (s *stubPort) promptVersion() string {
+	if s.version == "" {
+		return common.PromptVersion
+	}
+	return s.version
+}
+
+func newCache(t *testing.T, next aiport.AiPort, clock common.Clock) (*Cache, string) {
+	path := filepath.Join(t.TempDir(), "ai_cache.json")
+	return New(next, filestore.New(path), clock, time.UTC, time.Hour, 2), path
+}
+
+func TestCache_ImplementsAiPort(t *testing.T) {
+	var _ aiport.AiPort = &Cache{}
+}
+
+func TestCache_Text(t *testing.T) {
+	next := &stubPort{}
+	clock := &common.FixedClock{Time: time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)}
+	cache, path := newCache(t, next, clock)
+	ctx := context.Background()
+
+	first, _ := cache.TextToTransaction(ctx, "Makan  siang 35rb")
+	second, err := cache.TextToTransaction(ctx, " makan siang 35RB ")
+	if err != nil || next.calls != 1 || second.Amount != first.Amount {
+		t.Fatalf("expected a cache hit, got %+v, %v after %d calls", second, err, next.calls)
+	}
+
+	// Other categories, a bypass or another day all ask the provider again
+	cache.TextToTransaction(common.WithPromptCategories(ctx, []string{"Pets"}), "makan siang 35rb")
+	cache.TextToTransaction(common.WithCacheBypass(ctx), "makan siang 35rb")
+	if next.calls != 3 {
+		t.Errorf("expected categories and bypass to miss, got %d calls", next.calls)
+	}
+	if trx, _ := cache.TextToTransaction(ctx, "makan siang 35rb"); trx.Amount != "3" {
+		t.Errorf("a bypass should refresh the cached answer, got %+v", trx)
+	}
+	clock.Advance(24 * time.Hour)
+	cache.TextToTransaction(ctx, "makan siang 35rb")
+	if next.calls != 4 {
+		t.Errorf("relative dates make text keys daily, got %d calls", next.calls)
+	}
+
+	// Entries survive a restart
+	reloaded := New(next, filestore.New(path), clock, time.UTC, time.Hour, 2)
+	reloaded.TextToTransaction(ctx, "makan siang 35rb")
+	if next.calls != 4 {
+		t.Errorf("expected the persisted entry to be reused, got %d calls", next.calls)
+	}
+}
+
+func TestCache_Image(t *testing.T) {
+	next := &stubPort{}
+	cache, _ := newCache(t, next, &common.FixedClock{Time: time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)})
+	dir := t.TempDir()
+	write := func(name string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte("jpeg bytes"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	cache.ReadImageToTransaction(context.Background(), write("a.jpg"))
+	second := write("b.jpg")
+	trx, err := cache.ReadImageToTransaction(context.Background(), second)
+	if err != nil || next.calls != 1 {
+		t.Fatalf("expected the same image to hit, got %v after %d calls", err, next.calls)
+	}
+	if trx.FileID != "b.jpg" || len(trx.Tags) != 1 {
+		t.Errorf("expected the new file ID on a hit, got %+v", trx)
+	}
+	if _, err := os.Stat(second); !os.IsNotExist(err) {
+		t.Error("a cache hit should remove the image like the providers do")
+	}
+}
+
+func TestCache_ErrorsAreNotCached(t *testing.T) {
+	next := &stubPort{err: fmt.Errorf("quota")}
+	cache, _ := newCache(t, next, &common.FixedClock{Time: time.Now()})
+
+	if _, err := cache.TextToTransaction(context.Background(), "kopi 18k"); err == nil {
+		t.Fatal("expected the provider error")
+	}
+	next.err = nil
+	if _, err := cache.TextToTransaction(context.Background(), "kopi 18k"); err != nil || next.calls != 2 || cache.Len() != 1 {
+		t.Errorf("expected a retry after the error, got %v after %d calls", err, next.calls)
+	}
+	if text, err := cache.GenerateContent(context.Background(), "hi"); err != nil || text != "tip" || next.calls != 3 {
+		t.Error("content generation should pass through")
+	}
+}
+
+func TestCache_FallbackAnswersAreNotCached(t *testing.T) {
+	// The offline parser answered while the AI was down
+	next := &stubPort{version: "offline"}
+	cache, _ := newCache(t, next, &common.FixedClock{Time: time.Now()})
+
+	cache.TextToTransaction(context.Background(), "kopi 18k")
+	if cache.Len() != 0 {
+		t.Fatal("a fallback answer must not be cached under the AI prompt")
+	}
+	next.version = ""
+	trx, err := cache.TextToTransaction(context.Background(), "kopi 18k")
+	if err != nil || next.calls != 2 || trx.PromptVersion != common.PromptVersion {
+		t.Errorf("expected the recovered AI to answer, got %+v, %v after %d calls", trx, err, next.calls)
+	}
+}
+
+func TestCache_ServedAnswersAreCopies(t *testing.T) {
+	cache, _ := newCache(t, &stubPort{}, &common.FixedClock{Time: time.Now()})
+
+	first, _ := cache.TextToTransaction(context.Background(), "kopi 18k")
+	first.Confidence["amount"] = 1
+	second, _ := cache.TextToTransaction(context.Background(), "kopi 18k")
+	second.Confidence["amount"] = 1
+	third, _ := cache.TextToTransaction(context.Background(), "kopi 18k")
+	if third.Confidence["amount"] != 0.4 {
+		t.Errorf("changing a served answer must not change the cached one, got %v", third.Confidence)
+	}
+}
+
+func TestCache_Eviction(t *testing.T) {
+	next := &stubPort{}
+	clock := &common.FixedClock{Time: time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)}
+	cache, _ := newCache(t, next, clock)
+	ctx := context.Background()
+
+	cache.TextToTransaction(ctx, "a")
+	clock.Advance(time.Minute)
+	cache.TextToTransaction(ctx, "b")
+	clock.Advance(time.Minute)
+	cache.TextToTransaction(ctx, "a")
+	clock.Advance(time.Minute)
+	cache.TextToTransaction(ctx, "c")
+	if cache.Len() != 2 {
+		t.Fatalf("expected the size limit to hold, got %d entries", cache.Len())
+	}
+	calls := next.calls
+	cache.TextToTransaction(ctx, "a")
+	if next.calls != calls {
+		t.Error("the recently used entry should have been kept")
+	}
+
+	clock.Advance(2 * time.Hour)
+	cache.TextToTransaction(ctx, "a")
+	if next.calls != calls+1 {
+		t.Error("expired entries should be refreshed")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("expired entries should be evicted, got %d", cache.Len())
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	if got := NormalizeText("  Makan\tSiang  35rb "); got != "makan siang 35rb" {
+		t.Errorf("unexpected normalization %q", got)
+	}
+}
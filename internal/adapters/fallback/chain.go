@@ -0,0 +1,98 @@
+package fallback
+
+// Package fallback chains AI ports so a failing provider is backed by the next one.
+
+import (
+	"context"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	aiport "money-tracker-bot/internal/port/out/ai"
+	"money-tracker-bot/internal/resilience"
+)
+
+// Provider is an AI port with a name for logs
+type Provider struct {
+	Name string
+	Port aiport.AiPort
+	// Breaker is optional; while it is open the provider is skipped
+	Breaker *resilience.Breaker
+}
+
+// Chain is an aiport.AiPort that asks each provider in order and returns the
+// first answer. Retryable failures and open circuits are logged and the next
+// provider is tried; when all fail the last error is returned. Other errors,
+// such as an exhausted quota or invalid input, are returned right away, so
+// the user learns about them instead of getting a worse answer.
+type Chain struct {
+	Providers []Provider
+}
+
+func NewChain(providers ...Provider) *Chain {
+	return &Chain{Providers: providers}
+}
+
+func (c *Chain) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return try(ctx, c, "generating content", func(p aiport.AiPort) (string, error) {
+		return p.GenerateContent(ctx, prompt)
+	})
+}
+
+func (c *Chain) ReadImageToTransaction(ctx context.Context, imgPath string) (*transaction_domain.Transaction, error) {
+	return try(ctx, c, "reading image", func(p aiport.AiPort) (*transaction_domain.Transaction, error) {
+		return p.ReadImageToTransaction(ctx, imgPath)
+	})
+}
+
+func (c *Chain) TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error) {
+	return try(ctx, c, "parsing text", func(p aiport.AiPort) (*transaction_domain.Transaction, error) {
+		return p.TextToTransaction(ctx, message)
+	})
+}
+
+// try calls fn with each provider until one succeeds or fails in a way the
+// next provider cannot fix. A cancelled context stops the chain, since every
+// provider would fail the same way.
+func try[T any](ctx context.Context, c *Chain, operation string, fn func(aiport.AiPort) (T, error)) (T, error) {
+	var zero T
+	if len(c.Providers) == 0 {
+		return zero, errors.NewConfigError("no AI provider configured", nil).WithComponent("ai-fallback")
+	}
+	var err error
+	for i, p := range c.Providers {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return zero, ctxErr
+		}
+		var result T
+		result, err = resilience.Call(p.Breaker, func() (T, error) { return fn(p.Port) })
+		if err == nil {
+			return result, nil
+		}
+		if !canFallBack(err) {
+			return zero, err
+		}
+		if i < len(c.Providers)-1 {
+			errors.HandleError(err, operation+": "+p.Name+" failed, falling back to "+c.Providers[i+1].Name)
+		}
+	}
+	return zero, err
+}
+
+// canFallBack reports whether another provider may succeed where one failed
+// with err: after a retryable error, while its circuit is open, when it
+// refused the request (its own key or model) or when its answer held no
+// transaction. The last two are not retryable, so they do not open breakers.
+func canFallBack(err error) bool {
+	if errors.IsRetryableError(err) {
+		return true
+	}
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		return false
+	}
+	switch appErr.Code {
+	case errors.ErrCodeCircuitOpen, errors.ErrCodeAIRequest, errors.ErrCodeDataFormat:
+		return true
+	default:
+		return false
+	}
+}
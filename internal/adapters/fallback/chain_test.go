@@ -0,0 +1,152 @@
+package fallback
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/common"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	aiport "money-tracker-bot/internal/port/out/ai"
+	"money-tracker-bot/internal/resilience"
+	"testing"
+	"time"
+)
+
+type stubPort struct {
+	name  string
+	err   error
+	calls int
+}
+
+func (s *stubPort) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	s.calls++
+	return "tip", s.err
+}
+
+func (s *stubPort) ReadImageToTransaction(ctx context.Context, imgPath string) (*transaction_domain.Transaction, error) {
+	return s.TextToTransaction(ctx, imgPath)
+}
+
+func (s *stubPort) TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &transaction_domain.Transaction{Title: s.name}, nil
+}
+
+func TestChain_ImplementsAiPort(t *testing.T) {
+	var _ aiport.AiPort = &Chain{}
+}
+
+func TestChain_FallsBack(t *testing.T) {
+	gemini := &stubPort{name: "gemini", err: errors.NewGeminiError("rate limited", nil)}
+	offline := &stubPort{name: "offline"}
+	chain := NewChain(Provider{Name: "gemini", Port: gemini}, Provider{Name: "offline", Port: offline})
+
+	trx, err := chain.TextToTransaction(context.Background(), "makan 35rb")
+	if err != nil || trx.Title != "offline" {
+		t.Fatalf("expected the fallback answer, got %+v, %v", trx, err)
+	}
+	if text, err := chain.GenerateContent(context.Background(), "hi"); err != nil || text != "tip" {
+		t.Errorf("expected fallback for content, got %v", err)
+	}
+
+	gemini.err = nil
+	trx, _ = chain.ReadImageToTransaction(context.Background(), "img.jpg")
+	if trx.Title != "gemini" || offline.calls != 2 {
+		t.Errorf("the primary should answer when healthy, got %+v after %d fallback calls", trx, offline.calls)
+	}
+}
+
+func TestChain_AllFail(t *testing.T) {
+	chain := NewChain(
+		Provider{Name: "gemini", Port: &stubPort{err: errors.NewGeminiError("down", nil)}},
+		Provider{Name: "offline", Port: &stubPort{err: fmt.Errorf("no amount")}},
+	)
+	if _, err := chain.TextToTransaction(context.Background(), "hello"); err == nil || err.Error() != "no amount" {
+		t.Errorf("expected the last error, got %v", err)
+	}
+	if _, err := NewChain().TextToTransaction(context.Background(), "hello"); err == nil {
+		t.Error("expected error without providers")
+	}
+}
+
+func TestChain_StopsOnNonRetryableError(t *testing.T) {
+	gemini := &stubPort{err: errors.NewValidationError("amount must be positive", nil)}
+	offline := &stubPort{}
+	_, err := NewChain(Provider{Name: "gemini", Port: gemini}, Provider{Name: "offline", Port: offline}).TextToTransaction(context.Background(), "kopi -25rb")
+	if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.ErrCodeValidation {
+		t.Errorf("expected the first provider's error, got %v", err)
+	}
+	if offline.calls != 0 {
+		t.Error("a non-retryable error should not fall back")
+	}
+}
+
+func TestChain_FallsBackOnUnusableAnswer(t *testing.T) {
+	gemini := &stubPort{err: errors.NewDataFormatError("no transaction in Gemini response", nil)}
+	offline := &stubPort{name: "offline"}
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	breaker := resilience.NewBreaker("gemini", 2, time.Minute, clock)
+	chain := NewChain(Provider{Name: "gemini", Port: gemini, Breaker: breaker}, Provider{Name: "offline", Port: offline})
+
+	for i := 0; i < 3; i++ {
+		trx, err := chain.TextToTransaction(context.Background(), "hello there")
+		if err != nil || trx.Title != "offline" {
+			t.Fatalf("expected the offline answer, got %v, %v", trx, err)
+		}
+	}
+	if gemini.calls != 3 || breaker.Snapshot().State != resilience.Closed {
+		t.Errorf("chit-chat should not open the breaker, got %d calls and %v", gemini.calls, breaker.Snapshot().State)
+	}
+}
+
+func TestChain_StopsOnQuota(t *testing.T) {
+	gemini := &stubPort{err: errors.NewQuotaError("daily AI token quota used up", nil)}
+	offline := &stubPort{}
+	_, err := NewChain(Provider{Name: "gemini", Port: gemini}, Provider{Name: "offline", Port: offline}).TextToTransaction(context.Background(), "kopi 25rb")
+	if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.ErrCodeQuota {
+		t.Errorf("expected the quota error, got %v", err)
+	}
+	if offline.calls != 0 {
+		t.Error("an exhausted quota should not fall back")
+	}
+}
+
+func TestChain_StopsOnCancelledContext(t *testing.T) {
+	gemini := &stubPort{err: context.Canceled}
+	offline := &stubPort{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewChain(Provider{Name: "gemini", Port: gemini}, Provider{Name: "offline", Port: offline}).TextToTransaction(ctx, "x"); err == nil {
+		t.Error("expected the context error")
+	}
+	if gemini.calls+offline.calls != 0 {
+		t.Error("no provider should be called with a cancelled context")
+	}
+}
+
+func TestChain_SkipsOpenBreaker(t *testing.T) {
+	gemini := &stubPort{name: "gemini", err: errors.NewGeminiTimeoutError("request timed out", nil)}
+	offline := &stubPort{name: "offline"}
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	breaker := resilience.NewBreaker("gemini", 2, time.Minute, clock)
+	chain := NewChain(Provider{Name: "gemini", Port: gemini, Breaker: breaker}, Provider{Name: "offline", Port: offline})
+
+	for i := 0; i < 4; i++ {
+		trx, err := chain.TextToTransaction(context.Background(), "kopi 25rb")
+		if err != nil || trx.Title != "offline" {
+			t.Fatalf("expected the offline answer, got %v, %v", trx, err)
+		}
+	}
+	if gemini.calls != 2 {
+		t.Errorf("expected gemini to be skipped once its breaker opened, got %d calls", gemini.calls)
+	}
+
+	clock.Advance(time.Minute)
+	gemini.err = nil
+	if trx, _ := chain.TextToTransaction(context.Background(), "kopi 25rb"); trx.Title != "gemini" {
+		t.Errorf("expected the probe to reach gemini again, got %v", trx)
+	}
+}
@@ -0,0 +1,42 @@
+package filestore
+
+import (
+	category_domain "money-tracker-bot/internal/domain/category"
+	"strconv"
+)
+
+// CategoryRepository stores the category set of each chat keyed by chat ID
+type CategoryRepository struct {
+	store *Store
+}
+
+func NewCategoryRepository(path string) *CategoryRepository {
+	return &CategoryRepository{store: New(path)}
+}
+
+func (r *CategoryRepository) load() (map[string]category_domain.Set, error) {
+	sets := make(map[string]category_domain.Set)
+	if err := r.store.Load(&sets); err != nil {
+		return nil, err
+	}
+	return sets, nil
+}
+
+// Get returns the categories of a chat; found is false until the chat changes them.
+func (r *CategoryRepository) Get(chatID int64) (category_domain.Set, bool, error) {
+	sets, err := r.load()
+	if err != nil {
+		return category_domain.Set{}, false, err
+	}
+	s, ok := sets[strconv.FormatInt(chatID, 10)]
+	return s, ok, nil
+}
+
+func (r *CategoryRepository) Save(chatID int64, set category_domain.Set) error {
+	sets, err := r.load()
+	if err != nil {
+		return err
+	}
+	sets[strconv.FormatInt(chatID, 10)] = set
+	return r.store.Save(sets)
+}
@@ -0,0 +1,32 @@
+package filestore
+
+import (
+	category_domain "money-tracker-bot/internal/domain/category"
+	"path/filepath"
+	"testing"
+)
+
+func TestCategoryRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "categories.json")
+	repo := NewCategoryRepository(path)
+
+	if _, found, err := repo.Get(1); found || err != nil {
+		t.Fatalf("expected no categories yet, got %v, %v", found, err)
+	}
+	set := category_domain.DefaultSet([]string{"Groceries", "Eating Out"})
+	set.Add(category_domain.Category{Name: "Coffee", Parent: "Eating Out", Aliases: []string{"kopi"}})
+	if err := repo.Save(1, set); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	got, found, err := NewCategoryRepository(path).Get(1)
+	if err != nil || !found || len(got.Categories) != 3 {
+		t.Fatalf("unexpected categories: %+v, %v, %v", got, found, err)
+	}
+	if ref, ok := got.Match("kopi"); !ok || ref.Subcategory != "Coffee" {
+		t.Errorf("aliases should be persisted, got %+v", ref)
+	}
+	if _, found, _ := repo.Get(2); found {
+		t.Error("categories must be kept per chat")
+	}
+}
@@ -0,0 +1,56 @@
+package filestore
+
+import (
+	digest_domain "money-tracker-bot/internal/domain/digest"
+	"sort"
+	"strconv"
+)
+
+// DigestScheduleRepository stores digest schedules keyed by chat ID
+type DigestScheduleRepository struct {
+	store *Store
+}
+
+func NewDigestScheduleRepository(path string) *DigestScheduleRepository {
+	return &DigestScheduleRepository{store: New(path)}
+}
+
+func (r *DigestScheduleRepository) load() (map[string]digest_domain.Schedule, error) {
+	schedules := make(map[string]digest_domain.Schedule)
+	if err := r.store.Load(&schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// List returns every stored schedule ordered by chat ID.
+func (r *DigestScheduleRepository) List() ([]digest_domain.Schedule, error) {
+	schedules, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]digest_domain.Schedule, 0, len(schedules))
+	for _, s := range schedules {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ChatID < out[j].ChatID })
+	return out, nil
+}
+
+func (r *DigestScheduleRepository) Get(chatID int64) (digest_domain.Schedule, bool, error) {
+	schedules, err := r.load()
+	if err != nil {
+		return digest_domain.Schedule{}, false, err
+	}
+	s, ok := schedules[strconv.FormatInt(chatID, 10)]
+	return s, ok, nil
+}
+
+func (r *DigestScheduleRepository) Save(schedule digest_domain.Schedule) error {
+	schedules, err := r.load()
+	if err != nil {
+		return err
+	}
+	schedules[strconv.FormatInt(schedule.ChatID, 10)] = schedule
+	return r.store.Save(schedules)
+}
@@ -0,0 +1,32 @@
+package filestore
+
+import (
+	digest_domain "money-tracker-bot/internal/domain/digest"
+	"path/filepath"
+	"testing"
+)
+
+func TestDigestScheduleRepository(t *testing.T) {
+	repo := NewDigestScheduleRepository(filepath.Join(t.TempDir(), "digests.json"))
+
+	if _, found, err := repo.Get(42); err != nil || found {
+		t.Fatalf("expected empty repository, got found=%v err=%v", found, err)
+	}
+
+	for _, id := range []int64{42, -100} {
+		s := digest_domain.NewSchedule(id)
+		s.DailyEnabled = true
+		if err := repo.Save(s); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	got, found, err := repo.Get(42)
+	if err != nil || !found || !got.DailyEnabled {
+		t.Errorf("unexpected schedule: %+v found=%v err=%v", got, found, err)
+	}
+	all, err := repo.List()
+	if err != nil || len(all) != 2 || all[0].ChatID != -100 {
+		t.Errorf("expected two schedules ordered by chat ID, got %+v err=%v", all, err)
+	}
+}
@@ -0,0 +1,76 @@
+package filestore
+
+import (
+	goals_domain "money-tracker-bot/internal/domain/goals"
+	"sort"
+	"strconv"
+)
+
+// GoalRepository stores savings goals keyed by chat ID and goal name
+type GoalRepository struct {
+	store *Store
+}
+
+func NewGoalRepository(path string) *GoalRepository {
+	return &GoalRepository{store: New(path)}
+}
+
+func goalKey(chatID int64, name string) string {
+	return strconv.FormatInt(chatID, 10) + ":" + name
+}
+
+func (r *GoalRepository) load() (map[string]goals_domain.Goal, error) {
+	goals := make(map[string]goals_domain.Goal)
+	if err := r.store.Load(&goals); err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+// List returns the goals of a chat ordered by deadline.
+func (r *GoalRepository) List(chatID int64) ([]goals_domain.Goal, error) {
+	goals, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	var out []goals_domain.Goal
+	for _, g := range goals {
+		if g.ChatID == chatID {
+			out = append(out, g)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Deadline != out[j].Deadline {
+			return out[i].Deadline < out[j].Deadline
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+func (r *GoalRepository) Get(chatID int64, name string) (goals_domain.Goal, bool, error) {
+	goals, err := r.load()
+	if err != nil {
+		return goals_domain.Goal{}, false, err
+	}
+	g, ok := goals[goalKey(chatID, name)]
+	return g, ok, nil
+}
+
+func (r *GoalRepository) Save(g goals_domain.Goal) error {
+	goals, err := r.load()
+	if err != nil {
+		return err
+	}
+	goals[goalKey(g.ChatID, g.Name)] = g
+	return r.store.Save(goals)
+}
+
+func (r *GoalRepository) Delete(chatID int64, name string) error {
+	goals, err := r.load()
+	if err != nil {
+		return err
+	}
+	delete(goals, goalKey(chatID, name))
+	return r.store.Save(goals)
+}
@@ -0,0 +1,29 @@
+package filestore
+
+import (
+	goals_domain "money-tracker-bot/internal/domain/goals"
+	"path/filepath"
+	"testing"
+)
+
+func TestGoalRepository(t *testing.T) {
+	repo := NewGoalRepository(filepath.Join(t.TempDir(), "goals.json"))
+	repo.Save(goals_domain.Goal{ChatID: 1, Name: "car", Deadline: "2026-12-31"})
+	repo.Save(goals_domain.Goal{ChatID: 1, Name: "bali", Deadline: "2025-12-31"})
+	repo.Save(goals_domain.Goal{ChatID: 2, Name: "bali", Deadline: "2025-10-01"})
+
+	goals, err := repo.List(1)
+	if err != nil || len(goals) != 2 || goals[0].Name != "bali" {
+		t.Errorf("expected chat 1 goals ordered by deadline, got %+v, %v", goals, err)
+	}
+	if g, found, _ := repo.Get(2, "bali"); !found || g.Deadline != "2025-10-01" {
+		t.Errorf("goals with the same name must be kept per chat, got %+v", g)
+	}
+	repo.Delete(1, "bali")
+	if _, found, _ := repo.Get(1, "bali"); found {
+		t.Error("deleted goal should be gone")
+	}
+	if _, found, _ := repo.Get(2, "bali"); !found {
+		t.Error("deleting a goal must not affect other chats")
+	}
+}
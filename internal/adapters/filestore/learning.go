@@ -0,0 +1,41 @@
+package filestore
+
+import (
+	learning_domain "money-tracker-bot/internal/domain/learning"
+	"strconv"
+)
+
+// LearningRepository stores the learned categories of each chat keyed by chat ID
+type LearningRepository struct {
+	store *Store
+}
+
+func NewLearningRepository(path string) *LearningRepository {
+	return &LearningRepository{store: New(path)}
+}
+
+func (r *LearningRepository) load() (map[string]learning_domain.Memory, error) {
+	memories := make(map[string]learning_domain.Memory)
+	if err := r.store.Load(&memories); err != nil {
+		return nil, err
+	}
+	return memories, nil
+}
+
+// Get returns what a chat has taught the bot; an empty Memory until then.
+func (r *LearningRepository) Get(chatID int64) (learning_domain.Memory, error) {
+	memories, err := r.load()
+	if err != nil {
+		return learning_domain.Memory{}, err
+	}
+	return memories[strconv.FormatInt(chatID, 10)], nil
+}
+
+func (r *LearningRepository) Save(chatID int64, m learning_domain.Memory) error {
+	memories, err := r.load()
+	if err != nil {
+		return err
+	}
+	memories[strconv.FormatInt(chatID, 10)] = m
+	return r.store.Save(memories)
+}
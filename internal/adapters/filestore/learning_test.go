@@ -0,0 +1,31 @@
+package filestore
+
+import (
+	learning_domain "money-tracker-bot/internal/domain/learning"
+	"path/filepath"
+	"testing"
+)
+
+func TestLearningRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "learning.json")
+	repo := NewLearningRepository(path)
+
+	empty, err := repo.Get(1)
+	if err != nil || empty.Stats.Categorized != 0 || len(empty.Mappings) != 0 {
+		t.Fatalf("expected empty memory, got %+v, %v", empty, err)
+	}
+
+	m := learning_domain.Memory{
+		Stats:    learning_domain.Stats{Categorized: 3, Corrected: 1},
+		Mappings: []learning_domain.Mapping{{Merchant: "Superindo", Category: "Groceries", Corrections: 1}},
+	}
+	if err := repo.Save(1, m); err != nil {
+		t.Fatal(err)
+	}
+	repo.Save(2, learning_domain.Memory{Stats: learning_domain.Stats{Categorized: 9}})
+
+	got, err := NewLearningRepository(path).Get(1)
+	if err != nil || got.Stats.Corrected != 1 || len(got.Mappings) != 1 || got.Mappings[0].Merchant != "Superindo" {
+		t.Errorf("unexpected memory %+v, %v", got, err)
+	}
+}
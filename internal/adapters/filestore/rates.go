@@ -0,0 +1,59 @@
+package filestore
+
+import (
+	currency_domain "money-tracker-bot/internal/domain/currency"
+)
+
+// RateRepository stores the exchange rates of every chat
+type RateRepository struct {
+	store *Store
+}
+
+func NewRateRepository(path string) *RateRepository {
+	return &RateRepository{store: New(path)}
+}
+
+func (r *RateRepository) load() ([]currency_domain.Rate, error) {
+	var rates []currency_domain.Rate
+	if err := r.store.Load(&rates); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// List returns the rates of one chat in the order they were first saved.
+func (r *RateRepository) List(chatID int64) ([]currency_domain.Rate, error) {
+	all, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	var out []currency_domain.Rate
+	for _, rate := range all {
+		if rate.ChatID == chatID {
+			out = append(out, rate)
+		}
+	}
+	return out, nil
+}
+
+// Save adds rates, replacing a stored rate with the same chat, currencies
+// and date.
+func (r *RateRepository) Save(rates ...currency_domain.Rate) error {
+	all, err := r.load()
+	if err != nil {
+		return err
+	}
+	index := make(map[string]int, len(all))
+	for i, rate := range all {
+		index[rate.Key()] = i
+	}
+	for _, rate := range rates {
+		if i, ok := index[rate.Key()]; ok {
+			all[i] = rate
+			continue
+		}
+		index[rate.Key()] = len(all)
+		all = append(all, rate)
+	}
+	return r.store.Save(all)
+}
@@ -0,0 +1,33 @@
+package filestore
+
+import (
+	currency_domain "money-tracker-bot/internal/domain/currency"
+	"path/filepath"
+	"testing"
+)
+
+func TestRateRepository(t *testing.T) {
+	repo := NewRateRepository(filepath.Join(t.TempDir(), "rates.json"))
+
+	if rates, err := repo.List(42); err != nil || len(rates) != 0 {
+		t.Fatalf("expected empty repository, got %v, %v", rates, err)
+	}
+	usd := currency_domain.Rate{ChatID: 42, Base: "IDR", Currency: "USD", Rate: 16200, Date: "2025-07-01"}
+	sgd := currency_domain.Rate{ChatID: 42, Base: "IDR", Currency: "SGD", Rate: 12500, Date: "2025-07-01"}
+	other := currency_domain.Rate{ChatID: -100, Base: "IDR", Currency: "USD", Rate: 16000, Date: "2025-07-01"}
+	if err := repo.Save(usd, sgd, other); err != nil {
+		t.Fatal(err)
+	}
+	usd.Rate = 16300
+	if err := repo.Save(usd); err != nil {
+		t.Fatal(err)
+	}
+
+	rates, err := repo.List(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) != 2 || rates[0].Rate != 16300 || rates[1].Currency != "SGD" {
+		t.Errorf("expected the USD rate to be replaced, got %+v", rates)
+	}
+}
@@ -0,0 +1,98 @@
+package filestore
+
+import (
+	recurring_domain "money-tracker-bot/internal/domain/recurring"
+	"sort"
+	"strconv"
+)
+
+// RecurringRepository stores recurring transactions of every chat
+type RecurringRepository struct {
+	store *Store
+}
+
+type recurringDocument struct {
+	NextID int                                   `json:"next_id"`
+	Items  map[string]recurring_domain.Recurring `json:"items"`
+}
+
+func NewRecurringRepository(path string) *RecurringRepository {
+	return &RecurringRepository{store: New(path)}
+}
+
+func (r *RecurringRepository) load() (recurringDocument, error) {
+	doc := recurringDocument{NextID: 1, Items: make(map[string]recurring_domain.Recurring)}
+	if err := r.store.Load(&doc); err != nil {
+		return doc, err
+	}
+	if doc.Items == nil {
+		doc.Items = make(map[string]recurring_domain.Recurring)
+	}
+	return doc, nil
+}
+
+// ListAll returns every entry ordered by ID.
+func (r *RecurringRepository) ListAll() ([]recurring_domain.Recurring, error) {
+	doc, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]recurring_domain.Recurring, 0, len(doc.Items))
+	for _, item := range doc.Items {
+		out = append(out, item)
+	}
+	sort.Slice(out, func(i, j int) bool { return idNumber(out[i].ID) < idNumber(out[j].ID) })
+	return out, nil
+}
+
+// List returns the entries of one chat ordered by ID.
+func (r *RecurringRepository) List(chatID int64) ([]recurring_domain.Recurring, error) {
+	all, err := r.ListAll()
+	if err != nil {
+		return nil, err
+	}
+	var out []recurring_domain.Recurring
+	for _, item := range all {
+		if item.ChatID == chatID {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+func (r *RecurringRepository) Get(id string) (recurring_domain.Recurring, bool, error) {
+	doc, err := r.load()
+	if err != nil {
+		return recurring_domain.Recurring{}, false, err
+	}
+	item, ok := doc.Items[id]
+	return item, ok, nil
+}
+
+func (r *RecurringRepository) Save(item recurring_domain.Recurring) (recurring_domain.Recurring, error) {
+	doc, err := r.load()
+	if err != nil {
+		return item, err
+	}
+	if item.ID == "" {
+		item.ID = "r" + strconv.Itoa(doc.NextID)
+		doc.NextID++
+	}
+	doc.Items[item.ID] = item
+	return item, r.store.Save(doc)
+}
+
+func (r *RecurringRepository) Delete(id string) error {
+	doc, err := r.load()
+	if err != nil {
+		return err
+	}
+	delete(doc.Items, id)
+	return r.store.Save(doc)
+}
+
+// idNumber extracts the sequence number of a prefixed ID such as "r12"
+func idNumber(id string) int {
+	n, _ := strconv.Atoi(id[min(1, len(id)):])
+	return n
+}
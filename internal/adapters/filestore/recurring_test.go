@@ -0,0 +1,40 @@
+package filestore
+
+import (
+	recurring_domain "money-tracker-bot/internal/domain/recurring"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecurringRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recurring.json")
+	repo := NewRecurringRepository(path)
+
+	var ids []string
+	for i, chatID := range []int64{1, 2, 1} {
+		saved, err := repo.Save(recurring_domain.Recurring{ChatID: chatID, Category: "Utilities", DayOfMonth: i + 1})
+		if err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+		ids = append(ids, saved.ID)
+	}
+	if ids[0] != "r1" || ids[2] != "r3" {
+		t.Errorf("expected sequential IDs, got %v", ids)
+	}
+
+	chat1, err := NewRecurringRepository(path).List(1)
+	if err != nil || len(chat1) != 2 || chat1[1].ID != "r3" {
+		t.Errorf("unexpected entries for chat 1: %+v, %v", chat1, err)
+	}
+
+	if err := repo.Delete("r1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, found, _ := repo.Get("r1"); found {
+		t.Error("deleted entry should be gone")
+	}
+	next, _ := repo.Save(recurring_domain.Recurring{ChatID: 1})
+	if next.ID != "r4" {
+		t.Errorf("IDs must not be reused, got %s", next.ID)
+	}
+}
@@ -0,0 +1,87 @@
+package filestore
+
+import (
+	review_domain "money-tracker-bot/internal/domain/review"
+	"sort"
+	"strconv"
+)
+
+// ReviewRepository stores the transactions awaiting review in every chat
+type ReviewRepository struct {
+	store *Store
+}
+
+type reviewDocument struct {
+	NextID int                              `json:"next_id"`
+	Items  map[string]review_domain.Pending `json:"items"`
+}
+
+func NewReviewRepository(path string) *ReviewRepository {
+	return &ReviewRepository{store: New(path)}
+}
+
+func (r *ReviewRepository) load() (reviewDocument, error) {
+	doc := reviewDocument{NextID: 1, Items: make(map[string]review_domain.Pending)}
+	if err := r.store.Load(&doc); err != nil {
+		return doc, err
+	}
+	if doc.Items == nil {
+		doc.Items = make(map[string]review_domain.Pending)
+	}
+	return doc, nil
+}
+
+// List returns the pending transactions of one chat, oldest first.
+func (r *ReviewRepository) List(chatID int64) ([]review_domain.Pending, error) {
+	doc, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	var out []review_domain.Pending
+	for _, item := range doc.Items {
+		if item.ChatID == chatID {
+			out = append(out, item)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return idNumber(out[i].ID) < idNumber(out[j].ID) })
+	return out, nil
+}
+
+func (r *ReviewRepository) Get(id string) (review_domain.Pending, bool, error) {
+	doc, err := r.load()
+	if err != nil {
+		return review_domain.Pending{}, false, err
+	}
+	item, ok := doc.Items[id]
+	return item, ok, nil
+}
+
+// Add queues a transaction, assigning the next ID (q1, q2, …).
+func (r *ReviewRepository) Add(item review_domain.Pending) (review_domain.Pending, error) {
+	doc, err := r.load()
+	if err != nil {
+		return item, err
+	}
+	item.ID = "q" + strconv.Itoa(doc.NextID)
+	doc.NextID++
+	doc.Items[item.ID] = item
+	return item, r.store.Save(doc)
+}
+
+func (r *ReviewRepository) Delete(id string) error {
+	doc, err := r.load()
+	if err != nil {
+		return err
+	}
+	delete(doc.Items, id)
+	return r.store.Save(doc)
+}
+
+// Len counts the transactions awaiting review in every chat
+func (r *ReviewRepository) Len() (int, error) {
+	doc, err := r.load()
+	if err != nil {
+		return 0, err
+	}
+	return len(doc.Items), nil
+}
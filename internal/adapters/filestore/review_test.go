@@ -0,0 +1,45 @@
+package filestore
+
+import (
+	review_domain "money-tracker-bot/internal/domain/review"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"path/filepath"
+	"testing"
+)
+
+func TestReviewRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review.json")
+	repo := NewReviewRepository(path)
+
+	for _, chatID := range []int64{1, 2, 1} {
+		trx := transaction_domain.Transaction{Amount: "45,000", Confidence: map[string]float64{"amount": 0.4}, LowConfidence: []string{"amount"}}
+		if _, err := repo.Add(review_domain.Pending{ChatID: chatID, Transaction: trx}); err != nil {
+			t.Fatalf("add failed: %v", err)
+		}
+	}
+
+	chat1, err := NewReviewRepository(path).List(1)
+	if err != nil || len(chat1) != 2 || chat1[0].ID != "q1" || chat1[1].ID != "q3" {
+		t.Fatalf("unexpected entries for chat 1: %+v, %v", chat1, err)
+	}
+	if chat1[0].Transaction.Confidence["amount"] != 0.4 || len(chat1[0].Transaction.LowConfidence) != 1 {
+		t.Errorf("confidence should survive a reload, got %+v", chat1[0].Transaction)
+	}
+
+	if err := repo.Delete("q1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, found, _ := repo.Get("q1"); found {
+		t.Error("deleted entry should be gone")
+	}
+	if item, found, _ := repo.Get("q2"); !found || item.ChatID != 2 {
+		t.Errorf("expected q2 of chat 2, got %+v", item)
+	}
+	next, _ := repo.Add(review_domain.Pending{ChatID: 1})
+	if next.ID != "q4" {
+		t.Errorf("IDs must not be reused, got %s", next.ID)
+	}
+	if n, err := repo.Len(); err != nil || n != 3 {
+		t.Errorf("expected three queued transactions, got %d, %v", n, err)
+	}
+}
@@ -0,0 +1,77 @@
+package filestore
+
+import (
+	rules_domain "money-tracker-bot/internal/domain/rules"
+)
+
+// RuleRepository stores categorization rules of every chat in creation order
+type RuleRepository struct {
+	store *Store
+}
+
+type ruleDocument struct {
+	NextID int                 `json:"next_id"`
+	Rules  []rules_domain.Rule `json:"rules"`
+}
+
+func NewRuleRepository(path string) *RuleRepository {
+	return &RuleRepository{store: New(path)}
+}
+
+func (r *RuleRepository) load() (ruleDocument, error) {
+	doc := ruleDocument{NextID: 1}
+	if err := r.store.Load(&doc); err != nil {
+		return doc, err
+	}
+	return doc, nil
+}
+
+// List returns the rules of one chat ordered by ID.
+func (r *RuleRepository) List(chatID int64) ([]rules_domain.Rule, error) {
+	doc, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	var out []rules_domain.Rule
+	for _, rule := range doc.Rules {
+		if rule.ChatID == chatID {
+			out = append(out, rule)
+		}
+	}
+	return out, nil
+}
+
+// Save adds a rule with the next ID, or replaces the rule with the same ID.
+func (r *RuleRepository) Save(rule rules_domain.Rule) (rules_domain.Rule, error) {
+	doc, err := r.load()
+	if err != nil {
+		return rule, err
+	}
+	if rule.ID == 0 {
+		rule.ID = doc.NextID
+		doc.NextID++
+		doc.Rules = append(doc.Rules, rule)
+		return rule, r.store.Save(doc)
+	}
+	for i := range doc.Rules {
+		if doc.Rules[i].ID == rule.ID {
+			doc.Rules[i] = rule
+		}
+	}
+	return rule, r.store.Save(doc)
+}
+
+// Delete removes a rule of the chat and reports whether it existed.
+func (r *RuleRepository) Delete(chatID int64, id int) (bool, error) {
+	doc, err := r.load()
+	if err != nil {
+		return false, err
+	}
+	for i, rule := range doc.Rules {
+		if rule.ID == id && rule.ChatID == chatID {
+			doc.Rules = append(doc.Rules[:i], doc.Rules[i+1:]...)
+			return true, r.store.Save(doc)
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,36 @@
+package filestore
+
+import (
+	rules_domain "money-tracker-bot/internal/domain/rules"
+	"path/filepath"
+	"testing"
+)
+
+func TestRuleRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	repo := NewRuleRepository(path)
+
+	first, _ := repo.Save(rules_domain.Rule{ChatID: 1, Kind: rules_domain.KindKeyword, Pattern: "grab"})
+	repo.Save(rules_domain.Rule{ChatID: 2, Kind: rules_domain.KindKeyword, Pattern: "gojek"})
+	third, err := repo.Save(rules_domain.Rule{ChatID: 1, Kind: rules_domain.KindAccount, Pattern: "OVO"})
+	if err != nil || first.ID != 1 || third.ID != 3 {
+		t.Fatalf("expected sequential IDs, got %d, %d, %v", first.ID, third.ID, err)
+	}
+
+	first.Hits = 4
+	repo.Save(first)
+	rules, err := NewRuleRepository(path).List(1)
+	if err != nil || len(rules) != 2 || rules[0].Hits != 4 || rules[1].Pattern != "OVO" {
+		t.Fatalf("unexpected rules: %+v, %v", rules, err)
+	}
+
+	if found, _ := repo.Delete(2, 1); found {
+		t.Error("a chat must not delete another chat's rule")
+	}
+	if found, _ := repo.Delete(1, 1); !found {
+		t.Error("expected rule to be deleted")
+	}
+	if rules, _ := repo.List(1); len(rules) != 1 {
+		t.Errorf("expected one rule left, got %+v", rules)
+	}
+}
@@ -0,0 +1,41 @@
+package filestore
+
+import (
+	settings_domain "money-tracker-bot/internal/domain/settings"
+	"strconv"
+)
+
+// SettingsRepository stores the settings of each chat keyed by chat ID
+type SettingsRepository struct {
+	store *Store
+}
+
+func NewSettingsRepository(path string) *SettingsRepository {
+	return &SettingsRepository{store: New(path)}
+}
+
+func (r *SettingsRepository) load() (map[string]settings_domain.Settings, error) {
+	all := make(map[string]settings_domain.Settings)
+	if err := r.store.Load(&all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+func (r *SettingsRepository) Get(chatID int64) (settings_domain.Settings, bool, error) {
+	all, err := r.load()
+	if err != nil {
+		return settings_domain.Settings{}, false, err
+	}
+	s, ok := all[strconv.FormatInt(chatID, 10)]
+	return s, ok, nil
+}
+
+func (r *SettingsRepository) Save(s settings_domain.Settings) error {
+	all, err := r.load()
+	if err != nil {
+		return err
+	}
+	all[strconv.FormatInt(s.ChatID, 10)] = s
+	return r.store.Save(all)
+}
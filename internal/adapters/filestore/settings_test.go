@@ -0,0 +1,25 @@
+package filestore
+
+import (
+	settings_domain "money-tracker-bot/internal/domain/settings"
+	"path/filepath"
+	"testing"
+)
+
+func TestSettingsRepository(t *testing.T) {
+	repo := NewSettingsRepository(filepath.Join(t.TempDir(), "settings.json"))
+
+	if _, found, err := repo.Get(42); err != nil || found {
+		t.Fatalf("expected empty repository, got found=%v err=%v", found, err)
+	}
+	if err := repo.Save(settings_domain.Settings{ChatID: 42, Timezone: "Asia/Jakarta"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Save(settings_domain.Settings{ChatID: -100, Locale: "id-ID"}); err != nil {
+		t.Fatal(err)
+	}
+	got, found, err := repo.Get(42)
+	if err != nil || !found || got.Timezone != "Asia/Jakarta" || got.Locale != "" {
+		t.Errorf("unexpected settings %+v found=%v err=%v", got, found, err)
+	}
+}
@@ -0,0 +1,58 @@
+package filestore
+
+import (
+	split_domain "money-tracker-bot/internal/domain/split"
+	"strconv"
+)
+
+// SplitRepository stores the shared expense ledger of every chat
+type SplitRepository struct {
+	store *Store
+}
+
+type splitDocument struct {
+	NextID  int                  `json:"next_id"`
+	Entries []split_domain.Entry `json:"entries"`
+}
+
+func NewSplitRepository(path string) *SplitRepository {
+	return &SplitRepository{store: New(path)}
+}
+
+func (r *SplitRepository) load() (splitDocument, error) {
+	doc := splitDocument{NextID: 1}
+	if err := r.store.Load(&doc); err != nil {
+		return doc, err
+	}
+	return doc, nil
+}
+
+// List returns the ledger entries of one chat in the order they were added.
+func (r *SplitRepository) List(chatID int64) ([]split_domain.Entry, error) {
+	doc, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	var out []split_domain.Entry
+	for _, e := range doc.Entries {
+		if e.ChatID == chatID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// Add appends entries to the ledger in a single write, assigning IDs
+// (s1, s2, …) so a settlement is never half recorded.
+func (r *SplitRepository) Add(entries ...split_domain.Entry) ([]split_domain.Entry, error) {
+	doc, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].ID = "s" + strconv.Itoa(doc.NextID)
+		doc.NextID++
+	}
+	doc.Entries = append(doc.Entries, entries...)
+	return entries, r.store.Save(doc)
+}
@@ -0,0 +1,29 @@
+package filestore
+
+import (
+	split_domain "money-tracker-bot/internal/domain/split"
+	"path/filepath"
+	"testing"
+)
+
+func TestSplitRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "splits.json")
+	repo := NewSplitRepository(path)
+
+	added, err := repo.Add(
+		split_domain.Entry{ChatID: 1, PaidBy: "alice", Amount: 100},
+		split_domain.Entry{ChatID: 2, PaidBy: "bob", Amount: 50},
+	)
+	if err != nil || added[0].ID != "s1" || added[1].ID != "s2" {
+		t.Fatalf("expected sequential IDs, got %+v, %v", added, err)
+	}
+	repo.Add(split_domain.Entry{ChatID: 1, PaidBy: "carol", Amount: 30})
+
+	entries, err := NewSplitRepository(path).List(1)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("expected 2 entries for chat 1, got %+v, %v", entries, err)
+	}
+	if entries[0].PaidBy != "alice" || entries[1].ID != "s3" {
+		t.Errorf("entries should keep insertion order, got %+v", entries)
+	}
+}
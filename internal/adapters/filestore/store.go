@@ -0,0 +1,88 @@
+package filestore
+
+// Package filestore provides a small JSON file persistence layer for bot state
+// that does not belong in the spreadsheet (schedules, settings, caches).
+
+import (
+	"encoding/json"
+	"money-tracker-bot/internal/errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists a single JSON document on disk.
+// Writes go to a temporary file first and are renamed into place so a crash
+// never leaves a half-written document behind.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// New creates a Store backed by the file at path.
+// The file and its parent directory are created lazily on the first Save.
+func New(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the file backing the store.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load decodes the stored document into v.
+// A missing file is not an error: v is left untouched so callers can
+// pre-populate defaults.
+func (s *Store) Load(v interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.NewFileError("failed to read store", err).
+			WithContext("path", s.path).
+			WithComponent("filestore")
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.NewDataAccessError("failed to decode store", err).
+			WithContext("path", s.path).
+			WithComponent("filestore")
+	}
+	return nil
+}
+
+// Save encodes v as indented JSON and atomically replaces the stored document.
+func (s *Store) Save(v interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.NewDataAccessError("failed to encode store", err).
+			WithContext("path", s.path).
+			WithComponent("filestore")
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return errors.NewFileError("failed to create store directory", err).
+			WithContext("path", s.path).
+			WithComponent("filestore")
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return errors.NewFileError("failed to write store", err).
+			WithContext("path", tmp).
+			WithComponent("filestore")
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return errors.NewFileError("failed to replace store", err).
+			WithContext("path", s.path).
+			WithComponent("filestore")
+	}
+	return nil
+}
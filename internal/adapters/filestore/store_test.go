@@ -0,0 +1,53 @@
+package filestore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type sample struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestStore_LoadMissingFileKeepsDefaults(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "missing.json"))
+	v := sample{Name: "default"}
+	if err := s.Load(&v); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if v.Name != "default" {
+		t.Errorf("expected defaults to be kept, got %+v", v)
+	}
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+	s := New(path)
+	if err := s.Save(sample{Name: "rent", Count: 3}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("temporary file should be renamed away")
+	}
+
+	var got sample
+	if err := New(path).Load(&got); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if got.Name != "rent" || got.Count != 3 {
+		t.Errorf("unexpected document: %+v", got)
+	}
+}
+
+func TestStore_LoadCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corrupt.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var v sample
+	if err := New(path).Load(&v); err == nil {
+		t.Error("expected decode error for corrupt file")
+	}
+}
@@ -0,0 +1,62 @@
+package filestore
+
+import (
+	usage_domain "money-tracker-bot/internal/domain/usage"
+	"strconv"
+)
+
+// UsageRepository stores the daily AI usage of every chat, keyed by date
+// (YYYY-MM-DD) and chat ID
+type UsageRepository struct {
+	store *Store
+}
+
+func NewUsageRepository(path string) *UsageRepository {
+	return &UsageRepository{store: New(path)}
+}
+
+func (r *UsageRepository) load() (map[string]map[string]usage_domain.Totals, error) {
+	days := make(map[string]map[string]usage_domain.Totals)
+	if err := r.store.Load(&days); err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
+// Add counts tokens used by a chat on a date for one request type.
+func (r *UsageRepository) Add(date string, chatID int64, kind string, tokens usage_domain.Tokens) error {
+	days, err := r.load()
+	if err != nil {
+		return err
+	}
+	day := days[date]
+	if day == nil {
+		day = make(map[string]usage_domain.Totals)
+		days[date] = day
+	}
+	key := strconv.FormatInt(chatID, 10)
+	totals := day[key]
+	if totals == nil {
+		totals = make(usage_domain.Totals)
+		day[key] = totals
+	}
+	totals[kind] = totals[kind].Add(tokens)
+	return r.store.Save(days)
+}
+
+// Day returns the usage of every chat on a date; empty when nothing was used.
+func (r *UsageRepository) Day(date string) (map[int64]usage_domain.Totals, error) {
+	days, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[int64]usage_domain.Totals, len(days[date]))
+	for key, totals := range days[date] {
+		chatID, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			continue
+		}
+		out[chatID] = totals
+	}
+	return out, nil
+}
@@ -0,0 +1,35 @@
+package filestore
+
+import (
+	usage_domain "money-tracker-bot/internal/domain/usage"
+	"path/filepath"
+	"testing"
+)
+
+func TestUsageRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.json")
+	repo := NewUsageRepository(path)
+
+	empty, err := repo.Day("2025-03-10")
+	if err != nil || len(empty) != 0 {
+		t.Fatalf("expected no usage, got %+v, %v", empty, err)
+	}
+
+	repo.Add("2025-03-10", 1, usage_domain.KindImage, usage_domain.Tokens{Requests: 1, Prompt: 1200, Response: 150})
+	repo.Add("2025-03-10", 1, usage_domain.KindImage, usage_domain.Tokens{Requests: 1, Prompt: 1000, Response: 100})
+	repo.Add("2025-03-10", 2, usage_domain.KindText, usage_domain.Tokens{Requests: 1, Prompt: 300, Response: 40})
+	if err := repo.Add("2025-03-11", 1, usage_domain.KindText, usage_domain.Tokens{Requests: 1, Prompt: 300}); err != nil {
+		t.Fatal(err)
+	}
+
+	day, err := NewUsageRepository(path).Day("2025-03-10")
+	if err != nil || len(day) != 2 {
+		t.Fatalf("expected usage of two chats, got %+v, %v", day, err)
+	}
+	if got := day[1][usage_domain.KindImage]; got != (usage_domain.Tokens{Requests: 2, Prompt: 2200, Response: 250}) {
+		t.Errorf("unexpected image usage %+v", got)
+	}
+	if _, ok := day[1][usage_domain.KindText]; ok {
+		t.Error("usage of another day leaked into 2025-03-10")
+	}
+}
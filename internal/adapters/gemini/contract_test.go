@@ -0,0 +1,66 @@
+package gemini
+
+import (
+	"encoding/json"
+	aiport "money-tracker-bot/internal/port/out/ai"
+	"money-tracker-bot/internal/port/out/ai/aitest"
+	"net/http"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/option"
+)
+
+// geminiStub speaks the generateContent REST protocol of the Gemini API
+func geminiStub(respond aitest.Responder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, ":generateContent") {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			Contents []struct {
+				Parts []struct {
+					Text       string          `json:"text"`
+					InlineData json.RawMessage `json:"inlineData"`
+				} `json:"parts"`
+			} `json:"contents"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var call aitest.Call
+		for _, c := range req.Contents {
+			for _, p := range c.Parts {
+				call.Prompt += p.Text
+				call.HasImage = call.HasImage || len(p.InlineData) > 0
+			}
+		}
+		status, text := respond(call)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": status, "message": text}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"candidates": []any{map[string]any{
+				"content": map[string]any{"role": "model", "parts": []any{map[string]any{"text": text}}},
+			}},
+		})
+	})
+}
+
+func TestGeminiClient_Contract(t *testing.T) {
+	aitest.Run(t, aitest.Adapter{
+		Handler: geminiStub,
+		New: func(t *testing.T, baseURL string) aiport.AiPort {
+			client, err := NewClient("test-key", "", option.WithEndpoint(baseURL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return client
+		},
+	})
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"log/slog"
 	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	usage_domain "money-tracker-bot/internal/domain/usage"
 	"money-tracker-bot/internal/errors"
 	"os"
 	"strings"
@@ -22,36 +24,85 @@ type GenerativeModelPort interface {
 	GenerateContent(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error)
 }
 
+// UsageRecorder meters the tokens of every Gemini call per tenant
+type UsageRecorder interface {
+	// Allow fails when the tenant of ctx is over its quota
+	Allow(ctx context.Context) error
+	Record(ctx context.Context, kind string, tokens usage_domain.Tokens)
+}
+
 // GeminiClient is a client for communicating with the Gemini API
 type GeminiClient struct {
 	GenAi *genai.Client
 	Model GenerativeModelPort
+	// ModelName is the name of Model, checked by Ping
+	ModelName string
+	// PromptVersion selects the prompt template, common.PromptVersion when empty
+	PromptVersion string
+	// Clock dates text messages, the system clock when nil
+	Clock common.Clock
+	// Location is the timezone of those dates; UTC when nil
+	Location *time.Location
+	// Zones is optional; it dates text messages in each chat's timezone instead of Location
+	Zones common.Zones
+	// Usage is optional; calls are neither metered nor capped when nil
+	Usage UsageRecorder
 }
 
-// NewClient creates a new GeminiClient
-func NewClient(apiKey string) (*GeminiClient, error) {
-	client, err := genai.NewClient(context.Background(), option.WithAPIKey(apiKey))
+// DefaultModel is the Gemini model used when none is configured
+const DefaultModel = "gemini-2.0-flash"
+
+// NewClient creates a new GeminiClient for model, DefaultModel when empty.
+// Extra options such as option.WithEndpoint point it at another server.
+func NewClient(apiKey, model string, opts ...option.ClientOption) (*GeminiClient, error) {
+	if model == "" {
+		model = DefaultModel
+	}
+	client, err := genai.NewClient(context.Background(), append([]option.ClientOption{option.WithAPIKey(apiKey)}, opts...)...)
 	if err != nil {
 		return nil, errors.NewGeminiError("failed to create Gemini client", err).
 			WithContext("api_key_provided", apiKey != "").
+			WithContext("model", model).
 			WithComponent("gemini-client")
 	}
 	return &GeminiClient{
-		GenAi: client,
-		Model: client.GenerativeModel("gemini-2.0-flash"),
+		GenAi:     client,
+		Model:     client.GenerativeModel(model),
+		ModelName: model,
 	}, nil
 }
 
-// GenerateContent sends a prompt to Gemini and returns the response text
-func (c *GeminiClient) GenerateContent(ctx context.Context, prompt string) error {
-	_, err := c.Model.GenerateContent(ctx, genai.Text(prompt))
+// Ping checks that the API key is valid and the model exists, for the
+// readiness probe. It spends no tokens.
+func (c *GeminiClient) Ping(ctx context.Context) error {
+	if _, err := c.GenAi.GenerativeModel(c.ModelName).Info(ctx); err != nil {
+		return errors.NewGeminiError("Gemini rejected the API key or model", err).
+			WithContext("model", c.ModelName).
+			WithComponent("gemini-client")
+	}
+	return nil
+}
+
+// GenerateContent sends a prompt to Gemini and returns the text of the first
+// candidate that has any; an answer without text is an error
+func (c *GeminiClient) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	resp, err := c.generate(ctx, usage_domain.KindContent, genai.Text(prompt))
 	if err != nil {
-		return errors.NewGeminiError("failed to generate content", err).
+		if isQuotaError(err) {
+			return "", err
+		}
+		return "", errors.NewGeminiError("failed to generate content", err).
 			WithContext("prompt_length", len(prompt)).
 			WithComponent("gemini-client")
 	}
-	// For testability, we do not process the response here
-	return nil
+	for _, cand := range resp.Candidates {
+		if text := strings.TrimSpace(candidateText(cand)); text != "" {
+			return text, nil
+		}
+	}
+	return "", errors.NewDataFormatError("no text in Gemini response", nil).
+		WithContext("candidates", len(resp.Candidates)).
+		WithComponent("gemini-client")
 }
 
 func (c *GeminiClient) ReadImageToTransaction(ctx context.Context, imgPath string) (*transaction_domain.Transaction, error) {
@@ -65,87 +116,170 @@ func (c *GeminiClient) ReadImageToTransaction(ctx context.Context, imgPath strin
 		fileID = parts[len(parts)-1]
 	}
 
-	prompt := common.BuildPrompt(common.PromptParams{
-		IsImage: true,
-		FileID:  fileID,
+	prompt, err := common.BuildPrompt(common.PromptParams{
+		Version:    c.PromptVersion,
+		IsImage:    true,
+		FileID:     fileID,
+		Categories: common.PromptCategories(ctx),
+		Examples:   common.PromptExamples(ctx),
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	req := []genai.Part{
 		genai.ImageData("jpeg", imgData),
 		genai.Text(prompt),
 	}
 
-	resp, err := c.Model.GenerateContent(ctx, req...)
+	resp, err := c.generate(ctx, usage_domain.KindImage, req...)
 	if err != nil {
-		return nil, fmt.Errorf("gemini generate content error: %w", err)
+		if isQuotaError(err) {
+			return nil, err
+		}
+		return nil, errors.NewGeminiError("failed to read image", err).
+			WithContext("file_id", fileID).
+			WithComponent("gemini-client")
 	}
 
-	var transaction transaction_domain.Transaction
-	for _, cand := range resp.Candidates {
-		if cand.Content == nil || len(cand.Content.Parts) == 0 {
-			continue
-		}
-		var jsonText string
-		for _, part := range cand.Content.Parts {
-			if textPart, ok := part.(genai.Text); ok {
-				jsonText += fmt.Sprintf("%s", textPart)
-			}
-		}
-		jsonText = trimJson(jsonText)
-		if err := json.Unmarshal([]byte(jsonText), &transaction); err != nil {
-			log.Printf("Failed to parse JSON: %v\nResponse:\n%s", err, jsonText)
-			continue
-		}
-		// Ensure amount is positive
-		transaction.Amount = ensurePositiveAmount(transaction.Amount)
+	transaction, err := transactionFromResponse(ctx, resp)
+	if err != nil {
+		// Keep the image so a fallback provider can still read it
+		return nil, err
 	}
+	transaction.PromptVersion = c.promptVersion()
 
 	if err := os.Remove(imgPath); err != nil {
 		log.Printf("Failed to remove file %s: %v", imgPath, err)
 	}
-	return &transaction, nil
+	return transaction, nil
 }
 
 func (c *GeminiClient) TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error) {
-	currentDate := time.Now().Format("2006-01-02")
+	currentDate := c.now(ctx).Format("2006-01-02")
 
-	prompt := common.BuildPrompt(common.PromptParams{
+	prompt, err := common.BuildPrompt(common.PromptParams{
+		Version:     c.PromptVersion,
 		IsImage:     false,
 		Message:     message,
 		CurrentDate: currentDate,
+		Categories:  common.PromptCategories(ctx),
+		Examples:    common.PromptExamples(ctx),
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	req := []genai.Part{
 		genai.Text(prompt),
 	}
 
-	resp, err := c.Model.GenerateContent(ctx, req...)
+	resp, err := c.generate(ctx, usage_domain.KindText, req...)
+	if err != nil {
+		if isQuotaError(err) {
+			return nil, err
+		}
+		return nil, errors.NewGeminiError("failed to parse text", err).
+			WithContext("message_length", len(message)).
+			WithComponent("gemini-client")
+	}
+
+	transaction, err := transactionFromResponse(ctx, resp)
 	if err != nil {
-		return nil, fmt.Errorf("gemini generate content error: %w", err)
+		return nil, err
+	}
+	transaction.PromptVersion = c.promptVersion()
+	return transaction, nil
+}
+
+// generate calls the model within the tenant's quota and records the tokens
+// of the response, which are billed even when it holds no transaction
+func (c *GeminiClient) generate(ctx context.Context, kind string, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	if c.Usage == nil {
+		return c.Model.GenerateContent(ctx, parts...)
+	}
+	if err := c.Usage.Allow(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := c.Model.GenerateContent(ctx, parts...)
+	if resp != nil && resp.UsageMetadata != nil {
+		c.Usage.Record(ctx, kind, usage_domain.Tokens{
+			Requests: 1,
+			Prompt:   int(resp.UsageMetadata.PromptTokenCount),
+			Response: int(resp.UsageMetadata.CandidatesTokenCount),
+		})
+	}
+	return resp, err
+}
+
+// isQuotaError reports whether err is the tenant's exhausted quota from
+// generate. It is returned as is rather than as a Gemini error: it is not
+// retryable and says nothing about Gemini's health.
+func isQuotaError(err error) bool {
+	appErr, ok := err.(*errors.AppError)
+	return ok && appErr.Code == errors.ErrCodeQuota
+}
+
+func (c *GeminiClient) promptVersion() string {
+	if c.PromptVersion == "" {
+		return common.PromptVersion
+	}
+	return c.PromptVersion
+}
+
+// now is the current time in the timezone of the tenant in ctx
+func (c *GeminiClient) now(ctx context.Context) time.Time {
+	loc := common.ContextLocation(ctx, c.Zones, c.Location)
+	if c.Clock == nil {
+		return time.Now().In(loc)
 	}
+	return c.Clock.Now().In(loc)
+}
 
+// transactionFromResponse decodes the transaction JSON of the last candidate
+// that holds one. A response without any is a data format error: Gemini is
+// healthy, so the breaker ignores it, but another provider may still read it.
+func transactionFromResponse(ctx context.Context, resp *genai.GenerateContentResponse) (*transaction_domain.Transaction, error) {
 	var transaction transaction_domain.Transaction
+	parsed := false
+	var parseErr error
 	for _, cand := range resp.Candidates {
 		if cand.Content == nil || len(cand.Content.Parts) == 0 {
 			continue
 		}
-		var jsonText string
-		for _, part := range cand.Content.Parts {
-			if textPart, ok := part.(genai.Text); ok {
-				jsonText += fmt.Sprintf("%s", textPart)
-			}
-		}
-		jsonText = trimJson(jsonText)
+		jsonText := trimJson(candidateText(cand))
 		if err := json.Unmarshal([]byte(jsonText), &transaction); err != nil {
-			log.Printf("Failed to parse JSON: %v\nResponse:\n%s", err, jsonText)
+			errors.HandleErrorContext(ctx, err, "parsing JSON of the Gemini response")
+			slog.DebugContext(ctx, "Unparsed Gemini response", "response", jsonText)
+			parseErr = err
 			continue
 		}
 		// Ensure amount is positive
 		transaction.Amount = ensurePositiveAmount(transaction.Amount)
+		parsed = true
+	}
+	if !parsed {
+		return nil, errors.NewDataFormatError("no transaction in Gemini response", parseErr).
+			WithContext("candidates", len(resp.Candidates)).
+			WithComponent("gemini-client")
 	}
 	return &transaction, nil
 }
 
+// candidateText joins the text parts of a candidate
+func candidateText(cand *genai.Candidate) string {
+	if cand.Content == nil {
+		return ""
+	}
+	var text string
+	for _, part := range cand.Content.Parts {
+		if textPart, ok := part.(genai.Text); ok {
+			text += string(textPart)
+		}
+	}
+	return text
+}
+
 func trimJson(jsonText string) string {
 	jsonText = strings.TrimSpace(jsonText)
 	jsonText = strings.TrimPrefix(jsonText, "```json")
@@ -2,18 +2,36 @@ package gemini
 
 import (
 	"context"
+	"money-tracker-bot/internal/common"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	usage_domain "money-tracker-bot/internal/domain/usage"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/resilience"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/option"
 )
 
 type mockModel struct {
 	GenerateContentCalled bool
 	ResponseText          string
+	Prompt                string
+	Usage                 *genai.UsageMetadata
 }
 
 func (m *mockModel) GenerateContent(ctx context.Context, parts ...genai.Part) (*genai.GenerateContentResponse, error) {
 	m.GenerateContentCalled = true
+	for _, p := range parts {
+		if text, ok := p.(genai.Text); ok {
+			m.Prompt += string(text)
+		}
+	}
 	resp := &genai.GenerateContentResponse{
 		Candidates: []*genai.Candidate{
 			{
@@ -24,6 +42,7 @@ func (m *mockModel) GenerateContent(ctx context.Context, parts ...genai.Part) (*
 				},
 			},
 		},
+		UsageMetadata: m.Usage,
 	}
 	return resp, nil
 }
@@ -35,12 +54,23 @@ func (m *mockGenAi) GenerativeModel(name string) *mockModel {
 }
 
 func TestGeminiClient_GenerateContent(t *testing.T) {
+	model := &mockModel{ResponseText: "  Cook at home twice a week.\n"}
 	client := &GeminiClient{
 		GenAi: nil,
-		Model: &mockModel{},
+		Model: model,
+	}
+	text, err := client.GenerateContent(context.Background(), "test prompt")
+	if err != nil || text != "Cook at home twice a week." {
+		t.Errorf("unexpected answer %q, %v", text, err)
+	}
+	if model.Prompt != "test prompt" {
+		t.Errorf("prompt not sent, got %q", model.Prompt)
+	}
+
+	model.ResponseText = ""
+	if _, err := client.GenerateContent(context.Background(), "test prompt"); err == nil {
+		t.Error("expected error for an answer without text")
 	}
-	client.GenerateContent(context.Background(), "test prompt")
-	// No panic means pass for this stub
 }
 
 func TestGeminiClient_TextToTransaction(t *testing.T) {
@@ -136,3 +166,185 @@ func TestEnsurePositiveAmount(t *testing.T) {
 		})
 	}
 }
+
+func TestGeminiClient_TextToTransaction_TenantCategories(t *testing.T) {
+	model := &mockModel{ResponseText: `{"amount": "25000", "category": "Eating Out › Coffee"}`}
+	client := &GeminiClient{Model: model}
+
+	ctx := common.WithPromptCategories(context.Background(), []string{"Eating Out › Coffee (also: kopi)"})
+	if _, err := client.TextToTransaction(ctx, "kopi 25k"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.Contains(model.Prompt, "Eating Out › Coffee (also: kopi)") || strings.Contains(model.Prompt, "Rent House") {
+		t.Errorf("prompt should list the tenant categories, got:\n%s", model.Prompt)
+	}
+}
+
+func TestGeminiClient_TextToTransaction_LearnedExamples(t *testing.T) {
+	model := &mockModel{ResponseText: `{"amount": "150000", "category": "Groceries"}`}
+	client := &GeminiClient{Model: model}
+
+	ctx := common.WithPromptExamples(context.Background(), []string{`"Superindo" → Groceries`})
+	if _, err := client.TextToTransaction(ctx, "superindo 150k"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.Contains(model.Prompt, `"Superindo" → Groceries`) {
+		t.Errorf("prompt should list the learned examples, got:\n%s", model.Prompt)
+	}
+}
+
+type zonesStub map[int64]*time.Location
+
+func (z zonesStub) Location(chatID int64) *time.Location { return z[chatID] }
+
+func TestGeminiClient_TextToTransaction_TenantDate(t *testing.T) {
+	model := &mockModel{ResponseText: `{"amount": "25000"}`}
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	client := &GeminiClient{
+		Model: model,
+		// 20:00 UTC on 1 July is already 2 July in Jakarta
+		Clock: &common.FixedClock{Time: time.Date(2025, 7, 1, 20, 0, 0, 0, time.UTC)},
+		Zones: zonesStub{1: jakarta},
+	}
+
+	if _, err := client.TextToTransaction(common.WithTenant(context.Background(), 1), "kopi 25k"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.Contains(model.Prompt, "2025-07-02") {
+		t.Errorf("prompt should be dated in the chat's timezone, got:\n%s", model.Prompt)
+	}
+
+	model.Prompt = ""
+	if _, err := client.TextToTransaction(common.WithTenant(context.Background(), 2), "kopi 25k"); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if !strings.Contains(model.Prompt, "2025-07-01") {
+		t.Errorf("chats without a timezone should get Location, got:\n%s", model.Prompt)
+	}
+}
+
+type usageStub struct {
+	err    error
+	kinds  []string
+	tokens []usage_domain.Tokens
+}
+
+func (u *usageStub) Allow(ctx context.Context) error { return u.err }
+
+func (u *usageStub) Record(ctx context.Context, kind string, tokens usage_domain.Tokens) {
+	u.kinds = append(u.kinds, kind)
+	u.tokens = append(u.tokens, tokens)
+}
+
+func TestGeminiClient_RecordsUsage(t *testing.T) {
+	model := &mockModel{
+		ResponseText: `{"amount": "100", "title": "Groceries"}`,
+		Usage:        &genai.UsageMetadata{PromptTokenCount: 850, CandidatesTokenCount: 60, TotalTokenCount: 910},
+	}
+	usage := &usageStub{}
+	client := &GeminiClient{Model: model, Usage: usage}
+
+	if _, err := client.TextToTransaction(context.Background(), "spent 100 on groceries"); err != nil {
+		t.Fatal(err)
+	}
+	client.GenerateContent(context.Background(), "hello")
+	if len(usage.kinds) != 2 || usage.kinds[0] != usage_domain.KindText || usage.kinds[1] != usage_domain.KindContent {
+		t.Fatalf("unexpected recorded kinds %v", usage.kinds)
+	}
+	if usage.tokens[0] != (usage_domain.Tokens{Requests: 1, Prompt: 850, Response: 60}) {
+		t.Errorf("unexpected recorded tokens %+v", usage.tokens[0])
+	}
+}
+
+func TestGeminiClient_OverQuota(t *testing.T) {
+	model := &mockModel{ResponseText: `{"amount": "100"}`}
+	usage := &usageStub{err: errors.NewQuotaError("daily AI token quota used up", nil)}
+	client := &GeminiClient{Model: model, Usage: usage}
+
+	_, err := client.TextToTransaction(context.Background(), "spent 100")
+	if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.ErrCodeQuota {
+		t.Fatalf("expected a quota error, got %v", err)
+	}
+	if _, err := client.GenerateContent(context.Background(), "hello"); !isQuotaError(err) {
+		t.Errorf("expected a quota error from GenerateContent, got %v", err)
+	}
+	if errors.IsRetryableError(err) {
+		t.Error("a quota error must not be retryable")
+	}
+	if model.GenerateContentCalled {
+		t.Error("the model must not be called over quota")
+	}
+}
+
+func TestGeminiClient_Ping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("key") != "valid-key" && r.Header.Get("x-goog-api-key") != "valid-key" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"code": 400, "message": "API key not valid.", "status": "INVALID_ARGUMENT"}}`))
+			return
+		}
+		w.Write([]byte(`{"name": "models/gemini-2.0-flash"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("valid-key", "", option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Ping(context.Background()); err != nil {
+		t.Errorf("expected a valid key to pass, got %v", err)
+	}
+	client, err = NewClient("revoked-key", "", option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.Ping(context.Background())
+	if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.ErrCodeGemini || appErr.Context["model"] != DefaultModel {
+		t.Errorf("expected a Gemini error, got %v", err)
+	}
+}
+
+func TestGeminiClient_UnavailableOpensBreaker(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error": {"code": 503, "message": "The model is overloaded.", "status": "UNAVAILABLE"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("valid-key", "", option.WithEndpoint(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	breaker := resilience.NewBreaker("gemini", 2, time.Minute, &common.FixedClock{Time: time.Date(2025, 7, 1, 8, 0, 0, 0, time.UTC)})
+	parse := func() error {
+		// The client library retries 503 until the deadline, so keep it short
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := resilience.Call(breaker, func() (*transaction_domain.Transaction, error) {
+			return client.TextToTransaction(ctx, "kopi 25k")
+		})
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		err := parse()
+		if !errors.IsRetryableError(err) {
+			t.Fatalf("call %d: expected a retryable Gemini error, got %v", i+1, err)
+		}
+	}
+	if state := breaker.Snapshot().State; state != resilience.Open {
+		t.Fatalf("expected the breaker to open after 2 failures, got %s", state)
+	}
+	served := requests.Load()
+	err = parse()
+	if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.ErrCodeCircuitOpen {
+		t.Errorf("expected CIRCUIT_OPEN while open, got %v", err)
+	}
+	if requests.Load() != served {
+		t.Error("an open breaker must not call Gemini")
+	}
+}
@@ -6,7 +6,9 @@ import (
 
 type MockGeminiClient struct{}
 
-func (m *MockGeminiClient) GenerateContent(ctx context.Context, prompt string) {}
+func (m *MockGeminiClient) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return "", nil
+}
 func (m *MockGeminiClient) ReadImageToTransaction(ctx context.Context, imgPath string) (interface{}, error) {
 	return nil, nil
 }
@@ -5,14 +5,33 @@ package spreadsheet
 import (
 	"context"
 	"fmt"
+	"money-tracker-bot/internal/common"
+	category_domain "money-tracker-bot/internal/domain/category"
 	transaction_domain "money-tracker-bot/internal/domain/transactions"
 	"money-tracker-bot/internal/errors"
+	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
 )
 
+const (
+	// summaryRange covers the per-category budget table (columns E and F hold quota data)
+	summaryRange = "summary!A2:F12"
+	// detailedAppendRange covers the transaction columns written by AppendRow
+	detailedAppendRange = "detailed!A:O"
+	// detailedReadRange covers every transaction row below the header
+	detailedReadRange = "detailed!A2:O"
+	// chatColumn is the index of the column (O) holding the chat a row was
+	// recorded by
+	chatColumn = 14
+)
+
+// sheetEpoch is day zero of the Google Sheets serial date system.
+var sheetEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
 type CategorySummary struct {
 	Category        string
 	MonthlyExpenses string
@@ -24,87 +43,92 @@ type CategorySummary struct {
 
 type SpreadsheetService struct {
 	Sheet *sheets.Service
+	// Location is the timezone of the createdAt timestamps; UTC when nil
+	Location *time.Location
+	// Zones is optional; it stamps createdAt in each chat's timezone instead of Location
+	Zones common.Zones
+	// LegacyChat is the chat that rows recorded before the chat column
+	// existed belong to; 0 leaves them out of every chat
+	LegacyChat int64
 }
 
-func NewSpreadsheetService() (*SpreadsheetService, error) {
-	srv, err := sheets.NewService(context.Background(), option.WithCredentialsFile("google-service-account.json"))
+// NewSpreadsheetService creates a Google Sheets client authenticated with the
+// service account key at credentialsFile, writing timestamps in loc
+func NewSpreadsheetService(credentialsFile string, loc *time.Location) (*SpreadsheetService, error) {
+	srv, err := sheets.NewService(context.Background(), option.WithCredentialsFile(credentialsFile))
 	if err != nil {
 		return nil, errors.NewSpreadsheetCriticalError("failed to create Google Sheets client", err).
-			WithContext("credentials_file", "google-service-account.json").
+			WithContext("credentials_file", credentialsFile).
 			WithComponent("spreadsheet-client")
 	}
 
 	return &SpreadsheetService{
-		Sheet: srv,
+		Sheet:    srv,
+		Location: loc,
 	}, nil
 }
 
+// createdAt formats now in the timezone of the tenant in ctx
+func (s SpreadsheetService) createdAt(ctx context.Context, now time.Time) string {
+	return now.In(common.ContextLocation(ctx, s.Zones, s.Location)).Format("2006-01-02 15:04:05")
+}
+
 func (s SpreadsheetService) AppendRow(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction) (CategorySummary, error) {
-	// Add createdAt as UTC+7 timestamp (column G)
-	loc, err := time.LoadLocation("Asia/Bangkok")
-	if err != nil {
-		return CategorySummary{}, errors.NewConfigError("failed to load timezone", err).
-			WithContext("timezone", "Asia/Bangkok").
-			WithComponent("spreadsheet-client")
+	// Add createdAt as a local timestamp (column G)
+	createdAt := s.createdAt(ctx, time.Now())
+	chatID := ""
+	if id, ok := common.TenantFrom(ctx); ok {
+		chatID = strconv.FormatInt(id, 10)
 	}
-	createdAt := time.Now().In(loc).Format("2006-01-02 15:04:05")
 
 	values := &sheets.ValueRange{
 		Values: [][]interface{}{{
 			trx.TransactionDate,
 			trx.Category,
-			"",
+			trx.Subcategory,
 			trx.Notes,
 			trx.Amount,
 			trx.CreatedBy,
 			trx.FileID,
 			createdAt,
+			trx.Goal,
+			strings.Join(trx.Tags, ", "),
+			trx.PromptVersion,
+			trx.OriginalAmount,
+			trx.OriginalCurrency,
+			rateCell(trx.ExchangeRate),
+			chatID,
 		}},
 	}
 
-	// Update range to include column G
-	_, err = s.Sheet.Spreadsheets.Values.Append(spreadsheetId, "detailed!A:G", values).ValueInputOption("USER_ENTERED").Do()
+	// Columns I, J and K hold the savings goal, tags and prompt version; L, M
+	// and N what was paid in another currency and the rate it was converted
+	// with, empty for base currency transactions; O the chat that recorded it
+	_, err := s.Sheet.Spreadsheets.Values.Append(spreadsheetId, detailedAppendRange, values).ValueInputOption("USER_ENTERED").Context(ctx).Do()
 	if err != nil {
 		return CategorySummary{}, errors.NewSpreadsheetError("failed to insert data to sheet", err).
 			WithContext("spreadsheet_id", spreadsheetId).
-			WithContext("range", "detailed!A:G").
+			WithContext("range", detailedAppendRange).
 			WithComponent("spreadsheet-client")
 	}
 
 	// Fetch summary data from summary sheet (now includes columns E and F)
-	summaryRange := "summary!A2:F12"
-	summaryValues, err := s.Sheet.Spreadsheets.Values.Get(spreadsheetId, summaryRange).Do()
+	summaryValues, err := s.Sheet.Spreadsheets.Values.Get(spreadsheetId, summaryRange).Context(ctx).Do()
 	if err != nil {
 		// Log warning but don't fail the transaction append
 		errorWithContext := errors.NewSpreadsheetError("failed to get summary data", err).
 			WithContext("spreadsheet_id", spreadsheetId).
 			WithContext("range", summaryRange).
 			WithComponent("spreadsheet-client")
-		errors.HandleError(errorWithContext, "retrieving category summary")
+		errors.HandleErrorContext(ctx, errorWithContext, "retrieving category summary")
 		return CategorySummary{}, nil
 	}
 
 	// Find the summary for the transaction's category
 	var result CategorySummary
 	for _, row := range summaryValues.Values {
-		if len(row) >= 4 && fmt.Sprintf("%v", row[0]) == trx.Category {
-			// Defensive: handle missing quota columns gracefully
-			quota := ""
-			quotaLeft := ""
-			if len(row) > 4 {
-				quota = fmt.Sprintf("%v", row[4])
-			}
-			if len(row) > 5 {
-				quotaLeft = fmt.Sprintf("%v", row[5])
-			}
-			result = CategorySummary{
-				Category:        fmt.Sprintf("%v", row[0]),
-				MonthlyExpenses: fmt.Sprintf("%v", row[1]),
-				MonthlyBudget:   fmt.Sprintf("%v", row[2]),
-				BudgetLeft:      fmt.Sprintf("%v", row[3]),
-				Quota:           quota,
-				QuotaLeft:       quotaLeft,
-			}
+		if summary, ok := parseSummaryRow(row); ok && summary.Category == trx.Category {
+			result = summary
 			break
 		}
 	}
@@ -112,8 +136,311 @@ func (s SpreadsheetService) AppendRow(ctx context.Context, spreadsheetId string,
 	return result, nil
 }
 
+// ListCategorySummaries returns the budget summary of every category in the summary sheet.
+func (s SpreadsheetService) ListCategorySummaries(ctx context.Context, spreadsheetId string) ([]CategorySummary, error) {
+	summaryValues, err := s.Sheet.Spreadsheets.Values.Get(spreadsheetId, summaryRange).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.NewSpreadsheetError("failed to get summary data", err).
+			WithContext("spreadsheet_id", spreadsheetId).
+			WithContext("range", summaryRange).
+			WithComponent("spreadsheet-client")
+	}
+
+	var summaries []CategorySummary
+	for _, row := range summaryValues.Values {
+		if summary, ok := parseSummaryRow(row); ok {
+			summaries = append(summaries, summary)
+		}
+	}
+	return summaries, nil
+}
+
+// ListTransactions reads every transaction recorded in the detailed sheet.
+// Dates are normalized to YYYY-MM-DD and amounts to plain numbers. Rows
+// without a chat are attributed to LegacyChat.
+func (s SpreadsheetService) ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error) {
+	values, err := s.Sheet.Spreadsheets.Values.Get(spreadsheetId, detailedReadRange).
+		ValueRenderOption("UNFORMATTED_VALUE").
+		DateTimeRenderOption("SERIAL_NUMBER").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, errors.NewSpreadsheetError("failed to get transactions", err).
+			WithContext("spreadsheet_id", spreadsheetId).
+			WithContext("range", detailedReadRange).
+			WithComponent("spreadsheet-client")
+	}
+
+	var trxs []transaction_domain.Transaction
+	for _, row := range values.Values {
+		if trx, ok := parseTransactionRow(row); ok {
+			trx.ChatID, _ = rowChat(row, s.LegacyChat)
+			trxs = append(trxs, trx)
+		}
+	}
+	return trxs, nil
+}
+
+// MigrateCategory rewrites the category columns of the transactions recorded
+// by the tenant in ctx that are affected by a rename or merge, and returns the
+// number of rows changed. Categories are per chat while the sheet is shared,
+// so rows of other chats are left alone; rows recorded before the chat column
+// existed are migrated for LegacyChat.
+func (s SpreadsheetService) MigrateCategory(ctx context.Context, spreadsheetId string, m category_domain.Migration) (int, error) {
+	chatID, ok := common.TenantFrom(ctx)
+	if !ok {
+		return 0, errors.NewSpreadsheetError("no chat to migrate categories for", nil).
+			WithContext("spreadsheet_id", spreadsheetId).
+			WithComponent("spreadsheet-client")
+	}
+	values, err := s.Sheet.Spreadsheets.Values.Get(spreadsheetId, detailedReadRange).
+		ValueRenderOption("UNFORMATTED_VALUE").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return 0, errors.NewSpreadsheetError("failed to get transaction categories", err).
+			WithContext("spreadsheet_id", spreadsheetId).
+			WithContext("range", detailedReadRange).
+			WithComponent("spreadsheet-client")
+	}
+
+	updates := categoryUpdates(values.Values, chatID, s.LegacyChat, m)
+	if len(updates) == 0 {
+		return 0, nil
+	}
+	req := &sheets.BatchUpdateValuesRequest{ValueInputOption: "RAW", Data: updates}
+	if _, err := s.Sheet.Spreadsheets.Values.BatchUpdate(spreadsheetId, req).Context(ctx).Do(); err != nil {
+		return 0, errors.NewSpreadsheetError("failed to migrate transaction categories", err).
+			WithContext("spreadsheet_id", spreadsheetId).
+			WithContext("from", m.From.String()).
+			WithContext("to", m.To.String()).
+			WithComponent("spreadsheet-client")
+	}
+	return len(updates), nil
+}
+
+// categoryUpdates builds one B:C update per row of detailed!A2:O recorded by
+// chatID and changed by m, counting rows without a chat as legacyChat's.
+// rows[0] is sheet row 2.
+func categoryUpdates(rows [][]interface{}, chatID, legacyChat int64, m category_domain.Migration) []*sheets.ValueRange {
+	var updates []*sheets.ValueRange
+	for i, row := range rows {
+		if recordedBy, ok := rowChat(row, legacyChat); !ok || recordedBy != chatID {
+			continue
+		}
+		ref := category_domain.Ref{Category: cellString(row, 1), Subcategory: cellString(row, 2)}
+		migrated, changed := m.Apply(ref)
+		if !changed {
+			continue
+		}
+		rowNumber := i + 2
+		updates = append(updates, &sheets.ValueRange{
+			Range:  fmt.Sprintf("detailed!B%d:C%d", rowNumber, rowNumber),
+			Values: [][]interface{}{{migrated.Category, migrated.Subcategory}},
+		})
+	}
+	return updates
+}
+
+// rowChat returns the chat a detailed row was recorded by. Rows recorded
+// before the chat column existed belong to legacyChat, unless it is 0.
+func rowChat(row []interface{}, legacyChat int64) (int64, bool) {
+	cell := strings.TrimSpace(cellString(row, chatColumn))
+	if cell == "" {
+		return legacyChat, legacyChat != 0
+	}
+	id, err := strconv.ParseInt(cell, 10, 64)
+	return id, err == nil
+}
+
+// Recategorize moves a transaction recorded by the tenant in ctx to another
+// category. The row is looked up from the bottom by date, amount, notes,
+// creator and category, so the most recent copy of a repeated transaction is
+// changed; rows of other chats are never changed. It reports whether a row
+// was found.
+func (s SpreadsheetService) Recategorize(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction, to category_domain.Ref) (bool, error) {
+	chatID, ok := common.TenantFrom(ctx)
+	if !ok {
+		return false, errors.NewSpreadsheetError("no chat to recategorize the transaction for", nil).
+			WithContext("spreadsheet_id", spreadsheetId).
+			WithComponent("spreadsheet-client")
+	}
+	values, err := s.Sheet.Spreadsheets.Values.Get(spreadsheetId, detailedReadRange).
+		ValueRenderOption("UNFORMATTED_VALUE").
+		DateTimeRenderOption("SERIAL_NUMBER").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return false, errors.NewSpreadsheetError("failed to get transactions", err).
+			WithContext("spreadsheet_id", spreadsheetId).
+			WithContext("range", detailedReadRange).
+			WithComponent("spreadsheet-client")
+	}
+	rowNumber := findTransactionRow(values.Values, trx, chatID, s.LegacyChat)
+	if rowNumber == 0 {
+		return false, nil
+	}
+	rng := fmt.Sprintf("detailed!B%d:C%d", rowNumber, rowNumber)
+	update := &sheets.ValueRange{Values: [][]interface{}{{to.Category, to.Subcategory}}}
+	if _, err := s.Sheet.Spreadsheets.Values.Update(spreadsheetId, rng, update).ValueInputOption("RAW").Context(ctx).Do(); err != nil {
+		return false, errors.NewSpreadsheetError("failed to update transaction category", err).
+			WithContext("spreadsheet_id", spreadsheetId).
+			WithContext("range", rng).
+			WithComponent("spreadsheet-client")
+	}
+	return true, nil
+}
+
+// findTransactionRow returns the sheet row number of the last row of
+// detailed!A2:O recording trx for chatID, counting rows without a chat as
+// legacyChat's, or 0. rows[0] is sheet row 2.
+func findTransactionRow(rows [][]interface{}, trx transaction_domain.Transaction, chatID, legacyChat int64) int {
+	for i := len(rows) - 1; i >= 0; i-- {
+		if recordedBy, ok := rowChat(rows[i], legacyChat); !ok || recordedBy != chatID {
+			continue
+		}
+		recorded, ok := parseTransactionRow(rows[i])
+		if !ok {
+			continue
+		}
+		if recorded.TransactionDate == trx.TransactionDate &&
+			recorded.Category == trx.Category &&
+			recorded.Subcategory == trx.Subcategory &&
+			recorded.Notes == trx.Notes &&
+			recorded.CreatedBy == trx.CreatedBy &&
+			sameAmount(recorded.Amount, trx.Amount) {
+			return i + 2
+		}
+	}
+	return 0
+}
+
+// sameAmount compares an amount read back from the sheet ("150000") with
+// the one that was written ("150,000", "150.000" or "Rp 150,000").
+func sameAmount(a, b string) bool {
+	fa, errA := transaction_domain.ParseAmount(a)
+	fb, errB := transaction_domain.ParseAmount(b)
+	if errA != nil || errB != nil {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+	return fa == fb
+}
+
+// parseSummaryRow converts a summary sheet row into a CategorySummary.
+// Rows with fewer than four columns are rejected; quota columns are optional.
+func parseSummaryRow(row []interface{}) (CategorySummary, bool) {
+	if len(row) < 4 {
+		return CategorySummary{}, false
+	}
+	// Defensive: handle missing quota columns gracefully
+	quota := ""
+	quotaLeft := ""
+	if len(row) > 4 {
+		quota = fmt.Sprintf("%v", row[4])
+	}
+	if len(row) > 5 {
+		quotaLeft = fmt.Sprintf("%v", row[5])
+	}
+	return CategorySummary{
+		Category:        fmt.Sprintf("%v", row[0]),
+		MonthlyExpenses: fmt.Sprintf("%v", row[1]),
+		MonthlyBudget:   fmt.Sprintf("%v", row[2]),
+		BudgetLeft:      fmt.Sprintf("%v", row[3]),
+		Quota:           quota,
+		QuotaLeft:       quotaLeft,
+	}, true
+}
+
+// parseTransactionRow converts a detailed sheet row (as written by AppendRow)
+// into a Transaction. Header rows and rows without a readable date are rejected.
+func parseTransactionRow(row []interface{}) (transaction_domain.Transaction, bool) {
+	if len(row) < 5 {
+		return transaction_domain.Transaction{}, false
+	}
+	date, ok := parseSheetDate(row[0])
+	if !ok {
+		return transaction_domain.Transaction{}, false
+	}
+	trx := transaction_domain.Transaction{
+		TransactionDate: date,
+		Category:        cellString(row, 1),
+		Subcategory:     cellString(row, 2),
+		Notes:           cellString(row, 3),
+		Amount:          cellString(row, 4),
+		CreatedBy:       cellString(row, 5),
+		FileID:          cellString(row, 6),
+		Goal:            cellString(row, 8),
+		Tags:            splitTags(cellString(row, 9)),
+		PromptVersion:   cellString(row, 10),
+	}
+	if trx.OriginalCurrency = cellString(row, 12); trx.OriginalCurrency != "" {
+		trx.OriginalAmount = cellString(row, 11)
+		trx.ExchangeRate, _ = strconv.ParseFloat(strings.ReplaceAll(cellString(row, 13), ",", ""), 64)
+	}
+	trx.ChatID, _ = rowChat(row, 0)
+	return trx, true
+}
+
+// rateCell writes an exchange rate as a number, or leaves the cell empty
+// when the transaction was not converted
+func rateCell(rate float64) interface{} {
+	if rate == 0 {
+		return ""
+	}
+	return rate
+}
+
+// parseSheetDate accepts either a serial date number or a YYYY-MM-DD string.
+func parseSheetDate(cell interface{}) (string, bool) {
+	switch v := cell.(type) {
+	case float64:
+		return sheetEpoch.AddDate(0, 0, int(v)).Format("2006-01-02"), true
+	case string:
+		d, err := time.Parse("2006-01-02", strings.TrimSpace(v))
+		if err != nil {
+			return "", false
+		}
+		return d.Format("2006-01-02"), true
+	default:
+		return "", false
+	}
+}
+
+// splitTags parses the comma-separated tags column
+func splitTags(cell string) []string {
+	var tags []string
+	for _, t := range strings.Split(cell, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// cellString renders a cell as a string, formatting numbers without exponents.
+func cellString(row []interface{}, i int) string {
+	if i >= len(row) {
+		return ""
+	}
+	if f, ok := row[i].(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", row[i])
+}
+
+// Ping checks that the service account can read the spreadsheet, for the
+// readiness probe
+func (s SpreadsheetService) Ping(ctx context.Context, spreadsheetId string) error {
+	if _, err := s.Sheet.Spreadsheets.Get(spreadsheetId).Fields("spreadsheetId").Context(ctx).Do(); err != nil {
+		return errors.NewSpreadsheetError("spreadsheet not reachable", err).
+			WithContext("spreadsheet_id", spreadsheetId).
+			WithComponent("spreadsheet-client")
+	}
+	return nil
+}
+
 func (s SpreadsheetService) GetCellValue(ctx context.Context, spreadsheetId string) error {
-	values, err := s.Sheet.Spreadsheets.Values.Get(spreadsheetId, "Sheet1!A2:E7").Do()
+	values, err := s.Sheet.Spreadsheets.Values.Get(spreadsheetId, "Sheet1!A2:E7").Context(ctx).Do()
 
 	if err != nil {
 		return errors.NewSpreadsheetError("failed to get cell values", err).
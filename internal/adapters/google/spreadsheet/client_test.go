@@ -2,8 +2,19 @@ package spreadsheet
 
 import (
 	"context"
+	"encoding/json"
+	"money-tracker-bot/internal/common"
+	category_domain "money-tracker-bot/internal/domain/category"
 	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
 )
 
 func TestCategorySummary_WithQuota(t *testing.T) {
@@ -56,3 +67,370 @@ func TestCategorySummary_MissingQuota(t *testing.T) {
 		t.Errorf("expected empty QuotaLeft, got '%s'", result.QuotaLeft)
 	}
 }
+
+func TestParseSummaryRow(t *testing.T) {
+	if _, ok := parseSummaryRow([]interface{}{"Food", "1000"}); ok {
+		t.Error("rows with fewer than four columns should be rejected")
+	}
+	summary, ok := parseSummaryRow([]interface{}{"Food", "1000", "5000", "4000", "2000"})
+	if !ok {
+		t.Fatal("expected row to parse")
+	}
+	if summary.Category != "Food" || summary.BudgetLeft != "4000" || summary.Quota != "2000" || summary.QuotaLeft != "" {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+}
+
+func TestParseTransactionRow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		row      []interface{}
+		wantOK   bool
+		wantDate string
+		wantAmt  string
+		wantGoal string
+		wantSub  string
+		wantTags int
+		wantVer  string
+		wantOrig string
+		wantRate float64
+		wantChat int64
+	}{
+		{
+			name:     "serial date and numeric amount",
+			row:      []interface{}{float64(45839), "Groceries", "", "Superindo", float64(150000), "rompi", "", float64(45839.5), "bali", "monthly, family", "v1"},
+			wantOK:   true,
+			wantDate: "2025-07-01",
+			wantAmt:  "150000",
+			wantGoal: "bali",
+			wantTags: 2,
+			wantVer:  "v1",
+		},
+		{
+			name:     "string date",
+			row:      []interface{}{"2025-07-10", "Eating Out", "Coffee", "Latte", "35,000", "rompi"},
+			wantOK:   true,
+			wantDate: "2025-07-10",
+			wantAmt:  "35,000",
+			wantSub:  "Coffee",
+		},
+		{
+			name:     "converted from another currency",
+			row:      []interface{}{"2025-07-10", "Eating Out", "", "Hawker lunch", float64(153750), "rompi", "", "2025-07-10 12:00:00", "", "", "v4", "12.30", "SGD", float64(12500)},
+			wantOK:   true,
+			wantDate: "2025-07-10",
+			wantAmt:  "153750",
+			wantVer:  "v4",
+			wantOrig: "12.30 SGD",
+			wantRate: 12500,
+		},
+		{
+			name:     "recorded by a chat",
+			row:      []interface{}{"2025-07-10", "Eating Out", "", "Latte", float64(35000), "rompi", "", "", "", "", "", "", "", "", float64(-1001)},
+			wantOK:   true,
+			wantDate: "2025-07-10",
+			wantAmt:  "35000",
+			wantChat: -1001,
+		},
+		{
+			name: "header row",
+			row:  []interface{}{"Date", "Category", "", "Notes", "Amount"},
+		},
+		{
+			name: "short row",
+			row:  []interface{}{"2025-07-10", "Eating Out"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			trx, ok := parseTransactionRow(tc.row)
+			if ok != tc.wantOK {
+				t.Fatalf("expected ok=%v, got %v", tc.wantOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if trx.TransactionDate != tc.wantDate {
+				t.Errorf("expected date %s, got %s", tc.wantDate, trx.TransactionDate)
+			}
+			if trx.Amount != tc.wantAmt {
+				t.Errorf("expected amount %s, got %s", tc.wantAmt, trx.Amount)
+			}
+			if trx.Goal != tc.wantGoal {
+				t.Errorf("expected goal %q, got %q", tc.wantGoal, trx.Goal)
+			}
+			if len(trx.Tags) != tc.wantTags {
+				t.Errorf("expected %d tags, got %v", tc.wantTags, trx.Tags)
+			}
+			if trx.Subcategory != tc.wantSub {
+				t.Errorf("expected subcategory %q, got %q", tc.wantSub, trx.Subcategory)
+			}
+			if trx.PromptVersion != tc.wantVer {
+				t.Errorf("expected prompt version %q, got %q", tc.wantVer, trx.PromptVersion)
+			}
+			if orig := strings.TrimSpace(trx.OriginalAmount + " " + trx.OriginalCurrency); orig != tc.wantOrig || trx.ExchangeRate != tc.wantRate {
+				t.Errorf("expected original %q at %v, got %q at %v", tc.wantOrig, tc.wantRate, orig, trx.ExchangeRate)
+			}
+			if trx.ChatID != tc.wantChat {
+				t.Errorf("expected chat %d, got %d", tc.wantChat, trx.ChatID)
+			}
+		})
+	}
+}
+
+// detailedRow is a detailed sheet row of category and subcategory recorded by
+// chat, "" for rows from before the chat column
+func detailedRow(category, subcategory, chat string) []interface{} {
+	return []interface{}{float64(45839), category, subcategory, "", float64(35000), "alice", "", "", "", "", "", "", "", "", chat}
+}
+
+func TestSpreadsheetService_ListTransactions_AttributesLegacyRows(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"values": [][]interface{}{
+			// Recorded before the chat column existed: no cell at all, or an empty one
+			{float64(45839), "Groceries", "", "", float64(35000), "alice"},
+			detailedRow("Groceries", "", ""),
+			detailedRow("Groceries", "", "8"),
+		}})
+	}))
+	defer server.Close()
+	srv, err := sheets.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := SpreadsheetService{Sheet: srv, LegacyChat: 7}
+	trxs, err := s.ListTransactions(context.Background(), "sheet")
+	if err != nil || len(trxs) != 3 {
+		t.Fatalf("expected 3 transactions, got %d, %v", len(trxs), err)
+	}
+	if len(transaction_domain.OfChat(trxs, 7)) != 2 || len(transaction_domain.OfChat(trxs, 8)) != 1 {
+		t.Errorf("expected rows without a chat to belong to the legacy chat, got %+v", trxs)
+	}
+
+	s.LegacyChat = 0
+	trxs, _ = s.ListTransactions(context.Background(), "sheet")
+	if len(transaction_domain.OfChat(trxs, 7)) != 0 {
+		t.Errorf("rows without a chat belong to no chat without a legacy chat, got %+v", trxs)
+	}
+}
+
+func TestCategoryUpdates(t *testing.T) {
+	rows := [][]interface{}{
+		{"Date", "Category", "Subcategory"},
+		detailedRow("Eating Out", "Coffee", "7"),
+		detailedRow("Groceries", "", "7"),
+		detailedRow("eating out", "", "7"),
+		detailedRow("Eating Out", "", "8"),
+		detailedRow("Eating Out", "", ""),
+	}
+	m := category_domain.Migration{
+		From: category_domain.Ref{Category: "Eating Out"},
+		To:   category_domain.Ref{Category: "Dining"},
+	}
+	updates := categoryUpdates(rows, 7, 0, m)
+	if len(updates) != 2 {
+		t.Fatalf("expected 2 updates, got %d", len(updates))
+	}
+	if updates[0].Range != "detailed!B3:C3" || updates[0].Values[0][0] != "Dining" || updates[0].Values[0][1] != "Coffee" {
+		t.Errorf("unexpected first update: %+v", updates[0])
+	}
+	if updates[1].Range != "detailed!B5:C5" || updates[1].Values[0][1] != "" {
+		t.Errorf("unexpected second update: %+v", updates[1])
+	}
+
+	// The row without a chat belongs to the legacy chat
+	if updates := categoryUpdates(rows, 7, 7, m); len(updates) != 3 || updates[2].Range != "detailed!B7:C7" {
+		t.Errorf("expected the legacy row to migrate with chat 7, got %+v", updates)
+	}
+	if updates := categoryUpdates(rows, 8, 7, m); len(updates) != 1 || updates[0].Range != "detailed!B6:C6" {
+		t.Errorf("expected only chat 8's own row, got %+v", updates)
+	}
+}
+
+func TestSpreadsheetService_MigrateCategory_OnlyTenantRows(t *testing.T) {
+	var updated []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			// Chat 8 shares the sheet and also has an Eating Out category
+			json.NewEncoder(w).Encode(map[string]interface{}{"values": [][]interface{}{
+				detailedRow("Eating Out", "", "7"),
+				detailedRow("Eating Out", "", "8"),
+				detailedRow("Eating Out", "", "7"),
+			}})
+			return
+		}
+		var req sheets.BatchUpdateValuesRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, d := range req.Data {
+			updated = append(updated, d.Range)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	srv, err := sheets.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := SpreadsheetService{Sheet: srv}
+	m := category_domain.Migration{
+		From: category_domain.Ref{Category: "Eating Out"},
+		To:   category_domain.Ref{Category: "Dining"},
+	}
+
+	rows, err := s.MigrateCategory(common.WithTenant(context.Background(), 7), "sheet", m)
+	if err != nil || rows != 2 {
+		t.Fatalf("expected 2 rows of chat 7 to change, got %d, %v", rows, err)
+	}
+	if strings.Join(updated, " ") != "detailed!B2:C2 detailed!B4:C4" {
+		t.Errorf("the row of chat 8 must be left alone, updated %v", updated)
+	}
+	if _, err := s.MigrateCategory(context.Background(), "sheet", m); err == nil {
+		t.Error("expected an error without a chat to migrate for")
+	}
+}
+
+func TestFindTransactionRow(t *testing.T) {
+	trx := transaction_domain.Transaction{
+		TransactionDate: "2025-07-01",
+		Category:        "Household",
+		Notes:           "Weekly shop",
+		Amount:          "150,000",
+		CreatedBy:       "alice",
+	}
+	// shop is a row of the weekly shop recorded by chat, "" for none
+	shop := func(category string, amount float64, chat string) []interface{} {
+		return []interface{}{float64(45839), category, "", "Weekly shop", amount, "alice", "", "", "", "", "", "", "", "", chat}
+	}
+	rows := [][]interface{}{
+		{"Date", "Category"},
+		shop("Household", 150000, "7"),
+		shop("Groceries", 150000, "7"),
+		shop("Household", 150000, "7"),
+		shop("Household", 90000, "7"),
+		// The same shop in another chat sharing the sheet
+		shop("Household", 150000, "8"),
+	}
+	if got := findTransactionRow(rows, trx, 7, 0); got != 5 {
+		t.Errorf("expected the last matching row 5, got %d", got)
+	}
+	if got := findTransactionRow(rows, trx, 8, 0); got != 7 {
+		t.Errorf("expected chat 8's own row 7, got %d", got)
+	}
+	if got := findTransactionRow(rows, trx, 9, 0); got != 0 {
+		t.Errorf("expected no row of another chat, got %d", got)
+	}
+
+	// Rows recorded before the chat column belong to the legacy chat
+	legacy := [][]interface{}{{float64(45839), "Household", "", "Weekly shop", float64(150000), "alice"}}
+	if got := findTransactionRow(legacy, trx, 9, 9); got != 2 {
+		t.Errorf("expected the legacy row for the legacy chat, got %d", got)
+	}
+	if got := findTransactionRow(legacy, trx, 8, 9); got != 0 {
+		t.Errorf("expected the legacy row to be left to the legacy chat, got %d", got)
+	}
+
+	trx.CreatedBy = "bob"
+	if got := findTransactionRow(rows, trx, 7, 0); got != 0 {
+		t.Errorf("expected no row, got %d", got)
+	}
+}
+
+func TestSpreadsheetService_Recategorize_ReadsUnformattedValues(t *testing.T) {
+	var updated string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			row := []interface{}{float64(45839), "Household", "", "Weekly shop", float64(150000), "alice", "", "", "", "", "", "", "", "", "7"}
+			if r.URL.Query().Get("valueRenderOption") != "UNFORMATTED_VALUE" || r.URL.Query().Get("dateTimeRenderOption") != "SERIAL_NUMBER" {
+				// What the Sheets API returns by default, FORMATTED_VALUE
+				row = []interface{}{"7/1/2025", "Household", "", "Weekly shop", "Rp150.000", "alice", "", "", "", "", "", "", "", "", "7"}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"values": [][]interface{}{row}})
+			return
+		}
+		updated = r.URL.Path
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	srv, err := sheets.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := SpreadsheetService{Sheet: srv}
+	trx := transaction_domain.Transaction{
+		TransactionDate: "2025-07-01",
+		Category:        "Household",
+		Notes:           "Weekly shop",
+		Amount:          "150,000",
+		CreatedBy:       "alice",
+	}
+
+	found, err := s.Recategorize(common.WithTenant(context.Background(), 7), "sheet", trx, category_domain.Ref{Category: "Groceries"})
+	if err != nil || !found {
+		t.Fatalf("expected the row to be found, got %v, %v", found, err)
+	}
+	if !strings.HasSuffix(updated, "detailed!B2:C2") {
+		t.Errorf("expected row 2 to be updated, got %q", updated)
+	}
+	if _, err := s.Recategorize(context.Background(), "sheet", trx, category_domain.Ref{Category: "Groceries"}); err == nil {
+		t.Error("expected an error without a chat to recategorize for")
+	}
+}
+
+func TestSameAmount(t *testing.T) {
+	if !sameAmount("150000", "150,000") || !sameAmount("1500.5", "1,500.50") || !sameAmount("150000", "150.000") || !sameAmount("150000", "Rp 150,000") {
+		t.Error("expected equal amounts")
+	}
+	if sameAmount("150000", "15,000") || !sameAmount("n/a", " n/a ") {
+		t.Error("unexpected comparison")
+	}
+}
+
+type zones map[int64]*time.Location
+
+func (z zones) Location(chatID int64) *time.Location { return z[chatID] }
+
+func TestSpreadsheetService_CreatedAt(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	s := SpreadsheetService{Location: jakarta, Zones: zones{1: time.UTC}}
+	now := time.Date(2025, 7, 1, 20, 0, 0, 0, time.UTC)
+
+	if got := s.createdAt(common.WithTenant(context.Background(), 1), now); got != "2025-07-01 20:00:00" {
+		t.Errorf("expected the chat's timezone, got %s", got)
+	}
+	if got := s.createdAt(common.WithTenant(context.Background(), 2), now); got != "2025-07-02 03:00:00" {
+		t.Errorf("expected Location for chats without a timezone, got %s", got)
+	}
+	if got := (SpreadsheetService{}).createdAt(context.Background(), now); got != "2025-07-01 20:00:00" {
+		t.Errorf("expected UTC without a Location, got %s", got)
+	}
+}
+
+func TestSpreadsheetService_Ping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/spreadsheets/sheet-1") {
+			http.Error(w, `{"error": {"code": 404, "message": "Requested entity was not found."}}`, http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"spreadsheetId": "sheet-1"}`))
+	}))
+	defer server.Close()
+	srv, err := sheets.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := SpreadsheetService{Sheet: srv}
+
+	if err := s.Ping(context.Background(), "sheet-1"); err != nil {
+		t.Errorf("expected the spreadsheet to be reachable, got %v", err)
+	}
+	err = s.Ping(context.Background(), "missing")
+	if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.ErrCodeSpreadsheet || appErr.Context["spreadsheet_id"] != "missing" {
+		t.Errorf("expected a spreadsheet error, got %v", err)
+	}
+}
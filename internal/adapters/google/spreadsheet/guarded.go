@@ -0,0 +1,69 @@
+package spreadsheet
+
+import (
+	"context"
+	category_domain "money-tracker-bot/internal/domain/category"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/resilience"
+)
+
+// Service is the part of SpreadsheetService the bot's services use
+type Service interface {
+	AppendRow(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction) (CategorySummary, error)
+	ListCategorySummaries(ctx context.Context, spreadsheetId string) ([]CategorySummary, error)
+	ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error)
+	MigrateCategory(ctx context.Context, spreadsheetId string, m category_domain.Migration) (int, error)
+	Recategorize(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction, to category_domain.Ref) (bool, error)
+	GetCellValue(ctx context.Context, spreadsheetId string) error
+}
+
+var _ Service = SpreadsheetService{}
+
+// Guarded is a Service whose calls go through a circuit breaker, so an
+// unavailable Google Sheets fails fast with CIRCUIT_OPEN instead of making
+// every request wait for its timeout
+type Guarded struct {
+	Service Service
+	Breaker *resilience.Breaker
+}
+
+func NewGuarded(service Service, breaker *resilience.Breaker) *Guarded {
+	return &Guarded{Service: service, Breaker: breaker}
+}
+
+func (g *Guarded) AppendRow(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction) (CategorySummary, error) {
+	return resilience.Call(g.Breaker, func() (CategorySummary, error) {
+		return g.Service.AppendRow(ctx, spreadsheetId, trx)
+	})
+}
+
+func (g *Guarded) ListCategorySummaries(ctx context.Context, spreadsheetId string) ([]CategorySummary, error) {
+	return resilience.Call(g.Breaker, func() ([]CategorySummary, error) {
+		return g.Service.ListCategorySummaries(ctx, spreadsheetId)
+	})
+}
+
+func (g *Guarded) ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error) {
+	return resilience.Call(g.Breaker, func() ([]transaction_domain.Transaction, error) {
+		return g.Service.ListTransactions(ctx, spreadsheetId)
+	})
+}
+
+func (g *Guarded) MigrateCategory(ctx context.Context, spreadsheetId string, m category_domain.Migration) (int, error) {
+	return resilience.Call(g.Breaker, func() (int, error) {
+		return g.Service.MigrateCategory(ctx, spreadsheetId, m)
+	})
+}
+
+func (g *Guarded) Recategorize(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction, to category_domain.Ref) (bool, error) {
+	return resilience.Call(g.Breaker, func() (bool, error) {
+		return g.Service.Recategorize(ctx, spreadsheetId, trx, to)
+	})
+}
+
+func (g *Guarded) GetCellValue(ctx context.Context, spreadsheetId string) error {
+	_, err := resilience.Call(g.Breaker, func() (struct{}, error) {
+		return struct{}{}, g.Service.GetCellValue(ctx, spreadsheetId)
+	})
+	return err
+}
@@ -0,0 +1,66 @@
+package spreadsheet
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	category_domain "money-tracker-bot/internal/domain/category"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/resilience"
+	"testing"
+	"time"
+)
+
+type downSheets struct {
+	calls int
+}
+
+func (d *downSheets) AppendRow(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction) (CategorySummary, error) {
+	d.calls++
+	return CategorySummary{}, errors.NewSpreadsheetError("failed to insert data to sheet", nil)
+}
+
+func (d *downSheets) ListCategorySummaries(ctx context.Context, spreadsheetId string) ([]CategorySummary, error) {
+	d.calls++
+	return nil, errors.NewSpreadsheetError("failed to get summary data", nil)
+}
+
+func (d *downSheets) ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error) {
+	d.calls++
+	return nil, errors.NewSpreadsheetError("failed to read transactions", nil)
+}
+
+func (d *downSheets) MigrateCategory(ctx context.Context, spreadsheetId string, m category_domain.Migration) (int, error) {
+	d.calls++
+	return 0, errors.NewSpreadsheetError("failed to migrate", nil)
+}
+
+func (d *downSheets) Recategorize(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction, to category_domain.Ref) (bool, error) {
+	d.calls++
+	return false, errors.NewSpreadsheetError("failed to recategorize", nil)
+}
+
+func (d *downSheets) GetCellValue(ctx context.Context, spreadsheetId string) error {
+	d.calls++
+	return errors.NewSpreadsheetError("failed to read cells", nil)
+}
+
+func TestGuarded_FailsFastWhenOpen(t *testing.T) {
+	sheets := &downSheets{}
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	g := NewGuarded(sheets, resilience.NewBreaker("sheets", 2, time.Minute, clock))
+	ctx := context.Background()
+
+	g.AppendRow(ctx, "sheet", transaction_domain.Transaction{})
+	g.ListTransactions(ctx, "sheet")
+	_, err := g.ListCategorySummaries(ctx, "sheet")
+	if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.ErrCodeCircuitOpen {
+		t.Fatalf("expected a circuit open error, got %v", err)
+	}
+	g.MigrateCategory(ctx, "sheet", category_domain.Migration{})
+	g.Recategorize(ctx, "sheet", transaction_domain.Transaction{}, category_domain.Ref{})
+	g.GetCellValue(ctx, "sheet")
+	if sheets.calls != 2 {
+		t.Errorf("expected Sheets to be skipped once the breaker opened, got %d calls", sheets.calls)
+	}
+}
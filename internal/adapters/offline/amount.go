@@ -0,0 +1,78 @@
+package offline
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// amountPattern finds numbers with an optional currency prefix and shorthand
+// suffix, such as "35rb", "1,5jt", "Rp 35.000", "S$12.50" or "150000"
+var amountPattern = regexp.MustCompile(`(?i)(rp\.?\s*|idr\s*|(?:us|s|a|hk|nz)?\$\s*|[€£¥฿]\s*|rm\s*)?(\d+(?:[.,]\d+)*)\s*(rb|ribu|k|jt|juta)?\b`)
+
+// isoDatePattern matches dates so their digits are not taken for amounts
+var isoDatePattern = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b|\b\d{1,2}/\d{1,2}(?:/\d{2,4})?\b`)
+
+var multipliers = map[string]float64{
+	"rb":   1e3,
+	"ribu": 1e3,
+	"k":    1e3,
+	"jt":   1e6,
+	"juta": 1e6,
+}
+
+// parseAmount returns the amount written in a message, in whatever currency
+// it is written in. Numbers with a currency prefix or shorthand suffix win
+// over bare numbers; among equals the largest is taken, so "2 porsi 35rb"
+// reads as 35,000.
+// Input: "makan siang 35rb gopay", "kopi S$4.50"
+// Output: 35000, true; 4.5, true
+func parseAmount(message string) (float64, bool) {
+	text := isoDatePattern.ReplaceAllString(message, " ")
+	var best float64
+	bestMarked, found := false, false
+	for _, m := range amountPattern.FindAllStringSubmatch(text, -1) {
+		suffix := strings.ToLower(m[3])
+		value, ok := parseNumber(m[2], suffix != "")
+		if !ok {
+			continue
+		}
+		if mult, ok := multipliers[suffix]; ok {
+			value *= mult
+		}
+		marked := m[1] != "" || suffix != ""
+		if !found || (marked && !bestMarked) || (marked == bestMarked && value > best) {
+			best, bestMarked, found = value, marked, true
+		}
+	}
+	if !found || best <= 0 {
+		return 0, false
+	}
+	return best, true
+}
+
+// parseNumber reads "35.000", "35,000", "1,5" or "1.5". A separator followed
+// by exactly three digits groups thousands unless the number has a shorthand
+// suffix, where "1.500jt" is still read as thousands but "1,5jt" as a decimal.
+func parseNumber(s string, hasSuffix bool) (float64, bool) {
+	groups := strings.FieldsFunc(s, func(r rune) bool { return r == '.' || r == ',' })
+	if len(groups) == 1 {
+		v, err := strconv.ParseFloat(s, 64)
+		return v, err == nil
+	}
+	thousands := true
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			thousands = false
+		}
+	}
+	if thousands {
+		v, err := strconv.ParseFloat(strings.Join(groups, ""), 64)
+		return v, err == nil
+	}
+	if len(groups) == 2 && (hasSuffix || len(groups[1]) <= 2) {
+		v, err := strconv.ParseFloat(groups[0]+"."+groups[1], 64)
+		return v, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,46 @@
+package offline
+
+import "testing"
+
+func TestParseAmount(t *testing.T) {
+	testCases := []struct {
+		message  string
+		expected float64
+		ok       bool
+	}{
+		{"makan siang 35rb gopay", 35000, true},
+		{"kopi 25k", 25000, true},
+		{"sewa kos 1,5jt", 1500000, true},
+		{"sewa kos 1.5 juta", 1500000, true},
+		{"belanja Rp 150.000 superindo", 150000, true},
+		{"belanja rp150,000", 150000, true},
+		{"listrik 250000", 250000, true},
+		{"2 porsi bakso 30rb", 30000, true},
+		{"bensin 2025-07-01 50rb", 50000, true},
+		{"parkir 5.000 tgl 12/7", 5000, true},
+		{"kopi S$4.50 meja 12", 4.5, true},
+		{"taxi $18 for 3 people", 18, true},
+		{"makan siang", 0, false},
+		{"gratis 0", 0, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.message, func(t *testing.T) {
+			got, ok := parseAmount(tc.message)
+			if got != tc.expected || ok != tc.ok {
+				t.Errorf("expected %v, %v, got %v, %v", tc.expected, tc.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	if v, ok := parseNumber("1.234.567", false); !ok || v != 1234567 {
+		t.Errorf("expected thousands grouping, got %v, %v", v, ok)
+	}
+	if v, ok := parseNumber("12,50", false); !ok || v != 12.5 {
+		t.Errorf("expected decimal, got %v, %v", v, ok)
+	}
+	if _, ok := parseNumber("1.2345", false); ok {
+		t.Error("expected ambiguous number to be rejected")
+	}
+}
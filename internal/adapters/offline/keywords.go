@@ -0,0 +1,135 @@
+package offline
+
+import (
+	"money-tracker-bot/internal/common"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// categoryKeywords maps Indonesian and English words to the default
+// categories. Category resolution later maps them onto the tenant's set.
+var categoryKeywords = []struct {
+	category string
+	keywords []string
+}{
+	{"Eating Out", []string{"makan", "makan siang", "makan malam", "sarapan", "lunch", "dinner", "breakfast", "kopi", "coffee", "cafe", "resto", "restoran", "restaurant", "warung", "bakso", "nasi", "jajan", "snack", "gofood", "grabfood", "shopeefood"}},
+	{"Groceries", []string{"belanja", "groceries", "grocery", "sayur", "buah", "supermarket", "pasar", "indomaret", "alfamart", "superindo", "hypermart"}},
+	{"Transportation", []string{"grab", "gojek", "gocar", "goride", "ojek", "ojol", "taxi", "taksi", "bensin", "fuel", "parkir", "parking", "tol", "toll", "kereta", "train", "krl", "mrt", "busway", "transjakarta"}},
+	{"Utilities", []string{"listrik", "electricity", "pln", "pdam", "internet", "wifi", "pulsa", "paket data", "token listrik", "bpjs"}},
+	{"Entertainment", []string{"nonton", "movie", "bioskop", "cinema", "netflix", "spotify", "game", "konser", "concert", "liburan", "holiday"}},
+	{"Gifting", []string{"kado", "gift", "hadiah", "angpao", "sumbangan", "donasi", "donation"}},
+	{"Household", []string{"sabun", "detergen", "deterjen", "perabot", "furniture", "laundry", "galon", "peralatan rumah"}},
+	{"Health", []string{"obat", "medicine", "dokter", "doctor", "apotek", "pharmacy", "rumah sakit", "hospital", "klinik", "clinic", "vitamin"}},
+	{"Rent House", []string{"sewa", "rent", "kos", "kost", "kontrakan"}},
+	{"Savings", []string{"tabungan", "nabung", "saving", "savings", "investasi", "deposito"}},
+	{"Emergency", []string{"darurat", "emergency"}},
+}
+
+// accountAliases maps words that name a source account to SourceAccountList
+var accountAliases = map[string]string{
+	"tunai":  "CASH",
+	"cash":   "CASH",
+	"i.saku": "ISAKU",
+	"livin":  "MANDIRI",
+}
+
+var daysAgoPattern = regexp.MustCompile(`\b(\d+)\s*(?:hari\s*(?:yang\s*)?lalu|days?\s*ago)\b`)
+
+// words lowercases a message and splits it into words, padded with spaces so
+// phrases can be found on word boundaries with strings.Contains.
+// Input: "Makan-siang, 35rb!"
+// Output: " makan siang 35rb "
+func words(message string) string {
+	fields := strings.FieldsFunc(strings.ToLower(message), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r >= 0x80)
+	})
+	for i, f := range fields {
+		fields[i] = strings.Trim(f, ".")
+	}
+	return " " + strings.Join(fields, " ") + " "
+}
+
+// matchCategory returns the category of the keyword that appears first in text
+func matchCategory(text string) (string, bool) {
+	best, bestAt := "", -1
+	for _, c := range categoryKeywords {
+		for _, kw := range c.keywords {
+			at := strings.Index(text, " "+kw+" ")
+			if at >= 0 && (bestAt < 0 || at < bestAt) {
+				best, bestAt = c.category, at
+			}
+		}
+	}
+	return best, bestAt >= 0
+}
+
+// matchAccount returns the source account named in text
+func matchAccount(text string) (string, bool) {
+	for _, account := range common.SourceAccountList {
+		if strings.Contains(text, " "+strings.ToLower(account)+" ") {
+			return account, true
+		}
+	}
+	for alias, account := range accountAliases {
+		if strings.Contains(text, " "+alias+" ") {
+			return account, true
+		}
+	}
+	return "", false
+}
+
+// matchDate returns the date a message refers to, relative to today.
+// It understands "kemarin"/"yesterday", "kemarin lusa", "N hari lalu"/"N days ago",
+// "hari ini"/"today" and YYYY-MM-DD or DD/MM[/YYYY] dates.
+func matchDate(message string, today time.Time) time.Time {
+	lower := strings.ToLower(message)
+	if m := isoDatePattern.FindString(lower); m != "" {
+		if d, ok := parseDate(m, today); ok {
+			return d
+		}
+	}
+	if m := daysAgoPattern.FindStringSubmatch(lower); m != nil {
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			return today.AddDate(0, 0, -n)
+		}
+	}
+	text := words(message)
+	switch {
+	case strings.Contains(text, " kemarin lusa "):
+		return today.AddDate(0, 0, -2)
+	case strings.Contains(text, " kemarin ") || strings.Contains(text, " kmrn ") || strings.Contains(text, " yesterday "):
+		return today.AddDate(0, 0, -1)
+	default:
+		return today
+	}
+}
+
+func parseDate(s string, today time.Time) (time.Time, bool) {
+	if d, err := time.ParseInLocation("2006-01-02", s, today.Location()); err == nil {
+		return d, true
+	}
+	parts := strings.Split(s, "/")
+	day, errD := strconv.Atoi(parts[0])
+	month, errM := strconv.Atoi(parts[1])
+	if errD != nil || errM != nil || month < 1 || month > 12 || day < 1 || day > 31 {
+		return time.Time{}, false
+	}
+	year := today.Year()
+	if len(parts) == 3 {
+		y, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return time.Time{}, false
+		}
+		if y < 100 {
+			y += 2000
+		}
+		year = y
+	}
+	d := time.Date(year, time.Month(month), day, 0, 0, 0, 0, today.Location())
+	if d.Day() != day {
+		return time.Time{}, false
+	}
+	return d, true
+}
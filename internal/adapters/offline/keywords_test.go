@@ -0,0 +1,70 @@
+package offline
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWords(t *testing.T) {
+	if got := words("Makan-siang, 35rb!"); got != " makan siang 35rb " {
+		t.Errorf("unexpected words %q", got)
+	}
+}
+
+func TestMatchCategory(t *testing.T) {
+	testCases := []struct {
+		message  string
+		expected string
+	}{
+		{"makan siang 35rb gopay", "Eating Out"},
+		{"Grab ke kantor 25k", "Transportation"},
+		{"belanja superindo 150rb", "Groceries"},
+		{"bayar listrik 250rb", "Utilities"},
+		{"beli obat di apotek", "Health"},
+		{"grab lalu makan", "Transportation"},
+		{"grabbing something", ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.message, func(t *testing.T) {
+			got, _ := matchCategory(words(tc.message))
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestMatchAccount(t *testing.T) {
+	testCases := map[string]string{
+		"makan siang 35rb gopay": "GOPAY",
+		"transfer via BCA":       "BCA",
+		"bayar tunai":            "CASH",
+		"makan siang":            "",
+	}
+	for message, expected := range testCases {
+		if got, _ := matchAccount(words(message)); got != expected {
+			t.Errorf("%q: expected %q, got %q", message, expected, got)
+		}
+	}
+}
+
+func TestMatchDate(t *testing.T) {
+	today := time.Date(2025, 7, 10, 0, 0, 0, 0, time.UTC)
+	testCases := map[string]string{
+		"makan siang 35rb":             "2025-07-10",
+		"kemarin makan 35rb":           "2025-07-09",
+		"coffee yesterday 30k":         "2025-07-09",
+		"kemarin lusa bensin 50rb":     "2025-07-08",
+		"parkir 3 hari lalu 5rb":       "2025-07-07",
+		"taxi 2 days ago 80k":          "2025-07-08",
+		"sewa 2025-07-01 1,5jt":        "2025-07-01",
+		"obat 28/6 50rb":               "2025-06-28",
+		"kado 31/12/24 200rb":          "2024-12-31",
+		"invalid date 31/2 as numbers": "2025-07-10",
+	}
+	for message, expected := range testCases {
+		if got := matchDate(message, today).Format("2006-01-02"); got != expected {
+			t.Errorf("%q: expected %s, got %s", message, expected, got)
+		}
+	}
+}
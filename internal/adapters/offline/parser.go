@@ -0,0 +1,98 @@
+package offline
+
+// Package offline extracts transactions from short text messages with local
+// rules, without calling an AI service.
+
+import (
+	"context"
+	"math"
+	"money-tracker-bot/internal/common"
+	currency_domain "money-tracker-bot/internal/domain/currency"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultCategory is used when no keyword in the message names a category
+const DefaultCategory = "Household"
+
+// PromptVersion tags transactions parsed offline, which use no prompt
+const PromptVersion = "offline"
+
+// Parser is a deterministic aiport.AiPort for text messages such as
+// "makan siang 35rb gopay". It cannot read images or generate content.
+type Parser struct {
+	Clock    common.Clock
+	Location *time.Location
+	// Zones is optional; it resolves date words in each chat's timezone instead of Location
+	Zones common.Zones
+}
+
+func NewParser(clock common.Clock, loc *time.Location) *Parser {
+	return &Parser{Clock: clock, Location: loc}
+}
+
+func (p *Parser) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return "", errors.NewValidationError("the offline parser cannot generate content", nil).
+		WithKey("invalid.offline.no_generate").
+		WithComponent("offline-parser")
+}
+
+func (p *Parser) ReadImageToTransaction(ctx context.Context, imgPath string) (*transaction_domain.Transaction, error) {
+	return nil, errors.NewValidationError("receipts cannot be read offline, please type the transaction instead", nil).
+		WithKey("invalid.offline.no_receipts").
+		WithContext("image_path", imgPath).
+		WithComponent("offline-parser")
+}
+
+// TextToTransaction parses amount, currency, source account, date words and
+// category keywords from a message. A message naming no currency leaves
+// AmountCurrency empty, which is the base currency.
+// Input: "kemarin makan siang 35rb gopay" on 2025-07-10
+// Output: Transaction{TransactionDate: "2025-07-09", Amount: "35,000", SourceAccount: "GOPAY", Category: "Eating Out"}
+func (p *Parser) TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error) {
+	amount, ok := parseAmount(message)
+	if !ok {
+		return nil, errors.NewValidationError("no amount found in the message, try something like \"makan siang 35rb\"", nil).
+			WithKey("invalid.offline.no_amount").
+			WithComponent("offline-parser")
+	}
+	text := words(message)
+	category, ok := matchCategory(text)
+	if !ok {
+		category = DefaultCategory
+	}
+	account, _ := matchAccount(text)
+	title := strings.TrimSpace(message)
+	code, _ := currency_domain.Detect(message)
+
+	return &transaction_domain.Transaction{
+		TransactionDate: matchDate(message, p.today(ctx)).Format("2006-01-02"),
+		Amount:          formatAmount(amount, code),
+		AmountCurrency:  code,
+		Notes:           title,
+		SourceAccount:   account,
+		Category:        category,
+		Title:           title,
+		PromptVersion:   PromptVersion,
+	}, nil
+}
+
+// formatAmount writes rupiah and other whole-unit currencies with thousands
+// separators and the rest with their minor units
+// Input: 35000, ""; 4.5, "SGD"
+// Output: "35,000"; "4.50"
+func formatAmount(amount float64, code string) string {
+	if code == "" || currency_domain.MinorUnits(code) == 0 {
+		return common.FormatThousands(int64(math.Round(amount)))
+	}
+	return strconv.FormatFloat(currency_domain.Round(amount, code), 'f', currency_domain.MinorUnits(code), 64)
+}
+
+func (p *Parser) today(ctx context.Context) time.Time {
+	loc := common.ContextLocation(ctx, p.Zones, p.Location)
+	now := p.Clock.Now().In(loc)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,80 @@
+package offline
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	aiport "money-tracker-bot/internal/port/out/ai"
+	"testing"
+	"time"
+)
+
+func newTestParser() *Parser {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	// 23:30 UTC on the 9th is already the 10th in Jakarta
+	clock := &common.FixedClock{Time: time.Date(2025, 7, 9, 23, 30, 0, 0, time.UTC)}
+	return NewParser(clock, jakarta)
+}
+
+func TestParser_ImplementsAiPort(t *testing.T) {
+	var _ aiport.AiPort = &Parser{}
+}
+
+func TestParser_TextToTransaction(t *testing.T) {
+	trx, err := newTestParser().TextToTransaction(context.Background(), "kemarin makan siang 35rb gopay")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trx.TransactionDate != "2025-07-09" || trx.Amount != "35,000" || trx.SourceAccount != "GOPAY" || trx.Category != "Eating Out" {
+		t.Errorf("unexpected transaction %+v", trx)
+	}
+	if trx.Title != "kemarin makan siang 35rb gopay" || trx.Notes != trx.Title || trx.PromptVersion != PromptVersion {
+		t.Errorf("expected the message as title and notes, got %+v", trx)
+	}
+}
+
+func TestParser_Currency(t *testing.T) {
+	p := newTestParser()
+	trx, err := p.TextToTransaction(context.Background(), "hawker lunch S$12.5 cash")
+	if err != nil || trx.Amount != "12.50" || trx.AmountCurrency != "SGD" {
+		t.Errorf("expected SGD 12.50, got %+v, %v", trx, err)
+	}
+	trx, err = p.TextToTransaction(context.Background(), "makan siang 35rb")
+	if err != nil || trx.Amount != "35,000" || trx.AmountCurrency != "" {
+		t.Errorf("expected rupiah without a currency, got %+v, %v", trx, err)
+	}
+}
+
+func TestParser_DefaultsAndErrors(t *testing.T) {
+	p := newTestParser()
+	trx, err := p.TextToTransaction(context.Background(), "something 20rb")
+	if err != nil || trx.Category != DefaultCategory || trx.SourceAccount != "" || trx.TransactionDate != "2025-07-10" {
+		t.Errorf("unexpected defaults %+v, %v", trx, err)
+	}
+	if _, err := p.TextToTransaction(context.Background(), "makan siang"); err == nil {
+		t.Error("expected error without an amount")
+	}
+	if _, err := p.ReadImageToTransaction(context.Background(), "receipt.jpg"); err == nil {
+		t.Error("expected images to be unsupported")
+	}
+	if _, err := p.GenerateContent(context.Background(), "hi"); err == nil {
+		t.Error("expected content generation to be unsupported")
+	}
+}
+
+type zones map[int64]*time.Location
+
+func (z zones) Location(chatID int64) *time.Location { return z[chatID] }
+
+func TestParser_FollowsChatTimezone(t *testing.T) {
+	p := newTestParser()
+	p.Zones = zones{1: time.UTC}
+
+	trx, err := p.TextToTransaction(common.WithTenant(context.Background(), 1), "kopi 20rb")
+	if err != nil || trx.TransactionDate != "2025-07-09" {
+		t.Errorf("expected the chat's own date 2025-07-09, got %+v, %v", trx, err)
+	}
+	trx, err = p.TextToTransaction(common.WithTenant(context.Background(), 2), "kopi 20rb")
+	if err != nil || trx.TransactionDate != "2025-07-10" {
+		t.Errorf("expected Location's date 2025-07-10 for other chats, got %+v, %v", trx, err)
+	}
+}
@@ -0,0 +1,307 @@
+package openai
+
+// Package openai talks to servers implementing the OpenAI chat completions
+// API, including local ones such as Ollama and llama.cpp.
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"money-tracker-bot/internal/common"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the OpenAI API
+	DefaultBaseURL = "https://api.openai.com/v1"
+	// DefaultModel is the OpenAI model used when none is configured
+	DefaultModel = "gpt-4o-mini"
+	// OllamaBaseURL is the OpenAI-compatible API of a local Ollama server
+	OllamaBaseURL = "http://localhost:11434/v1"
+	// OllamaModel is the Ollama model used when none is configured; it reads images
+	OllamaModel = "llama3.2-vision"
+)
+
+// Client is an aiport.AiPort for an OpenAI-compatible chat completions API
+type Client struct {
+	// Name identifies the provider in errors, such as "openai" or "ollama"
+	Name    string
+	BaseURL string
+	// APIKey is sent as a bearer token; local servers usually need none
+	APIKey string
+	Model  string
+	HTTP   *http.Client
+	// PromptVersion selects the prompt template, common.PromptVersion when empty
+	PromptVersion string
+	// Clock dates text messages, the system clock when nil
+	Clock common.Clock
+	// Location is the timezone of those dates; UTC when nil
+	Location *time.Location
+	// Zones is optional; it dates text messages in each chat's timezone instead of Location
+	Zones common.Zones
+}
+
+// NewClient creates a client for the OpenAI API, or any compatible server at baseURL.
+func NewClient(baseURL, apiKey, model string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if model == "" {
+		model = DefaultModel
+	}
+	return &Client{
+		Name:    "openai",
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		APIKey:  apiKey,
+		Model:   model,
+		HTTP:    &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// NewOllamaClient creates a client for a local Ollama server.
+func NewOllamaClient(baseURL, model string) *Client {
+	if baseURL == "" {
+		baseURL = OllamaBaseURL
+	}
+	if model == "" {
+		model = OllamaModel
+	}
+	c := NewClient(baseURL, "", model)
+	c.Name = "ollama"
+	// Local models can be slow, especially on images
+	c.HTTP.Timeout = 5 * time.Minute
+	return c
+}
+
+type message struct {
+	Role string `json:"role"`
+	// Content is a string for text and a list of parts when an image is attached
+	Content any `json:"content"`
+}
+
+type contentPart struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	ImageURL *imageURL `json:"image_url,omitempty"`
+}
+
+type imageURL struct {
+	URL string `json:"url"`
+}
+
+type chatRequest struct {
+	Model       string    `json:"model"`
+	Messages    []message `json:"messages"`
+	Temperature float64   `json:"temperature"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// GenerateContent returns the model's answer to prompt; an empty answer is an error
+func (c *Client) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	text, err := c.complete(ctx, message{Role: "user", Content: prompt})
+	if err != nil {
+		return "", err
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", errors.NewDataFormatError(c.Name+" returned an empty answer", nil).
+			WithContext("model", c.Model).
+			WithComponent(c.component())
+	}
+	return text, nil
+}
+
+func (c *Client) ReadImageToTransaction(ctx context.Context, imgPath string) (*transaction_domain.Transaction, error) {
+	imgData, err := os.ReadFile(imgPath)
+	if err != nil {
+		return nil, errors.NewFileError("failed to read image", err).
+			WithContext("image_path", imgPath).
+			WithComponent(c.component())
+	}
+	prompt, err := common.BuildPrompt(common.PromptParams{
+		Version:    c.PromptVersion,
+		IsImage:    true,
+		FileID:     filepath.Base(imgPath),
+		Categories: common.PromptCategories(ctx),
+		Examples:   common.PromptExamples(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	text, err := c.complete(ctx, message{Role: "user", Content: []contentPart{
+		{Type: "text", Text: prompt},
+		{Type: "image_url", ImageURL: &imageURL{URL: "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(imgData)}},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	trx, err := c.parseTransaction(text)
+	if err != nil {
+		// Keep the image so a fallback provider can still read it
+		return nil, err
+	}
+	if err := os.Remove(imgPath); err != nil {
+		log.Printf("Failed to remove file %s: %v", imgPath, err)
+	}
+	return trx, nil
+}
+
+func (c *Client) TextToTransaction(ctx context.Context, msg string) (*transaction_domain.Transaction, error) {
+	prompt, err := common.BuildPrompt(common.PromptParams{
+		Version:     c.PromptVersion,
+		Message:     msg,
+		CurrentDate: c.now(ctx).Format("2006-01-02"),
+		Categories:  common.PromptCategories(ctx),
+		Examples:    common.PromptExamples(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+	text, err := c.complete(ctx, message{Role: "user", Content: prompt})
+	if err != nil {
+		return nil, err
+	}
+	return c.parseTransaction(text)
+}
+
+// complete sends one chat message and returns the text of the first choice
+func (c *Client) complete(ctx context.Context, msg message) (string, error) {
+	body, err := json.Marshal(chatRequest{Model: c.Model, Messages: []message{msg}})
+	if err != nil {
+		return "", errors.NewAIError("failed to encode request", err).WithComponent(c.component())
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", errors.NewAIError("failed to create request", err).WithComponent(c.component())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	c.authorize(req)
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return "", errors.NewNetworkError("failed to reach "+c.Name, err).
+			WithContext("base_url", c.BaseURL).
+			WithComponent(c.component())
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.NewNetworkError("failed to read "+c.Name+" response", err).WithComponent(c.component())
+	}
+
+	var decoded chatResponse
+	decodeErr := json.Unmarshal(raw, &decoded)
+	if resp.StatusCode != http.StatusOK {
+		detail := strings.TrimSpace(string(raw))
+		if decodeErr == nil && decoded.Error != nil {
+			detail = decoded.Error.Message
+		}
+		return "", c.statusError(resp).
+			WithContext("detail", detail).
+			WithContext("model", c.Model).
+			WithComponent(c.component())
+	}
+	if decodeErr != nil || len(decoded.Choices) == 0 {
+		return "", errors.NewDataFormatError(c.Name+" returned no answer", decodeErr).
+			WithContext("model", c.Model).
+			WithComponent(c.component())
+	}
+	return decoded.Choices[0].Message.Content, nil
+}
+
+// Ping lists the models of the server, which checks that it answers and
+// accepts the API key, for the readiness probe. It spends no tokens.
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+"/models", nil)
+	if err != nil {
+		return errors.NewAIError("failed to create request", err).WithComponent(c.component())
+	}
+	c.authorize(req)
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return errors.NewNetworkError("failed to reach "+c.Name, err).
+			WithContext("base_url", c.BaseURL).
+			WithComponent(c.component())
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return c.statusError(resp).
+			WithContext("base_url", c.BaseURL).
+			WithComponent(c.component())
+	}
+	return nil
+}
+
+// statusError describes a failed response. Only overload and server errors
+// are retryable; any other status, such as a bad key or model, would fail
+// again the same way.
+func (c *Client) statusError(resp *http.Response) *errors.AppError {
+	message := fmt.Sprintf("%s returned %s", c.Name, resp.Status)
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return errors.NewAIError(message, nil)
+	}
+	return errors.NewAIRequestError(message, nil)
+}
+
+// authorize sends the API key as a bearer token, when there is one
+func (c *Client) authorize(req *http.Request) {
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+}
+
+// parseTransaction decodes the transaction JSON the prompt asks for,
+// tolerating a Markdown code fence around it
+func (c *Client) parseTransaction(text string) (*transaction_domain.Transaction, error) {
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, "```json")
+	text = strings.TrimPrefix(text, "```")
+	text = strings.TrimSpace(strings.TrimSuffix(text, "```"))
+
+	var trx transaction_domain.Transaction
+	if err := json.Unmarshal([]byte(text), &trx); err != nil {
+		return nil, errors.NewDataFormatError("no transaction in "+c.Name+" response", err).
+			WithContext("response_length", len(text)).
+			WithComponent(c.component())
+	}
+	trx.Amount = strings.TrimPrefix(trx.Amount, "-")
+	trx.PromptVersion = c.PromptVersion
+	if trx.PromptVersion == "" {
+		trx.PromptVersion = common.PromptVersion
+	}
+	return &trx, nil
+}
+
+func (c *Client) component() string {
+	return c.Name + "-client"
+}
+
+// now is the current time in the timezone of the tenant in ctx
+func (c *Client) now(ctx context.Context) time.Time {
+	loc := common.ContextLocation(ctx, c.Zones, c.Location)
+	if c.Clock == nil {
+		return time.Now().In(loc)
+	}
+	return c.Clock.Now().In(loc)
+}
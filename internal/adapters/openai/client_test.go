@@ -0,0 +1,180 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"money-tracker-bot/internal/common"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	aiport "money-tracker-bot/internal/port/out/ai"
+	"money-tracker-bot/internal/port/out/ai/aitest"
+	"money-tracker-bot/internal/resilience"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// chatStub speaks the OpenAI chat completions protocol
+func chatStub(respond aitest.Responder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" || r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			Messages []struct {
+				Content json.RawMessage `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var call aitest.Call
+		for _, m := range req.Messages {
+			var text string
+			if json.Unmarshal(m.Content, &text) == nil {
+				call.Prompt += text
+				continue
+			}
+			var parts []contentPart
+			json.Unmarshal(m.Content, &parts)
+			for _, p := range parts {
+				call.Prompt += p.Text
+				if p.ImageURL != nil && strings.HasPrefix(p.ImageURL.URL, "data:image/jpeg;base64,") {
+					call.HasImage = true
+				}
+			}
+		}
+		status, text := respond(call)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if status != http.StatusOK {
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"message": text}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"choices": []any{map[string]any{"message": map[string]any{"role": "assistant", "content": text}}},
+		})
+	})
+}
+
+func TestClient_Contract(t *testing.T) {
+	aitest.Run(t, aitest.Adapter{
+		Handler: chatStub,
+		New: func(t *testing.T, baseURL string) aiport.AiPort {
+			return NewClient(baseURL+"/v1/", "test-key", "")
+		},
+	})
+}
+
+func TestOllamaClient_Contract(t *testing.T) {
+	aitest.Run(t, aitest.Adapter{
+		Handler: chatStub,
+		New: func(t *testing.T, baseURL string) aiport.AiPort {
+			return NewOllamaClient(baseURL+"/v1", "")
+		},
+	})
+}
+
+func TestClient_Defaults(t *testing.T) {
+	c := NewClient("", "key", "")
+	if c.BaseURL != DefaultBaseURL || c.Model != DefaultModel || c.Name != "openai" {
+		t.Errorf("unexpected defaults %+v", c)
+	}
+	o := NewOllamaClient("", "")
+	if o.BaseURL != OllamaBaseURL || o.Model != OllamaModel || o.Name != "ollama" || o.APIKey != "" {
+		t.Errorf("unexpected Ollama defaults %+v", o)
+	}
+}
+
+func TestClient_SendsModelAndKey(t *testing.T) {
+	var auth, model string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		var req chatRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		model = req.Model
+		w.Write([]byte(`{"choices": [{"message": {"content": "ok"}}]}`))
+	}))
+	defer server.Close()
+
+	if _, err := NewClient(server.URL, "secret", "gpt-test").GenerateContent(context.Background(), "hi"); err != nil {
+		t.Fatal(err)
+	}
+	if auth != "Bearer secret" || model != "gpt-test" {
+		t.Errorf("unexpected auth %q and model %q", auth, model)
+	}
+	if _, err := NewOllamaClient(server.URL, "llava").GenerateContent(context.Background(), "hi"); err != nil {
+		t.Fatal(err)
+	}
+	if auth != "" || model != "llava" {
+		t.Errorf("Ollama should send no key, got %q and model %q", auth, model)
+	}
+}
+
+func TestClient_Ping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" || r.Method != http.MethodGet {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer valid" {
+			http.Error(w, `{"error": {"message": "Incorrect API key provided"}}`, http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data": [{"id": "gpt-4o-mini"}]}`))
+	}))
+	defer server.Close()
+
+	if err := NewClient(server.URL+"/v1", "valid", "").Ping(context.Background()); err != nil {
+		t.Errorf("expected a valid key to pass, got %v", err)
+	}
+	err := NewClient(server.URL+"/v1", "revoked", "").Ping(context.Background())
+	if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.ErrCodeAIRequest || !strings.Contains(appErr.Message, "401") {
+		t.Errorf("expected an AI request error for a rejected key, got %v", err)
+	}
+	server.Close()
+	err = NewOllamaClient(server.URL+"/v1", "").Ping(context.Background())
+	if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.ErrCodeNetwork {
+		t.Errorf("expected a network error for a server that is down, got %v", err)
+	}
+}
+
+func TestClient_BreakerCountsOnlyOutages(t *testing.T) {
+	status, answer := http.StatusOK, "Hi! How can I help you today?"
+	server := httptest.NewServer(chatStub(func(aitest.Call) (int, string) { return status, answer }))
+	defer server.Close()
+	client := NewClient(server.URL+"/v1", "key", "")
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	breaker := resilience.NewBreaker("openai", 2, time.Minute, clock)
+	call := func() error {
+		_, err := resilience.Call(breaker, func() (*transaction_domain.Transaction, error) {
+			return client.TextToTransaction(context.Background(), "hello")
+		})
+		return err
+	}
+
+	for _, s := range []int{http.StatusOK, http.StatusUnauthorized, http.StatusNotFound, http.StatusOK} {
+		status = s
+		err := call()
+		if errors.IsRetryableError(err) {
+			t.Errorf("status %d should not be retryable, got %v", s, err)
+		}
+	}
+	if breaker.Snapshot().State != resilience.Closed {
+		t.Fatalf("chit-chat and rejected requests should not open the breaker, got %+v", breaker.Snapshot())
+	}
+
+	for _, s := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		status = s
+		if err := call(); !errors.IsRetryableError(err) {
+			t.Errorf("status %d should be retryable, got %v", s, err)
+		}
+	}
+	if breaker.Snapshot().State != resilience.Open {
+		t.Errorf("expected overload and server errors to open the breaker, got %+v", breaker.Snapshot())
+	}
+}
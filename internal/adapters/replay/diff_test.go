@@ -0,0 +1,15 @@
+package replay
+
+import "testing"
+
+func TestLineDiff(t *testing.T) {
+	if d := lineDiff("a\nb", "a\nb"); d != "" {
+		t.Errorf("expected no diff, got %q", d)
+	}
+	if d := lineDiff("a\nb\nc", "a\nx\nc\nd"); d != "+ x\n- b\n+ d\n" {
+		t.Errorf("unexpected diff %q", d)
+	}
+	if d := lineDiff("", "a"); d != "+ a\n- \n" {
+		t.Errorf("unexpected diff against an empty golden %q", d)
+	}
+}
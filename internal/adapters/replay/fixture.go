@@ -0,0 +1,136 @@
+// Package replay is a regression harness for AI extraction. It replays
+// recorded inputs and model responses through the AI adapters, without
+// calling any AI service, and compares the prompts and transactions they
+// produce with golden files.
+package replay
+
+import (
+	"encoding/json"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Fixture is one recorded input, stored as fixtures/<name>.json
+type Fixture struct {
+	// Name is the file name without extension
+	Name string `json:"-"`
+	// Provider is the adapter to replay through: gemini, openai, ollama or offline
+	Provider string `json:"provider"`
+	// Text is the message for text fixtures
+	Text string `json:"text,omitempty"`
+	// Image is the receipt file for image fixtures, relative to the fixtures directory
+	Image string `json:"image,omitempty"`
+	// Date is the day the message was sent, YYYY-MM-DD
+	Date       string   `json:"date"`
+	Categories []string `json:"categories,omitempty"`
+	Examples   []string `json:"examples,omitempty"`
+	// Responses holds the raw model answer per prompt version; offline
+	// fixtures need none
+	Responses map[string]string `json:"responses,omitempty"`
+}
+
+// Golden is the expected outcome of a fixture for one prompt version
+type Golden struct {
+	Transaction *transaction_domain.Transaction `json:"transaction,omitempty"`
+	Error       string                          `json:"error,omitempty"`
+}
+
+// Corpus is a directory holding fixtures/ and golden/<version>/
+type Corpus struct {
+	Dir string
+}
+
+func (c Corpus) fixturesDir() string {
+	return filepath.Join(c.Dir, "fixtures")
+}
+
+func (c Corpus) goldenDir(version string) string {
+	return filepath.Join(c.Dir, "golden", version)
+}
+
+// Fixtures loads every fixture of the corpus sorted by name
+func (c Corpus) Fixtures() ([]Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(c.fixturesDir(), "*.json"))
+	if err != nil {
+		return nil, errors.NewFileError("failed to list fixtures", err).
+			WithContext("dir", c.fixturesDir()).
+			WithComponent("replay")
+	}
+	sort.Strings(paths)
+	fixtures := make([]Fixture, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.NewFileError("failed to read fixture", err).
+				WithContext("path", path).
+				WithComponent("replay")
+		}
+		var f Fixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, errors.NewDataAccessError("failed to decode fixture", err).
+				WithContext("path", path).
+				WithComponent("replay")
+		}
+		f.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+		if (f.Text == "") == (f.Image == "") {
+			return nil, errors.NewValidationError("fixture needs either text or image", nil).
+				WithContext("path", path).
+				WithComponent("replay")
+		}
+		fixtures = append(fixtures, f)
+	}
+	return fixtures, nil
+}
+
+// readGolden returns the golden files of a fixture; missing files read as empty
+func (c Corpus) readGolden(version, name string) (prompt, result string, err error) {
+	dir := c.goldenDir(version)
+	p, err := readOptional(filepath.Join(dir, name+".prompt.txt"))
+	if err != nil {
+		return "", "", err
+	}
+	r, err := readOptional(filepath.Join(dir, name+".json"))
+	return p, r, err
+}
+
+func (c Corpus) writeGolden(version, name, prompt, result string) error {
+	dir := c.goldenDir(version)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.NewFileError("failed to create golden directory", err).
+			WithContext("dir", dir).
+			WithComponent("replay")
+	}
+	files := make(map[string]string)
+	if result != "" {
+		files[name+".json"] = result
+	}
+	if prompt != "" {
+		files[name+".prompt.txt"] = prompt
+	}
+	for file, content := range files {
+		path := filepath.Join(dir, file)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return errors.NewFileError("failed to write golden file", err).
+				WithContext("path", path).
+				WithComponent("replay")
+		}
+	}
+	return nil
+}
+
+func readOptional(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.NewFileError("failed to read golden file", err).
+			WithContext("path", path).
+			WithComponent("replay")
+	}
+	return string(data), nil
+}
@@ -0,0 +1,56 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFixture(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, "fixtures"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "fixtures", name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCorpus_Fixtures(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "b.json", `{"provider": "offline", "text": "kopi 18k", "date": "2025-07-10"}`)
+	writeFixture(t, dir, "a.json", `{"provider": "openai", "image": "a.jpg", "date": "2025-07-10", "responses": {"v1": "{}"}}`)
+	fixtures, err := Corpus{Dir: dir}.Fixtures()
+	if err != nil || len(fixtures) != 2 {
+		t.Fatalf("expected two fixtures, got %v, %v", fixtures, err)
+	}
+	if fixtures[0].Name != "a" || fixtures[0].Responses["v1"] != "{}" || fixtures[1].Text != "kopi 18k" {
+		t.Errorf("unexpected fixtures %+v", fixtures)
+	}
+
+	writeFixture(t, dir, "c.json", `{"provider": "offline", "date": "2025-07-10"}`)
+	if _, err := (Corpus{Dir: dir}).Fixtures(); err == nil {
+		t.Error("expected error for a fixture without input")
+	}
+	writeFixture(t, dir, "c.json", `{`)
+	if _, err := (Corpus{Dir: dir}).Fixtures(); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestCorpus_Golden(t *testing.T) {
+	c := Corpus{Dir: t.TempDir()}
+	if p, r, err := c.readGolden("v1", "a"); err != nil || p != "" || r != "" {
+		t.Errorf("missing golden files should read empty, got %q, %q, %v", p, r, err)
+	}
+	if err := c.writeGolden("v1", "a", "prompt", "{}\n"); err != nil {
+		t.Fatal(err)
+	}
+	if p, r, _ := c.readGolden("v1", "a"); p != "prompt" || r != "{}\n" {
+		t.Errorf("unexpected golden files %q, %q", p, r)
+	}
+	c.writeGolden("v1", "b", "", "{}\n")
+	if _, err := os.Stat(filepath.Join(c.Dir, "golden", "v1", "b.prompt.txt")); !os.IsNotExist(err) {
+		t.Error("fixtures without a prompt should have no prompt file")
+	}
+}
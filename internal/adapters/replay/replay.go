@@ -0,0 +1,183 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"money-tracker-bot/internal/adapters/gemini"
+	"money-tracker-bot/internal/adapters/offline"
+	"money-tracker-bot/internal/adapters/openai"
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/errors"
+	aiport "money-tracker-bot/internal/port/out/ai"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"google.golang.org/api/option"
+)
+
+// Status is the outcome of replaying one fixture
+type Status string
+
+const (
+	// StatusOK means the prompt and transaction match the golden files
+	StatusOK Status = "ok"
+	// StatusChanged means the prompt or transaction differ from the golden files
+	StatusChanged Status = "changed"
+	// StatusUpdated means the golden files were rewritten
+	StatusUpdated Status = "updated"
+	// StatusMissing means no response was recorded for the prompt version, so
+	// only the prompt was compared
+	StatusMissing Status = "missing response"
+)
+
+// Result is the outcome of one fixture; Diff lists the changed golden lines
+type Result struct {
+	Fixture string
+	Status  Status
+	Diff    string
+}
+
+// Replay runs every fixture of the corpus through its adapter with the
+// prompt template of version and compares the outcome with
+// golden/<version>/. With update the golden files are rewritten instead.
+func Replay(corpus Corpus, version string, update bool) ([]Result, error) {
+	if !slices.Contains(common.PromptVersions(), version) {
+		return nil, errors.NewConfigError("unknown prompt version", nil).
+			WithContext("version", version).
+			WithComponent("replay")
+	}
+	fixtures, err := corpus.Fixtures()
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.MkdirTemp("", "replay")
+	if err != nil {
+		return nil, errors.NewFileError("failed to create temporary directory", err).WithComponent("replay")
+	}
+	defer os.RemoveAll(tmp)
+	server := newModelServer()
+	defer server.Close()
+
+	results := make([]Result, 0, len(fixtures))
+	for _, f := range fixtures {
+		r, err := replayFixture(corpus, f, version, update, server, tmp)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func replayFixture(corpus Corpus, f Fixture, version string, update bool, server *modelServer, tmp string) (Result, error) {
+	date, err := time.Parse("2006-01-02", f.Date)
+	if err != nil {
+		return Result{}, errors.NewValidationError("fixture date must be YYYY-MM-DD", err).
+			WithContext("fixture", f.Name).
+			WithComponent("replay")
+	}
+	clock := &common.FixedClock{Time: date.Add(12 * time.Hour)}
+	port, err := newPort(f.Provider, server.URL, version, clock)
+	if err != nil {
+		return Result{}, err
+	}
+	response, recorded := f.Responses[version]
+	offlineFixture := f.Provider == "offline"
+	server.answer(response, recorded)
+
+	ctx := common.WithPromptCategories(context.Background(), f.Categories)
+	ctx = common.WithPromptExamples(ctx, f.Examples)
+	var golden Golden
+	if f.Image != "" {
+		// Adapters remove images once read, so replay a copy named after the fixture
+		img := filepath.Join(tmp, f.Name+filepath.Ext(f.Image))
+		if err := copyFile(filepath.Join(corpus.fixturesDir(), f.Image), img); err != nil {
+			return Result{}, err
+		}
+		golden.Transaction, err = port.ReadImageToTransaction(ctx, img)
+	} else {
+		golden.Transaction, err = port.TextToTransaction(ctx, f.Text)
+	}
+	if err != nil {
+		golden.Error = err.Error()
+	}
+	prompt := server.lastPrompt()
+	if offlineFixture {
+		prompt = ""
+	}
+	encoded, err := json.MarshalIndent(golden, "", "  ")
+	if err != nil {
+		return Result{}, errors.NewDataAccessError("failed to encode result", err).WithComponent("replay")
+	}
+	result := string(encoded) + "\n"
+
+	missing := !offlineFixture && !recorded
+	if update {
+		if missing {
+			// Keep the golden transaction; there is nothing to compare it with
+			_, result, err = corpus.readGolden(version, f.Name)
+			if err != nil {
+				return Result{}, err
+			}
+		}
+		return Result{Fixture: f.Name, Status: StatusUpdated}, corpus.writeGolden(version, f.Name, prompt, result)
+	}
+
+	wantPrompt, wantResult, err := corpus.readGolden(version, f.Name)
+	if err != nil {
+		return Result{}, err
+	}
+	diff := lineDiff(wantPrompt, prompt)
+	if !missing {
+		diff += lineDiff(wantResult, result)
+	}
+	switch {
+	case diff != "":
+		return Result{Fixture: f.Name, Status: StatusChanged, Diff: diff}, nil
+	case missing:
+		return Result{Fixture: f.Name, Status: StatusMissing}, nil
+	default:
+		return Result{Fixture: f.Name, Status: StatusOK}, nil
+	}
+}
+
+// newPort creates the adapter of provider talking to the replay server
+func newPort(provider, baseURL, version string, clock common.Clock) (aiport.AiPort, error) {
+	switch provider {
+	case "gemini":
+		client, err := gemini.NewClient("replay", "", option.WithEndpoint(baseURL))
+		if err != nil {
+			return nil, err
+		}
+		client.PromptVersion, client.Clock = version, clock
+		return client, nil
+	case "openai", "ollama":
+		client := openai.NewClient(baseURL, "replay", "")
+		client.Name = provider
+		client.PromptVersion, client.Clock = version, clock
+		return client, nil
+	case "offline":
+		return offline.NewParser(clock, time.UTC), nil
+	default:
+		return nil, errors.NewValidationError("unknown fixture provider, expected gemini, openai, ollama or offline", nil).
+			WithContext("provider", provider).
+			WithComponent("replay")
+	}
+}
+
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return errors.NewFileError("failed to read fixture image", err).
+			WithContext("path", src).
+			WithComponent("replay")
+	}
+	if err := os.WriteFile(dst, data, 0o600); err != nil {
+		return errors.NewFileError("failed to copy fixture image", err).
+			WithContext("path", dst).
+			WithComponent("replay")
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package replay
+
+import (
+	"money-tracker-bot/internal/common"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestReplay_Corpus keeps the recorded corpus of the repository green for
+// every prompt version, so parser and prompt changes show up as failing
+// golden diffs. Fixtures recorded for newer prompts only compare the prompt
+// of older ones.
+func TestReplay_Corpus(t *testing.T) {
+	for _, version := range common.PromptVersions() {
+		results, err := Replay(Corpus{Dir: filepath.Join("..", "..", "..", "testdata", "replay")}, version, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) == 0 {
+			t.Fatal("expected recorded fixtures")
+		}
+		for _, r := range results {
+			if r.Status == StatusChanged || (version == common.PromptVersion && r.Status != StatusOK) {
+				t.Errorf("%s %s: %s, run go run ./cmd/promptreplay -version %s to review\n%s", version, r.Fixture, r.Status, version, r.Diff)
+			}
+		}
+	}
+}
+
+func TestReplay_UpdateAndCompare(t *testing.T) {
+	dir := t.TempDir()
+	c := Corpus{Dir: dir}
+	writeFixture(t, dir, "kopi.json", `{"provider": "openai", "text": "kopi 18k", "date": "2025-07-10",
+		"responses": {"v1": "{\"title\": \"Kopi\", \"amount\": \"-18,000\", \"category\": \"Eating Out\"}"}}`)
+	writeFixture(t, dir, "offline.json", `{"provider": "offline", "text": "kopi 18k", "date": "2025-07-10"}`)
+
+	results, err := Replay(c, "v1", true)
+	if err != nil || len(results) != 2 || results[0].Status != StatusUpdated {
+		t.Fatalf("expected the golden files to be written, got %+v, %v", results, err)
+	}
+	golden, _ := os.ReadFile(filepath.Join(dir, "golden", "v1", "kopi.json"))
+	if !strings.Contains(string(golden), `"amount": "18,000"`) || !strings.Contains(string(golden), `"prompt_version": "v1"`) {
+		t.Errorf("unexpected golden transaction:\n%s", golden)
+	}
+	prompt, _ := os.ReadFile(filepath.Join(dir, "golden", "v1", "kopi.prompt.txt"))
+	if !strings.Contains(string(prompt), "kopi 18k") || !strings.Contains(string(prompt), "2025-07-10") {
+		t.Errorf("unexpected golden prompt:\n%s", prompt)
+	}
+
+	if results, _ := Replay(c, "v1", false); results[0].Status != StatusOK || results[1].Status != StatusOK {
+		t.Errorf("expected a clean replay, got %+v", results)
+	}
+
+	// A different model answer changes the transaction
+	writeFixture(t, dir, "kopi.json", `{"provider": "openai", "text": "kopi 18k", "date": "2025-07-10",
+		"responses": {"v1": "{\"title\": \"Kopi\", \"amount\": \"18,000\", \"category\": \"Groceries\"}"}}`)
+	results, _ = Replay(c, "v1", false)
+	if results[0].Status != StatusChanged || !strings.Contains(results[0].Diff, `+     "category": "Groceries",`) {
+		t.Errorf("expected a category diff, got %+v", results[0])
+	}
+
+	// Without a response only the prompt is compared
+	writeFixture(t, dir, "kopi.json", `{"provider": "openai", "text": "kopi 18k", "date": "2025-07-10"}`)
+	if results, _ = Replay(c, "v1", false); results[0].Status != StatusMissing {
+		t.Errorf("expected a missing response, got %+v", results[0])
+	}
+}
+
+func TestReplay_Errors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Replay(Corpus{Dir: dir}, "v0", false); err == nil {
+		t.Error("expected error for an unknown prompt version")
+	}
+	writeFixture(t, dir, "a.json", `{"provider": "magic", "text": "kopi 18k", "date": "2025-07-10"}`)
+	if _, err := Replay(Corpus{Dir: dir}, "v1", false); err == nil {
+		t.Error("expected error for an unknown provider")
+	}
+	writeFixture(t, dir, "a.json", `{"provider": "offline", "text": "kopi 18k", "date": "10/07/2025"}`)
+	if _, err := Replay(Corpus{Dir: dir}, "v1", false); err == nil {
+		t.Error("expected error for an invalid date")
+	}
+}
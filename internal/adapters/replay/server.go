@@ -0,0 +1,111 @@
+package replay
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// modelServer answers every request with one recorded response, speaking
+// both the Gemini generateContent and the OpenAI chat completions protocols.
+// It keeps the prompt of the last request.
+type modelServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	response string
+	recorded bool
+	prompt   string
+}
+
+func newModelServer() *modelServer {
+	s := &modelServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// answer sets the response of the next requests; without a recorded
+// response the server fails them
+func (s *modelServer) answer(response string, recorded bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.response, s.recorded, s.prompt = response, recorded, ""
+}
+
+// lastPrompt returns the prompt of the last request
+func (s *modelServer) lastPrompt() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.prompt
+}
+
+func (s *modelServer) handle(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		// Gemini
+		Contents []struct {
+			Parts []struct {
+				Text string `json:"text"`
+			} `json:"parts"`
+		} `json:"contents"`
+		// OpenAI
+		Messages []struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var prompt strings.Builder
+	for _, c := range req.Contents {
+		for _, p := range c.Parts {
+			prompt.WriteString(p.Text)
+		}
+	}
+	for _, m := range req.Messages {
+		prompt.WriteString(messageText(m.Content))
+	}
+
+	s.mu.Lock()
+	s.prompt = prompt.String()
+	response, recorded := s.response, s.recorded
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !recorded {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": http.StatusNotFound, "message": "no recorded response"}})
+		return
+	}
+	if strings.HasSuffix(r.URL.Path, ":generateContent") {
+		json.NewEncoder(w).Encode(map[string]any{
+			"candidates": []any{map[string]any{
+				"content": map[string]any{"role": "model", "parts": []any{map[string]any{"text": response}}},
+			}},
+		})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"choices": []any{map[string]any{"message": map[string]any{"role": "assistant", "content": response}}},
+	})
+}
+
+// messageText returns the text of an OpenAI message content, which is a
+// string or a list of parts
+func messageText(content json.RawMessage) string {
+	var text string
+	if json.Unmarshal(content, &text) == nil {
+		return text
+	}
+	var parts []struct {
+		Text string `json:"text"`
+	}
+	json.Unmarshal(content, &parts)
+	var b strings.Builder
+	for _, p := range parts {
+		b.WriteString(p.Text)
+	}
+	return b.String()
+}
@@ -0,0 +1,36 @@
+package replay
+
+import (
+	"context"
+	"money-tracker-bot/internal/adapters/openai"
+	"testing"
+)
+
+func TestModelServer(t *testing.T) {
+	s := newModelServer()
+	defer s.Close()
+	client := openai.NewClient(s.URL, "", "")
+
+	s.answer(`{"title": "Kopi", "amount": "18,000"}`, true)
+	trx, err := client.TextToTransaction(context.Background(), "kopi 18k")
+	if err != nil || trx.Title != "Kopi" {
+		t.Fatalf("expected the recorded answer, got %+v, %v", trx, err)
+	}
+	if prompt := s.lastPrompt(); prompt == "" {
+		t.Error("expected the prompt to be kept")
+	}
+
+	s.answer("", false)
+	if _, err := client.TextToTransaction(context.Background(), "kopi 18k"); err == nil {
+		t.Error("expected requests to fail without a recorded response")
+	}
+}
+
+func TestMessageText(t *testing.T) {
+	if got := messageText([]byte(`"hello"`)); got != "hello" {
+		t.Errorf("unexpected text %q", got)
+	}
+	if got := messageText([]byte(`[{"type": "text", "text": "read"}, {"type": "image_url"}]`)); got != "read" {
+		t.Errorf("unexpected text %q", got)
+	}
+}
@@ -0,0 +1,36 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleAdviceCommand asks the AI for saving suggestions based on the last
+// three months of spending
+func (t *TelegramHandler) handleAdviceCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Advice == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("advice.disabled")))
+		return
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("advice.thinking")))
+	text, err := t.Advice.Advise(i18n.WithLanguage(ctx, p.Lang()), chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "getting saving advice")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("advice.failed", userMessage(p, err))))
+		return
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("advice.title", text)))
+}
+
+// overBudgetWarning is appended to the saved reply once a category is overspent
+func (t *TelegramHandler) overBudgetWarning(p i18n.Printer) string {
+	if t.Advice == nil {
+		return p.T("advice.over_budget")
+	}
+	return p.T("advice.over_budget_hint")
+}
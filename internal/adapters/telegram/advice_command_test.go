@@ -0,0 +1,71 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/errors"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestAdviceCommand(t *testing.T) {
+	bot := &MockBotAPI{}
+	advice := &MockAdviceService{Answer: "• Cook at home twice a week to save Rp 300,000"}
+	h := &TelegramHandler{Telebot: bot, Advice: advice}
+
+	h.handleCommand(context.Background(), newCommand(3, "/advice"))
+	if len(advice.Chats) != 1 || advice.Chats[0] != 3 {
+		t.Fatalf("expected advice for chat 3, got %v", advice.Chats)
+	}
+	if reply := lastText(t, bot); reply != "💡 Saving suggestions\n\n• Cook at home twice a week to save Rp 300,000" {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+
+	advice.Err = errors.NewValidationError("no spending recorded in the last three months yet", nil)
+	h.handleCommand(context.Background(), newCommand(3, "/advice"))
+	if reply := lastText(t, bot); reply != "Could not get saving advice: no spending recorded in the last three months yet" {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+
+	advice.Err = fmt.Errorf("gemini down")
+	h.handleCommand(context.Background(), newCommand(3, "/advice"))
+	if reply := lastText(t, bot); strings.Contains(reply, "gemini") {
+		t.Errorf("internal errors should not be shown: %s", reply)
+	}
+}
+
+func TestAdviceCommand_Disabled(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot}
+	h.handleCommand(context.Background(), newCommand(3, "/advice"))
+	if !strings.Contains(lastText(t, bot), "not enabled") {
+		t.Errorf("unexpected reply: %s", lastText(t, bot))
+	}
+}
+
+func TestSavedReply_OverBudgetPointsToAdvice(t *testing.T) {
+	bot := &MockBotAPI{}
+	trxs := &MockTransactionService{Summary: spreadsheet.CategorySummary{BudgetLeft: "-50000"}}
+	h := &TelegramHandler{Telebot: bot, TransactionService: trxs}
+	msg := &tgbotapi.Message{Text: "kopi 60k", From: &tgbotapi.User{UserName: "user"}, Chat: &tgbotapi.Chat{ID: 3}}
+
+	h.handleMessage(context.Background(), bot, msg)
+	if reply := lastText(t, bot); !strings.HasSuffix(reply, "⚠️ This category is over budget.") {
+		t.Errorf("expected an over budget warning, got: %s", reply)
+	}
+
+	h.Advice = &MockAdviceService{}
+	h.handleMessage(context.Background(), bot, msg)
+	if reply := lastText(t, bot); !strings.HasSuffix(reply, "Try /advice for saving suggestions.") {
+		t.Errorf("expected a pointer to /advice, got: %s", reply)
+	}
+
+	trxs.Summary.BudgetLeft = "50000"
+	h.handleMessage(context.Background(), bot, msg)
+	if reply := lastText(t, bot); strings.Contains(reply, "over budget") {
+		t.Errorf("unexpected warning within budget: %s", reply)
+	}
+}
@@ -0,0 +1,59 @@
+package telegram
+
+import (
+	"fmt"
+	"money-tracker-bot/internal/i18n"
+	"strings"
+)
+
+// errUnterminatedQuote is the only error of parseKeyValues; replies word it
+// with the "args.unterminated_quote" message
+var errUnterminatedQuote = fmt.Errorf("unterminated quote")
+
+// argsProblem is the reply to arguments parseKeyValues rejects, followed by usage
+func argsProblem(p i18n.Printer, usage string) string {
+	return p.T("args.unterminated_quote") + "\n\n" + usage
+}
+
+// parseKeyValues splits command arguments into key=value options and
+// positional words. Values may be double-quoted to include spaces.
+// Input: `day=1 amount=5000000 category="Rent House" extra`
+// Output: {"day": "1", "amount": "5000000", "category": "Rent House"}, ["extra"]
+func parseKeyValues(args string) (map[string]string, []string, error) {
+	options := make(map[string]string)
+	var positional []string
+	var token strings.Builder
+	inQuotes, hasToken := false, false
+
+	flush := func() {
+		if !hasToken {
+			return
+		}
+		word := token.String()
+		if key, value, ok := strings.Cut(word, "="); ok && key != "" {
+			options[strings.ToLower(key)] = value
+		} else {
+			positional = append(positional, word)
+		}
+		token.Reset()
+		hasToken = false
+	}
+
+	for _, r := range args {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasToken = true
+		case (r == ' ' || r == '\t' || r == '\n') && !inQuotes:
+			flush()
+		default:
+			token.WriteRune(r)
+			hasToken = true
+		}
+	}
+	if inQuotes {
+		return nil, nil, errUnterminatedQuote
+	}
+	flush()
+	return options, positional, nil
+}
@@ -0,0 +1,20 @@
+package telegram
+
+import "testing"
+
+func TestParseKeyValues(t *testing.T) {
+	options, positional, err := parseKeyValues(`add day=1 amount=5,000,000 category="Rent House" title="Monthly rent"`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if options["category"] != "Rent House" || options["title"] != "Monthly rent" || options["amount"] != "5,000,000" || options["day"] != "1" {
+		t.Errorf("unexpected options: %v", options)
+	}
+	if len(positional) != 1 || positional[0] != "add" {
+		t.Errorf("unexpected positional args: %v", positional)
+	}
+
+	if _, _, err := parseKeyValues(`title="unterminated`); err == nil {
+		t.Error("expected error for unterminated quote")
+	}
+}
@@ -0,0 +1,150 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	category_domain "money-tracker-bot/internal/domain/category"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleCategoriesCommand manages the categories of the chat
+func (t *TelegramHandler) handleCategoriesCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Categories == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("categories.defaults", strings.Join(common.TransactionCategoryList, ", "))))
+		return
+	}
+
+	usage := p.T("categories.usage")
+	options, args, err := parseKeyValues(msg.CommandArguments())
+	if err != nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, argsProblem(p, usage)))
+		return
+	}
+	sub := "list"
+	if len(args) > 0 {
+		sub = args[0]
+	}
+
+	switch sub {
+	case "list":
+		t.sendCategoryList(ctx, p, chatID)
+	case "add":
+		c, err := t.Categories.Add(chatID, category_domain.Category{
+			Name:        options["name"],
+			Parent:      options["parent"],
+			Aliases:     splitList(options["aliases"]),
+			Description: options["description"],
+		})
+		if err != nil {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("categories.add_failed", userMessage(p, err))+"\n\n"+usage))
+			return
+		}
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("categories.added", c.Path())))
+	case "rename", "merge":
+		if len(args) != 3 {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, usage))
+			return
+		}
+		var m category_domain.Migration
+		var rows int
+		if sub == "rename" {
+			m, rows, err = t.Categories.Rename(ctx, chatID, args[1], args[2])
+		} else {
+			m, rows, err = t.Categories.Merge(ctx, chatID, args[1], args[2])
+		}
+		if err != nil {
+			errors.HandleErrorContext(ctx, err, "running /categories "+sub)
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("categories."+sub+"_failed", userMessage(p, err))))
+			return
+		}
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("categories.migrated", m.From, m.To, rows)))
+	default:
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, usage))
+	}
+}
+
+func (t *TelegramHandler) sendCategoryList(ctx context.Context, p i18n.Printer, chatID int64) {
+	set, err := t.Categories.List(chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "listing categories")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("categories.load_failed")))
+		return
+	}
+	lines := []string{p.T("categories.title")}
+	for _, c := range set.Categories {
+		if c.Parent != "" {
+			continue
+		}
+		lines = append(lines, "• "+describeCategory(p, c))
+		for _, child := range set.Children(c.Name) {
+			lines = append(lines, "   ◦ "+describeCategory(p, child))
+		}
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, strings.Join(lines, "\n")))
+}
+
+// matchCategory resolves a category name typed in a command, using the
+// chat's categories when they are configurable
+func (t *TelegramHandler) matchCategory(ctx context.Context, chatID int64, name string) (category_domain.Ref, bool) {
+	if t.Categories == nil {
+		c, ok := common.MatchCategory(name)
+		return category_domain.Ref{Category: c}, ok
+	}
+	ref, ok, err := t.Categories.Match(chatID, name)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "matching category")
+		return category_domain.Ref{}, false
+	}
+	return ref, ok
+}
+
+// categoryChoices lists the category paths a command may use
+func (t *TelegramHandler) categoryChoices(ctx context.Context, chatID int64) []string {
+	if t.Categories == nil {
+		return common.TransactionCategoryList
+	}
+	set, err := t.Categories.List(chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "listing categories")
+		return nil
+	}
+	choices := make([]string, 0, len(set.Categories))
+	for _, c := range set.Categories {
+		choices = append(choices, c.Path())
+	}
+	return choices
+}
+
+// categoryLabel renders the category of a transaction with its subcategory
+func categoryLabel(trx *transaction_domain.Transaction) string {
+	return category_domain.Ref{Category: trx.Category, Subcategory: trx.Subcategory}.String()
+}
+
+func describeCategory(p i18n.Printer, c category_domain.Category) string {
+	text := c.Name
+	if len(c.Aliases) > 0 {
+		text += p.T("categories.also", strings.Join(c.Aliases, ", "))
+	}
+	if c.Description != "" {
+		text += " – " + c.Description
+	}
+	return text
+}
+
+// splitList splits a comma-separated option into trimmed, non-empty values
+func splitList(value string) []string {
+	var out []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCategoriesCommand_List(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot, Categories: NewMockCategoryService()}
+
+	h.handleCommand(context.Background(), newCommand(3, "/categories"))
+	reply := lastText(t, bot)
+	if !strings.Contains(reply, "• Eating Out\n   ◦ Coffee (also: kopi)") {
+		t.Errorf("subcategories should be listed under their parent, got: %s", reply)
+	}
+}
+
+func TestCategoriesCommand_AddRenameMerge(t *testing.T) {
+	bot := &MockBotAPI{}
+	cats := NewMockCategoryService()
+	h := &TelegramHandler{Telebot: bot, Categories: cats}
+
+	h.handleCommand(context.Background(), newCommand(3, `/categories add name=Pets aliases="cat food, vet" description="Pet supplies"`))
+	if reply := lastText(t, bot); reply != "Category added ✅ Pets" {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+	if ref, ok := cats.Set.Match("vet"); !ok || ref.Category != "Pets" {
+		t.Errorf("aliases should be split on commas, got %+v", cats.Set.Categories)
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, `/categories rename "Eating Out" Dining`))
+	if reply := lastText(t, bot); !strings.Contains(reply, "Eating Out → Dining") || !strings.Contains(reply, "Updated 4 recorded") {
+		t.Errorf("unexpected rename reply: %s", reply)
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, `/categories merge "Dining › Coffee" Dining`))
+	if reply := lastText(t, bot); !strings.Contains(reply, "Dining › Coffee → Dining") {
+		t.Errorf("unexpected merge reply: %s", reply)
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, `/categories merge Dining`))
+	if !strings.HasPrefix(lastText(t, bot), "Usage:") {
+		t.Errorf("expected usage, got: %s", lastText(t, bot))
+	}
+}
+
+func TestRecurringCommand_UsesChatCategories(t *testing.T) {
+	bot := &MockBotAPI{}
+	svc := &MockRecurringService{}
+	h := &TelegramHandler{Telebot: bot, Recurring: svc, Categories: NewMockCategoryService()}
+
+	h.handleCommand(context.Background(), newCommand(5, `/recurring add day=3 amount=50000 category=kopi`))
+	if len(svc.Added) != 1 || svc.Added[0].Category != "Eating Out" || svc.Added[0].Subcategory != "Coffee" {
+		t.Fatalf("category should resolve through the chat categories, got %+v", svc.Added)
+	}
+	if !strings.Contains(lastText(t, bot), "(Eating Out › Coffee)") {
+		t.Errorf("unexpected reply: %s", lastText(t, bot))
+	}
+}
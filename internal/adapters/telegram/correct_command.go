@@ -0,0 +1,101 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	learning_domain "money-tracker-bot/internal/domain/learning"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleCorrectCommand fixes the category of the last transaction saved in the
+// chat and teaches the bot the merchant
+func (t *TelegramHandler) handleCorrectCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Learning == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("correct.disabled")))
+		return
+	}
+
+	usage := p.T("correct.usage")
+	options, args, err := parseKeyValues(msg.CommandArguments())
+	if err != nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, argsProblem(p, usage)))
+		return
+	}
+	if len(args) == 0 {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, usage))
+		return
+	}
+	name := strings.Join(args, " ")
+	ref, ok := t.matchCategory(ctx, chatID, name)
+	if !ok {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("correct.unknown_category", name, strings.Join(t.categoryChoices(ctx, chatID), ", "))))
+		return
+	}
+
+	c, err := t.Learning.Correct(ctx, chatID, ref, options["merchant"])
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "correcting category")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("correct.failed", userMessage(p, err))))
+		return
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("correct.done", c.From, c.To, c.Merchant)))
+}
+
+// handleStatsCommand reports how often the chat corrects categories and what the bot learned
+func (t *TelegramHandler) handleStatsCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Learning == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("correct.disabled")))
+		return
+	}
+	m, err := t.Learning.Memory(chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "loading correction stats")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("stats.load_failed")))
+		return
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, formatLearningStats(p, m)))
+}
+
+// observeForCorrection lets /correct refer to the transaction just saved in the chat
+func (t *TelegramHandler) observeForCorrection(chatID int64, trx transaction_domain.Transaction) {
+	if t.Learning != nil {
+		t.Learning.Observe(chatID, trx)
+	}
+}
+
+// correctionHint tells users how to fix a wrong category when corrections are enabled
+func (t *TelegramHandler) correctionHint(p i18n.Printer) string {
+	if t.Learning == nil {
+		return ""
+	}
+	return p.T("correct.hint")
+}
+
+func formatLearningStats(p i18n.Printer, m learning_domain.Memory) string {
+	lines := []string{
+		p.T("stats.title"),
+		p.T("stats.summary", m.Stats.Categorized, m.Stats.Corrected, m.Stats.Rate()*100),
+	}
+	if len(m.Mappings) == 0 {
+		lines = append(lines, "", p.T("stats.none"))
+		return strings.Join(lines, "\n")
+	}
+	lines = append(lines, "", p.T("stats.merchants"))
+	for _, mp := range m.Ranked() {
+		line := fmt.Sprintf("• %s → %s (%d×", mp.Merchant, mp.Ref(), mp.Corrections)
+		if mp.LastCorrected != "" {
+			line += p.T("stats.last", mp.LastCorrected)
+		}
+		lines = append(lines, line+")")
+	}
+	return strings.Join(lines, "\n")
+}
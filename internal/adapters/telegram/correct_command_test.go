@@ -0,0 +1,86 @@
+package telegram
+
+import (
+	"context"
+	learning_domain "money-tracker-bot/internal/domain/learning"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestCorrectCommand(t *testing.T) {
+	bot := &MockBotAPI{}
+	learning := &MockLearningService{}
+	h := &TelegramHandler{Telebot: bot, TransactionService: &MockTransactionService{}, Learning: learning, Categories: NewMockCategoryService()}
+
+	h.handleCommand(context.Background(), newCommand(3, `/correct Groceries`))
+	if !strings.Contains(lastText(t, bot), "no recent transaction") {
+		t.Errorf("expected hint to send a transaction first, got: %s", lastText(t, bot))
+	}
+
+	h.handleMessage(context.Background(), bot, &tgbotapi.Message{
+		Text: "superindo 150000",
+		From: &tgbotapi.User{UserName: "user"},
+		Chat: &tgbotapi.Chat{ID: 3},
+	})
+	if len(learning.Observed) != 1 {
+		t.Fatalf("saved transaction should be observed for /correct, got %+v", learning.Observed)
+	}
+	if !strings.Contains(lastText(t, bot), "Wrong category? /correct <category>") {
+		t.Errorf("saved reply should mention /correct, got: %s", lastText(t, bot))
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, `/correct Pets`))
+	if len(learning.Corrections) != 0 || !strings.Contains(lastText(t, bot), "Unknown category") {
+		t.Errorf("unknown categories should be rejected, got: %s", lastText(t, bot))
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, `/correct kopi merchant="Kopi Kenangan"`))
+	if len(learning.Corrections) != 1 {
+		t.Fatalf("expected a correction, got: %s", lastText(t, bot))
+	}
+	c := learning.Corrections[0]
+	if c.To.String() != "Eating Out › Coffee" || c.Merchant != "Kopi Kenangan" {
+		t.Errorf("unexpected correction %+v", c)
+	}
+	if reply := lastText(t, bot); !strings.Contains(reply, `I'll file "Kopi Kenangan" under Eating Out › Coffee`) {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+}
+
+func TestStatsCommand(t *testing.T) {
+	bot := &MockBotAPI{}
+	learning := &MockLearningService{}
+	h := &TelegramHandler{Telebot: bot, Learning: learning}
+
+	h.handleCommand(context.Background(), newCommand(3, `/stats`))
+	if reply := lastText(t, bot); !strings.Contains(reply, "Saved: 0 · corrected: 0 (0.0%)") || !strings.Contains(reply, "No corrections learned yet") {
+		t.Errorf("unexpected empty stats: %s", reply)
+	}
+
+	learning.Stats = learning_domain.Memory{
+		Stats: learning_domain.Stats{Categorized: 40, Corrected: 3},
+		Mappings: []learning_domain.Mapping{
+			{Merchant: "Indomaret", Category: "Groceries", Corrections: 1, LastCorrected: "2025-07-01"},
+			{Merchant: "Superindo", Category: "Groceries", Corrections: 2, LastCorrected: "2025-07-20"},
+		},
+	}
+	h.handleCommand(context.Background(), newCommand(3, `/stats`))
+	reply := lastText(t, bot)
+	if !strings.Contains(reply, "Saved: 40 · corrected: 3 (7.5%)") {
+		t.Errorf("expected correction rate, got: %s", reply)
+	}
+	if !strings.Contains(reply, "Learned merchants:\n• Superindo → Groceries (2×, last 2025-07-20)\n• Indomaret") {
+		t.Errorf("expected learned merchants most corrected first, got: %s", reply)
+	}
+}
+
+func TestCorrectCommand_Disabled(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot}
+	h.handleCommand(context.Background(), newCommand(3, `/correct Groceries`))
+	if !strings.Contains(lastText(t, bot), "not enabled") {
+		t.Errorf("unexpected reply: %s", lastText(t, bot))
+	}
+}
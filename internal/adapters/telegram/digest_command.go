@@ -0,0 +1,128 @@
+package telegram
+
+import (
+	"context"
+	stderrors "errors"
+	digest_domain "money-tracker-bot/internal/domain/digest"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleDigestCommand shows or updates the digest schedule of the chat
+func (t *TelegramHandler) handleDigestCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Digests == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("digest.disabled")))
+		return
+	}
+
+	schedule, err := t.Digests.GetSchedule(chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "loading digest schedule")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("digest.load_failed")))
+		return
+	}
+
+	usage := p.T("digest.usage")
+	args := strings.Fields(msg.CommandArguments())
+	if len(args) == 0 {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, formatSchedule(p, schedule)+"\n\n"+usage))
+		return
+	}
+
+	if args[0] == "now" {
+		kind := digest_domain.KindDaily
+		if len(args) > 1 && args[1] == string(digest_domain.KindWeekly) {
+			kind = digest_domain.KindWeekly
+		}
+		text, err := t.Digests.BuildDigest(ctx, chatID, kind)
+		if err != nil {
+			errors.HandleErrorContext(ctx, err, "building digest")
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("digest.build_failed")))
+			return
+		}
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, text))
+		return
+	}
+
+	// Digests follow the chat's timezone when there are chat settings, so
+	// /digest tz changes that rather than one only digests would use
+	if args[0] == "tz" && len(args) > 1 && t.Settings != nil {
+		t.setTimezone(ctx, p, chatID, args[1])
+		return
+	}
+
+	updated, err := applyDigestArgs(p, schedule, args)
+	if err != nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, localize(p, err)+"\n\n"+usage))
+		return
+	}
+	if err := t.Digests.SaveSchedule(updated); err != nil {
+		errors.HandleErrorContext(ctx, err, "saving digest schedule")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("digest.save_failed")))
+		return
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("digest.updated")+"\n"+formatSchedule(p, updated)))
+}
+
+// applyDigestArgs applies the /digest arguments to a schedule. Its errors
+// are worded by p, since they are shown to the user as they are.
+func applyDigestArgs(p i18n.Printer, s digest_domain.Schedule, args []string) (digest_domain.Schedule, error) {
+	switch args[0] {
+	case "on", "off":
+		s.DailyEnabled = args[0] == "on"
+		s.WeeklyEnabled = args[0] == "on"
+		return s, nil
+	case "tz":
+		if len(args) < 2 {
+			return s, stderrors.New(p.T("digest.missing_timezone"))
+		}
+		s.Timezone = args[1]
+		return s, nil
+	case "daily", "weekly":
+		if len(args) < 2 {
+			return s, stderrors.New(p.T("digest.missing_value", args[0]))
+		}
+		enabled, at := true, ""
+		switch args[1] {
+		case "on":
+		case "off":
+			enabled = false
+		default:
+			if _, _, err := digest_domain.ParseTimeOfDay(args[1]); err != nil {
+				return s, err
+			}
+			at = args[1]
+		}
+		if args[0] == "daily" {
+			s.DailyEnabled = enabled
+			if at != "" {
+				s.DailyTime = at
+			}
+		} else {
+			s.WeeklyEnabled = enabled
+			if at != "" {
+				s.WeeklyTime = at
+			}
+		}
+		return s, nil
+	default:
+		return s, stderrors.New(p.T("digest.unknown_option", args[0]))
+	}
+}
+
+func formatSchedule(p i18n.Printer, s digest_domain.Schedule) string {
+	return p.T("digest.schedule",
+		onOff(p, s.DailyEnabled), s.DailyTime, onOff(p, s.WeeklyEnabled), s.WeeklyTime, s.Timezone)
+}
+
+func onOff(p i18n.Printer, enabled bool) string {
+	if enabled {
+		return p.T("digest.on")
+	}
+	return p.T("digest.off")
+}
@@ -0,0 +1,112 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// newCommand builds a command message the way Telegram delivers it
+func newCommand(chatID int64, text string) *tgbotapi.Message {
+	command := strings.Fields(text)[0]
+	return &tgbotapi.Message{
+		Text:     text,
+		From:     &tgbotapi.User{UserName: "user"},
+		Chat:     &tgbotapi.Chat{ID: chatID},
+		Entities: []tgbotapi.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(command)}},
+	}
+}
+
+func lastText(t *testing.T, bot *MockBotAPI) string {
+	t.Helper()
+	if len(bot.SentMessages) == 0 {
+		t.Fatal("expected a reply")
+	}
+	msg, ok := bot.SentMessages[len(bot.SentMessages)-1].(tgbotapi.MessageConfig)
+	if !ok {
+		t.Fatalf("expected text message, got %T", bot.SentMessages[len(bot.SentMessages)-1])
+	}
+	return msg.Text
+}
+
+func TestDigestCommand_UpdatesSchedule(t *testing.T) {
+	bot := &MockBotAPI{}
+	digests := &MockDigestService{}
+	h := &TelegramHandler{Telebot: bot, Digests: digests}
+
+	h.handleCommand(context.Background(), newCommand(10, "/digest daily 22:15"))
+	s := digests.Schedules[10]
+	if !s.DailyEnabled || s.DailyTime != "22:15" {
+		t.Errorf("expected daily digest at 22:15, got %+v", s)
+	}
+	if !strings.Contains(lastText(t, bot), "Digest settings updated") {
+		t.Errorf("unexpected reply: %s", lastText(t, bot))
+	}
+
+	h.handleCommand(context.Background(), newCommand(10, "/digest tz Asia/Jakarta"))
+	if digests.Schedules[10].Timezone != "Asia/Jakarta" || !digests.Schedules[10].DailyEnabled {
+		t.Errorf("timezone change should keep other settings, got %+v", digests.Schedules[10])
+	}
+
+	h.handleCommand(context.Background(), newCommand(10, "/digest off"))
+	if digests.Schedules[10].DailyEnabled || digests.Schedules[10].WeeklyEnabled {
+		t.Error("expected digests to be disabled")
+	}
+}
+
+func TestDigestCommand_TimezoneFollowsChatSettings(t *testing.T) {
+	bot := &MockBotAPI{}
+	digests := &MockDigestService{}
+	settings := &MockSettingsService{}
+	h := &TelegramHandler{Telebot: bot, Digests: digests, Settings: settings}
+
+	h.handleCommand(context.Background(), newCommand(10, "/digest tz Asia/Jakarta"))
+	if s, _ := settings.Get(10); s.Timezone != "Asia/Jakarta" {
+		t.Errorf("expected the chat's timezone to change, got %q", s.Timezone)
+	}
+	if _, saved := digests.Schedules[10]; saved {
+		t.Error("the digest schedule should not keep a timezone of its own")
+	}
+	if !strings.Contains(lastText(t, bot), "Timezone set to Asia/Jakarta") {
+		t.Errorf("unexpected reply: %s", lastText(t, bot))
+	}
+}
+
+func TestDigestCommand_InvalidArgs(t *testing.T) {
+	bot := &MockBotAPI{}
+	digests := &MockDigestService{}
+	h := &TelegramHandler{Telebot: bot, Digests: digests}
+
+	h.handleCommand(context.Background(), newCommand(10, "/digest weekly 7pm"))
+	if _, saved := digests.Schedules[10]; saved {
+		t.Error("invalid arguments should not be saved")
+	}
+	if !strings.Contains(lastText(t, bot), "Usage:") {
+		t.Errorf("expected usage help, got: %s", lastText(t, bot))
+	}
+}
+
+func TestDigestCommand_Now(t *testing.T) {
+	bot := &MockBotAPI{}
+	digests := &MockDigestService{}
+	h := &TelegramHandler{Telebot: bot, Digests: digests}
+
+	h.handleCommand(context.Background(), newCommand(10, "/digest now weekly"))
+	if len(digests.Built) != 1 || digests.Built[0] != "weekly" {
+		t.Errorf("expected weekly digest to be built, got %v", digests.Built)
+	}
+	if lastText(t, bot) != "digest weekly" {
+		t.Errorf("unexpected reply: %s", lastText(t, bot))
+	}
+}
+
+func TestDigestCommand_Disabled(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot}
+	h.handleCommand(context.Background(), newCommand(10, "/digest"))
+	if !strings.Contains(lastText(t, bot), "not enabled") {
+		t.Errorf("unexpected reply: %s", lastText(t, bot))
+	}
+}
@@ -0,0 +1,132 @@
+package telegram
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	stderrors "errors"
+	"log/slog"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"money-tracker-bot/internal/logging"
+	"money-tracker-bot/internal/tracing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Inputs a failed request was about; they pick the reply for AI failures
+const (
+	inputText    = "text"
+	inputReceipt = "receipt"
+)
+
+// unreadableReplies are the message keys shown when the AI could not
+// extract a transaction
+var unreadableReplies = map[string]string{
+	inputText:    "error.unreadable_text",
+	inputReceipt: "error.unreadable_receipt",
+}
+
+// errorReplies are the message keys of the replies for AppError codes that
+// users can act on
+var errorReplies = map[string]string{
+	errors.ErrCodeQuota:         "error.quota",
+	errors.ErrCodeSpreadsheet:   "error.spreadsheet",
+	errors.ErrCodeCircuitOpen:   "error.circuit_open",
+	errors.ErrCodeNetwork:       "error.network",
+	errors.ErrCodeTimeout:       "error.timeout",
+	errors.ErrCodeFileOperation: "error.file",
+	errors.ErrCodeTelegram:      "error.telegram",
+}
+
+// friendlyMessage translates err into a reply for the user in the language
+// of p. input is inputText or inputReceipt and words AI failures.
+func friendlyMessage(p i18n.Printer, err error, input string) string {
+	appErr, ok := err.(*errors.AppError)
+	if !ok {
+		return p.T("error.generic")
+	}
+	switch {
+	case appErr.Code == errors.ErrCodeValidation:
+		return localize(p, appErr)
+	case appErr.Severity == errors.SeverityCritical:
+		return p.T("error.critical")
+	case appErr.Code == errors.ErrCodeGemini || appErr.Code == errors.ErrCodeAI || appErr.Code == errors.ErrCodeAIRequest || appErr.Code == errors.ErrCodeDataFormat:
+		if key, ok := unreadableReplies[input]; ok {
+			return p.T(key)
+		}
+	}
+	if key, ok := errorReplies[appErr.Code]; ok {
+		return p.T(key)
+	}
+	return p.T("error.generic")
+}
+
+// localize words an error users can fix in the language of p: the catalog
+// message of a keyed validation error or of a domain errors.Message it
+// wraps, else its English text
+func localize(p i18n.Printer, err error) string {
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) && appErr.Key != "" {
+		return p.T(appErr.Key, localizeArgs(p, appErr.Args)...)
+	}
+	var msg *errors.Message
+	if stderrors.As(err, &msg) {
+		return p.T(msg.Key, localizeArgs(p, msg.Args)...)
+	}
+	if appErr != nil {
+		return appErr.Message
+	}
+	return err.Error()
+}
+
+// localizeArgs words the messages among args, such as the problem a CSV
+// line has, in the language of p
+func localizeArgs(p i18n.Printer, args []interface{}) []interface{} {
+	out := make([]interface{}, len(args))
+	for i, arg := range args {
+		if msg, ok := arg.(*errors.Message); ok {
+			arg = p.T(msg.Key, localizeArgs(p, msg.Args)...)
+		}
+		out[i] = arg
+	}
+	return out
+}
+
+// replyError logs err and tells the chat what went wrong
+func (t *TelegramHandler) replyError(ctx context.Context, chatID int64, err error, input, operation string) {
+	p := t.printer(ctx, chatID)
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, withReference(p, friendlyMessage(p, err, input), reportError(ctx, err, operation))))
+}
+
+// reportError logs err and returns the correlation ID of the record, which
+// users quote to support. Validation errors are the user's to fix and get no
+// ID.
+func reportError(ctx context.Context, err error, operation string) string {
+	if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.ErrCodeValidation {
+		errors.HandleErrorContext(ctx, err, operation)
+		return ""
+	}
+	id := newCorrelationID()
+	errors.HandleErrorContext(logging.WithAttrs(ctx, slog.String("correlation_id", id)), err, operation)
+	tracing.Fail(ctx, err, attribute.String("correlation_id", id))
+	return id
+}
+
+// withReference appends the correlation ID, if any, to a reply
+func withReference(p i18n.Printer, text, id string) string {
+	if id == "" {
+		return text
+	}
+	return p.T("error.reference", text, id)
+}
+
+// newCorrelationID returns a short random ID that ties a reply to its log record
+func newCorrelationID() string {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
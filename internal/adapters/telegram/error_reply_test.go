@@ -0,0 +1,80 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"money-tracker-bot/internal/logging"
+	"regexp"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestFriendlyMessage(t *testing.T) {
+	cases := []struct {
+		err   error
+		input string
+		want  string
+	}{
+		{errors.NewValidationError("amount must be positive", nil), inputText, "amount must be positive"},
+		{errors.NewGeminiError("no transaction in Gemini response", nil), inputReceipt, "I couldn't read that receipt"},
+		{errors.NewAIError("empty answer", nil), inputText, "I couldn't understand that transaction"},
+		{errors.NewQuotaError("daily token quota used up", nil), inputReceipt, "today's AI quota"},
+		{errors.NewSpreadsheetError("append failed", nil), inputText, "The spreadsheet is unavailable"},
+		{errors.NewSpreadsheetCriticalError("credentials revoked", nil), inputText, "contact support"},
+		{fmt.Errorf("boom"), inputText, "Something went wrong"},
+	}
+	for _, c := range cases {
+		if got := friendlyMessage(i18n.For(i18n.English), c.err, c.input); !strings.Contains(got, c.want) {
+			t.Errorf("friendlyMessage(%v, %s) = %q, want it to contain %q", c.err, c.input, got, c.want)
+		}
+	}
+}
+
+func TestExtractText_RepliesWithReference(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, _ := logging.New(buf, logging.FormatJSON, slog.LevelInfo, nil)
+	errors.SetLogger(l)
+	defer errors.SetLogger(errors.DefaultLogger{})
+
+	bot := &MockBotAPI{}
+	trx := &MockTransactionService{TextErr: errors.NewGeminiError("no transaction in Gemini response", nil)}
+	h := &TelegramHandler{Telebot: bot, TransactionService: trx}
+	h.handleMessage(context.Background(), bot, &tgbotapi.Message{Text: "hmm", From: &tgbotapi.User{UserName: "user"}, Chat: &tgbotapi.Chat{ID: 3}})
+
+	reply := lastText(t, bot)
+	match := regexp.MustCompile(`Reference: ([0-9a-f]{8})$`).FindStringSubmatch(reply)
+	if !strings.HasPrefix(reply, "I couldn't understand that transaction") || match == nil {
+		t.Fatalf("unexpected reply %q", reply)
+	}
+	if !strings.Contains(buf.String(), `"correlation_id":"`+match[1]+`"`) {
+		t.Errorf("expected the reference in the log, got %s", buf.String())
+	}
+}
+
+func TestSave_KeepsTransactionWhenSpreadsheetFails(t *testing.T) {
+	bot := &MockBotAPI{}
+	trx := &MockTransactionService{SaveErr: errors.NewSpreadsheetError("append failed", nil)}
+	queue := &MockReviewService{}
+	h := &TelegramHandler{Telebot: bot, TransactionService: trx, Review: queue}
+	msg := &tgbotapi.Message{Text: "kopi 25rb", From: &tgbotapi.User{UserName: "user"}, Chat: &tgbotapi.Chat{ID: 3}}
+
+	h.handleMessage(context.Background(), bot, msg)
+	if len(queue.Pending) != 1 {
+		t.Fatalf("expected the transaction to be kept in the review queue, got %d", len(queue.Pending))
+	}
+	if reply := lastText(t, bot); !strings.Contains(reply, "kept the transaction here (q1)") || !strings.Contains(reply, "Reference: ") {
+		t.Errorf("unexpected reply %q", reply)
+	}
+
+	h.Review = nil
+	h.handleMessage(context.Background(), bot, msg)
+	if reply := lastText(t, bot); !strings.Contains(reply, "The spreadsheet is unavailable") || !strings.Contains(reply, "was not saved") {
+		t.Errorf("unexpected reply without a review queue %q", reply)
+	}
+}
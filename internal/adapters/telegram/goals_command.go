@@ -0,0 +1,134 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	goals_domain "money-tracker-bot/internal/domain/goals"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleGoalsCommand manages savings goals of the chat
+func (t *TelegramHandler) handleGoalsCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	pr := t.printer(ctx, chatID)
+	if t.Goals == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("goals.disabled")))
+		return
+	}
+
+	usage := pr.T("goals.usage")
+	options, args, err := parseKeyValues(msg.CommandArguments())
+	if err != nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, argsProblem(pr, usage)))
+		return
+	}
+	sub := "list"
+	if len(args) > 0 {
+		sub = args[0]
+	}
+
+	switch sub {
+	case "list":
+		t.sendGoalList(ctx, pr, chatID)
+	case "add":
+		g, err := t.Goals.Add(goals_domain.Goal{
+			ChatID:    chatID,
+			Name:      options["name"],
+			Title:     options["title"],
+			Target:    options["target"],
+			Deadline:  options["deadline"],
+			CreatedBy: senderName(msg.From),
+		})
+		if err != nil {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("goals.add_failed", userMessage(pr, err))+"\n\n"+usage))
+			return
+		}
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("goals.added", g.Name, g.DisplayName())))
+	case "save":
+		if len(args) < 3 {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, usage))
+			return
+		}
+		p, err := t.Goals.Contribute(ctx, chatID, args[1], args[2], senderName(msg.From))
+		if err != nil {
+			errors.HandleErrorContext(ctx, err, "recording goal contribution")
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("goals.contribute_failed", userMessage(pr, err))))
+			return
+		}
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("goals.saved")+"\n"+formatGoalProgress(pr, p, t.locale(chatID))))
+	case "remove":
+		if len(args) < 2 {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, usage))
+			return
+		}
+		if err := t.Goals.Remove(chatID, args[1]); err != nil {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("goals.remove_failed", args[1], userMessage(pr, err))))
+			return
+		}
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("goals.removed", args[1])))
+	default:
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, usage))
+	}
+}
+
+func (t *TelegramHandler) sendGoalList(ctx context.Context, pr i18n.Printer, chatID int64) {
+	progress, err := t.Goals.List(ctx, chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "listing goals")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("goals.load_failed")))
+		return
+	}
+	if len(progress) == 0 {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("goals.none")+"\n\n"+pr.T("goals.usage")))
+		return
+	}
+	parts := make([]string, 0, len(progress))
+	for _, p := range progress {
+		parts = append(parts, formatGoalProgress(pr, p, t.locale(chatID)))
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("goals.title")+"\n\n"+strings.Join(parts, "\n\n")))
+}
+
+// tagGoal marks the transaction as a goal contribution when the message carries a known #tag
+func (t *TelegramHandler) tagGoal(ctx context.Context, chatID int64, text string, trx *transaction_domain.Transaction) {
+	if t.Goals == nil {
+		return
+	}
+	if _, err := t.Goals.Tag(chatID, text, trx); err != nil {
+		errors.HandleErrorContext(ctx, err, "tagging goal")
+	}
+}
+
+// goalSavedText is the reply for a saved goal contribution
+func (t *TelegramHandler) goalSavedText(ctx context.Context, chatID int64, trx *transaction_domain.Transaction) string {
+	pr := t.printer(ctx, chatID)
+	locale := t.locale(chatID)
+	text := pr.T("goals.saved_amount", formatAmount(trx.Amount, locale), trx.Notes)
+	p, err := t.Goals.Progress(ctx, chatID, trx.Goal)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "loading goal progress")
+		return text
+	}
+	return text + "\n\n" + formatGoalProgress(pr, p, locale)
+}
+
+func formatGoalProgress(pr i18n.Printer, p goals_domain.Progress, locale common.Locale) string {
+	text := pr.T("goals.progress",
+		p.Goal.DisplayName(), p.Goal.Name,
+		locale.FormatAmount(p.Saved), locale.FormatAmount(p.Target), p.Percent,
+		locale.FormatDate(p.Goal.Deadline))
+	switch {
+	case p.Remaining == 0:
+		text += pr.T("goals.reached")
+	case p.Overdue:
+		text += pr.T("goals.overdue", locale.FormatAmount(p.Remaining))
+	default:
+		text += pr.T("goals.needed", locale.FormatAmount(p.MonthlyRequired), p.MonthsLeft)
+	}
+	return text
+}
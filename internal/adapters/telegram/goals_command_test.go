@@ -0,0 +1,54 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestGoalsCommand_List(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot, Goals: &MockGoalService{}}
+
+	h.handleCommand(context.Background(), newCommand(3, "/goals"))
+	reply := lastText(t, bot)
+	if !strings.Contains(reply, "Rp 3,000,000 / Rp 12,000,000 (25%)") || !strings.Contains(reply, "Needed: Rp 1,500,000/month for 6 month(s)") {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+}
+
+func TestGoalsCommand_AddAndSave(t *testing.T) {
+	bot := &MockBotAPI{}
+	goals := &MockGoalService{}
+	h := &TelegramHandler{Telebot: bot, Goals: goals}
+
+	h.handleCommand(context.Background(), newCommand(3, `/goals add name="Bali Trip" target=12000000 deadline=2025-12-31`))
+	if len(goals.Added) != 1 || goals.Added[0].Name != "bali-trip" || goals.Added[0].ChatID != 3 {
+		t.Fatalf("unexpected goal: %+v", goals.Added)
+	}
+	if !strings.Contains(lastText(t, bot), "#bali-trip") {
+		t.Errorf("reply should explain the tag, got: %s", lastText(t, bot))
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, "/goals save bali-trip 500000"))
+	if len(goals.Contributions) != 1 || goals.Contributions[0] != "bali-trip=500000" {
+		t.Errorf("unexpected contributions: %v", goals.Contributions)
+	}
+}
+
+func TestHandleMessage_GoalTag(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot, TransactionService: &MockTransactionService{}, Goals: &MockGoalService{}}
+
+	h.handleMessage(context.Background(), bot, &tgbotapi.Message{
+		Text: "nabung 1000 #bali",
+		From: &tgbotapi.User{UserName: "user"},
+		Chat: &tgbotapi.Chat{ID: 3},
+	})
+	reply := lastText(t, bot)
+	if !strings.Contains(reply, "Saved towards goal") || strings.Contains(reply, "Monthly Budget") {
+		t.Errorf("goal contributions should show goal progress instead of budget, got: %s", reply)
+	}
+}
@@ -5,27 +5,97 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
 	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/health"
+	"money-tracker-bot/internal/i18n"
+	"money-tracker-bot/internal/logging"
+	"money-tracker-bot/internal/metrics"
+	"money-tracker-bot/internal/port/out/notify"
+	"money-tracker-bot/internal/resilience"
+	"money-tracker-bot/internal/service/advice"
+	"money-tracker-bot/internal/service/categories"
+	"money-tracker-bot/internal/service/currency"
+	"money-tracker-bot/internal/service/digest"
+	"money-tracker-bot/internal/service/goals"
+	"money-tracker-bot/internal/service/learning"
+	"money-tracker-bot/internal/service/recurring"
+	"money-tracker-bot/internal/service/review"
+	"money-tracker-bot/internal/service/rules"
+	"money-tracker-bot/internal/service/settings"
+	"money-tracker-bot/internal/service/split"
 	"money-tracker-bot/internal/service/transactions"
+	"money-tracker-bot/internal/service/usage"
+	"money-tracker-bot/internal/tracing"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // BotAPI is an interface for sending messages (for testability)
 type BotAPI interface {
 	Send(c tgbotapi.Chattable) (tgbotapi.Message, error)
+	// Request is used for API calls that do not return a message, such as callback answers
+	Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error)
 }
 
 type TelegramHandler struct {
 	Telebot            BotAPI
 	TransactionService transactions.ITransaction
+	// Digests is optional; /digest is unavailable when nil
+	Digests digest.IDigest
+	// Recurring is optional; /recurring is unavailable when nil
+	Recurring recurring.IRecurring
+	// Goals is optional; /goals and #goal tags are unavailable when nil
+	Goals goals.IGoals
+	// Splits is optional; /split, /balances and /settle are unavailable when nil
+	Splits split.ISplit
+	// Categories is optional; the default category list is used when nil
+	Categories categories.ICategories
+	// Rules is optional; /rule is unavailable when nil
+	Rules rules.IRules
+	// Learning is optional; /correct and /stats are unavailable when nil
+	Learning learning.ILearning
+	// Review is optional; without it low-confidence transactions are saved
+	// right away and /review is unavailable
+	Review review.IReview
+	// Usage is optional; /usage is unavailable when nil
+	Usage usage.IUsage
+	// Advice is optional; /advice is unavailable when nil
+	Advice advice.IAdvice
+	// Currency is optional; /rate is unavailable when nil
+	Currency currency.ICurrency
+	// Settings is optional; /timezone and /locale are unavailable and replies
+	// use common.DefaultLocale when nil
+	Settings settings.ISettings
+	// Admins are the chats allowed to see the AI usage of every chat
+	Admins map[int64]bool
+	// Debug makes the Telegram library log every API request and response
+	Debug bool
+	// Breakers are the circuit breakers /status reports to admins
+	Breakers []*resilience.Breaker
+	// Metrics is optional; updates and saved transactions are counted when set
+	Metrics *metrics.Metrics
+	// Heartbeat is optional; it beats whenever the update loop makes progress
+	Heartbeat *health.Heartbeat
+	// SpreadsheetID is the spreadsheet linked in the reply to a saved transaction
+	SpreadsheetID string
+	// DownloadsDir holds the photos sent to the bot; defaultDownloadsDir when empty
+	DownloadsDir string
 }
 
+// defaultDownloadsDir holds the photos sent to the bot unless DownloadsDir is set
+const defaultDownloadsDir = "downloads"
+
 // NewTelegramHandler creates a TelegramHandler with a real bot (for production)
 func NewTelegramHandler(token string, transactionService transactions.ITransaction) (*TelegramHandler, error) {
 	bot, err := tgbotapi.NewBotAPI(token)
@@ -64,60 +134,202 @@ func (t *TelegramHandler) Start() error {
 			WithContext("expected_type", "*tgbotapi.BotAPI").
 			WithComponent("telegram-handler")
 	}
-	realBot.Debug = true
+	// The library logs through the default logger, which redacts the bot token
+	tgbotapi.SetLogger(log.Default())
+	realBot.Debug = t.Debug
 	log.Printf("Authorized on account %s", realBot.Self.UserName)
 
 	u := tgbotapi.NewUpdate(0)
 	u.Timeout = 60
 
-	updates := realBot.GetUpdatesChan(u)
-
-	for update := range updates {
-		if update.Message == nil {
-			continue
+	for {
+		if err := t.poll(realBot, &u); err != nil {
+			errors.HandleError(err, "polling updates")
+			time.Sleep(pollRetryDelay)
 		}
+	}
+}
 
-		if update.Message.IsCommand() {
-			switch update.Message.Command() {
-			case "list":
-				handleListCommand(t.Telebot, update.Message)
-			case "view":
-				handleViewCommand(realBot, update.Message)
-			case "download":
-				handleDownloadCommand(realBot, update.Message)
-			default:
-				msg := tgbotapi.NewMessage(update.Message.Chat.ID, "Unknown command.")
-				t.Telebot.Send(msg)
-			}
-			continue
+// handleUpdate routes one update. Everything logged while handling it
+// carries the update ID, chat ID and tenant, and every reply is in the
+// language of the chat.
+func (t *TelegramHandler) handleUpdate(update tgbotapi.Update) {
+	chat := update.FromChat()
+	if chat == nil {
+		return
+	}
+	kind := updateKind(update)
+	if kind == "" {
+		return
+	}
+	t.Metrics.UpdateReceived(kind)
+	ctx := logging.WithAttrs(context.Background(), slog.Int("update_id", update.UpdateID), slog.Int64("chat_id", chat.ID))
+	ctx = common.WithTenant(ctx, chat.ID)
+	ctx = i18n.WithLanguage(ctx, t.language(chat.ID, update.SentFrom()))
+	ctx, span := tracing.Start(ctx, "telegram.update",
+		attribute.Int("telegram.update_id", update.UpdateID),
+		attribute.Int64("telegram.chat_id", chat.ID),
+		attribute.String("telegram.update_type", kind))
+	defer span.End()
+
+	switch kind {
+	case metrics.UpdateCallback:
+		t.handleCallback(ctx, update.CallbackQuery)
+	case metrics.UpdateCommand:
+		t.handleCommand(ctx, update.Message)
+	case metrics.UpdateDocument:
+		if isRateImport(update.Message) {
+			t.handleRateImport(ctx, t.Telebot, update.Message)
+			return
 		}
+		t.handleDocument(ctx, t.Telebot, update.Message)
+	case metrics.UpdatePhoto:
+		t.handlePhoto(ctx, t.Telebot, update.Message)
+	default:
+		t.handleMessage(ctx, t.Telebot, update.Message)
+	}
+}
 
-		if update.Message.Document != nil {
-			handleDocument(t.Telebot, update.Message)
-		} else if update.Message.Photo != nil {
-			t.handlePhoto(t.Telebot, update.Message)
-		} else {
-			t.handleMessage(t.Telebot, update.Message)
-		}
+// updateKind returns the type of an update, or "" for updates the bot ignores
+func updateKind(update tgbotapi.Update) string {
+	switch {
+	case update.CallbackQuery != nil:
+		return metrics.UpdateCallback
+	case update.Message == nil:
+		return ""
+	case update.Message.IsCommand():
+		return metrics.UpdateCommand
+	case update.Message.Document != nil:
+		return metrics.UpdateDocument
+	case update.Message.Photo != nil:
+		return metrics.UpdatePhoto
+	default:
+		return metrics.UpdateText
+	}
+}
+
+// handleCommand routes a bot command to its handler
+func (t *TelegramHandler) handleCommand(ctx context.Context, msg *tgbotapi.Message) {
+	switch msg.Command() {
+	case "list":
+		t.handleListCommand(ctx, t.Telebot, msg)
+	case "view":
+		t.handleViewCommand(ctx, t.Telebot, msg)
+	case "download":
+		t.handleDownloadCommand(ctx, t.Telebot, msg)
+	case "digest":
+		t.handleDigestCommand(ctx, msg)
+	case "recurring":
+		t.handleRecurringCommand(ctx, msg)
+	case "goals":
+		t.handleGoalsCommand(ctx, msg)
+	case "categories":
+		t.handleCategoriesCommand(ctx, msg)
+	case "rule", "rules":
+		t.handleRuleCommand(ctx, msg)
+	case "correct":
+		t.handleCorrectCommand(ctx, msg)
+	case "stats":
+		t.handleStatsCommand(ctx, msg)
+	case "review":
+		t.handleReviewCommand(ctx, msg)
+	case "advice":
+		t.handleAdviceCommand(ctx, msg)
+	case "timezone":
+		t.handleTimezoneCommand(ctx, msg)
+	case "locale":
+		t.handleLocaleCommand(ctx, msg)
+	case "language":
+		t.handleLanguageCommand(ctx, msg)
+	case "rate", "rates":
+		t.handleRateCommand(ctx, msg)
+	case "usage":
+		t.handleUsageCommand(ctx, msg)
+	case "status":
+		t.handleStatusCommand(ctx, msg)
+	case "reparse":
+		t.handleReparseCommand(ctx, msg)
+	case "split":
+		t.handleSplitCommand(ctx, msg)
+	case "balances":
+		t.handleBalancesCommand(ctx, msg)
+	case "settle":
+		t.handleSettleCommand(ctx, msg)
+	default:
+		t.Telebot.Send(tgbotapi.NewMessage(msg.Chat.ID, t.printer(ctx, msg.Chat.ID).T("command.unknown")))
+	}
+}
+
+// SendText sends a plain text message to a chat.
+// It lets background services such as the digest scheduler push messages.
+func (t *TelegramHandler) SendText(chatID int64, text string) error {
+	if _, err := t.Telebot.Send(tgbotapi.NewMessage(chatID, text)); err != nil {
+		return errors.NewTelegramError("failed to send message", err).
+			WithContext("chat_id", chatID).
+			WithComponent("telegram-handler")
 	}
 	return nil
 }
 
-func handleListCommand(bot BotAPI, msg *tgbotapi.Message) {
+// SendWithButtons sends a text message with a row of inline action buttons.
+func (t *TelegramHandler) SendWithButtons(chatID int64, text string, buttons []notify.Button) error {
+	row := make([]tgbotapi.InlineKeyboardButton, 0, len(buttons))
+	for _, b := range buttons {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(b.Label, b.Data))
+	}
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+	if _, err := t.Telebot.Send(msg); err != nil {
+		return errors.NewTelegramError("failed to send message with buttons", err).
+			WithContext("chat_id", chatID).
+			WithComponent("telegram-handler")
+	}
+	return nil
+}
+
+// handleCallback routes inline button taps by their data prefix
+func (t *TelegramHandler) handleCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) {
+	if cb.Message == nil {
+		return
+	}
+	answer := ""
+	switch {
+	case strings.HasPrefix(cb.Data, recurring.CallbackPrefix):
+		answer = t.handleRecurringCallback(ctx, cb)
+	case strings.HasPrefix(cb.Data, review.CallbackPrefix):
+		answer = t.handleReviewCallback(ctx, cb)
+	default:
+		answer = t.printer(ctx, cb.Message.Chat.ID).T("callback.unsupported")
+	}
+	if _, err := t.Telebot.Request(tgbotapi.NewCallback(cb.ID, answer)); err != nil {
+		errors.HandleErrorContext(ctx, err, "answering callback")
+	}
+}
+
+// resolveCallback replaces the buttons of a callback message with a final status line
+func (t *TelegramHandler) resolveCallback(cb *tgbotapi.CallbackQuery, status string) {
+	edit := tgbotapi.NewEditMessageText(cb.Message.Chat.ID, cb.Message.MessageID, cb.Message.Text+"\n\n"+status)
+	t.Telebot.Send(edit)
+}
+
+func (t *TelegramHandler) handleListCommand(ctx context.Context, bot BotAPI, msg *tgbotapi.Message) {
+	p := t.printer(ctx, msg.Chat.ID)
 	if len(storedFiles) == 0 {
-		bot.Send(tgbotapi.NewMessage(msg.Chat.ID, "No files received yet."))
+		bot.Send(tgbotapi.NewMessage(msg.Chat.ID, p.T("files.none")))
 		return
 	}
 
+	// Files are listed in the chat's timezone and date format
+	locale, loc := t.locale(msg.Chat.ID), t.location(msg.Chat.ID)
 	var text string
 	for i, f := range storedFiles {
-		text += fmt.Sprintf("%d. %s (from @%s, %s)\n", i+1, f.FileName, f.User, f.Date.Format("Jan 2 15:04"))
+		text += p.T("files.item", i+1, f.FileName, f.User, locale.FormatTime(f.Date.In(loc)))
 	}
 
 	bot.Send(tgbotapi.NewMessage(msg.Chat.ID, text))
 }
 
-func handleDocument(bot BotAPI, msg *tgbotapi.Message) {
+func (t *TelegramHandler) handleDocument(ctx context.Context, bot BotAPI, msg *tgbotapi.Message) {
 	doc := msg.Document
 	fileID := doc.FileID
 	fileName := doc.FileName
@@ -125,19 +337,25 @@ func handleDocument(bot BotAPI, msg *tgbotapi.Message) {
 	storedFiles = append(storedFiles, StoredFile{
 		FileID:   fileID,
 		FileName: fileName,
-		User:     msg.From.UserName,
+		User:     senderName(msg.From),
 		Date:     time.Now(),
 	})
 
-	bot.Send(tgbotapi.NewMessage(msg.Chat.ID, fmt.Sprintf("Saved %s ✅", fileName)))
+	bot.Send(tgbotapi.NewMessage(msg.Chat.ID, t.printer(ctx, msg.Chat.ID).T("files.saved", fileName)))
+}
+
+func (t *TelegramHandler) handlePhoto(ctx context.Context, bot BotAPI, msg *tgbotapi.Message) {
+	t.extractPhoto(common.WithTenant(ctx, msg.Chat.ID), bot, msg)
 }
 
-func (t *TelegramHandler) handlePhoto(bot BotAPI, msg *tgbotapi.Message) {
+// extractPhoto downloads the largest size of a photo message, extracts its
+// transaction and saves it
+func (t *TelegramHandler) extractPhoto(ctx context.Context, bot BotAPI, msg *tgbotapi.Message) {
 	photos := msg.Photo
 	largest := photos[len(photos)-1]
 	fileID := largest.FileID
 	fileName := fmt.Sprintf("%s.jpg", fileID)
-	localPath := "downloads/" + fileName
+	localPath := t.downloadPath(fileName)
 
 	// Cast to *tgbotapi.BotAPI for downloadFile
 	realBot, ok := bot.(*tgbotapi.BotAPI)
@@ -145,70 +363,93 @@ func (t *TelegramHandler) handlePhoto(bot BotAPI, msg *tgbotapi.Message) {
 		log.Println("Bot is not *tgbotapi.BotAPI, skipping downloadFile")
 		return
 	}
+	_, span := tracing.Start(ctx, "telegram.download")
 	err := downloadFile(realBot, fileID, localPath)
+	tracing.End(span, err)
 	if err != nil {
-		log.Println("Download error:", err)
+		t.replyError(ctx, msg.Chat.ID, errors.NewFileError("failed to download photo", err).
+			WithContext("file_id", fileID).
+			WithComponent("telegram-handler"), inputReceipt, "downloading photo")
 		return
 	}
 
 	storedFiles = append(storedFiles, StoredFile{
 		FileID:   fileID,
 		FileName: fileName,
-		User:     msg.From.UserName,
+		User:     senderName(msg.From),
 		Date:     time.Now(),
 	})
 
-	ctx := context.Background()
-	transaction, err := t.TransactionService.HandleImageInput(ctx, localPath, msg.From.UserName, nil)
+	transaction, err := t.TransactionService.HandleImageInput(ctx, localPath, senderName(msg.From), nil)
 	if err != nil {
-		log.Println("Error handling image input:", err)
+		t.replyError(ctx, msg.Chat.ID, err, inputReceipt, "handling image input")
 		return
 	}
 
-	summary, _ := t.TransactionService.SaveTransaction(*transaction)
-	spreadsheetId := os.Getenv("GOOGLE_SPREADSHEET_ID")
-	spreadsheetLink := "https://docs.google.com/spreadsheets/d/" + spreadsheetId
-	rupiah := formatRupiah(transaction.Amount)
-	msgText := fmt.Sprintf(
-		"Saved photo ✅\nCategory: %s\nAmount: %s\nNotes: %s\nLink: %s\n"+
-			"Monthly Expenses: %s\nMonthly Budget: %s\nBudget Left: %s\n"+
-			"Monthly Quota: %s\nQuota Left: %s",
-		transaction.Category,
-		rupiah,
-		transaction.Notes,
-		spreadsheetLink,
-		summary.MonthlyExpenses,
-		summary.MonthlyBudget,
-		summary.BudgetLeft,
-		summary.Quota,
-		summary.QuotaLeft,
-	)
-	// Check budget and quota left, append Gemini's warning_message if needed
-	budgetLeft, _ := strconv.ParseFloat(summary.BudgetLeft, 64)
-	quotaLeft, _ := strconv.ParseFloat(summary.QuotaLeft, 64)
-	if (budgetLeft < 0 || quotaLeft < 0) && transaction.WarningMessage != "" {
-		msgText += "\n\n⚠️ " + transaction.WarningMessage
+	t.tagGoal(ctx, msg.Chat.ID, msg.Caption, transaction)
+	if t.holdForReview(ctx, msg.Chat.ID, transaction) {
+		return
 	}
-	bot.Send(tgbotapi.NewMessage(msg.Chat.ID, msgText))
+	t.save(ctx, bot, msg.Chat.ID, t.printer(ctx, msg.Chat.ID).T("saved.photo"), transaction)
+}
+
+func (t *TelegramHandler) handleMessage(ctx context.Context, bot BotAPI, msg *tgbotapi.Message) {
+	t.extractText(common.WithTenant(ctx, msg.Chat.ID), bot, msg, msg.Text)
 }
 
-func (t *TelegramHandler) handleMessage(bot BotAPI, msg *tgbotapi.Message) {
-	ctx := context.Background()
-	transaction, err := t.TransactionService.HandleTextInput(ctx, msg.Text, msg.From.UserName, nil)
+// extractText extracts the transaction described by text, sent with msg, and saves it
+func (t *TelegramHandler) extractText(ctx context.Context, bot BotAPI, msg *tgbotapi.Message, text string) {
+	transaction, err := t.TransactionService.HandleTextInput(ctx, text, senderName(msg.From), nil)
 	if err != nil {
-		log.Println("Error handling text input:", err)
+		t.replyError(ctx, msg.Chat.ID, err, inputText, "handling text input")
 		return
 	}
 
-	summary, _ := t.TransactionService.SaveTransaction(*transaction)
-	spreadsheetId := os.Getenv("GOOGLE_SPREADSHEET_ID")
-	spreadsheetLink := "https://docs.google.com/spreadsheets/d/" + spreadsheetId
-	rupiah := formatRupiah(transaction.Amount)
-	msgText := fmt.Sprintf(
-		"Saved text ✅\nCategory: %s\nAmount: %s\nNotes: %s\nLink: %s\n"+
-			"Monthly Expenses: %s\nMonthly Budget: %s\nBudget Left: %s\n"+
-			"Monthly Quota: %s\nQuota Left: %s",
-		transaction.Category,
+	t.tagGoal(ctx, msg.Chat.ID, text, transaction)
+	if t.holdForReview(ctx, msg.Chat.ID, transaction) {
+		return
+	}
+	t.save(ctx, bot, msg.Chat.ID, t.printer(ctx, msg.Chat.ID).T("saved.text"), transaction)
+}
+
+// save saves a transaction and replies with its summary. When the spreadsheet
+// fails, the transaction waits in the review queue so it is not lost.
+func (t *TelegramHandler) save(ctx context.Context, bot BotAPI, chatID int64, title string, transaction *transaction_domain.Transaction) {
+	summary, err := t.TransactionService.SaveTransaction(ctx, *transaction)
+	if err == nil {
+		t.replySaved(ctx, bot, chatID, title, transaction, summary)
+		return
+	}
+	id := reportError(ctx, err, "saving transaction")
+	p := t.printer(ctx, chatID)
+	if t.Review != nil {
+		if pending, qerr := t.Review.Flag(chatID, *transaction); qerr == nil {
+			bot.Send(tgbotapi.NewMessage(chatID, withReference(p, p.T("saved.kept_for_review", pending.ID), id)))
+			return
+		}
+	}
+	bot.Send(tgbotapi.NewMessage(chatID, withReference(p, p.T("saved.not_saved", friendlyMessage(p, err, inputText)), id)))
+}
+
+// replySaved tells the chat a transaction was saved, with the budget summary
+// of its category, and lets /split and /correct refer to it
+func (t *TelegramHandler) replySaved(ctx context.Context, bot BotAPI, chatID int64, title string, transaction *transaction_domain.Transaction, summary spreadsheet.CategorySummary) {
+	t.Metrics.TransactionSaved(transaction.Category, chatID)
+	t.rememberForSplit(chatID, *transaction)
+	t.observeForCorrection(chatID, *transaction)
+	if transaction.Goal != "" {
+		bot.Send(tgbotapi.NewMessage(chatID, t.goalSavedText(ctx, chatID, transaction)))
+		return
+	}
+	p := t.printer(ctx, chatID)
+	spreadsheetLink := "https://docs.google.com/spreadsheets/d/" + t.SpreadsheetID
+	rupiah := formatAmount(transaction.Amount, t.locale(chatID))
+	if transaction.OriginalCurrency != "" {
+		rupiah = p.T("saved.converted", rupiah, transaction.OriginalCurrency, transaction.OriginalAmount, formatRate(transaction.ExchangeRate))
+	}
+	msgText := p.T("saved.summary",
+		title,
+		categoryLabel(transaction),
 		rupiah,
 		transaction.Notes,
 		spreadsheetLink,
@@ -217,38 +458,52 @@ func (t *TelegramHandler) handleMessage(bot BotAPI, msg *tgbotapi.Message) {
 		summary.BudgetLeft,
 		summary.Quota,
 		summary.QuotaLeft,
-	)
-	// Check budget and quota left, append Gemini's warning_message if needed
+	) + t.correctionHint(p)
+	// Check budget and quota left and point overspending chats to /advice
 	budgetLeft, _ := strconv.ParseFloat(summary.BudgetLeft, 64)
 	quotaLeft, _ := strconv.ParseFloat(summary.QuotaLeft, 64)
-	if (budgetLeft < 0 || quotaLeft < 0) && transaction.WarningMessage != "" {
-		msgText += "\n\n⚠️ " + transaction.WarningMessage
+	if budgetLeft < 0 || quotaLeft < 0 {
+		msgText += "\n\n" + t.overBudgetWarning(p)
+	}
+	bot.Send(tgbotapi.NewMessage(chatID, msgText))
+}
+
+// userMessage returns the part of an error that is safe to show to users.
+// Validation messages are shown in the language of p; anything else gets a
+// generic hint.
+func userMessage(p i18n.Printer, err error) string {
+	if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.ErrCodeValidation {
+		return localize(p, appErr)
 	}
-	bot.Send(tgbotapi.NewMessage(msg.Chat.ID, msgText))
+	return p.T("error.try_later")
 }
 
-// formatRupiah formats a string amount to Indonesian Rupiah currency
-func formatRupiah(amount string) string {
+// formatAmount formats a string amount in the currency and with the
+// separators of locale
+func formatAmount(amount string, locale common.Locale) string {
 	// Try to parse as float, fallback to original string
 	f, err := strconv.ParseFloat(amount, 64)
 	if err != nil {
-		return "Rp " + amount
+		return amount
 	}
-	// Format with thousands separator
-	return fmt.Sprintf("Rp %s", formatThousands(int64(f)))
+	return locale.FormatAmount(f)
 }
 
-// formatThousands formats an integer with thousands separator
-func formatThousands(n int64) string {
-	s := fmt.Sprintf("%d", n)
-	var out []byte
-	for i, c := range s {
-		if i != 0 && (len(s)-i)%3 == 0 {
-			out = append(out, ',')
-		}
-		out = append(out, byte(c))
+// senderName names who sent a message: the username, else the first name,
+// else the user ID, since Telegram usernames are optional
+// Input: {UserName: "", FirstName: "Alice", ID: 42}
+// Output: "Alice"
+func senderName(u *tgbotapi.User) string {
+	switch {
+	case u == nil:
+		return ""
+	case u.UserName != "":
+		return u.UserName
+	case u.FirstName != "":
+		return u.FirstName
+	default:
+		return strconv.FormatInt(u.ID, 10)
 	}
-	return string(out)
 }
 
 func downloadFile(bot *tgbotapi.BotAPI, fileID, localPath string) error {
@@ -286,28 +541,39 @@ func parseIndexArg(text string) (int, error) {
 	return i - 1, nil
 }
 
-func handleViewCommand(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) {
+// downloadPath is where the file named fileName sent to the bot is kept
+func (t *TelegramHandler) downloadPath(fileName string) string {
+	dir := t.DownloadsDir
+	if dir == "" {
+		dir = defaultDownloadsDir
+	}
+	return filepath.Join(dir, fileName)
+}
+
+func (t *TelegramHandler) handleViewCommand(ctx context.Context, bot BotAPI, msg *tgbotapi.Message) {
+	p := t.printer(ctx, msg.Chat.ID)
 	index, err := parseIndexArg(msg.Text)
 	if err != nil {
-		bot.Send(tgbotapi.NewMessage(msg.Chat.ID, "Usage: /view <number>"))
+		bot.Send(tgbotapi.NewMessage(msg.Chat.ID, p.T("files.view_usage")))
 		return
 	}
 
 	file := storedFiles[index]
-	photo := tgbotapi.NewPhoto(msg.Chat.ID, tgbotapi.FilePath("downloads/"+file.FileName))
-	photo.Caption = fmt.Sprintf("Viewing: %s", file.FileName)
+	photo := tgbotapi.NewPhoto(msg.Chat.ID, tgbotapi.FilePath(t.downloadPath(file.FileName)))
+	photo.Caption = p.T("files.viewing", file.FileName)
 	bot.Send(photo)
 }
 
-func handleDownloadCommand(bot *tgbotapi.BotAPI, msg *tgbotapi.Message) {
+func (t *TelegramHandler) handleDownloadCommand(ctx context.Context, bot BotAPI, msg *tgbotapi.Message) {
+	p := t.printer(ctx, msg.Chat.ID)
 	index, err := parseIndexArg(msg.Text)
 	if err != nil {
-		bot.Send(tgbotapi.NewMessage(msg.Chat.ID, "Usage: /download <number>"))
+		bot.Send(tgbotapi.NewMessage(msg.Chat.ID, p.T("files.download_usage")))
 		return
 	}
 
 	file := storedFiles[index]
-	doc := tgbotapi.NewDocument(msg.Chat.ID, tgbotapi.FilePath("downloads/"+file.FileName))
-	doc.Caption = fmt.Sprintf("Download: %s", file.FileName)
+	doc := tgbotapi.NewDocument(msg.Chat.ID, tgbotapi.FilePath(t.downloadPath(file.FileName)))
+	doc.Caption = p.T("files.download", file.FileName)
 	bot.Send(doc)
 }
@@ -1,9 +1,22 @@
 package telegram
 
 import (
+	"context"
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/logging"
+	"money-tracker-bot/internal/metrics"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestNewTelegramHandler(t *testing.T) {
@@ -29,7 +42,7 @@ func TestHandleMessage_CallsService(t *testing.T) {
 		From: &tgbotapi.User{UserName: "user"},
 		Chat: &tgbotapi.Chat{ID: 12345},
 	}
-	h.handleMessage(mockBot, msg)
+	h.handleMessage(context.Background(), mockBot, msg)
 	if !m.HandleTextInputCalled {
 		t.Error("HandleTextInput should be called")
 	}
@@ -40,3 +53,141 @@ func TestHandleMessage_CallsService(t *testing.T) {
 		t.Error("Bot should have sent a message")
 	}
 }
+
+func TestSenderName(t *testing.T) {
+	tests := []struct {
+		user *tgbotapi.User
+		want string
+	}{
+		{&tgbotapi.User{ID: 42, UserName: "alice", FirstName: "Alice"}, "alice"},
+		{&tgbotapi.User{ID: 42, FirstName: "Alice"}, "Alice"},
+		{&tgbotapi.User{ID: 42}, "42"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := senderName(tt.user); got != tt.want {
+			t.Errorf("senderName(%+v) = %q, expected %q", tt.user, got, tt.want)
+		}
+	}
+}
+
+func TestReplySaved_LinksConfiguredSpreadsheet(t *testing.T) {
+	mockBot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: mockBot, TransactionService: &MockTransactionService{}, SpreadsheetID: "sheet-123"}
+	h.handleMessage(context.Background(), mockBot, &tgbotapi.Message{
+		Text: "coffee 25k",
+		From: &tgbotapi.User{UserName: "user"},
+		Chat: &tgbotapi.Chat{ID: 12345},
+	})
+	if text := lastText(t, mockBot); !strings.Contains(text, "https://docs.google.com/spreadsheets/d/sheet-123") {
+		t.Errorf("expected a link to the configured spreadsheet, got %q", text)
+	}
+}
+
+func TestHandleListCommand_UsesChatTimezoneAndLocale(t *testing.T) {
+	storedFiles = []StoredFile{{FileName: "receipt.pdf", User: "user", Date: time.Date(2025, 7, 13, 20, 30, 0, 0, time.UTC)}}
+	t.Cleanup(func() { storedFiles = nil })
+	settings := &MockSettingsService{}
+	settings.SetTimezone(1, "Asia/Jakarta")
+	settings.SetLocale(1, "id-ID")
+	mockBot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: mockBot, Settings: settings}
+	h.handleCommand(context.Background(), newCommand(1, "/list"))
+	if reply := lastText(t, mockBot); !strings.Contains(reply, "14/07/2025 03:30") {
+		t.Errorf("expected the time in Jakarta written the Indonesian way, got %q", reply)
+	}
+}
+
+func TestHandleViewCommand_UsesDownloadsDir(t *testing.T) {
+	storedFiles = []StoredFile{{FileID: "f1", FileName: "f1.jpg"}}
+	t.Cleanup(func() { storedFiles = nil })
+	mockBot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: mockBot, DownloadsDir: "/var/receipts"}
+	h.handleCommand(context.Background(), newCommand(1, "/view 1"))
+	photo, ok := mockBot.SentMessages[0].(tgbotapi.PhotoConfig)
+	if !ok || photo.File != tgbotapi.FilePath("/var/receipts/f1.jpg") {
+		t.Errorf("expected the photo from the downloads directory, got %#v", mockBot.SentMessages[0])
+	}
+	if got := (&TelegramHandler{}).downloadPath("f1.jpg"); got != "downloads/f1.jpg" {
+		t.Errorf("expected the default downloads directory, got %q", got)
+	}
+}
+
+func TestHandleUpdate_AddsRequestAttributes(t *testing.T) {
+	m := &MockTransactionService{}
+	mockBot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: mockBot, TransactionService: m}
+	h.handleUpdate(tgbotapi.Update{
+		UpdateID: 7,
+		Message: &tgbotapi.Message{
+			Text: "coffee 25k",
+			From: &tgbotapi.User{UserName: "user"},
+			Chat: &tgbotapi.Chat{ID: 12345},
+		},
+	})
+
+	if tenant, ok := common.TenantFrom(m.LastCtx); !ok || tenant != 12345 {
+		t.Errorf("expected tenant 12345, got %d (%v)", tenant, ok)
+	}
+	attrs := map[string]string{}
+	for _, a := range logging.Attrs(m.LastCtx) {
+		attrs[a.Key] = a.Value.String()
+	}
+	if attrs["update_id"] != "7" || attrs["chat_id"] != "12345" {
+		t.Errorf("unexpected request attributes %v", attrs)
+	}
+}
+
+func TestHandleUpdate_CountsMetrics(t *testing.T) {
+	m := metrics.New()
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot, TransactionService: &MockTransactionService{}, Metrics: m}
+	h.handleUpdate(tgbotapi.Update{UpdateID: 1, Message: &tgbotapi.Message{
+		Text: "coffee 25k",
+		From: &tgbotapi.User{UserName: "user"},
+		Chat: &tgbotapi.Chat{ID: 12345},
+	}})
+	h.handleUpdate(tgbotapi.Update{UpdateID: 2, Message: newCommand(12345, "/stats")})
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	for _, want := range []string{
+		`moneybot_updates_received_total{type="text"} 1`,
+		`moneybot_updates_received_total{type="command"} 1`,
+		`moneybot_transactions_saved_total{category="Uncategorized",tenant="12345"} 1`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("expected %q in metrics", want)
+		}
+	}
+}
+
+func TestHandleUpdate_TracesFailures(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+	m := &MockTransactionService{TextErr: errors.NewGeminiError("no transaction in Gemini response", nil)}
+	h := &TelegramHandler{Telebot: &MockBotAPI{}, TransactionService: m}
+
+	h.handleUpdate(tgbotapi.Update{UpdateID: 7, Message: &tgbotapi.Message{
+		Text: "coffee 25k",
+		From: &tgbotapi.User{UserName: "user"},
+		Chat: &tgbotapi.Chat{ID: 12345},
+	}})
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "telegram.update" || spans[0].Status().Code != codes.Error {
+		t.Fatalf("expected a failed update span, got %v", spans)
+	}
+	if trace.SpanContextFromContext(m.LastCtx).SpanID() != spans[0].SpanContext().SpanID() {
+		t.Error("expected the transaction service to get the update span")
+	}
+	attrs := map[string]string{}
+	for _, a := range spans[0].Attributes() {
+		attrs[string(a.Key)] = a.Value.Emit()
+	}
+	if attrs["telegram.update_type"] != "text" || attrs["telegram.chat_id"] != "12345" || len(attrs["correlation_id"]) != 8 {
+		t.Errorf("unexpected span attributes %v", attrs)
+	}
+}
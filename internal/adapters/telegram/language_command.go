@@ -0,0 +1,63 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleLanguageCommand shows or changes the language the bot replies in.
+// "auto" clears the choice so replies follow the Telegram app of whoever writes.
+func (t *TelegramHandler) handleLanguageCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Settings == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("settings.disabled")))
+		return
+	}
+	usage := p.T("settings.language_usage", strings.Join(i18n.Languages(), "|"))
+	arg := strings.TrimSpace(msg.CommandArguments())
+	if arg == "" {
+		t.sendSettings(ctx, p, chatID, usage)
+		return
+	}
+	s, err := t.Settings.SetLanguage(chatID, arg)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "saving language")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("settings.language_failed", userMessage(p, err))+"\n\n"+usage))
+		return
+	}
+	if s.Language == "" {
+		p = i18n.For(t.language(chatID, msg.From))
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("settings.language_auto")))
+		return
+	}
+	// Confirm in the language just chosen
+	p = i18n.For(s.Language)
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("settings.language_set", p.T("language.name."+s.Language))))
+}
+
+// language picks the language of a reply to chatID: the chat's /language
+// setting, else the Telegram app language of the sender, else i18n.Default
+func (t *TelegramHandler) language(chatID int64, from *tgbotapi.User) string {
+	if t.Settings != nil {
+		if lang := t.Settings.Language(chatID); lang != "" {
+			return lang
+		}
+	}
+	if from != nil {
+		if lang, ok := i18n.Parse(from.LanguageCode); ok {
+			return lang
+		}
+	}
+	return i18n.Default
+}
+
+// printer returns the Printer for replies to chatID, following the language
+// handleUpdate stored in ctx
+func (t *TelegramHandler) printer(ctx context.Context, chatID int64) i18n.Printer {
+	return i18n.Tenant(ctx, t.Settings, chatID)
+}
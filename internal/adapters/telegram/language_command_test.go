@@ -0,0 +1,103 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/i18n"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestLanguageCommand(t *testing.T) {
+	bot := &MockBotAPI{}
+	settings := &MockSettingsService{}
+	h := &TelegramHandler{Telebot: bot, Settings: settings}
+
+	h.handleUpdate(tgbotapi.Update{Message: newCommand(1, "/language id")})
+	if reply := lastText(t, bot); reply != "Bahasa diatur ke Bahasa Indonesia ✅" {
+		t.Errorf("expected the confirmation in the new language, got: %s", reply)
+	}
+	h.handleUpdate(tgbotapi.Update{Message: newCommand(1, "/timezone")})
+	if reply := lastText(t, bot); !strings.Contains(reply, "Zona waktu: UTC") || !strings.Contains(reply, "Bahasa: Bahasa Indonesia") {
+		t.Errorf("expected the settings in Indonesian, got: %s", reply)
+	}
+
+	h.handleUpdate(tgbotapi.Update{Message: newCommand(1, "/language fr")})
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "Bahasa tidak bisa diubah: unknown language") {
+		t.Errorf("expected the validation error, got: %s", reply)
+	}
+
+	h.handleUpdate(tgbotapi.Update{Message: newCommand(1, "/language auto")})
+	if reply := lastText(t, bot); reply != "Language follows your Telegram app ✅" {
+		t.Errorf("expected the Telegram language again, got: %s", reply)
+	}
+	if lang := settings.Language(1); lang != "" {
+		t.Errorf("expected the language to be cleared, got %q", lang)
+	}
+}
+
+func TestHandleUpdate_RepliesInTelegramLanguage(t *testing.T) {
+	bot := &MockBotAPI{}
+	settings := &MockSettingsService{}
+	h := &TelegramHandler{Telebot: bot, TransactionService: &MockTransactionService{}, Settings: settings}
+	message := func(chatID int64) *tgbotapi.Message {
+		return &tgbotapi.Message{
+			Text: "kopi 25rb",
+			From: &tgbotapi.User{UserName: "user", LanguageCode: "id"},
+			Chat: &tgbotapi.Chat{ID: chatID},
+		}
+	}
+
+	h.handleUpdate(tgbotapi.Update{Message: message(1)})
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "Teks disimpan ✅\nKategori:") {
+		t.Errorf("expected an Indonesian reply, got: %s", reply)
+	}
+
+	// The chat's /language setting wins over the Telegram app
+	settings.SetLanguage(2, "en")
+	h.handleUpdate(tgbotapi.Update{Message: message(2)})
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "Saved text ✅\nCategory:") {
+		t.Errorf("expected an English reply, got: %s", reply)
+	}
+}
+
+func TestLanguage_WithoutSettings(t *testing.T) {
+	h := &TelegramHandler{}
+	if got := h.language(1, &tgbotapi.User{LanguageCode: "in"}); got != i18n.Indonesian {
+		t.Errorf("expected Indonesian, got %s", got)
+	}
+	if got := h.language(1, &tgbotapi.User{LanguageCode: "de"}); got != i18n.Default {
+		t.Errorf("expected the default for an unsupported language, got %s", got)
+	}
+	if got := h.printer(context.Background(), 1).Lang(); got != i18n.Default {
+		t.Errorf("expected the default printer, got %s", got)
+	}
+}
+
+// TestMessageKeysExist fails when a reply uses a key that is not in the catalog
+func TestMessageKeysExist(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := regexp.MustCompile(`\.T\("([^"]+)"[,)]`)
+	english := i18n.For(i18n.English)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range key.FindAllStringSubmatch(string(src), -1) {
+			if english.T(m[1]) == m[1] {
+				t.Errorf("%s uses %q, which is not in the catalog", file, m[1])
+			}
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package telegram
+
+import "context"
+
+type MockAdviceService struct {
+	Answer string
+	Err    error
+	Chats  []int64
+}
+
+func (m *MockAdviceService) Advise(ctx context.Context, chatID int64) (string, error) {
+	m.Chats = append(m.Chats, chatID)
+	return m.Answer, m.Err
+}
@@ -4,9 +4,15 @@ import tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
 type MockBotAPI struct {
 	SentMessages []tgbotapi.Chattable
+	Requests     []tgbotapi.Chattable
 }
 
 func (m *MockBotAPI) Send(c tgbotapi.Chattable) (tgbotapi.Message, error) {
 	m.SentMessages = append(m.SentMessages, c)
 	return tgbotapi.Message{}, nil
 }
+
+func (m *MockBotAPI) Request(c tgbotapi.Chattable) (*tgbotapi.APIResponse, error) {
+	m.Requests = append(m.Requests, c)
+	return &tgbotapi.APIResponse{Ok: true}, nil
+}
@@ -0,0 +1,58 @@
+package telegram
+
+import (
+	"context"
+	category_domain "money-tracker-bot/internal/domain/category"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+// MockCategoryService keeps a single category set in memory
+type MockCategoryService struct {
+	Set        category_domain.Set
+	Migrations []category_domain.Migration
+}
+
+func NewMockCategoryService() *MockCategoryService {
+	set := category_domain.DefaultSet([]string{"Groceries", "Eating Out", "Rent House"})
+	set.Add(category_domain.Category{Name: "Coffee", Parent: "Eating Out", Aliases: []string{"kopi"}})
+	return &MockCategoryService{Set: set}
+}
+
+func (m *MockCategoryService) List(chatID int64) (category_domain.Set, error) {
+	return m.Set, nil
+}
+
+func (m *MockCategoryService) Add(chatID int64, c category_domain.Category) (category_domain.Category, error) {
+	if err := m.Set.Add(c); err != nil {
+		return c, err
+	}
+	return m.Set.Categories[len(m.Set.Categories)-1], nil
+}
+
+func (m *MockCategoryService) Rename(ctx context.Context, chatID int64, path, newName string) (category_domain.Migration, int, error) {
+	mig, err := m.Set.Rename(path, newName)
+	m.Migrations = append(m.Migrations, mig)
+	return mig, 4, err
+}
+
+func (m *MockCategoryService) Merge(ctx context.Context, chatID int64, from, into string) (category_domain.Migration, int, error) {
+	mig, err := m.Set.Merge(from, into)
+	m.Migrations = append(m.Migrations, mig)
+	return mig, 2, err
+}
+
+func (m *MockCategoryService) Match(chatID int64, name string) (category_domain.Ref, bool, error) {
+	ref, ok := m.Set.Match(name)
+	return ref, ok, nil
+}
+
+func (m *MockCategoryService) PromptLines(chatID int64) ([]string, error) {
+	return m.Set.PromptLines(), nil
+}
+
+func (m *MockCategoryService) Resolve(chatID int64, trx *transaction_domain.Transaction) error {
+	if ref, ok := m.Set.Match(trx.Category); ok {
+		trx.Category, trx.Subcategory = ref.Category, ref.Subcategory
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package telegram
+
+import (
+	"io"
+	currency_domain "money-tracker-bot/internal/domain/currency"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+)
+
+type MockCurrencyService struct {
+	Saved []currency_domain.Rate
+}
+
+func (m *MockCurrencyService) Base() string {
+	return currency_domain.Default
+}
+
+func (m *MockCurrencyService) SetRate(chatID int64, code string, rate float64, date string, setBy string) (currency_domain.Rate, error) {
+	if date == "" {
+		date = "2025-07-10"
+	}
+	r := currency_domain.Rate{ChatID: chatID, Base: m.Base(), Currency: code, Rate: rate, Date: date, SetBy: setBy}
+	if parsed, ok := currency_domain.ParseCode(code); ok {
+		r.Currency = parsed
+	}
+	if err := r.Validate(); err != nil {
+		return r, errors.NewValidationError(err.Error(), err)
+	}
+	m.Saved = append(m.Saved, r)
+	return r, nil
+}
+
+func (m *MockCurrencyService) Import(chatID int64, r io.Reader, setBy string) ([]currency_domain.Rate, error) {
+	rates, err := currency_domain.ParseCSV(r, m.Base())
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error(), err)
+	}
+	m.Saved = append(m.Saved, rates...)
+	return rates, nil
+}
+
+func (m *MockCurrencyService) Rates(chatID int64) ([]currency_domain.Rate, error) {
+	return currency_domain.Latest(m.Saved, m.Base()), nil
+}
+
+func (m *MockCurrencyService) Convert(chatID int64, trx *transaction_domain.Transaction) error {
+	return nil
+}
@@ -0,0 +1,31 @@
+package telegram
+
+import (
+	"context"
+	digest_domain "money-tracker-bot/internal/domain/digest"
+)
+
+type MockDigestService struct {
+	Schedules map[int64]digest_domain.Schedule
+	Built     []digest_domain.Kind
+}
+
+func (m *MockDigestService) GetSchedule(chatID int64) (digest_domain.Schedule, error) {
+	if s, ok := m.Schedules[chatID]; ok {
+		return s, nil
+	}
+	return digest_domain.NewSchedule(chatID), nil
+}
+
+func (m *MockDigestService) SaveSchedule(s digest_domain.Schedule) error {
+	if m.Schedules == nil {
+		m.Schedules = make(map[int64]digest_domain.Schedule)
+	}
+	m.Schedules[s.ChatID] = s
+	return nil
+}
+
+func (m *MockDigestService) BuildDigest(ctx context.Context, chatID int64, kind digest_domain.Kind) (string, error) {
+	m.Built = append(m.Built, kind)
+	return "digest " + string(kind), nil
+}
@@ -0,0 +1,55 @@
+package telegram
+
+import (
+	"context"
+	goals_domain "money-tracker-bot/internal/domain/goals"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+type MockGoalService struct {
+	Added         []goals_domain.Goal
+	Contributions []string
+}
+
+func (m *MockGoalService) Add(g goals_domain.Goal) (goals_domain.Goal, error) {
+	g.Name = goals_domain.NormalizeName(g.Name)
+	m.Added = append(m.Added, g)
+	return g, nil
+}
+
+func (m *MockGoalService) Remove(chatID int64, name string) error { return nil }
+
+func (m *MockGoalService) List(ctx context.Context, chatID int64) ([]goals_domain.Progress, error) {
+	return []goals_domain.Progress{m.progress("bali")}, nil
+}
+
+func (m *MockGoalService) Progress(ctx context.Context, chatID int64, name string) (goals_domain.Progress, error) {
+	return m.progress(name), nil
+}
+
+func (m *MockGoalService) Contribute(ctx context.Context, chatID int64, name, amount, user string) (goals_domain.Progress, error) {
+	m.Contributions = append(m.Contributions, name+"="+amount)
+	return m.progress(name), nil
+}
+
+func (m *MockGoalService) Tag(chatID int64, text string, trx *transaction_domain.Transaction) (bool, error) {
+	name, ok := goals_domain.ExtractTag(text)
+	if !ok || name != "bali" {
+		return false, nil
+	}
+	trx.Goal = name
+	trx.Category = goals_domain.SavingsCategory
+	return true, nil
+}
+
+func (m *MockGoalService) progress(name string) goals_domain.Progress {
+	return goals_domain.Progress{
+		Goal:            goals_domain.Goal{Name: name, Deadline: "2025-12-31"},
+		Saved:           3000000,
+		Target:          12000000,
+		Remaining:       9000000,
+		Percent:         25,
+		MonthsLeft:      6,
+		MonthlyRequired: 1500000,
+	}
+}
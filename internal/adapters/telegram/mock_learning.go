@@ -0,0 +1,48 @@
+package telegram
+
+import (
+	"context"
+	category_domain "money-tracker-bot/internal/domain/category"
+	learning_domain "money-tracker-bot/internal/domain/learning"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+)
+
+type MockLearningService struct {
+	Observed    []transaction_domain.Transaction
+	Corrections []learning_domain.Correction
+	Stats       learning_domain.Memory
+}
+
+func (m *MockLearningService) Observe(chatID int64, trx transaction_domain.Transaction) {
+	m.Observed = append(m.Observed, trx)
+}
+
+func (m *MockLearningService) Correct(ctx context.Context, chatID int64, to category_domain.Ref, merchant string) (learning_domain.Correction, error) {
+	if len(m.Observed) == 0 {
+		return learning_domain.Correction{}, errors.NewValidationError("no recent transaction to correct, send one first", nil)
+	}
+	last := m.Observed[len(m.Observed)-1]
+	if merchant == "" {
+		merchant = learning_domain.MerchantOf(last)
+	}
+	c := learning_domain.Correction{
+		Merchant: merchant,
+		From:     category_domain.Ref{Category: last.Category, Subcategory: last.Subcategory},
+		To:       to,
+	}
+	m.Corrections = append(m.Corrections, c)
+	return c, nil
+}
+
+func (m *MockLearningService) Memory(chatID int64) (learning_domain.Memory, error) {
+	return m.Stats, nil
+}
+
+func (m *MockLearningService) PromptExamples(chatID int64) ([]string, error) {
+	return m.Stats.Examples(20), nil
+}
+
+func (m *MockLearningService) Recall(chatID int64, trx *transaction_domain.Transaction) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,51 @@
+package telegram
+
+import (
+	"context"
+	recurring_domain "money-tracker-bot/internal/domain/recurring"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+)
+
+type MockRecurringService struct {
+	Added     []recurring_domain.Recurring
+	Confirmed []string
+	Skipped   []string
+	Accepted  []string
+}
+
+func (m *MockRecurringService) Add(r recurring_domain.Recurring) (recurring_domain.Recurring, error) {
+	r.ID = "r1"
+	m.Added = append(m.Added, r)
+	return r, nil
+}
+
+func (m *MockRecurringService) List(chatID int64) ([]recurring_domain.Recurring, error) {
+	return m.Added, nil
+}
+
+func (m *MockRecurringService) Remove(chatID int64, id string) error {
+	if id != "r1" {
+		return errors.NewValidationError("recurring transaction not found", nil)
+	}
+	return nil
+}
+
+func (m *MockRecurringService) Confirm(ctx context.Context, chatID int64, id, period string) (transaction_domain.Transaction, error) {
+	m.Confirmed = append(m.Confirmed, id+"@"+period)
+	return transaction_domain.Transaction{Amount: "450000", TransactionDate: period + "-01"}, nil
+}
+
+func (m *MockRecurringService) Skip(chatID int64, id, period string) error {
+	m.Skipped = append(m.Skipped, id+"@"+period)
+	return nil
+}
+
+func (m *MockRecurringService) Suggest(ctx context.Context, chatID int64) ([]recurring_domain.Suggestion, error) {
+	return []recurring_domain.Suggestion{{Title: "netflix", Category: "Entertainment", Amount: 186000, DayOfMonth: 3, Months: 3}}, nil
+}
+
+func (m *MockRecurringService) AcceptSuggestion(ctx context.Context, chatID int64, key string, user string) (recurring_domain.Recurring, error) {
+	m.Accepted = append(m.Accepted, key)
+	return recurring_domain.Recurring{ID: "r2"}, nil
+}
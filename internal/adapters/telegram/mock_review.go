@@ -0,0 +1,47 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	review_domain "money-tracker-bot/internal/domain/review"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"strconv"
+)
+
+type MockReviewService struct {
+	Pending  []review_domain.Pending
+	Approved []string
+}
+
+func (m *MockReviewService) Flag(chatID int64, trx transaction_domain.Transaction) (review_domain.Pending, error) {
+	p := review_domain.Pending{ID: "q" + strconv.Itoa(len(m.Pending)+1), ChatID: chatID, Transaction: trx}
+	m.Pending = append(m.Pending, p)
+	return p, nil
+}
+
+func (m *MockReviewService) List(chatID int64) ([]review_domain.Pending, error) {
+	return m.Pending, nil
+}
+
+func (m *MockReviewService) Approve(ctx context.Context, chatID int64, id string) (review_domain.Pending, spreadsheet.CategorySummary, error) {
+	p, err := m.remove(id)
+	if err == nil {
+		m.Approved = append(m.Approved, id)
+	}
+	return p, spreadsheet.CategorySummary{BudgetLeft: "50000"}, err
+}
+
+func (m *MockReviewService) Discard(chatID int64, id string) (review_domain.Pending, error) {
+	return m.remove(id)
+}
+
+func (m *MockReviewService) remove(id string) (review_domain.Pending, error) {
+	for i, p := range m.Pending {
+		if p.ID == id {
+			m.Pending = append(m.Pending[:i], m.Pending[i+1:]...)
+			return p, nil
+		}
+	}
+	return review_domain.Pending{}, errors.NewValidationError("this transaction is no longer awaiting review", nil)
+}
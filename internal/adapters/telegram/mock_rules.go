@@ -0,0 +1,44 @@
+package telegram
+
+import (
+	rules_domain "money-tracker-bot/internal/domain/rules"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+type MockRuleService struct {
+	Rules   []rules_domain.Rule
+	Removed []int
+}
+
+func (m *MockRuleService) Add(r rules_domain.Rule) (rules_domain.Rule, error) {
+	if err := r.Validate(); err != nil {
+		return r, err
+	}
+	r.ID = len(m.Rules) + 1
+	m.Rules = append(m.Rules, r)
+	return r, nil
+}
+
+func (m *MockRuleService) List(chatID int64) ([]rules_domain.Rule, error) {
+	return m.Rules, nil
+}
+
+func (m *MockRuleService) Remove(chatID int64, id int) error {
+	m.Removed = append(m.Removed, id)
+	return nil
+}
+
+func (m *MockRuleService) Test(chatID int64, in rules_domain.Input) (rules_domain.Rule, bool, error) {
+	r, ok := rules_domain.FirstMatch(m.Rules, in)
+	return r, ok, nil
+}
+
+func (m *MockRuleService) Apply(chatID int64, message string, trx *transaction_domain.Transaction) (bool, error) {
+	return false, nil
+}
+
+func (m *MockRuleService) RecordHit(chatID int64, id int) error { return nil }
+
+func (m *MockRuleService) Report(chatID int64) ([]rules_domain.Rule, error) {
+	return []rules_domain.Rule{{ID: 2, Kind: rules_domain.KindKeyword, Pattern: "grab", Category: "Transportation", Hits: 12, LastHit: "2025-07-20"}}, nil
+}
@@ -0,0 +1,80 @@
+package telegram
+
+import (
+	"money-tracker-bot/internal/common"
+	settings_domain "money-tracker-bot/internal/domain/settings"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"time"
+)
+
+type MockSettingsService struct {
+	Settings map[int64]settings_domain.Settings
+}
+
+func (m *MockSettingsService) Get(chatID int64) (settings_domain.Settings, error) {
+	s, ok := m.Settings[chatID]
+	if !ok {
+		s = settings_domain.Settings{ChatID: chatID, Timezone: "UTC", Locale: common.DefaultLocale}
+	}
+	return s, nil
+}
+
+func (m *MockSettingsService) SetTimezone(chatID int64, timezone string) (settings_domain.Settings, error) {
+	if _, err := settings_domain.LoadTimezone(timezone); err != nil {
+		return settings_domain.Settings{}, errors.NewValidationError("unknown timezone", err)
+	}
+	s, _ := m.Get(chatID)
+	s.Timezone = timezone
+	m.save(s)
+	return s, nil
+}
+
+func (m *MockSettingsService) SetLocale(chatID int64, tag string) (settings_domain.Settings, error) {
+	l, ok := common.ParseLocale(tag)
+	if !ok {
+		return settings_domain.Settings{}, errors.NewValidationError("unknown locale", nil)
+	}
+	s, _ := m.Get(chatID)
+	s.Locale = l.Tag
+	m.save(s)
+	return s, nil
+}
+
+func (m *MockSettingsService) SetLanguage(chatID int64, lang string) (settings_domain.Settings, error) {
+	s, _ := m.Get(chatID)
+	if lang == "auto" {
+		s.Language = ""
+	} else {
+		l, ok := i18n.Parse(lang)
+		if !ok {
+			return settings_domain.Settings{}, errors.NewValidationError("unknown language", nil)
+		}
+		s.Language = l
+	}
+	m.save(s)
+	return s, nil
+}
+
+func (m *MockSettingsService) Language(chatID int64) string {
+	s, _ := m.Get(chatID)
+	return s.Language
+}
+
+func (m *MockSettingsService) Location(chatID int64) *time.Location {
+	s, _ := m.Get(chatID)
+	loc, _ := settings_domain.LoadTimezone(s.Timezone)
+	return loc
+}
+
+func (m *MockSettingsService) Locale(chatID int64) common.Locale {
+	s, _ := m.Get(chatID)
+	return common.LocaleOf(s.Locale)
+}
+
+func (m *MockSettingsService) save(s settings_domain.Settings) {
+	if m.Settings == nil {
+		m.Settings = make(map[int64]settings_domain.Settings)
+	}
+	m.Settings[s.ChatID] = s
+}
@@ -0,0 +1,40 @@
+package telegram
+
+import (
+	"context"
+	split_domain "money-tracker-bot/internal/domain/split"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+type MockSplitService struct {
+	Remembered []transaction_domain.Transaction
+	Members    []string
+	Shares     map[string]string
+	Settled    bool
+}
+
+func (m *MockSplitService) Remember(chatID int64, trx transaction_domain.Transaction) {
+	m.Remembered = append(m.Remembered, trx)
+}
+
+func (m *MockSplitService) SplitLast(chatID int64, members []string, shares map[string]string) (split_domain.Entry, error) {
+	m.Members, m.Shares = members, shares
+	entry := split_domain.Entry{ChatID: chatID, PaidBy: "user", Amount: 300000, Description: "Dinner"}
+	entry.Shares, _ = split_domain.EqualShares(entry.Amount, members)
+	return entry, nil
+}
+
+func (m *MockSplitService) Balances(chatID int64) (map[string]int64, error) {
+	if m.Settled {
+		return nil, nil
+	}
+	return map[string]int64{"user": 200000, "bob": -100000, "carol": -100000}, nil
+}
+
+func (m *MockSplitService) Settle(ctx context.Context, chatID int64) ([]split_domain.Transfer, error) {
+	if m.Settled {
+		return nil, nil
+	}
+	m.Settled = true
+	return split_domain.Settle(map[string]int64{"user": 200000, "bob": -100000, "carol": -100000}), nil
+}
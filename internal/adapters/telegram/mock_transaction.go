@@ -11,17 +11,41 @@ type MockTransactionService struct {
 	HandleTextInputCalled  bool
 	HandleImageInputCalled bool
 	SaveTransactionCalled  bool
+	// LastCtx and LastText record the most recent HandleTextInput call
+	LastCtx  context.Context
+	LastText string
+	// TextResult replaces the transaction HandleTextInput returns
+	TextResult *transaction_domain.Transaction
+	// TextErr is returned by HandleTextInput
+	TextErr error
+	// SaveErr is returned by SaveTransaction
+	SaveErr error
+	Saved   []transaction_domain.Transaction
+	// Summary is returned by SaveTransaction
+	Summary spreadsheet.CategorySummary
 }
 
 func (m *MockTransactionService) HandleTextInput(ctx context.Context, text, user string, ai aiport.AiPort) (*transaction_domain.Transaction, error) {
 	m.HandleTextInputCalled = true
+	m.LastCtx, m.LastText = ctx, text
+	if m.TextErr != nil {
+		return nil, m.TextErr
+	}
+	if m.TextResult != nil {
+		trx := *m.TextResult
+		return &trx, nil
+	}
 	return &transaction_domain.Transaction{Notes: "test notes", Amount: "1000"}, nil
 }
 func (m *MockTransactionService) HandleImageInput(ctx context.Context, path, user string, ai aiport.AiPort) (*transaction_domain.Transaction, error) {
 	m.HandleImageInputCalled = true
 	return &transaction_domain.Transaction{Notes: "img notes", Amount: "2000"}, nil
 }
-func (m *MockTransactionService) SaveTransaction(tx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
+func (m *MockTransactionService) SaveTransaction(ctx context.Context, tx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
 	m.SaveTransactionCalled = true
-	return spreadsheet.CategorySummary{}, nil
+	if m.SaveErr != nil {
+		return spreadsheet.CategorySummary{}, m.SaveErr
+	}
+	m.Saved = append(m.Saved, tx)
+	return m.Summary, nil
 }
@@ -0,0 +1,31 @@
+package telegram
+
+import (
+	"context"
+	usage_domain "money-tracker-bot/internal/domain/usage"
+)
+
+type MockUsageService struct {
+	Reports  map[int64]usage_domain.Report
+	Chats    map[int64]usage_domain.Totals
+	Prices   usage_domain.Pricing
+	Recorded []usage_domain.Tokens
+}
+
+func (m *MockUsageService) Allow(ctx context.Context) error { return nil }
+
+func (m *MockUsageService) Record(ctx context.Context, kind string, tokens usage_domain.Tokens) {
+	m.Recorded = append(m.Recorded, tokens)
+}
+
+func (m *MockUsageService) Report(chatID int64) (usage_domain.Report, error) {
+	return m.Reports[chatID], nil
+}
+
+func (m *MockUsageService) Today() (map[int64]usage_domain.Totals, error) {
+	return m.Chats, nil
+}
+
+func (m *MockUsageService) Pricing() usage_domain.Pricing {
+	return m.Prices
+}
@@ -0,0 +1,54 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/errors"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// pollRetryDelay is the pause after a failed poll, as in the Telegram library
+const pollRetryDelay = 3 * time.Second
+
+// updateSource long-polls Telegram for updates; *tgbotapi.BotAPI implements it
+type updateSource interface {
+	GetUpdates(config tgbotapi.UpdateConfig) ([]tgbotapi.Update, error)
+}
+
+// poll fetches one batch of updates and handles them in order. The heartbeat
+// beats after the fetch, even an empty one, and after every update, so a
+// stuck poll or a stuck handler both show on /healthz.
+func (t *TelegramHandler) poll(source updateSource, u *tgbotapi.UpdateConfig) error {
+	updates, err := source.GetUpdates(*u)
+	if err != nil {
+		return errors.NewTelegramError("failed to get updates", err).
+			WithContext("offset", u.Offset).
+			WithComponent("telegram-handler")
+	}
+	t.Heartbeat.Beat()
+	for _, update := range updates {
+		if update.UpdateID >= u.Offset {
+			u.Offset = update.UpdateID + 1
+		}
+		t.handleUpdate(update)
+		t.Heartbeat.Beat()
+	}
+	return nil
+}
+
+// Ping checks that Telegram accepts the bot token, for the readiness probe
+func (t *TelegramHandler) Ping(ctx context.Context) error {
+	bot, ok := t.Telebot.(interface {
+		GetMe() (tgbotapi.User, error)
+	})
+	if !ok {
+		return errors.NewTelegramError("bot cannot be checked", nil).
+			WithComponent("telegram-handler")
+	}
+	if _, err := bot.GetMe(); err != nil {
+		return errors.NewTelegramError("Telegram rejected the bot token", err).
+			WithComponent("telegram-handler")
+	}
+	return nil
+}
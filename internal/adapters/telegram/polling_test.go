@@ -0,0 +1,99 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/health"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type stubUpdates struct {
+	batches [][]tgbotapi.Update
+	err     error
+	offsets []int
+}
+
+func (s *stubUpdates) GetUpdates(config tgbotapi.UpdateConfig) ([]tgbotapi.Update, error) {
+	s.offsets = append(s.offsets, config.Offset)
+	if s.err != nil {
+		return nil, s.err
+	}
+	if len(s.batches) == 0 {
+		return nil, nil
+	}
+	batch := s.batches[0]
+	s.batches = s.batches[1:]
+	return batch, nil
+}
+
+func TestPoll_HandlesUpdatesAndBeats(t *testing.T) {
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot, Heartbeat: health.NewHeartbeat(clock)}
+	source := &stubUpdates{batches: [][]tgbotapi.Update{{
+		{UpdateID: 7, Message: newCommand(12345, "/unknown")},
+		{UpdateID: 8, Message: newCommand(12345, "/unknown")},
+	}}}
+	u := tgbotapi.NewUpdate(0)
+
+	clock.Advance(time.Minute)
+	if err := h.poll(source, &u); err != nil {
+		t.Fatal(err)
+	}
+	if len(bot.SentMessages) != 2 || u.Offset != 9 {
+		t.Errorf("expected both updates handled and the offset moved past them, got %d replies and offset %d", len(bot.SentMessages), u.Offset)
+	}
+	if h.Heartbeat.Age() != 0 {
+		t.Error("expected the heartbeat to beat")
+	}
+
+	clock.Advance(time.Minute)
+	if err := h.poll(source, &u); err != nil || h.Heartbeat.Age() != 0 {
+		t.Errorf("an empty poll is progress too, got %v and age %v", err, h.Heartbeat.Age())
+	}
+
+	clock.Advance(time.Minute)
+	source.err = fmt.Errorf("connection reset")
+	if err := h.poll(source, &u); err == nil || h.Heartbeat.Age() != time.Minute {
+		t.Errorf("a failed poll should not beat, got %v and age %v", err, h.Heartbeat.Age())
+	}
+	if source.offsets[len(source.offsets)-1] != 9 {
+		t.Errorf("expected the offset to be kept, got %v", source.offsets)
+	}
+}
+
+func TestPing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasPrefix(r.URL.Path, "/botvalid-token/") {
+			w.Write([]byte(`{"ok": false, "error_code": 401, "description": "Unauthorized"}`))
+			return
+		}
+		w.Write([]byte(`{"ok": true, "result": {"id": 1, "is_bot": true, "username": "money_bot"}}`))
+	}))
+	defer server.Close()
+
+	bot, err := tgbotapi.NewBotAPIWithAPIEndpoint("valid-token", server.URL+"/bot%s/%s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &TelegramHandler{Telebot: bot}
+	if err := h.Ping(context.Background()); err != nil {
+		t.Errorf("expected an authorized bot to pass, got %v", err)
+	}
+
+	bot.Token = "revoked-token"
+	if err := h.Ping(context.Background()); err == nil {
+		t.Error("expected a revoked token to fail")
+	}
+	if err := (&TelegramHandler{Telebot: &MockBotAPI{}}).Ping(context.Background()); err == nil {
+		t.Error("expected a bot without GetMe to fail")
+	}
+}
@@ -0,0 +1,142 @@
+package telegram
+
+import (
+	"context"
+	"io"
+	"log"
+	currency_domain "money-tracker-bot/internal/domain/currency"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleRateCommand lists or sets the exchange rates amounts in other
+// currencies are converted with
+func (t *TelegramHandler) handleRateCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Currency == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rate.disabled")))
+		return
+	}
+	base := t.Currency.Base()
+	usage := p.T("rate.usage", base)
+	args := strings.Fields(msg.CommandArguments())
+	switch len(args) {
+	case 0:
+		t.sendRates(ctx, p, chatID, usage)
+	case 2, 3:
+		value, err := currency_domain.ParseRate(args[1])
+		if err != nil {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rate.invalid")+"\n\n"+usage))
+			return
+		}
+		date := ""
+		if len(args) == 3 {
+			date = args[2]
+		}
+		r, err := t.Currency.SetRate(chatID, args[0], value, date, senderName(msg.From))
+		if err != nil {
+			errors.HandleErrorContext(ctx, err, "saving exchange rate")
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rate.set_failed", userMessage(p, err))+"\n\n"+usage))
+			return
+		}
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rate.set", r.Currency, formatRate(r.Rate), r.Base, t.locale(chatID).FormatDate(r.Date))))
+	default:
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, usage))
+	}
+}
+
+// sendRates replies with the newest rate of each currency followed by usage
+func (t *TelegramHandler) sendRates(ctx context.Context, p i18n.Printer, chatID int64, usage string) {
+	rates, err := t.Currency.Rates(chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "loading exchange rates")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rate.load_failed")))
+		return
+	}
+	if len(rates) == 0 {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rate.none")+"\n\n"+usage))
+		return
+	}
+	locale := t.locale(chatID)
+	lines := []string{p.T("rate.title", t.Currency.Base())}
+	for _, r := range rates {
+		lines = append(lines, p.T("rate.entry", r.Currency, formatRate(r.Rate), r.Base, locale.FormatDate(r.Date)))
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, strings.Join(lines, "\n")+"\n\n"+usage))
+}
+
+// isRateImport reports whether a document is a rate file sent with the
+// caption /rate
+func isRateImport(msg *tgbotapi.Message) bool {
+	fields := strings.Fields(msg.Caption)
+	if len(fields) == 0 {
+		return false
+	}
+	command, _, _ := strings.Cut(fields[0], "@")
+	return command == "/rate" || command == "/rates"
+}
+
+// handleRateImport downloads a CSV document captioned /rate and imports its
+// exchange rates
+func (t *TelegramHandler) handleRateImport(ctx context.Context, bot BotAPI, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Currency == nil {
+		bot.Send(tgbotapi.NewMessage(chatID, p.T("rate.disabled")))
+		return
+	}
+	doc := msg.Document
+	if !strings.EqualFold(filepath.Ext(doc.FileName), ".csv") && doc.MimeType != "text/csv" {
+		bot.Send(tgbotapi.NewMessage(chatID, p.T("rate.not_csv")))
+		return
+	}
+
+	// Cast to *tgbotapi.BotAPI for downloadFile
+	realBot, ok := bot.(*tgbotapi.BotAPI)
+	if !ok {
+		log.Println("Bot is not *tgbotapi.BotAPI, skipping downloadFile")
+		return
+	}
+	localPath := t.downloadPath(doc.FileID + ".csv")
+	if err := downloadFile(realBot, doc.FileID, localPath); err != nil {
+		t.replyError(ctx, chatID, errors.NewFileError("failed to download rate file", err).
+			WithContext("file_id", doc.FileID).
+			WithComponent("telegram-handler"), inputText, "downloading rate file")
+		return
+	}
+	defer os.Remove(localPath)
+	file, err := os.Open(localPath)
+	if err != nil {
+		t.replyError(ctx, chatID, errors.NewFileError("failed to open rate file", err).
+			WithContext("path", localPath).
+			WithComponent("telegram-handler"), inputText, "opening rate file")
+		return
+	}
+	defer file.Close()
+	t.importRates(ctx, p, chatID, senderName(msg.From), file)
+}
+
+// importRates imports the rates of a CSV file and replies how many were read
+func (t *TelegramHandler) importRates(ctx context.Context, p i18n.Printer, chatID int64, user string, file io.Reader) {
+	rates, err := t.Currency.Import(chatID, file, user)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "importing exchange rates")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rate.import_failed", userMessage(p, err))+"\n\n"+p.T("rate.usage", t.Currency.Base())))
+		return
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rate.imported", len(rates))))
+}
+
+// formatRate writes a rate without trailing zeros or an exponent
+// Input: 16200, 0.0091
+// Output: "16200", "0.0091"
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64)
+}
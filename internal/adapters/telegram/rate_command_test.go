@@ -0,0 +1,102 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestRateCommand(t *testing.T) {
+	bot := &MockBotAPI{}
+	rates := &MockCurrencyService{}
+	h := &TelegramHandler{Telebot: bot, Currency: rates}
+
+	h.handleCommand(context.Background(), newCommand(1, "/rate"))
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "No exchange rates yet.") || !strings.Contains(reply, "how many IDR one unit buys") {
+		t.Errorf("expected no rates and usage, got: %s", reply)
+	}
+
+	h.handleCommand(context.Background(), newCommand(1, "/rate usd 16,200"))
+	if reply := lastText(t, bot); reply != "1 USD = 16200 IDR from 2025-07-10 ✅" {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+	h.handleCommand(context.Background(), newCommand(1, "/rate S$ 12500 2025-07-01"))
+	if len(rates.Saved) != 2 || rates.Saved[1].Currency != "SGD" || rates.Saved[1].Date != "2025-07-01" || rates.Saved[1].SetBy != "user" {
+		t.Errorf("unexpected rates %+v", rates.Saved)
+	}
+
+	h.handleCommand(context.Background(), newCommand(1, "/rate"))
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "💱 Exchange rates to IDR\n1 SGD = 12500 IDR · since 2025-07-01\n1 USD = 16200 IDR · since 2025-07-10") {
+		t.Errorf("unexpected rate list: %s", reply)
+	}
+
+	h.handleCommand(context.Background(), newCommand(1, "/rate USD lots"))
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "Rate must be a positive number") {
+		t.Errorf("expected an invalid rate, got: %s", reply)
+	}
+	h.handleCommand(context.Background(), newCommand(1, "/rate XYZ 5"))
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, `Could not set the rate: unknown currency "XYZ"`) {
+		t.Errorf("expected the validation error, got: %s", reply)
+	}
+}
+
+func TestRateCommand_Disabled(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot}
+	h.handleCommand(context.Background(), newCommand(1, "/rate USD 16200"))
+	if reply := lastText(t, bot); reply != "Exchange rates are not enabled on this bot." {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+}
+
+func TestRateImport(t *testing.T) {
+	bot := &MockBotAPI{}
+	rates := &MockCurrencyService{}
+	h := &TelegramHandler{Telebot: bot, Currency: rates}
+	p := h.printer(context.Background(), 1)
+
+	h.importRates(context.Background(), p, 1, "user", strings.NewReader("date,currency,rate\n2025-07-01,USD,16200\n2025-07-01,EUR,17600\n"))
+	if reply := lastText(t, bot); reply != "Imported 2 rate(s) ✅" || len(rates.Saved) != 2 {
+		t.Errorf("unexpected import %q, %+v", reply, rates.Saved)
+	}
+	h.importRates(context.Background(), p, 1, "user", strings.NewReader("2025-07-01,USD,16200\n2025-07-01,XYZ,1\n"))
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, `Could not import the rates: line 2: unknown currency "XYZ"`) {
+		t.Errorf("expected the failing line, got: %s", reply)
+	}
+
+	doc := &tgbotapi.Message{
+		Caption:  "/rate",
+		From:     &tgbotapi.User{UserName: "user"},
+		Chat:     &tgbotapi.Chat{ID: 1},
+		Document: &tgbotapi.Document{FileID: "f1", FileName: "rates.xlsx"},
+	}
+	if !isRateImport(doc) {
+		t.Fatal("expected a document captioned /rate to be a rate import")
+	}
+	h.handleRateImport(context.Background(), bot, doc)
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "Send the rates as a .csv file") {
+		t.Errorf("expected only CSV files to be imported, got: %s", reply)
+	}
+	for _, caption := range []string{"", "receipt", "/ratex"} {
+		if isRateImport(&tgbotapi.Message{Caption: caption}) {
+			t.Errorf("caption %q should not import rates", caption)
+		}
+	}
+	if !isRateImport(&tgbotapi.Message{Caption: "/rate@money_bot"}) || !isRateImport(&tgbotapi.Message{Caption: "/rates"}) {
+		t.Error("expected a command addressed to the bot to import rates")
+	}
+}
+
+func TestReplySaved_ShowsOriginalCurrency(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot}
+	trx := &transaction_domain.Transaction{Amount: "202500", AmountCurrency: "IDR", OriginalAmount: "12.50", OriginalCurrency: "USD", ExchangeRate: 16200}
+	h.replySaved(context.Background(), bot, 1, "Saved text ✅", trx, spreadsheet.CategorySummary{})
+	if text := lastText(t, bot); !strings.Contains(text, "Amount: Rp 202,500 (USD 12.50 at 16200)\n") {
+		t.Errorf("expected the original amount next to the converted one, got %q", text)
+	}
+}
@@ -0,0 +1,197 @@
+package telegram
+
+import (
+	"context"
+	stderrors "errors"
+	"money-tracker-bot/internal/common"
+	category_domain "money-tracker-bot/internal/domain/category"
+	recurring_domain "money-tracker-bot/internal/domain/recurring"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"money-tracker-bot/internal/port/out/notify"
+	"money-tracker-bot/internal/service/recurring"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleRecurringCommand manages recurring transactions of the chat
+func (t *TelegramHandler) handleRecurringCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Recurring == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("recurring.disabled")))
+		return
+	}
+
+	usage := p.T("recurring.usage")
+	options, args, err := parseKeyValues(msg.CommandArguments())
+	if err != nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, argsProblem(p, usage)))
+		return
+	}
+	sub := "list"
+	if len(args) > 0 {
+		sub = args[0]
+	}
+
+	switch sub {
+	case "list":
+		t.sendRecurringList(ctx, p, chatID)
+	case "add":
+		r, err := t.recurringFromOptions(ctx, p, chatID, options)
+		if err != nil {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, localize(p, err)+"\n\n"+usage))
+			return
+		}
+		r.ChatID = chatID
+		r.CreatedBy = senderName(msg.From)
+		saved, err := t.Recurring.Add(r)
+		if err != nil {
+			errors.HandleErrorContext(ctx, err, "adding recurring transaction")
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("recurring.add_failed", userMessage(p, err))))
+			return
+		}
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("recurring.added")+"\n"+formatRecurring(p, saved, t.locale(chatID))))
+	case "remove":
+		if len(args) < 2 {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, usage))
+			return
+		}
+		if err := t.Recurring.Remove(chatID, args[1]); err != nil {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("recurring.remove_failed", args[1], userMessage(p, err))))
+			return
+		}
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("recurring.removed", args[1])))
+	case "suggest":
+		t.sendRecurringSuggestions(ctx, p, chatID)
+	default:
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, usage))
+	}
+}
+
+func (t *TelegramHandler) sendRecurringList(ctx context.Context, p i18n.Printer, chatID int64) {
+	entries, err := t.Recurring.List(chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "listing recurring transactions")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("recurring.load_failed")))
+		return
+	}
+	if len(entries) == 0 {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("recurring.none")+"\n\n"+p.T("recurring.usage")))
+		return
+	}
+	var b strings.Builder
+	for _, r := range entries {
+		b.WriteString(formatRecurring(p, r, t.locale(chatID)) + "\n")
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, b.String()))
+}
+
+func (t *TelegramHandler) sendRecurringSuggestions(ctx context.Context, p i18n.Printer, chatID int64) {
+	suggestions, err := t.Recurring.Suggest(ctx, chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "detecting recurring transactions")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("recurring.suggest_failed")))
+		return
+	}
+	if len(suggestions) == 0 {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("recurring.no_suggestions")))
+		return
+	}
+	locale := t.locale(chatID)
+	for _, s := range suggestions {
+		text := p.T("recurring.suggestion",
+			s.Title, s.Category, locale.FormatAmount(s.Amount), s.DayOfMonth, s.Months)
+		button := notify.Button{Label: p.T("recurring.add_reminder"), Data: recurring.CallbackPrefix + "accept:" + s.Key()}
+		if err := t.SendWithButtons(chatID, text, []notify.Button{button}); err != nil {
+			errors.HandleErrorContext(ctx, err, "sending suggestion")
+		}
+	}
+}
+
+// handleRecurringCallback handles confirm/skip on reminders and accept on suggestions.
+// It returns the short text shown to the user as the callback answer.
+func (t *TelegramHandler) handleRecurringCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) string {
+	chatID := cb.Message.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Recurring == nil {
+		return p.T("recurring.callback_disabled")
+	}
+	parts := strings.Split(strings.TrimPrefix(cb.Data, recurring.CallbackPrefix), ":")
+	user := ""
+	if cb.From != nil {
+		user = senderName(cb.From)
+	}
+
+	switch {
+	case parts[0] == "confirm" && len(parts) == 3:
+		trx, err := t.Recurring.Confirm(ctx, chatID, parts[1], parts[2])
+		if err != nil {
+			return p.T("recurring.not_recorded", userMessage(p, err))
+		}
+		locale := t.locale(chatID)
+		t.resolveCallback(cb, p.T("recurring.confirmed", formatAmount(trx.Amount, locale), locale.FormatDate(trx.TransactionDate)))
+		return p.T("recurring.saved")
+	case parts[0] == "skip" && len(parts) == 3:
+		if err := t.Recurring.Skip(chatID, parts[1], parts[2]); err != nil {
+			return p.T("recurring.skip_failed", userMessage(p, err))
+		}
+		t.resolveCallback(cb, p.T("recurring.skipped_month"))
+		return p.T("recurring.skipped")
+	case parts[0] == "accept" && len(parts) == 2:
+		r, err := t.Recurring.AcceptSuggestion(ctx, chatID, parts[1], user)
+		if err != nil {
+			return p.T("recurring.not_added", userMessage(p, err))
+		}
+		t.resolveCallback(cb, p.T("recurring.added_as", r.ID))
+		return p.T("recurring.added_short")
+	default:
+		return p.T("recurring.unknown_action")
+	}
+}
+
+// recurringFromOptions builds a recurring entry from /recurring add options.
+// Its errors are worded by p, since they are shown to the user as they are.
+func (t *TelegramHandler) recurringFromOptions(ctx context.Context, p i18n.Printer, chatID int64, options map[string]string) (recurring_domain.Recurring, error) {
+	day, err := strconv.Atoi(options["day"])
+	if err != nil {
+		return recurring_domain.Recurring{}, stderrors.New(p.T("recurring.day_required"))
+	}
+	if _, err := transaction_domain.ParseAmount(options["amount"]); err != nil {
+		return recurring_domain.Recurring{}, stderrors.New(p.T("recurring.amount_required"))
+	}
+	category, ok := t.matchCategory(ctx, chatID, options["category"])
+	if !ok {
+		return recurring_domain.Recurring{}, stderrors.New(p.T("recurring.unknown_category",
+			options["category"], strings.Join(t.categoryChoices(ctx, chatID), ", ")))
+	}
+	mode, err := recurring_domain.ParseMode(options["mode"])
+	if err != nil {
+		return recurring_domain.Recurring{}, err
+	}
+	return recurring_domain.Recurring{
+		Title:         options["title"],
+		Amount:        options["amount"],
+		Category:      category.Category,
+		Subcategory:   category.Subcategory,
+		SourceAccount: strings.ToUpper(options["account"]),
+		DayOfMonth:    day,
+		Mode:          mode,
+	}, nil
+}
+
+func formatRecurring(p i18n.Printer, r recurring_domain.Recurring, locale common.Locale) string {
+	title := r.Title
+	if title == "" {
+		title = r.Category
+	}
+	category := category_domain.Ref{Category: r.Category, Subcategory: r.Subcategory}
+	line := p.T("recurring.entry", r.ID, title, formatAmount(r.Amount, locale), category, r.DayOfMonth, r.Mode)
+	if r.SourceAccount != "" {
+		line += p.T("recurring.via", r.SourceAccount)
+	}
+	return line
+}
@@ -0,0 +1,105 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestRecurringCommand_Add(t *testing.T) {
+	bot := &MockBotAPI{}
+	svc := &MockRecurringService{}
+	h := &TelegramHandler{Telebot: bot, Recurring: svc}
+
+	h.handleCommand(context.Background(), newCommand(5, `/recurring add day=1 amount=5000000 category="rent house" account=bca mode=auto title="Monthly rent"`))
+	if len(svc.Added) != 1 {
+		t.Fatalf("expected entry to be added, reply: %s", lastText(t, bot))
+	}
+	r := svc.Added[0]
+	if r.Category != "Rent House" || r.SourceAccount != "BCA" || r.Mode != "auto" || r.DayOfMonth != 1 || r.ChatID != 5 || r.CreatedBy != "user" {
+		t.Errorf("unexpected entry: %+v", r)
+	}
+	if !strings.Contains(lastText(t, bot), "Monthly rent") {
+		t.Errorf("unexpected reply: %s", lastText(t, bot))
+	}
+}
+
+func TestRecurringCommand_AddRejectsUnknownCategory(t *testing.T) {
+	bot := &MockBotAPI{}
+	svc := &MockRecurringService{}
+	h := &TelegramHandler{Telebot: bot, Recurring: svc}
+
+	h.handleCommand(context.Background(), newCommand(5, `/recurring add day=1 amount=100 category=Pets`))
+	if len(svc.Added) != 0 {
+		t.Error("unknown category should be rejected")
+	}
+	if !strings.Contains(lastText(t, bot), "unknown category") {
+		t.Errorf("unexpected reply: %s", lastText(t, bot))
+	}
+}
+
+func TestRecurringCommand_SuggestSendsButtons(t *testing.T) {
+	bot := &MockBotAPI{}
+	svc := &MockRecurringService{}
+	h := &TelegramHandler{Telebot: bot, Recurring: svc}
+	suggestions, _ := svc.Suggest(context.Background(), 5)
+
+	h.handleCommand(context.Background(), newCommand(5, "/recurring suggest"))
+	msg := bot.SentMessages[0].(tgbotapi.MessageConfig)
+	markup, ok := msg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup)
+	if !ok || *markup.InlineKeyboard[0][0].CallbackData != "recurring:accept:"+suggestions[0].Key() {
+		t.Fatalf("expected accept button, got %+v", msg.ReplyMarkup)
+	}
+
+	h.handleCallback(context.Background(), &tgbotapi.CallbackQuery{
+		ID:      "cb1",
+		From:    &tgbotapi.User{UserName: "user"},
+		Data:    *markup.InlineKeyboard[0][0].CallbackData,
+		Message: &tgbotapi.Message{MessageID: 9, Text: msg.Text, Chat: &tgbotapi.Chat{ID: 5}},
+	})
+	if len(svc.Accepted) != 1 || svc.Accepted[0] != suggestions[0].Key() {
+		t.Errorf("expected the suggestion's key to be accepted, got %v", svc.Accepted)
+	}
+}
+
+func TestRecurringCallback_Confirm(t *testing.T) {
+	bot := &MockBotAPI{}
+	svc := &MockRecurringService{}
+	h := &TelegramHandler{Telebot: bot, Recurring: svc}
+
+	h.handleCallback(context.Background(), &tgbotapi.CallbackQuery{
+		ID:      "cb1",
+		From:    &tgbotapi.User{UserName: "user"},
+		Data:    "recurring:confirm:r1:2025-07",
+		Message: &tgbotapi.Message{MessageID: 9, Text: "PLN is due", Chat: &tgbotapi.Chat{ID: 5}},
+	})
+	if len(svc.Confirmed) != 1 || svc.Confirmed[0] != "r1@2025-07" {
+		t.Errorf("expected confirmation, got %v", svc.Confirmed)
+	}
+	if len(bot.Requests) != 1 {
+		t.Fatal("callback should be answered")
+	}
+	if answer := bot.Requests[0].(tgbotapi.CallbackConfig); answer.Text != "Saved" {
+		t.Errorf("unexpected callback answer: %s", answer.Text)
+	}
+	edit, ok := bot.SentMessages[0].(tgbotapi.EditMessageTextConfig)
+	if !ok || !strings.Contains(edit.Text, "Saved ✅") {
+		t.Errorf("expected reminder to be updated, got %+v", bot.SentMessages[0])
+	}
+}
+
+func TestRecurringCallback_Unknown(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot, Recurring: &MockRecurringService{}}
+
+	h.handleCallback(context.Background(), &tgbotapi.CallbackQuery{
+		ID:      "cb2",
+		Data:    "recurring:explode",
+		Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 5}},
+	})
+	if answer := bot.Requests[0].(tgbotapi.CallbackConfig); answer.Text != "Unknown action" {
+		t.Errorf("unexpected callback answer: %s", answer.Text)
+	}
+}
@@ -0,0 +1,31 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleReparseCommand extracts a transaction again, skipping the AI response
+// cache. It works on the replied-to message or on the command arguments.
+func (t *TelegramHandler) handleReparseCommand(ctx context.Context, msg *tgbotapi.Message) {
+	ctx = common.WithCacheBypass(common.WithTenant(ctx, msg.Chat.ID))
+
+	if reply := msg.ReplyToMessage; reply != nil {
+		switch {
+		case len(reply.Photo) > 0:
+			t.extractPhoto(ctx, t.Telebot, reply)
+			return
+		case strings.TrimSpace(reply.Text) != "" && !reply.IsCommand():
+			t.extractText(ctx, t.Telebot, reply, reply.Text)
+			return
+		}
+	}
+	if text := strings.TrimSpace(msg.CommandArguments()); text != "" {
+		t.extractText(ctx, t.Telebot, msg, text)
+		return
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(msg.Chat.ID, t.printer(ctx, msg.Chat.ID).T("reparse.usage")))
+}
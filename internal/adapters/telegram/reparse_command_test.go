@@ -0,0 +1,52 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestReparseCommand_Reply(t *testing.T) {
+	bot := &MockBotAPI{}
+	trx := &MockTransactionService{}
+	h := &TelegramHandler{Telebot: bot, TransactionService: trx}
+
+	msg := newCommand(3, "/reparse")
+	msg.ReplyToMessage = &tgbotapi.Message{
+		Text: "kopi susu 18k",
+		From: &tgbotapi.User{UserName: "alice"},
+		Chat: &tgbotapi.Chat{ID: 3},
+	}
+	h.handleCommand(context.Background(), msg)
+	if !trx.SaveTransactionCalled || trx.LastText != "kopi susu 18k" {
+		t.Fatalf("expected the replied message to be saved again, got %q", trx.LastText)
+	}
+	if !common.CacheBypassed(trx.LastCtx) {
+		t.Error("expected the extraction to bypass the cache")
+	}
+	if chatID, _ := common.TenantFrom(trx.LastCtx); chatID != 3 {
+		t.Errorf("expected the tenant to be kept, got %d", chatID)
+	}
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "Saved text ✅") {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+}
+
+func TestReparseCommand_Arguments(t *testing.T) {
+	bot := &MockBotAPI{}
+	trx := &MockTransactionService{}
+	h := &TelegramHandler{Telebot: bot, TransactionService: trx}
+
+	h.handleCommand(context.Background(), newCommand(3, "/reparse makan siang 35rb"))
+	if trx.LastText != "makan siang 35rb" || !common.CacheBypassed(trx.LastCtx) {
+		t.Errorf("expected the arguments to be extracted without the cache, got %q", trx.LastText)
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, "/reparse"))
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "Usage: reply /reparse") {
+		t.Errorf("expected usage, got: %s", reply)
+	}
+}
@@ -0,0 +1,112 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	review_domain "money-tracker-bot/internal/domain/review"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"money-tracker-bot/internal/port/out/notify"
+	"money-tracker-bot/internal/service/review"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// holdForReview queues a transaction the AI was unsure about and asks the
+// user to check it. It reports whether the transaction was held back.
+func (t *TelegramHandler) holdForReview(ctx context.Context, chatID int64, trx *transaction_domain.Transaction) bool {
+	if t.Review == nil || !trx.NeedsReview() {
+		return false
+	}
+	p, err := t.Review.Flag(chatID, *trx)
+	if err != nil {
+		// Saving unchecked beats losing the transaction
+		errors.HandleErrorContext(ctx, err, "queueing transaction for review")
+		return false
+	}
+	t.askForReview(ctx, t.printer(ctx, chatID), p)
+	return true
+}
+
+// askForReview sends a queued transaction with Save and Discard buttons
+func (t *TelegramHandler) askForReview(ctx context.Context, pr i18n.Printer, p review_domain.Pending) {
+	buttons := []notify.Button{
+		{Label: pr.T("review.save"), Data: review.CallbackPrefix + "approve:" + p.ID},
+		{Label: pr.T("review.discard"), Data: review.CallbackPrefix + "discard:" + p.ID},
+	}
+	if err := t.SendWithButtons(p.ChatID, reviewText(pr, p, t.locale(p.ChatID)), buttons); err != nil {
+		errors.HandleErrorContext(ctx, err, "asking for review")
+	}
+}
+
+// reviewText describes a queued transaction and what the AI was unsure about
+func reviewText(pr i18n.Printer, p review_domain.Pending, locale common.Locale) string {
+	trx := p.Transaction
+	text := pr.T("review.check",
+		p.ID, locale.FormatDate(trx.TransactionDate), categoryLabel(&trx), formatAmount(trx.Amount, locale), trx.Notes)
+	// Transactions kept while the spreadsheet was down have no doubts
+	if doubts := p.Doubts(); doubts != "" {
+		text += pr.T("review.doubts", doubts)
+	}
+	return text
+}
+
+// handleReviewCommand lists the transactions of the chat awaiting review
+func (t *TelegramHandler) handleReviewCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	pr := t.printer(ctx, chatID)
+	if t.Review == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("review.disabled")))
+		return
+	}
+	pending, err := t.Review.List(chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "listing transactions awaiting review")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("review.load_failed", userMessage(pr, err))))
+		return
+	}
+	if len(pending) == 0 {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("review.none")))
+		return
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, pr.T("review.pending", len(pending))))
+	for _, p := range pending {
+		t.askForReview(ctx, pr, p)
+	}
+}
+
+// handleReviewCallback handles Save and Discard on queued transactions.
+// It returns the short text shown to the user as the callback answer.
+func (t *TelegramHandler) handleReviewCallback(ctx context.Context, cb *tgbotapi.CallbackQuery) string {
+	chatID := cb.Message.Chat.ID
+	pr := t.printer(ctx, chatID)
+	if t.Review == nil {
+		return pr.T("review.callback_disabled")
+	}
+	action, id, ok := strings.Cut(strings.TrimPrefix(cb.Data, review.CallbackPrefix), ":")
+	if !ok {
+		return pr.T("review.unknown_action")
+	}
+
+	switch action {
+	case "approve":
+		p, summary, err := t.Review.Approve(ctx, chatID, id)
+		if err != nil {
+			errors.HandleErrorContext(ctx, err, "saving reviewed transaction")
+			return pr.T("review.not_saved", userMessage(pr, err))
+		}
+		t.resolveCallback(cb, pr.T("review.checked"))
+		t.replySaved(ctx, t.Telebot, chatID, pr.T("review.saved_title"), &p.Transaction, summary)
+		return pr.T("review.saved")
+	case "discard":
+		if _, err := t.Review.Discard(chatID, id); err != nil {
+			return pr.T("review.not_discarded", userMessage(pr, err))
+		}
+		t.resolveCallback(cb, pr.T("review.discarded_hint"))
+		return pr.T("review.discarded")
+	default:
+		return pr.T("review.unknown_action")
+	}
+}
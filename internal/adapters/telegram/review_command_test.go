@@ -0,0 +1,116 @@
+package telegram
+
+import (
+	"context"
+	review_domain "money-tracker-bot/internal/domain/review"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func blurryReceipt() *transaction_domain.Transaction {
+	return &transaction_domain.Transaction{
+		TransactionDate: "2025-07-10",
+		Category:        "Groceries",
+		Amount:          "45,000",
+		Notes:           "Superindo",
+		Confidence:      map[string]float64{"amount": 0.4},
+		LowConfidence:   []string{"amount"},
+	}
+}
+
+func TestExtractText_HoldsLowConfidence(t *testing.T) {
+	bot := &MockBotAPI{}
+	trx := &MockTransactionService{TextResult: blurryReceipt()}
+	queue := &MockReviewService{}
+	h := &TelegramHandler{Telebot: bot, TransactionService: trx, Review: queue}
+
+	h.handleMessage(context.Background(), bot, &tgbotapi.Message{Text: "superindo 45rb", From: &tgbotapi.User{UserName: "user"}, Chat: &tgbotapi.Chat{ID: 3}})
+	if trx.SaveTransactionCalled || len(queue.Pending) != 1 {
+		t.Fatalf("expected the transaction to wait for review, saved=%v queued=%d", trx.SaveTransactionCalled, len(queue.Pending))
+	}
+	msg := bot.SentMessages[len(bot.SentMessages)-1].(tgbotapi.MessageConfig)
+	if !strings.Contains(msg.Text, "Please check before I save it ⚠️ (q1)") || !strings.Contains(msg.Text, "Not sure about: amount (40%)") {
+		t.Errorf("unexpected review request: %s", msg.Text)
+	}
+	if _, ok := msg.ReplyMarkup.(tgbotapi.InlineKeyboardMarkup); !ok {
+		t.Error("expected Save and Discard buttons")
+	}
+
+	// Confident transactions and bots without review are saved right away
+	trx.TextResult = nil
+	h.handleMessage(context.Background(), bot, &tgbotapi.Message{Text: "kopi 18k", From: &tgbotapi.User{UserName: "user"}, Chat: &tgbotapi.Chat{ID: 3}})
+	h.Review = nil
+	trx.TextResult = blurryReceipt()
+	h.handleMessage(context.Background(), bot, &tgbotapi.Message{Text: "superindo 45rb", From: &tgbotapi.User{UserName: "user"}, Chat: &tgbotapi.Chat{ID: 3}})
+	if len(trx.Saved) != 2 {
+		t.Errorf("expected two saved transactions, got %d", len(trx.Saved))
+	}
+}
+
+func TestReviewCommand(t *testing.T) {
+	bot := &MockBotAPI{}
+	queue := &MockReviewService{}
+	h := &TelegramHandler{Telebot: bot, Review: queue}
+
+	h.handleCommand(context.Background(), newCommand(3, "/review"))
+	if reply := lastText(t, bot); reply != "Nothing to review ✅" {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+
+	queue.Flag(3, *blurryReceipt())
+	queue.Flag(3, *blurryReceipt())
+	h.handleCommand(context.Background(), newCommand(3, "/review"))
+	if len(bot.SentMessages) != 4 || !strings.Contains(bot.SentMessages[1].(tgbotapi.MessageConfig).Text, "2 transaction(s) awaiting review") {
+		t.Errorf("expected a header and one message per transaction, got %d messages", len(bot.SentMessages))
+	}
+	if reply := lastText(t, bot); !strings.Contains(reply, "(q2)") {
+		t.Errorf("unexpected review entry: %s", reply)
+	}
+
+	h.Review = nil
+	h.handleCommand(context.Background(), newCommand(3, "/review"))
+	if reply := lastText(t, bot); !strings.Contains(reply, "not enabled") {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+}
+
+func TestReviewCallback(t *testing.T) {
+	bot := &MockBotAPI{}
+	split := &MockSplitService{}
+	queue := &MockReviewService{Pending: []review_domain.Pending{
+		{ID: "q1", ChatID: 5, Transaction: *blurryReceipt()},
+		{ID: "q2", ChatID: 5, Transaction: *blurryReceipt()},
+	}}
+	h := &TelegramHandler{Telebot: bot, Review: queue, Splits: split}
+	callback := func(data string) string {
+		bot.Requests = nil
+		h.handleCallback(context.Background(), &tgbotapi.CallbackQuery{
+			ID:      "cb",
+			Data:    data,
+			Message: &tgbotapi.Message{MessageID: 9, Text: "Please check", Chat: &tgbotapi.Chat{ID: 5}},
+		})
+		return bot.Requests[0].(tgbotapi.CallbackConfig).Text
+	}
+
+	if answer := callback("review:approve:q1"); answer != "Saved" || len(queue.Approved) != 1 {
+		t.Fatalf("expected q1 to be saved, got %q", answer)
+	}
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "Saved ✅\nCategory: Groceries") || !strings.Contains(reply, "Budget Left: 50000") {
+		t.Errorf("unexpected saved reply: %s", reply)
+	}
+	if len(split.Remembered) != 1 {
+		t.Error("approved transactions should be available to /split")
+	}
+	if answer := callback("review:approve:q1"); !strings.HasPrefix(answer, "Not saved: this transaction is no longer awaiting review") {
+		t.Errorf("unexpected answer for a handled entry: %q", answer)
+	}
+	if answer := callback("review:discard:q2"); answer != "Discarded" || len(queue.Pending) != 0 {
+		t.Errorf("expected q2 to be discarded, got %q", answer)
+	}
+	if answer := callback("review:explode"); answer != "Unknown action" {
+		t.Errorf("unexpected answer: %q", answer)
+	}
+}
@@ -0,0 +1,163 @@
+package telegram
+
+import (
+	"context"
+	stderrors "errors"
+	category_domain "money-tracker-bot/internal/domain/category"
+	rules_domain "money-tracker-bot/internal/domain/rules"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleRuleCommand manages the categorization rules of the chat
+func (t *TelegramHandler) handleRuleCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Rules == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rule.disabled")))
+		return
+	}
+
+	ruleUsage := p.T("rule.usage")
+	options, args, err := parseKeyValues(msg.CommandArguments())
+	if err != nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, argsProblem(p, ruleUsage)))
+		return
+	}
+	sub := "list"
+	if len(args) > 0 {
+		sub = args[0]
+	}
+
+	switch sub {
+	case "list", "report":
+		t.sendRuleList(ctx, p, chatID, sub == "report")
+	case "add":
+		if len(args) != 3 {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, ruleUsage))
+			return
+		}
+		r, err := t.ruleFromArgs(ctx, p, chatID, args[1], args[2], options)
+		if err != nil {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, localize(p, err)+"\n\n"+ruleUsage))
+			return
+		}
+		r.CreatedBy = senderName(msg.From)
+		saved, err := t.Rules.Add(r)
+		if err != nil {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rule.add_failed", userMessage(p, err))+"\n\n"+ruleUsage))
+			return
+		}
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rule.added")+"\n"+formatRule(p, saved)))
+	case "test":
+		if len(args) < 2 && options["merchant"] == "" && options["account"] == "" {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, ruleUsage))
+			return
+		}
+		in := rules_domain.Input{
+			Text:     strings.Join(args[1:], " "),
+			Merchant: options["merchant"],
+			Account:  options["account"],
+		}
+		r, ok, err := t.Rules.Test(chatID, in)
+		if err != nil {
+			errors.HandleErrorContext(ctx, err, "testing rules")
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rule.test_failed")))
+			return
+		}
+		if !ok {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rule.no_match")))
+			return
+		}
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rule.matches")+"\n"+formatRule(p, r)))
+	case "remove":
+		if len(args) < 2 {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, ruleUsage))
+			return
+		}
+		id, err := strconv.Atoi(args[1])
+		if err != nil {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rule.id_not_number")+"\n\n"+ruleUsage))
+			return
+		}
+		if err := t.Rules.Remove(chatID, id); err != nil {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rule.remove_failed", args[1], userMessage(p, err))))
+			return
+		}
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rule.removed", args[1])))
+	default:
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, ruleUsage))
+	}
+}
+
+func (t *TelegramHandler) sendRuleList(ctx context.Context, p i18n.Printer, chatID int64, byHits bool) {
+	list := t.Rules.List
+	if byHits {
+		list = t.Rules.Report
+	}
+	rules, err := list(chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "listing rules")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rule.load_failed")))
+		return
+	}
+	if len(rules) == 0 {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("rule.none")+"\n\n"+p.T("rule.usage")))
+		return
+	}
+	title := p.T("rule.title")
+	if byHits {
+		title = p.T("rule.report_title")
+	}
+	lines := []string{title}
+	for _, r := range rules {
+		lines = append(lines, formatRule(p, r))
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, strings.Join(lines, "\n")))
+}
+
+// ruleFromArgs builds a rule from /rule add arguments, resolving the category
+// against the chat's categories
+func (t *TelegramHandler) ruleFromArgs(ctx context.Context, p i18n.Printer, chatID int64, kind, pattern string, options map[string]string) (rules_domain.Rule, error) {
+	k, err := rules_domain.ParseKind(kind)
+	if err != nil {
+		return rules_domain.Rule{}, err
+	}
+	r := rules_domain.Rule{
+		ChatID:  chatID,
+		Kind:    k,
+		Pattern: pattern,
+		Tags:    splitList(options["tags"]),
+		Account: strings.ToUpper(options["account"]),
+	}
+	if name := options["category"]; name != "" {
+		ref, ok := t.matchCategory(ctx, chatID, name)
+		if !ok {
+			return r, stderrors.New(p.T("rule.unknown_category", name, strings.Join(t.categoryChoices(ctx, chatID), ", ")))
+		}
+		r.Category, r.Subcategory = ref.Category, ref.Subcategory
+	}
+	return r, nil
+}
+
+func formatRule(p i18n.Printer, r rules_domain.Rule) string {
+	var actions []string
+	if r.Category != "" {
+		actions = append(actions, category_domain.Ref{Category: r.Category, Subcategory: r.Subcategory}.String())
+	}
+	if r.Account != "" {
+		actions = append(actions, p.T("rule.account", r.Account))
+	}
+	if len(r.Tags) > 0 {
+		actions = append(actions, p.T("rule.tags", strings.Join(r.Tags, ", ")))
+	}
+	line := p.T("rule.entry", r.ID, r.Kind, r.Pattern, strings.Join(actions, "; "), r.Hits)
+	if r.LastHit != "" {
+		line += p.T("rule.last", r.LastHit)
+	}
+	return line
+}
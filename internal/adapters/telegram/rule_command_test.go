@@ -0,0 +1,67 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRuleCommand_AddAndTest(t *testing.T) {
+	bot := &MockBotAPI{}
+	rules := &MockRuleService{}
+	h := &TelegramHandler{Telebot: bot, Rules: rules, Categories: NewMockCategoryService()}
+
+	h.handleCommand(context.Background(), newCommand(3, `/rule add keyword kopi category=coffee tags="daily, caffeine" account=gopay`))
+	if len(rules.Rules) != 1 {
+		t.Fatalf("expected rule to be added, got: %s", lastText(t, bot))
+	}
+	r := rules.Rules[0]
+	if r.Category != "Eating Out" || r.Subcategory != "Coffee" || r.Account != "GOPAY" || len(r.Tags) != 2 || r.ChatID != 3 {
+		t.Errorf("unexpected rule: %+v", r)
+	}
+	if reply := lastText(t, bot); !strings.Contains(reply, `1. keyword "kopi" → Eating Out › Coffee; account GOPAY; tags daily, caffeine · 0 hit(s)`) {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, `/rule test kopi susu 18k`))
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "Matches rule:\n1.") {
+		t.Errorf("unexpected test reply: %s", reply)
+	}
+	h.handleCommand(context.Background(), newCommand(3, `/rule test salary july`))
+	if reply := lastText(t, bot); !strings.Contains(reply, "No rule matches") {
+		t.Errorf("unexpected test reply: %s", reply)
+	}
+}
+
+func TestRuleCommand_Validation(t *testing.T) {
+	bot := &MockBotAPI{}
+	rules := &MockRuleService{}
+	h := &TelegramHandler{Telebot: bot, Rules: rules, Categories: NewMockCategoryService()}
+
+	h.handleCommand(context.Background(), newCommand(3, `/rule add keyword grab category=Pets`))
+	if len(rules.Rules) != 0 || !strings.Contains(lastText(t, bot), "unknown category") {
+		t.Errorf("unknown categories should be rejected, got: %s", lastText(t, bot))
+	}
+	h.handleCommand(context.Background(), newCommand(3, `/rule add fuzzy grab category=Groceries`))
+	if !strings.Contains(lastText(t, bot), "unknown rule type") {
+		t.Errorf("unknown kinds should be rejected, got: %s", lastText(t, bot))
+	}
+	h.handleCommand(context.Background(), newCommand(3, `/rule remove two`))
+	if len(rules.Removed) != 0 {
+		t.Error("non-numeric IDs should be rejected")
+	}
+	h.handleCommand(context.Background(), newCommand(3, `/rule remove 2`))
+	if len(rules.Removed) != 1 || rules.Removed[0] != 2 {
+		t.Errorf("expected rule 2 to be removed, got %v", rules.Removed)
+	}
+}
+
+func TestRuleCommand_Report(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot, Rules: &MockRuleService{}}
+
+	h.handleCommand(context.Background(), newCommand(3, "/rule report"))
+	if reply := lastText(t, bot); !strings.Contains(reply, "📊 Rule report\n2. keyword \"grab\" → Transportation · 12 hit(s), last 2025-07-20") {
+		t.Errorf("unexpected report: %s", reply)
+	}
+}
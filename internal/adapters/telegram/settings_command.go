@@ -0,0 +1,105 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	settings_domain "money-tracker-bot/internal/domain/settings"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleTimezoneCommand shows or changes the timezone the chat's dates,
+// timestamps and budget months follow
+func (t *TelegramHandler) handleTimezoneCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Settings == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("settings.disabled")))
+		return
+	}
+	arg := strings.TrimSpace(msg.CommandArguments())
+	if arg == "" {
+		t.sendSettings(ctx, p, chatID, p.T("settings.timezone_usage"))
+		return
+	}
+	t.setTimezone(ctx, p, chatID, arg)
+}
+
+// setTimezone changes the timezone of the chat and replies with the result
+func (t *TelegramHandler) setTimezone(ctx context.Context, p i18n.Printer, chatID int64, timezone string) {
+	s, err := t.Settings.SetTimezone(chatID, timezone)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "saving timezone")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("settings.timezone_failed", userMessage(p, err))))
+		return
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("settings.timezone_set", s.Timezone)))
+}
+
+// handleLocaleCommand shows or changes how amounts and dates are written
+func (t *TelegramHandler) handleLocaleCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Settings == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("settings.disabled")))
+		return
+	}
+	usage := p.T("settings.locale_usage", strings.Join(common.Locales(), "|"))
+	arg := strings.TrimSpace(msg.CommandArguments())
+	if arg == "" {
+		t.sendSettings(ctx, p, chatID, usage)
+		return
+	}
+	s, err := t.Settings.SetLocale(chatID, arg)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "saving locale")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("settings.locale_failed", userMessage(p, err))+"\n\n"+usage))
+		return
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("settings.locale_set", s.Locale, formatSettingsExample(t.locale(chatID)))))
+}
+
+// sendSettings replies with the chat's current settings followed by usage
+func (t *TelegramHandler) sendSettings(ctx context.Context, p i18n.Printer, chatID int64, usage string) {
+	s, err := t.Settings.Get(chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "loading chat settings")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("settings.load_failed")))
+		return
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, formatSettings(p, s, t.locale(chatID))+"\n\n"+usage))
+}
+
+func formatSettings(p i18n.Printer, s settings_domain.Settings, locale common.Locale) string {
+	language := p.T("settings.language_auto_name")
+	if s.Language != "" {
+		language = p.T("language.name." + s.Language)
+	}
+	return p.T("settings.summary", s.Timezone, s.Locale, formatSettingsExample(locale), language)
+}
+
+// formatSettingsExample shows how a locale writes an amount and a date
+func formatSettingsExample(locale common.Locale) string {
+	return locale.FormatAmount(1500000) + ", " + locale.FormatDate("2025-07-13")
+}
+
+// location returns the timezone of a chat, the server's when Settings is nil
+func (t *TelegramHandler) location(chatID int64) *time.Location {
+	if t.Settings == nil {
+		return time.Local
+	}
+	return t.Settings.Location(chatID)
+}
+
+// locale returns how amounts and dates are written for a chat,
+// common.DefaultLocale when Settings is nil
+func (t *TelegramHandler) locale(chatID int64) common.Locale {
+	if t.Settings == nil {
+		return common.LocaleOf(common.DefaultLocale)
+	}
+	return t.Settings.Locale(chatID)
+}
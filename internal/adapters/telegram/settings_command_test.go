@@ -0,0 +1,76 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/i18n"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestTimezoneCommand(t *testing.T) {
+	bot := &MockBotAPI{}
+	settings := &MockSettingsService{}
+	h := &TelegramHandler{Telebot: bot, Settings: settings}
+
+	h.handleCommand(context.Background(), newCommand(1, "/timezone"))
+	if reply := lastText(t, bot); !strings.Contains(reply, "Timezone: UTC") || !strings.Contains(reply, i18n.For(i18n.English).T("settings.timezone_usage")) {
+		t.Errorf("expected the current settings and usage, got: %s", reply)
+	}
+
+	h.handleCommand(context.Background(), newCommand(1, "/timezone Asia/Jakarta"))
+	if reply := lastText(t, bot); reply != "Timezone set to Asia/Jakarta ✅" {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+	if loc := settings.Location(1); loc.String() != "Asia/Jakarta" {
+		t.Errorf("expected the timezone to be saved, got %v", loc)
+	}
+
+	h.handleCommand(context.Background(), newCommand(1, "/timezone Nowhere/City"))
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "Could not change the timezone: unknown timezone") {
+		t.Errorf("expected the validation error, got: %s", reply)
+	}
+}
+
+func TestLocaleCommand(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot, Settings: &MockSettingsService{}}
+
+	h.handleCommand(context.Background(), newCommand(1, "/locale id_ID"))
+	if reply := lastText(t, bot); reply != "Locale set to id-ID ✅\nExample: Rp 1.500.000, 13/07/2025" {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+	h.handleCommand(context.Background(), newCommand(1, "/locale"))
+	if reply := lastText(t, bot); !strings.Contains(reply, "Locale: id-ID (Rp 1.500.000, 13/07/2025)") || !strings.Contains(reply, "Usage: /locale <en|en-US|en-GB|id-ID>") {
+		t.Errorf("expected the current locale and usage, got: %s", reply)
+	}
+	h.handleCommand(context.Background(), newCommand(1, "/locale fr-FR"))
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "Could not change the locale: unknown locale") {
+		t.Errorf("expected the validation error, got: %s", reply)
+	}
+}
+
+func TestSettingsCommands_Disabled(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot}
+	h.handleCommand(context.Background(), newCommand(1, "/locale id-ID"))
+	if reply := lastText(t, bot); reply != "Chat settings are not enabled on this bot." {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+}
+
+func TestReplySaved_UsesChatLocale(t *testing.T) {
+	bot := &MockBotAPI{}
+	settings := &MockSettingsService{}
+	settings.SetLocale(12345, "id-ID")
+	h := &TelegramHandler{Telebot: bot, TransactionService: &MockTransactionService{}, Settings: settings}
+	h.handleMessage(context.Background(), bot, &tgbotapi.Message{
+		Text: "coffee 25k",
+		From: &tgbotapi.User{UserName: "user"},
+		Chat: &tgbotapi.Chat{ID: 12345},
+	})
+	if text := lastText(t, bot); !strings.Contains(text, "Amount: Rp 1.000\n") {
+		t.Errorf("expected the amount in the chat's locale, got %q", text)
+	}
+}
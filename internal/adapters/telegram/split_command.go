@@ -0,0 +1,121 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/common"
+	split_domain "money-tracker-bot/internal/domain/split"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"sort"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleSplitCommand marks the last transaction of the chat as shared
+func (t *TelegramHandler) handleSplitCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Splits == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("split.disabled")))
+		return
+	}
+
+	shares, members, err := parseKeyValues(msg.CommandArguments())
+	if err != nil || (len(shares) == 0 && len(members) == 0) {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("split.usage")))
+		return
+	}
+	entry, err := t.Splits.SplitLast(chatID, members, shares)
+	if err != nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("split.failed", userMessage(p, err))+"\n\n"+p.T("split.usage")))
+		return
+	}
+
+	locale := t.locale(chatID)
+	lines := []string{p.T("split.shared", locale.FormatAmount(float64(entry.Amount)), entry.PaidBy)}
+	if entry.Description != "" {
+		lines[0] += p.T("split.for", entry.Description)
+	}
+	for _, s := range entry.Shares {
+		lines = append(lines, fmt.Sprintf("• %s: %s", s.Member, locale.FormatAmount(float64(s.Amount))))
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, strings.Join(lines, "\n")))
+}
+
+// handleBalancesCommand shows the net position of every member
+func (t *TelegramHandler) handleBalancesCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Splits == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("split.disabled")))
+		return
+	}
+	balances, err := t.Splits.Balances(chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "loading balances")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("split.balances_failed")))
+		return
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, formatBalances(p, balances, t.locale(chatID))))
+}
+
+// handleSettleCommand records the transfers that bring every balance to zero
+func (t *TelegramHandler) handleSettleCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Splits == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("split.disabled")))
+		return
+	}
+	transfers, err := t.Splits.Settle(ctx, chatID)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "settling balances")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("split.settle_failed", userMessage(p, err))))
+		return
+	}
+	if len(transfers) == 0 {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("split.settled_up")))
+		return
+	}
+	locale := t.locale(chatID)
+	lines := []string{p.T("split.settlement")}
+	for _, tr := range transfers {
+		lines = append(lines, fmt.Sprintf("• %s → %s: %s", tr.From, tr.To, locale.FormatAmount(float64(tr.Amount))))
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, strings.Join(lines, "\n")))
+}
+
+// rememberForSplit lets /split refer to the transaction just saved in the chat
+func (t *TelegramHandler) rememberForSplit(chatID int64, trx transaction_domain.Transaction) {
+	if t.Splits != nil {
+		t.Splits.Remember(chatID, trx)
+	}
+}
+
+func formatBalances(p i18n.Printer, balances map[string]int64, locale common.Locale) string {
+	if len(balances) == 0 {
+		return p.T("split.settled_up")
+	}
+	members := make([]string, 0, len(balances))
+	for m := range balances {
+		members = append(members, m)
+	}
+	sort.Strings(members)
+	lines := []string{p.T("split.balances")}
+	for _, m := range members {
+		b := balances[m]
+		if b > 0 {
+			lines = append(lines, p.T("split.owed", m, locale.FormatAmount(float64(b))))
+		} else {
+			lines = append(lines, p.T("split.owes", m, locale.FormatAmount(float64(-b))))
+		}
+	}
+	lines = append(lines, "", p.T("split.transfers"))
+	for _, tr := range split_domain.Settle(balances) {
+		lines = append(lines, fmt.Sprintf("• %s → %s: %s", tr.From, tr.To, locale.FormatAmount(float64(tr.Amount))))
+	}
+	return strings.Join(lines, "\n")
+}
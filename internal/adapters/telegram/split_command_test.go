@@ -0,0 +1,89 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestSplitCommand(t *testing.T) {
+	bot := &MockBotAPI{}
+	splits := &MockSplitService{}
+	h := &TelegramHandler{Telebot: bot, Splits: splits}
+
+	h.handleCommand(context.Background(), newCommand(3, "/split user bob carol"))
+	if len(splits.Members) != 3 || splits.Members[1] != "bob" {
+		t.Fatalf("unexpected members: %v", splits.Members)
+	}
+	reply := lastText(t, bot)
+	if !strings.Contains(reply, "Rp 300,000 paid by user for Dinner") || !strings.Contains(reply, "• bob: Rp 100,000") {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, "/split bob=200000 user=100000"))
+	if splits.Shares["bob"] != "200000" || len(splits.Members) != 0 {
+		t.Errorf("custom shares should be passed as options, got %v, %v", splits.Shares, splits.Members)
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, "/split"))
+	if !strings.HasPrefix(lastText(t, bot), "Usage:") {
+		t.Errorf("expected usage, got: %s", lastText(t, bot))
+	}
+}
+
+func TestBalancesAndSettleCommands(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot, Splits: &MockSplitService{}}
+
+	h.handleCommand(context.Background(), newCommand(3, "/balances"))
+	reply := lastText(t, bot)
+	if !strings.Contains(reply, "• bob owes Rp 100,000") || !strings.Contains(reply, "• user is owed Rp 200,000") || !strings.Contains(reply, "• bob → user: Rp 100,000") {
+		t.Errorf("unexpected balances: %s", reply)
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, "/settle"))
+	if reply := lastText(t, bot); !strings.Contains(reply, "Settlement recorded") || !strings.Contains(reply, "• carol → user: Rp 100,000") {
+		t.Errorf("unexpected settlement: %s", reply)
+	}
+	h.handleCommand(context.Background(), newCommand(3, "/settle"))
+	if reply := lastText(t, bot); !strings.Contains(reply, "settled up") {
+		t.Errorf("expected nothing to settle, got: %s", reply)
+	}
+}
+
+func TestSplitCommands_UseChatLocale(t *testing.T) {
+	bot := &MockBotAPI{}
+	settings := &MockSettingsService{}
+	settings.SetLocale(3, "id-ID")
+	h := &TelegramHandler{Telebot: bot, Splits: &MockSplitService{}, Settings: settings}
+
+	h.handleCommand(context.Background(), newCommand(3, "/split user bob carol"))
+	if reply := lastText(t, bot); !strings.Contains(reply, "Rp 300.000") || !strings.Contains(reply, "• bob: Rp 100.000") {
+		t.Errorf("expected amounts in the chat's locale, got: %s", reply)
+	}
+	h.handleCommand(context.Background(), newCommand(3, "/balances"))
+	if reply := lastText(t, bot); !strings.Contains(reply, "Rp 200.000") || !strings.Contains(reply, "• bob → user: Rp 100.000") {
+		t.Errorf("expected balances in the chat's locale, got: %s", reply)
+	}
+	h.handleCommand(context.Background(), newCommand(3, "/settle"))
+	if reply := lastText(t, bot); !strings.Contains(reply, "• carol → user: Rp 100.000") {
+		t.Errorf("expected the settlement in the chat's locale, got: %s", reply)
+	}
+}
+
+func TestHandleMessage_RemembersForSplit(t *testing.T) {
+	bot := &MockBotAPI{}
+	splits := &MockSplitService{}
+	h := &TelegramHandler{Telebot: bot, TransactionService: &MockTransactionService{}, Splits: splits}
+
+	h.handleMessage(context.Background(), bot, &tgbotapi.Message{
+		Text: "dinner 300000",
+		From: &tgbotapi.User{UserName: "user"},
+		Chat: &tgbotapi.Chat{ID: 3},
+	})
+	if len(splits.Remembered) != 1 {
+		t.Errorf("saved transaction should be remembered for /split, got %+v", splits.Remembered)
+	}
+}
@@ -0,0 +1,58 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/i18n"
+	"money-tracker-bot/internal/resilience"
+	"strings"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleStatusCommand shows admins the circuit breaker state of every
+// dependency
+func (t *TelegramHandler) handleStatusCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if !t.Admins[chatID] {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("status.admins_only")))
+		return
+	}
+	if len(t.Breakers) == 0 {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("status.none")))
+		return
+	}
+	lines := []string{p.T("status.title")}
+	for _, b := range t.Breakers {
+		lines = append(lines, formatBreaker(p, b.Snapshot()))
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, strings.Join(lines, "\n")))
+}
+
+// formatBreaker renders a breaker as "gemini: open since 09:00 UTC (5 failures)"
+// followed by its last error and counters
+func formatBreaker(p i18n.Printer, s resilience.Snapshot) string {
+	icon := "✅"
+	switch s.State {
+	case resilience.Open:
+		icon = "🔴"
+	case resilience.HalfOpen:
+		icon = "🟡"
+	}
+	line := fmt.Sprintf("%s %s: %s", icon, s.Name, p.T("status.state."+s.State.String()))
+	if s.State != resilience.Closed {
+		line += p.T("status.since", s.OpenedAt.Format(time.Kitchen))
+	}
+	if s.Failures > 0 {
+		line += p.T("status.failures", s.Failures)
+	}
+	if s.Opens > 0 {
+		line += p.T("status.opens", s.Opens, s.Rejected)
+	}
+	if s.LastError != "" && s.State != resilience.Closed {
+		line += p.T("status.last_error", s.LastError)
+	}
+	return line
+}
@@ -0,0 +1,41 @@
+package telegram
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/resilience"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatusCommand(t *testing.T) {
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	gemini := resilience.NewBreaker("gemini", 1, time.Minute, clock)
+	gemini.Allow()
+	gemini.Record(errors.NewGeminiTimeoutError("request timed out", nil))
+	gemini.Allow()
+	sheets := resilience.NewBreaker("sheets", 3, time.Minute, clock)
+
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot, Admins: map[int64]bool{1: true}, Breakers: []*resilience.Breaker{gemini, sheets}}
+
+	h.handleCommand(context.Background(), newCommand(1, "/status"))
+	reply := lastText(t, bot)
+	for _, want := range []string{
+		"🔴 gemini: open since 9:00AM (1 failures in a row)",
+		"opened 1 times, 1 calls skipped",
+		"last error: [TIMEOUT_ERROR] request timed out",
+		"✅ sheets: closed",
+	} {
+		if !strings.Contains(reply, want) {
+			t.Errorf("expected %q in reply:\n%s", want, reply)
+		}
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, "/status"))
+	if reply := lastText(t, bot); !strings.Contains(reply, "Only admins") {
+		t.Errorf("expected non-admins to be refused, got %q", reply)
+	}
+}
@@ -0,0 +1,110 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/common"
+	usage_domain "money-tracker-bot/internal/domain/usage"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"sort"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleUsageCommand reports the AI tokens and estimated cost of the chat.
+// Admin chats also see today's usage of every chat and can pass a chat ID
+// to see that chat's report.
+func (t *TelegramHandler) handleUsageCommand(ctx context.Context, msg *tgbotapi.Message) {
+	chatID := msg.Chat.ID
+	p := t.printer(ctx, chatID)
+	if t.Usage == nil {
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("usage.disabled")))
+		return
+	}
+	admin := t.Admins[chatID]
+	target := chatID
+	if args := strings.TrimSpace(msg.CommandArguments()); args != "" {
+		id, err := strconv.ParseInt(args, 10, 64)
+		if !admin || err != nil {
+			t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("usage.usage")))
+			return
+		}
+		target = id
+	}
+
+	report, err := t.Usage.Report(target)
+	if err != nil {
+		errors.HandleErrorContext(ctx, err, "loading AI usage")
+		t.Telebot.Send(tgbotapi.NewMessage(chatID, p.T("usage.load_failed")))
+		return
+	}
+	prices := t.Usage.Pricing()
+	text := formatUsageReport(p, report, prices)
+	if target != chatID {
+		text = p.T("usage.chat", target, text)
+	}
+	if admin && target == chatID {
+		today, err := t.Usage.Today()
+		if err != nil {
+			errors.HandleErrorContext(ctx, err, "loading AI usage of all chats")
+		} else {
+			text += "\n\n" + formatUsageByChat(p, today, prices)
+		}
+	}
+	t.Telebot.Send(tgbotapi.NewMessage(chatID, text))
+}
+
+func formatUsageReport(p i18n.Printer, r usage_domain.Report, prices usage_domain.Pricing) string {
+	lines := []string{p.T("usage.title"), p.T("usage.today", formatTokens(p, r.Today.Sum(), prices))}
+	for _, kind := range r.Today.Kinds() {
+		lines = append(lines, fmt.Sprintf("  %s: %s", kind, formatTokens(p, r.Today[kind], prices)))
+	}
+	lines = append(lines, p.T("usage.week", formatTokens(p, r.Week.Sum(), prices)))
+	if r.Quota > 0 {
+		lines = append(lines, p.T("usage.quota",
+			common.FormatThousands(int64(r.Remaining())), common.FormatThousands(int64(r.Quota))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatUsageByChat lists today's usage of every chat, biggest spender first
+func formatUsageByChat(p i18n.Printer, byChat map[int64]usage_domain.Totals, prices usage_domain.Pricing) string {
+	if len(byChat) == 0 {
+		return p.T("usage.no_calls")
+	}
+	chats := make([]int64, 0, len(byChat))
+	var total usage_domain.Tokens
+	for chatID, totals := range byChat {
+		chats = append(chats, chatID)
+		total = total.Add(totals.Sum())
+	}
+	sort.Slice(chats, func(i, j int) bool {
+		a, b := byChat[chats[i]].Sum().Total(), byChat[chats[j]].Sum().Total()
+		if a != b {
+			return a > b
+		}
+		return chats[i] < chats[j]
+	})
+	lines := []string{p.T("usage.all_chats")}
+	for _, chatID := range chats {
+		name := strconv.FormatInt(chatID, 10)
+		if chatID == 0 {
+			name = p.T("usage.scheduled")
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", name, formatTokens(p, byChat[chatID].Sum(), prices)))
+	}
+	lines = append(lines, p.T("usage.total", formatTokens(p, total, prices)))
+	return strings.Join(lines, "\n")
+}
+
+// formatTokens renders usage as "3 calls · 2,030 tokens (~$0.0003)"
+func formatTokens(p i18n.Printer, t usage_domain.Tokens, prices usage_domain.Pricing) string {
+	calls := p.T("usage.calls")
+	if t.Requests == 1 {
+		calls = p.T("usage.call")
+	}
+	return p.T("usage.tokens", t.Requests, calls, common.FormatThousands(int64(t.Total())), prices.Cost(t))
+}
@@ -0,0 +1,80 @@
+package telegram
+
+import (
+	"context"
+	usage_domain "money-tracker-bot/internal/domain/usage"
+	"strings"
+	"testing"
+)
+
+func newUsageHandler(bot *MockBotAPI) *TelegramHandler {
+	today := usage_domain.Totals{
+		usage_domain.KindImage: {Requests: 1, Prompt: 1200, Response: 150},
+		usage_domain.KindText:  {Requests: 2, Prompt: 600, Response: 80},
+	}
+	return &TelegramHandler{
+		Telebot: bot,
+		Usage: &MockUsageService{
+			Reports: map[int64]usage_domain.Report{
+				3: {Today: today, Week: today, Quota: 5000},
+				9: {Today: usage_domain.Totals{usage_domain.KindText: {Requests: 1, Prompt: 100}}},
+			},
+			Chats: map[int64]usage_domain.Totals{
+				3: today,
+				9: {usage_domain.KindText: {Requests: 1, Prompt: 100}},
+			},
+			Prices: usage_domain.Pricing{PromptPerMillion: 0.1, ResponsePerMillion: 0.4},
+		},
+		Admins: map[int64]bool{1: true},
+	}
+}
+
+func TestUsageCommand(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := newUsageHandler(bot)
+
+	h.handleCommand(context.Background(), newCommand(3, "/usage"))
+	reply := lastText(t, bot)
+	for _, want := range []string{
+		"Today: 3 calls · 2,030 tokens (~$0.0003)",
+		"  image: 1 call · 1,350 tokens",
+		"Quota: 2,970 of 5,000 tokens left today",
+	} {
+		if !strings.Contains(reply, want) {
+			t.Errorf("expected %q in reply:\n%s", want, reply)
+		}
+	}
+	if strings.Contains(reply, "All chats") {
+		t.Errorf("only admins may see other chats:\n%s", reply)
+	}
+
+	h.handleCommand(context.Background(), newCommand(3, "/usage 9"))
+	if !strings.HasPrefix(lastText(t, bot), "Usage:") {
+		t.Errorf("non-admins should not look up other chats, got: %s", lastText(t, bot))
+	}
+}
+
+func TestUsageCommand_Admin(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := newUsageHandler(bot)
+
+	h.handleCommand(context.Background(), newCommand(1, "/usage"))
+	reply := lastText(t, bot)
+	if !strings.Contains(reply, "All chats today:\n  3: 3 calls") || !strings.Contains(reply, "Total: 4 calls · 2,130 tokens") {
+		t.Errorf("unexpected admin overview:\n%s", reply)
+	}
+
+	h.handleCommand(context.Background(), newCommand(1, "/usage 9"))
+	if reply := lastText(t, bot); !strings.HasPrefix(reply, "Chat 9\n") || !strings.Contains(reply, "Today: 1 call · 100 tokens") {
+		t.Errorf("unexpected report of chat 9:\n%s", reply)
+	}
+}
+
+func TestUsageCommand_Disabled(t *testing.T) {
+	bot := &MockBotAPI{}
+	h := &TelegramHandler{Telebot: bot}
+	h.handleCommand(context.Background(), newCommand(3, "/usage"))
+	if !strings.Contains(lastText(t, bot), "not enabled") {
+		t.Errorf("unexpected reply: %s", lastText(t, bot))
+	}
+}
@@ -0,0 +1,25 @@
+package common
+
+import "strings"
+
+// BuildAdvicePrompt asks the AI for saving suggestions based on an anonymized
+// spending summary, one fact per line, to be answered in language, such as
+// "Bahasa Indonesia"; "" leaves the answer in English.
+// Input: ["2025-01: Rp 3,500,000 (Groceries Rp 1,200,000)", "Budget Groceries: …"], ""
+// Output: a prompt listing the lines and the rules for the answer
+func BuildAdvicePrompt(summary []string, language string) string {
+	var b strings.Builder
+	b.WriteString("You are a personal finance coach for a household in Indonesia. ")
+	b.WriteString("Here is their spending of the last three months, with their monthly budgets and the category trends between the last two complete months:\n\n")
+	for _, line := range summary {
+		b.WriteString("- " + line + "\n")
+	}
+	b.WriteString("\nGive 3 to 5 concrete saving suggestions based on these numbers. ")
+	b.WriteString("Name the category and an amount to save per month for each one, and start with the biggest saving. ")
+	b.WriteString("Mention overspent budgets first. ")
+	b.WriteString("Answer in plain text without Markdown, one suggestion per line starting with \"• \", in at most 120 words.")
+	if language != "" {
+		b.WriteString(" Write the answer in " + language + ".")
+	}
+	return b.String()
+}
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildAdvicePrompt(t *testing.T) {
+	prompt := BuildAdvicePrompt([]string{
+		"2025-01: Rp 1,200,000 (Groceries Rp 1,000,000, Eating Out Rp 200,000)",
+		"Budget Groceries: Rp 1,500,000 per month, Rp 300,000 left this month",
+	}, "")
+	for _, want := range []string{
+		"- 2025-01: Rp 1,200,000 (Groceries Rp 1,000,000, Eating Out Rp 200,000)\n",
+		"- Budget Groceries: Rp 1,500,000 per month, Rp 300,000 left this month\n",
+		"concrete saving suggestions",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected %q in prompt:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestBuildAdvicePrompt_Language(t *testing.T) {
+	prompt := BuildAdvicePrompt([]string{"2025-01: Rp 1,200,000"}, "Bahasa Indonesia")
+	if !strings.HasSuffix(prompt, " Write the answer in Bahasa Indonesia.") {
+		t.Errorf("expected the answer language at the end of the prompt:\n%s", prompt)
+	}
+	if strings.Contains(BuildAdvicePrompt(nil, ""), "Write the answer in") {
+		t.Error("expected no language instruction for English")
+	}
+}
@@ -0,0 +1,17 @@
+package common
+
+import "context"
+
+type cacheBypassKey struct{}
+
+// WithCacheBypass returns a context whose AI extractions skip cached answers,
+// e.g. for /reparse. The fresh answer still replaces the cached one.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+// CacheBypassed reports whether WithCacheBypass was used.
+func CacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
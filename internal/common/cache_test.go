@@ -0,0 +1,15 @@
+package common
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCacheBypass(t *testing.T) {
+	if CacheBypassed(context.Background()) {
+		t.Error("expected no bypass in an empty context")
+	}
+	if !CacheBypassed(WithCacheBypass(context.Background())) {
+		t.Error("expected bypass to be set")
+	}
+}
@@ -0,0 +1,32 @@
+package common
+
+import "time"
+
+// Clock abstracts the current time so schedulers and date-sensitive logic can
+// be tested deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the production Clock backed by time.Now.
+type SystemClock struct{}
+
+// Now returns the current wall-clock time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock always returns the same instant. It is intended for tests.
+type FixedClock struct {
+	Time time.Time
+}
+
+// Now returns the fixed instant.
+func (f *FixedClock) Now() time.Time {
+	return f.Time
+}
+
+// Advance moves the fixed instant forward by d.
+func (f *FixedClock) Advance(d time.Duration) {
+	f.Time = f.Time.Add(d)
+}
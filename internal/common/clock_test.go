@@ -0,0 +1,25 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFixedClock_Advance(t *testing.T) {
+	start := time.Date(2025, 7, 10, 21, 0, 0, 0, time.UTC)
+	c := &FixedClock{Time: start}
+	if !c.Now().Equal(start) {
+		t.Errorf("expected %v, got %v", start, c.Now())
+	}
+	c.Advance(time.Hour)
+	if !c.Now().Equal(start.Add(time.Hour)) {
+		t.Errorf("expected clock to advance by an hour, got %v", c.Now())
+	}
+}
+
+func TestSystemClock_Now(t *testing.T) {
+	var c Clock = SystemClock{}
+	if time.Since(c.Now()) > time.Minute {
+		t.Error("system clock should be close to time.Now")
+	}
+}
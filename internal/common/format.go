@@ -0,0 +1,26 @@
+package common
+
+import "fmt"
+
+// FormatRupiah formats a number as Indonesian Rupiah with thousands separators.
+// Input: 1500000
+// Output: "Rp 1,500,000"
+func FormatRupiah(amount float64) string {
+	if amount < 0 {
+		return "-Rp " + FormatThousands(int64(-amount))
+	}
+	return "Rp " + FormatThousands(int64(amount))
+}
+
+// FormatThousands formats an integer with thousands separator
+func FormatThousands(n int64) string {
+	s := fmt.Sprintf("%d", n)
+	var out []byte
+	for i, c := range s {
+		if i != 0 && c != '-' && s[i-1] != '-' && (len(s)-i)%3 == 0 {
+			out = append(out, ',')
+		}
+		out = append(out, byte(c))
+	}
+	return string(out)
+}
@@ -0,0 +1,29 @@
+package common
+
+import "testing"
+
+func TestFormatRupiah(t *testing.T) {
+	testCases := []struct {
+		input    float64
+		expected string
+	}{
+		{input: 0, expected: "Rp 0"},
+		{input: 950, expected: "Rp 950"},
+		{input: 1500000, expected: "Rp 1,500,000"},
+		{input: -25000, expected: "-Rp 25,000"},
+	}
+	for _, tc := range testCases {
+		if got := FormatRupiah(tc.input); got != tc.expected {
+			t.Errorf("FormatRupiah(%v): expected %q, got %q", tc.input, tc.expected, got)
+		}
+	}
+}
+
+func TestFormatThousands(t *testing.T) {
+	if got := FormatThousands(-125000); got != "-125,000" {
+		t.Errorf("expected -125,000, got %s", got)
+	}
+	if got := FormatThousands(100); got != "100" {
+		t.Errorf("expected 100, got %s", got)
+	}
+}
@@ -0,0 +1,148 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Locale formats the amounts and dates of replies and reports for a tenant
+type Locale struct {
+	// Tag is the locale name, such as "id-ID"
+	Tag string
+	// Thousands separates groups of three digits in amounts
+	Thousands string
+	// DateLayout formats dates, in Go's reference time layout
+	DateLayout string
+	// Currency is the ISO 4217 code amounts are written in, "" for IDR
+	Currency string
+	// Decimals is the number of minor units of Currency
+	Decimals int
+}
+
+// DefaultLocale is used by tenants that have not chosen one
+const DefaultLocale = "en"
+
+// locales are the locales a tenant can choose from
+var locales = []Locale{
+	{Tag: "en", Thousands: ",", DateLayout: "2006-01-02"},
+	{Tag: "en-US", Thousands: ",", DateLayout: "Jan 2, 2006"},
+	{Tag: "en-GB", Thousands: ",", DateLayout: "2 Jan 2006"},
+	{Tag: "id-ID", Thousands: ".", DateLayout: "02/01/2006"},
+}
+
+// TenantLocales resolves the locale each tenant has chosen
+type TenantLocales interface {
+	Locale(chatID int64) Locale
+}
+
+// TenantLocale returns the locale locales gives chatID, or DefaultLocale
+// when locales is nil
+func TenantLocale(locales TenantLocales, chatID int64) Locale {
+	if locales == nil {
+		return LocaleOf(DefaultLocale)
+	}
+	return locales.Locale(chatID)
+}
+
+// Locales returns the tags of the supported locales
+func Locales() []string {
+	tags := make([]string, len(locales))
+	for i, l := range locales {
+		tags[i] = l.Tag
+	}
+	return tags
+}
+
+// ParseLocale returns the supported locale named by tag, ignoring case and
+// accepting "_" for "-"
+// Input: "id_id"
+// Output: Locale{Tag: "id-ID", ...}, true
+func ParseLocale(tag string) (Locale, bool) {
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	for _, l := range locales {
+		if strings.EqualFold(l.Tag, tag) {
+			return l, true
+		}
+	}
+	return Locale{}, false
+}
+
+// LocaleOf returns the locale named by tag, or DefaultLocale when it is not supported
+func LocaleOf(tag string) Locale {
+	if l, ok := ParseLocale(tag); ok {
+		return l
+	}
+	l, _ := ParseLocale(DefaultLocale)
+	return l
+}
+
+// WithCurrency returns the locale writing amounts in the currency code, with
+// decimals minor units
+func (l Locale) WithCurrency(code string, decimals int) Locale {
+	l.Currency, l.Decimals = code, decimals
+	return l
+}
+
+// FormatAmount formats amount in the locale's currency with its separators,
+// rounded to the currency's minor units. Rupiah keep their "Rp" symbol,
+// other currencies are prefixed with their code.
+// Input: 1500000 in id-ID, 1234.5 in en with USD
+// Output: "Rp 1.500.000", "USD 1,234.50"
+func (l Locale) FormatAmount(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	digits := strconv.FormatFloat(amount, 'f', l.Decimals, 64)
+	whole, fraction, _ := strings.Cut(digits, ".")
+	n, _ := strconv.ParseInt(whole, 10, 64)
+	text := strings.ReplaceAll(FormatThousands(n), ",", l.thousands())
+	if fraction != "" {
+		text += l.decimal() + fraction
+	}
+	symbol := "Rp"
+	if l.Currency != "" && l.Currency != "IDR" {
+		symbol = l.Currency
+	}
+	return sign + symbol + " " + text
+}
+
+// FormatDate formats a YYYY-MM-DD date with the locale's layout. Other text
+// is returned unchanged.
+// Input: "2025-07-13" in en-GB
+// Output: "13 Jul 2025"
+func (l Locale) FormatDate(date string) string {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil || l.DateLayout == "" {
+		return date
+	}
+	return d.Format(l.DateLayout)
+}
+
+// FormatTime formats a moment with the locale's date layout and a 24-hour
+// clock, in the moment's own timezone.
+// Input: 2025-07-13 09:30 in id-ID
+// Output: "13/07/2025 09:30"
+func (l Locale) FormatTime(t time.Time) string {
+	layout := l.DateLayout
+	if layout == "" {
+		layout = "2006-01-02"
+	}
+	return t.Format(layout + " 15:04")
+}
+
+// decimal separates the minor units: a comma where dots group thousands
+func (l Locale) decimal() string {
+	if l.thousands() == "." {
+		return ","
+	}
+	return "."
+}
+
+func (l Locale) thousands() string {
+	if l.Thousands == "" {
+		return ","
+	}
+	return l.Thousands
+}
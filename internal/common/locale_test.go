@@ -0,0 +1,73 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLocale(t *testing.T) {
+	if l, ok := ParseLocale(" id_id "); !ok || l.Tag != "id-ID" {
+		t.Errorf("expected id-ID, got %+v, %v", l, ok)
+	}
+	if _, ok := ParseLocale("fr-FR"); ok {
+		t.Error("expected fr-FR to be unsupported")
+	}
+	if l := LocaleOf("fr-FR"); l.Tag != DefaultLocale {
+		t.Errorf("expected the default locale, got %+v", l)
+	}
+	if len(Locales()) != 4 {
+		t.Errorf("unexpected locales %v", Locales())
+	}
+}
+
+func TestLocale_Format(t *testing.T) {
+	tests := []struct {
+		tag, amount, date string
+	}{
+		{"en", "Rp 1,500,000", "2025-07-13"},
+		{"en-US", "Rp 1,500,000", "Jul 13, 2025"},
+		{"en-GB", "Rp 1,500,000", "13 Jul 2025"},
+		{"id-ID", "-Rp 1.500.000", "13/07/2025"},
+	}
+	for _, tt := range tests {
+		l := LocaleOf(tt.tag)
+		amount := 1500000.0
+		if tt.tag == "id-ID" {
+			amount = -amount
+		}
+		if got := l.FormatAmount(amount); got != tt.amount {
+			t.Errorf("%s: expected %q, got %q", tt.tag, tt.amount, got)
+		}
+		if got := l.FormatDate("2025-07-13"); got != tt.date {
+			t.Errorf("%s: expected %q, got %q", tt.tag, tt.date, got)
+		}
+	}
+	if got := LocaleOf("en").WithCurrency("USD", 2).FormatAmount(1234.5); got != "USD 1,234.50" {
+		t.Errorf("expected dollars with cents, got %q", got)
+	}
+	if got := LocaleOf("id-ID").WithCurrency("EUR", 2).FormatAmount(-0.126); got != "-EUR 0,13" {
+		t.Errorf("expected a decimal comma where dots group thousands, got %q", got)
+	}
+	if got := LocaleOf("id-ID").WithCurrency("IDR", 0).FormatAmount(25000.4); got != "Rp 25.000" {
+		t.Errorf("expected whole rupiah, got %q", got)
+	}
+	if got := LocaleOf("en-GB").FormatTime(time.Date(2025, 7, 13, 9, 30, 0, 0, time.UTC)); got != "13 Jul 2025 09:30" {
+		t.Errorf("expected the date layout and a 24-hour clock, got %q", got)
+	}
+	if got := LocaleOf("id-ID").FormatDate("next week"); got != "next week" {
+		t.Errorf("expected text that is not a date unchanged, got %q", got)
+	}
+}
+
+type localeStub map[int64]string
+
+func (l localeStub) Locale(chatID int64) Locale { return LocaleOf(l[chatID]) }
+
+func TestTenantLocale(t *testing.T) {
+	if l := TenantLocale(nil, 1); l.Tag != DefaultLocale {
+		t.Errorf("expected the default locale without locales, got %+v", l)
+	}
+	if l := TenantLocale(localeStub{1: "id-ID"}, 1); l.Tag != "id-ID" {
+		t.Errorf("expected the chat's locale, got %+v", l)
+	}
+}
@@ -1,11 +1,17 @@
 package common
 
 import (
-	"fmt"
+	"context"
+	"embed"
+	"money-tracker-bot/internal/errors"
+	"sort"
+	"strconv"
 	"strings"
+	"text/template"
 )
 
-// TransactionCategoryList is the static list of allowed categories for transactions
+// TransactionCategoryList is the default list of categories, used for
+// tenants that have not configured their own
 var TransactionCategoryList = []string{
 	"Groceries",
 	"Utilities",
@@ -33,79 +39,134 @@ var SourceAccountList = []string{
 	"CASH",
 }
 
+// PromptVersion is the prompt template used by default. Prompt changes go in
+// a new template under prompts/ and a new version here, so cached AI answers
+// to the old prompt are not reused and saved transactions show which prompt
+// produced them.
+const PromptVersion = "v4"
+
 // PromptParams holds parameters for building the prompt
 // If IsImage is true, FileID must be set. If false, Message and CurrentDate must be set.
+// Categories describes the tenant categories one per line; when empty the
+// default TransactionCategoryList is used. Examples are merchant→category
+// lines learned from the tenant's corrections. Version selects the template,
+// PromptVersion when empty.
 type PromptParams struct {
+	Version     string
 	IsImage     bool
 	FileID      string
 	Message     string
 	CurrentDate string
+	Categories  []string
+	Examples    []string
 }
 
-// BuildPrompt builds the prompt for Gemini based on the input params
-func BuildPrompt(params PromptParams) string {
-	categoryStr := strings.Join(TransactionCategoryList, " / ")
-	sourceAccountStr := strings.Join(SourceAccountList, " / ")
-
-	fields := fmt.Sprintf(`Fields:
-  - title (summary of the transaction notes)
-  - transaction_date (format always YYYY-MM-DD)
-  - amount (ALWAYS use positive numbers in rupiah. Format: 1,000,000 for 1 million, 100,000 for 100k. Never use negative numbers, the transaction type is determined by context words like "spent", "bought", "earned", "received")
-  - notes (details of the transaction, containing items bought)
-  - category (%s)`,
-		categoryStr)
-
-	if params.IsImage {
-		fields += fmt.Sprintf(`
-  - destination_number
-  - source_account (only %s)
-  - file_id %s`, sourceAccountStr, params.FileID)
-		fields += `
-  - warning_message this is up to you. please generate the messagae to tell them to save money for living`
-	} else {
-		fields += `
-  - file_id should be empty`
-		fields += `
-  - warning_message - this is up to you. please generate the messagae to tell them to save money for living`
-	}
+type promptCategoriesKey struct{}
 
-	var inputDesc string
-	if params.IsImage {
-		inputDesc = "from the image"
-	} else {
-		inputDesc = fmt.Sprintf("from the following message: %s", params.Message)
-	}
+// WithPromptCategories returns a context carrying the category lines the AI
+// adapters should put in the prompt for the current tenant.
+func WithPromptCategories(ctx context.Context, lines []string) context.Context {
+	return context.WithValue(ctx, promptCategoriesKey{}, lines)
+}
+
+// PromptCategories returns the lines stored by WithPromptCategories, or nil.
+func PromptCategories(ctx context.Context) []string {
+	lines, _ := ctx.Value(promptCategoriesKey{}).([]string)
+	return lines
+}
+
+type promptExamplesKey struct{}
+
+// WithPromptExamples returns a context carrying the learned merchant→category
+// lines the AI adapters should put in the prompt for the current tenant.
+func WithPromptExamples(ctx context.Context, lines []string) context.Context {
+	return context.WithValue(ctx, promptExamplesKey{}, lines)
+}
+
+// PromptExamples returns the lines stored by WithPromptExamples, or nil.
+func PromptExamples(ctx context.Context) []string {
+	lines, _ := ctx.Value(promptExamplesKey{}).([]string)
+	return lines
+}
 
-	var dateLine string
-	if params.IsImage {
-		dateLine = ""
-	} else {
-		dateLine = fmt.Sprintf("  - transaction_date should be %s (format always YYYY-MM-DD)\n", params.CurrentDate)
+// promptTemplates holds one template per prompt version, named <version>.tmpl.
+// Old versions are kept so the replay harness can compare them.
+//
+//go:embed prompts/*.tmpl
+var promptTemplates embed.FS
+
+var prompts = template.Must(template.New("prompts").
+	Funcs(template.FuncMap{"join": strings.Join}).
+	ParseFS(promptTemplates, "prompts/*.tmpl"))
+
+// PromptVersions lists the available prompt versions, oldest first.
+// Output: ["v1", "v2", "v3", "v4"]
+func PromptVersions() []string {
+	var versions []string
+	for _, t := range prompts.Templates() {
+		if version, ok := strings.CutSuffix(t.Name(), ".tmpl"); ok {
+			versions = append(versions, version)
+		}
 	}
+	sortVersions(versions)
+	return versions
+}
 
-	exampleFileID := params.FileID
-	if !params.IsImage {
-		exampleFileID = ""
+// sortVersions orders versions by their number, so v10 comes after v2
+func sortVersions(versions []string) {
+	sort.Slice(versions, func(i, j int) bool {
+		ni, nj := versionNumber(versions[i]), versionNumber(versions[j])
+		if ni != nj {
+			return ni < nj
+		}
+		return versions[i] < versions[j]
+	})
+}
+
+// versionNumber returns the number of a version such as "v12", or 0
+func versionNumber(version string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	return n
+}
+
+// BuildPrompt renders the prompt template of params.Version, or of
+// PromptVersion when empty. An unknown version is a config error.
+func BuildPrompt(params PromptParams) (string, error) {
+	version := params.Version
+	if version == "" {
+		version = PromptVersion
+	}
+	tmpl := prompts.Lookup(version + ".tmpl")
+	if tmpl == nil {
+		return "", errors.NewConfigError("unknown prompt version", nil).
+			WithContext("version", version).
+			WithContext("available", strings.Join(PromptVersions(), ", ")).
+			WithComponent("prompt")
+	}
+	data := struct {
+		PromptParams
+		DefaultCategories []string
+		SourceAccounts    []string
+	}{params, TransactionCategoryList, SourceAccountList}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", errors.NewConfigError("failed to render prompt", err).
+			WithContext("version", version).
+			WithComponent("prompt")
 	}
+	// Template files end with a newline the prompt does not need
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
 
-	prompt := fmt.Sprintf(`Please extract the following data %s and return it as valid JSON.
-
-%s
-%sIMPORTANT:
-Respond ONLY with raw JSON.
-No explanation, no formatting, no code blocks.
-
-Example:
-{
-  "title": "Spent on Lunch at ABC Cafe",
-  "transaction_date": "2025-03-30",
-  "amount": "150,000",
-  "notes": "Lunch payment at ABC cafe - always use positive amounts regardless of whether it's spending or earning",
-  "destination_number": "0524012911",
-  "source_account": "Gopay",
-  "category": "Eating Out",
-  "file_id": "%s"
-}`,
-		inputDesc, fields, dateLine, exampleFileID)
-	return prompt
+// MatchCategory finds the category in TransactionCategoryList matching name
+// case-insensitively and returns its canonical spelling.
+// Input: "rent house"
+// Output: "Rent House", true
+func MatchCategory(name string) (string, bool) {
+	for _, c := range TransactionCategoryList {
+		if strings.EqualFold(c, strings.TrimSpace(name)) {
+			return c, true
+		}
+	}
+	return "", false
 }
@@ -1,16 +1,26 @@
 package common
 
 import (
+	"context"
 	"strings"
 	"testing"
 )
 
+func mustBuildPrompt(t *testing.T, params PromptParams) string {
+	t.Helper()
+	prompt, err := BuildPrompt(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return prompt
+}
+
 func TestBuildPrompt_Image(t *testing.T) {
 	params := PromptParams{
 		IsImage: true,
 		FileID:  "testfile.jpg",
 	}
-	prompt := BuildPrompt(params)
+	prompt := mustBuildPrompt(t, params)
 
 	if !strings.Contains(prompt, "from the image") {
 		t.Errorf("Prompt should mention 'from the image'")
@@ -32,7 +42,7 @@ func TestBuildPrompt_Text(t *testing.T) {
 		Message:     "Transfer 100k to Budi",
 		CurrentDate: "2025-07-10",
 	}
-	prompt := BuildPrompt(params)
+	prompt := mustBuildPrompt(t, params)
 
 	if !strings.Contains(prompt, "from the following message: Transfer 100k to Budi") {
 		t.Errorf("Prompt should mention the message")
@@ -47,3 +57,84 @@ func TestBuildPrompt_Text(t *testing.T) {
 		t.Errorf("Prompt should include category list")
 	}
 }
+
+func TestMatchCategory(t *testing.T) {
+	if got, ok := MatchCategory(" eating out "); !ok || got != "Eating Out" {
+		t.Errorf("expected Eating Out, got %q, %v", got, ok)
+	}
+	if _, ok := MatchCategory("Pets"); ok {
+		t.Error("unknown category should not match")
+	}
+}
+
+func TestBuildPrompt_TenantCategories(t *testing.T) {
+	prompt := mustBuildPrompt(t, PromptParams{
+		Message:     "kopi 25k",
+		CurrentDate: "2025-07-10",
+		Categories:  []string{"Groceries", "Eating Out › Coffee (also: kopi) – cafes"},
+	})
+	if !strings.Contains(prompt, "      - Eating Out › Coffee (also: kopi) – cafes") {
+		t.Errorf("tenant categories should be listed, got:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "Rent House") {
+		t.Error("default categories should not be used when tenant categories are given")
+	}
+}
+
+func TestPromptCategories(t *testing.T) {
+	if lines := PromptCategories(context.Background()); lines != nil {
+		t.Errorf("expected no categories, got %v", lines)
+	}
+	ctx := WithPromptCategories(context.Background(), []string{"Pets"})
+	if lines := PromptCategories(ctx); len(lines) != 1 || lines[0] != "Pets" {
+		t.Errorf("unexpected categories: %v", lines)
+	}
+}
+
+func TestBuildPrompt_Examples(t *testing.T) {
+	prompt := mustBuildPrompt(t, PromptParams{
+		Message:     "superindo 150k",
+		CurrentDate: "2025-07-10",
+		Examples:    []string{`"Superindo" → Groceries`},
+	})
+	if !strings.Contains(prompt, "corrected these merchants") || !strings.Contains(prompt, "      - \"Superindo\" → Groceries\n") {
+		t.Errorf("learned examples should be listed, got:\n%s", prompt)
+	}
+	if strings.Contains(mustBuildPrompt(t, PromptParams{Message: "x"}), "corrected these merchants") {
+		t.Error("no example section expected without examples")
+	}
+}
+
+func TestPromptExamples(t *testing.T) {
+	if lines := PromptExamples(context.Background()); lines != nil {
+		t.Errorf("expected no examples, got %v", lines)
+	}
+	ctx := WithPromptExamples(context.Background(), []string{"x"})
+	if lines := PromptExamples(ctx); len(lines) != 1 {
+		t.Errorf("unexpected examples: %v", lines)
+	}
+}
+
+func TestSortVersions(t *testing.T) {
+	versions := []string{"v10", "v2", "v1", "v9"}
+	sortVersions(versions)
+	if strings.Join(versions, " ") != "v1 v2 v9 v10" {
+		t.Errorf("expected numeric order, got %v", versions)
+	}
+}
+
+func TestBuildPrompt_Versions(t *testing.T) {
+	versions := PromptVersions()
+	if len(versions) == 0 || versions[len(versions)-1] != PromptVersion {
+		t.Fatalf("expected %s to be the latest version, got %v", PromptVersion, versions)
+	}
+	for _, v := range versions {
+		prompt := mustBuildPrompt(t, PromptParams{Version: v, Message: "kopi 18k", CurrentDate: "2025-07-10"})
+		if !strings.Contains(prompt, "kopi 18k") || strings.HasSuffix(prompt, "\n") {
+			t.Errorf("unexpected %s prompt:\n%s", v, prompt)
+		}
+	}
+	if _, err := BuildPrompt(PromptParams{Version: "v0"}); err == nil {
+		t.Error("expected error for an unknown version")
+	}
+}
@@ -0,0 +1,17 @@
+package common
+
+import "context"
+
+type tenantKey struct{}
+
+// WithTenant returns a context carrying the tenant (the Telegram chat ID)
+// a request is made for, so per-tenant settings reach deeper layers.
+func WithTenant(ctx context.Context, chatID int64) context.Context {
+	return context.WithValue(ctx, tenantKey{}, chatID)
+}
+
+// TenantFrom returns the tenant stored by WithTenant.
+func TenantFrom(ctx context.Context) (int64, bool) {
+	chatID, ok := ctx.Value(tenantKey{}).(int64)
+	return chatID, ok
+}
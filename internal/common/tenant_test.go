@@ -0,0 +1,16 @@
+package common
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTenant(t *testing.T) {
+	if _, ok := TenantFrom(context.Background()); ok {
+		t.Error("expected no tenant in an empty context")
+	}
+	chatID, ok := TenantFrom(WithTenant(context.Background(), -100123))
+	if !ok || chatID != -100123 {
+		t.Errorf("expected tenant -100123, got %d, %v", chatID, ok)
+	}
+}
@@ -0,0 +1,29 @@
+package common
+
+import "strings"
+
+// ContainsWord reports whether word appears in text on word boundaries.
+// Letters, digits, underscores and non-ASCII bytes are word characters.
+// Input: "isi gas 3kg", "gas"
+// Output: true; "gasket", "gas" gives false
+func ContainsWord(text, word string) bool {
+	if word == "" {
+		return false
+	}
+	for start := 0; ; {
+		i := strings.Index(text[start:], word)
+		if i < 0 {
+			return false
+		}
+		i += start
+		end := i + len(word)
+		if (i == 0 || !isWordByte(text[i-1])) && (end == len(text) || !isWordByte(text[end])) {
+			return true
+		}
+		start = i + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= 0x80
+}
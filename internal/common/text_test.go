@@ -0,0 +1,23 @@
+package common
+
+import "testing"
+
+func TestContainsWord(t *testing.T) {
+	tests := []struct {
+		text, word string
+		want       bool
+	}{
+		{"isi gas 3kg", "gas", true},
+		{"gasket", "gas", false},
+		{"grab 25k", "grab", true},
+		{"grabbing a grab", "grab", true},
+		{"kopi_susu", "kopi", false},
+		{"superindo express", "superindo express", true},
+		{"anything", "", false},
+	}
+	for _, tt := range tests {
+		if got := ContainsWord(tt.text, tt.word); got != tt.want {
+			t.Errorf("ContainsWord(%q, %q) = %v, want %v", tt.text, tt.word, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"context"
+	"time"
+)
+
+// Zones resolves the timezone each tenant has chosen, so dates such as
+// "today" follow the chat rather than the server
+type Zones interface {
+	Location(chatID int64) *time.Location
+}
+
+// TenantLocation returns the timezone zones gives chatID, or fallback when
+// zones is nil. It is UTC when both are nil.
+func TenantLocation(zones Zones, chatID int64, fallback *time.Location) *time.Location {
+	if zones != nil {
+		if loc := zones.Location(chatID); loc != nil {
+			return loc
+		}
+	}
+	if fallback == nil {
+		return time.UTC
+	}
+	return fallback
+}
+
+// ContextLocation is TenantLocation for the tenant stored by WithTenant. A
+// context without a tenant gets fallback.
+func ContextLocation(ctx context.Context, zones Zones, fallback *time.Location) *time.Location {
+	chatID, ok := TenantFrom(ctx)
+	if !ok {
+		zones = nil
+	}
+	return TenantLocation(zones, chatID, fallback)
+}
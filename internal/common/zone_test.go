@@ -0,0 +1,32 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type fixedZones map[int64]*time.Location
+
+func (z fixedZones) Location(chatID int64) *time.Location { return z[chatID] }
+
+func TestTenantLocation(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	zones := fixedZones{42: jakarta}
+	if loc := TenantLocation(zones, 42, bangkok); loc != jakarta {
+		t.Errorf("expected the tenant's timezone, got %v", loc)
+	}
+	if loc := TenantLocation(zones, 7, bangkok); loc != bangkok {
+		t.Errorf("expected the fallback for an unknown tenant, got %v", loc)
+	}
+	if loc := TenantLocation(nil, 42, nil); loc != time.UTC {
+		t.Errorf("expected UTC without zones or fallback, got %v", loc)
+	}
+	if loc := ContextLocation(WithTenant(context.Background(), 42), zones, bangkok); loc != jakarta {
+		t.Errorf("expected the timezone of the tenant in the context, got %v", loc)
+	}
+	if loc := ContextLocation(context.Background(), zones, bangkok); loc != bangkok {
+		t.Errorf("expected the fallback without a tenant, got %v", loc)
+	}
+}
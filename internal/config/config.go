@@ -0,0 +1,374 @@
+// Package config loads the bot configuration from built-in defaults, an
+// optional YAML or TOML file and environment variables, and validates it as
+// a whole so every problem is reported at once.
+package config
+
+import (
+	"fmt"
+	"money-tracker-bot/internal/common"
+	currency_domain "money-tracker-bot/internal/domain/currency"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/health"
+	"money-tracker-bot/internal/logging"
+	"money-tracker-bot/internal/resilience"
+	"money-tracker-bot/internal/tracing"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AI providers that ai.providers can list
+const (
+	ProviderGemini = "gemini"
+	// ProviderOpenAI is the OpenAI API or any compatible server at openai.base_url
+	ProviderOpenAI = "openai"
+	// ProviderOllama is a local Ollama server at ollama.base_url
+	ProviderOllama = "ollama"
+	// ProviderOffline parses text messages locally without any AI service
+	ProviderOffline = "offline"
+)
+
+// Config is the configuration of the bot. Every setting can come from the
+// config file and be overridden by the environment variable named in its
+// comment.
+type Config struct {
+	Telegram TelegramConfig `yaml:"telegram" toml:"telegram"`
+	Sheets   SheetsConfig   `yaml:"sheets" toml:"sheets"`
+	AI       AIConfig       `yaml:"ai" toml:"ai"`
+	// Gemini reads GEMINI_API_KEY and GEMINI_MODEL
+	Gemini ProviderConfig `yaml:"gemini" toml:"gemini"`
+	// OpenAI reads OPENAI_API_KEY, OPENAI_BASE_URL and OPENAI_MODEL
+	OpenAI ProviderConfig `yaml:"openai" toml:"openai"`
+	// Ollama reads OLLAMA_BASE_URL and OLLAMA_MODEL
+	Ollama  ProviderConfig `yaml:"ollama" toml:"ollama"`
+	Storage StorageConfig  `yaml:"storage" toml:"storage"`
+	// Timezone (TIMEZONE) is the IANA timezone of spreadsheet timestamps,
+	// budgets and schedules for chats that have not set their own
+	Timezone string `yaml:"timezone" toml:"timezone"`
+	// Locale (LOCALE) formats amounts and dates for chats that have not
+	// set their own; one of common.Locales
+	Locale string `yaml:"locale" toml:"locale"`
+	// Currency (CURRENCY) is the ISO 4217 code of the spreadsheet's amounts;
+	// transactions in other currencies are converted to it
+	Currency string        `yaml:"currency" toml:"currency"`
+	Log      LogConfig     `yaml:"log" toml:"log"`
+	Trace    TraceConfig   `yaml:"trace" toml:"trace"`
+	HTTP     HTTPConfig    `yaml:"http" toml:"http"`
+	Health   HealthConfig  `yaml:"health" toml:"health"`
+	Breaker  BreakerConfig `yaml:"breaker" toml:"breaker"`
+
+	// Location is Timezone, resolved by Validate
+	Location *time.Location `yaml:"-" toml:"-"`
+}
+
+type TelegramConfig struct {
+	// Token (TELEGRAM_BOT_TOKEN) is the bot token from BotFather
+	Token string `yaml:"token" toml:"token"`
+	// Debug (TELEGRAM_DEBUG) logs every Telegram API request and response
+	Debug bool `yaml:"debug" toml:"debug"`
+	// AdminChats (ADMIN_CHAT_IDS) may see the AI usage of every chat and /status
+	AdminChats []int64 `yaml:"admin_chats" toml:"admin_chats"`
+}
+
+type SheetsConfig struct {
+	// SpreadsheetID (GOOGLE_SPREADSHEET_ID) is the spreadsheet transactions are saved to
+	SpreadsheetID string `yaml:"spreadsheet_id" toml:"spreadsheet_id"`
+	// CredentialsFile (GOOGLE_CREDENTIALS_FILE) is the service account key
+	CredentialsFile string `yaml:"credentials_file" toml:"credentials_file"`
+	// LegacyChat (LEGACY_CHAT_ID) is the chat that rows recorded before the
+	// chat column (O) existed belong to; 0 leaves them out of every chat
+	LegacyChat int64 `yaml:"legacy_chat" toml:"legacy_chat"`
+}
+
+type AIConfig struct {
+	// Providers (AI_PROVIDERS) are asked in this fallback order
+	Providers []string `yaml:"providers" toml:"providers"`
+	// CacheTTL (AI_CACHE_TTL) is how long identical inputs reuse an AI
+	// answer; 0 disables the cache
+	CacheTTL time.Duration `yaml:"cache_ttl" toml:"cache_ttl"`
+	// CacheMaxEntries (AI_CACHE_MAX_ENTRIES) is how many answers are cached
+	CacheMaxEntries int `yaml:"cache_max_entries" toml:"cache_max_entries"`
+	// ReviewThreshold (REVIEW_CONFIDENCE_THRESHOLD) holds back transactions
+	// with a field rated below it for /review; 0 saves every transaction
+	ReviewThreshold float64 `yaml:"review_threshold" toml:"review_threshold"`
+	// DailyTokenQuota (AI_DAILY_TOKEN_QUOTA) is the daily token quota of every
+	// chat; 0 for none
+	DailyTokenQuota int `yaml:"daily_token_quota" toml:"daily_token_quota"`
+	// TenantTokenQuotas (AI_TENANT_TOKEN_QUOTAS, such as "-1001234=200000,42=0")
+	// overrides DailyTokenQuota per chat ID. The keys are strings because
+	// TOML keys are.
+	TenantTokenQuotas map[string]int `yaml:"tenant_token_quotas" toml:"tenant_token_quotas"`
+	// PromptPricePerMTok and ResponsePricePerMTok (AI_PRICE_PROMPT_PER_MTOK,
+	// AI_PRICE_RESPONSE_PER_MTOK) are the US dollar prices per million tokens
+	// /usage estimates costs with
+	PromptPricePerMTok   float64 `yaml:"prompt_price_per_mtok" toml:"prompt_price_per_mtok"`
+	ResponsePricePerMTok float64 `yaml:"response_price_per_mtok" toml:"response_price_per_mtok"`
+}
+
+// ProviderConfig is the connection of one AI provider. Empty values use the
+// adapter's defaults.
+type ProviderConfig struct {
+	APIKey  string `yaml:"api_key" toml:"api_key"`
+	BaseURL string `yaml:"base_url" toml:"base_url"`
+	Model   string `yaml:"model" toml:"model"`
+}
+
+type StorageConfig struct {
+	// DataDir (DATA_DIR) holds local bot state such as digest schedules
+	DataDir string `yaml:"data_dir" toml:"data_dir"`
+	// DownloadsDir (DOWNLOADS_DIR) holds the receipts and files sent to the bot
+	DownloadsDir string `yaml:"downloads_dir" toml:"downloads_dir"`
+	// CategoriesFile (CATEGORIES_FILE) is an optional JSON file with the
+	// default categories
+	CategoriesFile string `yaml:"categories_file" toml:"categories_file"`
+}
+
+type LogConfig struct {
+	// Format (LOG_FORMAT) is json or text
+	Format string `yaml:"format" toml:"format"`
+	// Level (LOG_LEVEL) is debug, info, warn or error
+	Level string `yaml:"level" toml:"level"`
+}
+
+type TraceConfig struct {
+	// Exporter (TRACE_EXPORTER) is none, stdout or otlp
+	Exporter string `yaml:"exporter" toml:"exporter"`
+}
+
+type HTTPConfig struct {
+	// Addr (HTTP_ADDR) serves /metrics, /healthz and /readyz, such as ":9090";
+	// empty disables the server
+	Addr string `yaml:"addr" toml:"addr"`
+}
+
+type HealthConfig struct {
+	// StallAfter (HEALTH_STALL_AFTER) is how long the update loop may make no
+	// progress before /healthz fails
+	StallAfter time.Duration `yaml:"stall_after" toml:"stall_after"`
+	// CheckTimeout and CheckTTL (READY_CHECK_TIMEOUT, READY_CHECK_TTL) are how
+	// long a /readyz dependency check may take and how long its result is reused
+	CheckTimeout time.Duration `yaml:"check_timeout" toml:"check_timeout"`
+	CheckTTL     time.Duration `yaml:"check_ttl" toml:"check_ttl"`
+}
+
+type BreakerConfig struct {
+	// FailureThreshold (BREAKER_FAILURE_THRESHOLD) is how many consecutive
+	// failures open the breaker of a dependency
+	FailureThreshold int `yaml:"failure_threshold" toml:"failure_threshold"`
+	// Cooldown (BREAKER_COOLDOWN) is how long calls are then skipped before a probe
+	Cooldown time.Duration `yaml:"cooldown" toml:"cooldown"`
+}
+
+// Default returns the configuration used for every setting that neither the
+// config file nor the environment sets
+func Default() *Config {
+	return &Config{
+		Sheets: SheetsConfig{CredentialsFile: "google-service-account.json"},
+		AI: AIConfig{
+			Providers:       []string{ProviderGemini, ProviderOffline},
+			CacheTTL:        7 * 24 * time.Hour,
+			CacheMaxEntries: 1000,
+			ReviewThreshold: 0.7,
+			// The Gemini 2.0 Flash prices
+			PromptPricePerMTok:   0.10,
+			ResponsePricePerMTok: 0.40,
+		},
+		Storage:  StorageConfig{DataDir: "data", DownloadsDir: "downloads"},
+		Timezone: "Asia/Bangkok",
+		Locale:   common.DefaultLocale,
+		Currency: currency_domain.Default,
+		Log:      LogConfig{Format: logging.FormatJSON, Level: "info"},
+		Trace:    TraceConfig{Exporter: tracing.ExporterNone},
+		Health: HealthConfig{
+			StallAfter:   health.DefaultMaxAge,
+			CheckTimeout: health.DefaultTimeout,
+			CheckTTL:     health.DefaultTTL,
+		},
+		Breaker: BreakerConfig{
+			FailureThreshold: resilience.DefaultThreshold,
+			Cooldown:         resilience.DefaultCooldown,
+		},
+	}
+}
+
+// Uses reports whether provider is one of the AI providers
+func (a AIConfig) Uses(provider string) bool {
+	for _, p := range a.Providers {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// TenantQuotas returns TenantTokenQuotas keyed by chat ID, skipping keys
+// that are not chat IDs, which Validate reports
+func (a AIConfig) TenantQuotas() map[int64]int {
+	quotas := make(map[int64]int, len(a.TenantTokenQuotas))
+	for chat, tokens := range a.TenantTokenQuotas {
+		if chatID, err := strconv.ParseInt(strings.TrimSpace(chat), 10, 64); err == nil {
+			quotas[chatID] = tokens
+		}
+	}
+	return quotas
+}
+
+// Secrets are the values that must never be logged
+func (c *Config) Secrets() []string {
+	return []string{c.Telegram.Token, c.Gemini.APIKey, c.OpenAI.APIKey}
+}
+
+// Validate checks every setting and returns one CONFIG_ERROR listing all
+// problems, wrapping a *ValidationError. It normalizes provider names and
+// resolves Location.
+func (c *Config) Validate() error {
+	var p problems
+	c.validate(&p)
+	return p.err()
+}
+
+func (c *Config) validate(p *problems) {
+	if c.Telegram.Token == "" {
+		p.add("telegram.token (TELEGRAM_BOT_TOKEN) is required")
+	}
+	if c.Sheets.SpreadsheetID == "" {
+		p.add("sheets.spreadsheet_id (GOOGLE_SPREADSHEET_ID) is required")
+	}
+	if c.Sheets.CredentialsFile == "" {
+		p.add("sheets.credentials_file (GOOGLE_CREDENTIALS_FILE) is required")
+	}
+
+	c.validateProviders(p)
+	if c.AI.Uses(ProviderGemini) && c.Gemini.APIKey == "" {
+		p.add("gemini.api_key (GEMINI_API_KEY) is required when the gemini provider is used")
+	}
+	if c.AI.Uses(ProviderOpenAI) && c.OpenAI.APIKey == "" {
+		p.add("openai.api_key (OPENAI_API_KEY) is required when the openai provider is used")
+	}
+	if c.AI.CacheTTL < 0 {
+		p.add("ai.cache_ttl (AI_CACHE_TTL) must not be negative, got %v", c.AI.CacheTTL)
+	}
+	if c.AI.CacheMaxEntries < 1 {
+		p.add("ai.cache_max_entries (AI_CACHE_MAX_ENTRIES) must be positive, got %d", c.AI.CacheMaxEntries)
+	}
+	if c.AI.ReviewThreshold < 0 || c.AI.ReviewThreshold > 1 {
+		p.add("ai.review_threshold (REVIEW_CONFIDENCE_THRESHOLD) must be from 0 to 1, got %v", c.AI.ReviewThreshold)
+	}
+	if c.AI.DailyTokenQuota < 0 {
+		p.add("ai.daily_token_quota (AI_DAILY_TOKEN_QUOTA) must not be negative, got %d", c.AI.DailyTokenQuota)
+	}
+	for chat, tokens := range c.AI.TenantTokenQuotas {
+		if _, err := strconv.ParseInt(strings.TrimSpace(chat), 10, 64); err != nil {
+			p.add("ai.tenant_token_quotas (AI_TENANT_TOKEN_QUOTAS) has %q, which is not a chat ID", chat)
+		} else if tokens < 0 {
+			p.add("ai.tenant_token_quotas (AI_TENANT_TOKEN_QUOTAS) has a negative quota for %s", chat)
+		}
+	}
+	if c.AI.PromptPricePerMTok < 0 {
+		p.add("ai.prompt_price_per_mtok (AI_PRICE_PROMPT_PER_MTOK) must not be negative")
+	}
+	if c.AI.ResponsePricePerMTok < 0 {
+		p.add("ai.response_price_per_mtok (AI_PRICE_RESPONSE_PER_MTOK) must not be negative")
+	}
+
+	if c.Storage.DataDir == "" {
+		p.add("storage.data_dir (DATA_DIR) is required")
+	}
+	if c.Storage.DownloadsDir == "" {
+		p.add("storage.downloads_dir (DOWNLOADS_DIR) is required")
+	}
+	if loc, err := time.LoadLocation(c.Timezone); err != nil || c.Timezone == "" {
+		p.add("timezone (TIMEZONE) must be an IANA timezone such as Asia/Bangkok, got %q", c.Timezone)
+	} else {
+		c.Location = loc
+	}
+	if l, ok := common.ParseLocale(c.Locale); ok {
+		c.Locale = l.Tag
+	} else {
+		p.add("locale (LOCALE) must be one of %s, got %q", strings.Join(common.Locales(), ", "), c.Locale)
+	}
+	if code, ok := currency_domain.ParseCode(c.Currency); ok {
+		c.Currency = code
+	} else {
+		p.add("currency (CURRENCY) must be one of %s, got %q", strings.Join(currency_domain.Codes(), ", "), c.Currency)
+	}
+
+	switch c.Log.Format {
+	case "", logging.FormatJSON, logging.FormatText:
+	default:
+		p.add("log.format (LOG_FORMAT) must be json or text, got %q", c.Log.Format)
+	}
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		p.add("log.level (LOG_LEVEL) must be debug, info, warn or error, got %q", c.Log.Level)
+	}
+	switch strings.ToLower(c.Trace.Exporter) {
+	case "", tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		p.add("trace.exporter (TRACE_EXPORTER) must be none, stdout or otlp, got %q", c.Trace.Exporter)
+	}
+
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"health.stall_after (HEALTH_STALL_AFTER)", c.Health.StallAfter},
+		{"health.check_timeout (READY_CHECK_TIMEOUT)", c.Health.CheckTimeout},
+		{"health.check_ttl (READY_CHECK_TTL)", c.Health.CheckTTL},
+		{"breaker.cooldown (BREAKER_COOLDOWN)", c.Breaker.Cooldown},
+	} {
+		if d.value <= 0 {
+			p.add("%s must be positive, got %v", d.name, d.value)
+		}
+	}
+	if c.Breaker.FailureThreshold < 1 {
+		p.add("breaker.failure_threshold (BREAKER_FAILURE_THRESHOLD) must be positive, got %d", c.Breaker.FailureThreshold)
+	}
+}
+
+// validateProviders normalizes the provider names and reports unknown and
+// repeated ones
+func (c *Config) validateProviders(p *problems) {
+	if len(c.AI.Providers) == 0 {
+		p.add("ai.providers (AI_PROVIDERS) must list at least one provider")
+	}
+	seen := make(map[string]bool)
+	for i, name := range c.AI.Providers {
+		name = strings.ToLower(strings.TrimSpace(name))
+		c.AI.Providers[i] = name
+		switch name {
+		case ProviderGemini, ProviderOpenAI, ProviderOllama, ProviderOffline:
+		default:
+			p.add("ai.providers (AI_PROVIDERS) has unknown provider %q, expected gemini, openai, ollama or offline", name)
+			continue
+		}
+		if seen[name] {
+			p.add("ai.providers (AI_PROVIDERS) lists %s twice", name)
+		}
+		seen[name] = true
+	}
+}
+
+// ValidationError lists every problem found in a configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
+
+// problems collects configuration problems so they are reported together
+type problems []string
+
+func (p *problems) add(format string, args ...interface{}) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+func (p problems) err() error {
+	if len(p) == 0 {
+		return nil
+	}
+	return errors.NewConfigError(fmt.Sprintf("invalid configuration, %d problem(s)", len(p)), &ValidationError{Problems: p}).
+		WithContext("problems", len(p)).
+		WithComponent("config")
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+)
+
+// valid returns the defaults with the required settings filled in
+func valid() *Config {
+	cfg := Default()
+	cfg.Telegram.Token = "bot-token"
+	cfg.Sheets.SpreadsheetID = "sheet-id"
+	cfg.Gemini.APIKey = "gemini-key"
+	return cfg
+}
+
+func problemsOf(t *testing.T, err error) []string {
+	t.Helper()
+	var v *ValidationError
+	if !stderrors.As(err, &v) {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+	return v.Problems
+}
+
+func TestValidate_Defaults(t *testing.T) {
+	cfg := valid()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected the defaults to be valid, got %v", err)
+	}
+	if cfg.Location == nil || cfg.Location.String() != "Asia/Bangkok" {
+		t.Errorf("expected Location to be resolved, got %v", cfg.Location)
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	cfg := Default()
+	cfg.AI.Providers = []string{"gemini", "magic", "openai", " Gemini "}
+	cfg.AI.ReviewThreshold = 1.5
+	cfg.AI.TenantTokenQuotas = map[string]int{"me": 10}
+	cfg.Timezone = "Mars/Olympus"
+	cfg.Locale = "tlh"
+	cfg.Currency = "dollars"
+	cfg.Log.Level = "loud"
+	cfg.Breaker.Cooldown = 0
+
+	err := cfg.Validate()
+	problems := problemsOf(t, err)
+	for _, want := range []string{
+		"TELEGRAM_BOT_TOKEN", "GOOGLE_SPREADSHEET_ID", `unknown provider "magic"`, "lists gemini twice",
+		"GEMINI_API_KEY", "OPENAI_API_KEY", "REVIEW_CONFIDENCE_THRESHOLD", `"me"`, "TIMEZONE", "LOCALE", "CURRENCY", "LOG_LEVEL", "BREAKER_COOLDOWN",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected a problem about %s, got %v", want, problems)
+		}
+	}
+	if len(problems) != 13 {
+		t.Errorf("expected 13 problems, got %d: %v", len(problems), problems)
+	}
+}
+
+func TestValidate_ProviderKeys(t *testing.T) {
+	cfg := valid()
+	cfg.Gemini.APIKey = ""
+	cfg.AI.Providers = []string{ProviderOllama, ProviderOffline}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Ollama and the offline parser need no API key, got %v", err)
+	}
+}
+
+func TestAIConfig_TenantQuotas(t *testing.T) {
+	ai := AIConfig{TenantTokenQuotas: map[string]int{"-1001234": 200000, "42": 0, "me": 5}}
+	quotas := ai.TenantQuotas()
+	if len(quotas) != 2 || quotas[-1001234] != 200000 || quotas[42] != 0 {
+		t.Errorf("unexpected quotas %v", quotas)
+	}
+	if !(AIConfig{Providers: []string{ProviderOffline}}).Uses(ProviderOffline) {
+		t.Error("expected Uses to find a listed provider")
+	}
+}
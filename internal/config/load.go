@@ -0,0 +1,240 @@
+package config
+
+import (
+	"bytes"
+	"io"
+	"money-tracker-bot/internal/errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Load returns the validated configuration: the defaults, overridden by the
+// YAML (.yaml, .yml) or TOML (.toml) file at path when it is not empty,
+// overridden by the environment variables getenv returns. Empty variables
+// override nothing. Values that do not parse and settings that fail
+// Validate are reported together in one CONFIG_ERROR.
+func Load(path string, getenv func(string) string) (*Config, error) {
+	cfg := Default()
+	var p problems
+	if path != "" {
+		if err := decodeFile(path, cfg, &p); err != nil {
+			return nil, err
+		}
+	}
+	env := envReader{getenv: getenv, problems: &p}
+	env.apply(cfg)
+	cfg.validate(&p)
+	if err := p.err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// decodeFile reads the config file at path into cfg. Settings the file
+// names but Config does not have are problems, since they are usually typos.
+func decodeFile(path string, cfg *Config, p *problems) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.NewConfigError("failed to read config file", err).
+			WithContext("path", path).
+			WithComponent("config")
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && err != io.EOF {
+			return errors.NewConfigError("invalid YAML config file", err).
+				WithContext("path", path).
+				WithComponent("config")
+		}
+	case ".toml":
+		meta, err := toml.Decode(string(data), cfg)
+		if err != nil {
+			return errors.NewConfigError("invalid TOML config file", err).
+				WithContext("path", path).
+				WithComponent("config")
+		}
+		for _, key := range meta.Undecoded() {
+			p.add("%s: unknown setting %s", path, key)
+		}
+	default:
+		return errors.NewConfigError("unknown config file type, expected .yaml, .yml or .toml", nil).
+			WithContext("path", path).
+			WithComponent("config")
+	}
+	return nil
+}
+
+// envReader overrides settings with the environment variables that are set,
+// recording the values that do not parse
+type envReader struct {
+	getenv   func(string) string
+	problems *problems
+}
+
+func (e envReader) apply(cfg *Config) {
+	e.string("TELEGRAM_BOT_TOKEN", &cfg.Telegram.Token)
+	e.bool("TELEGRAM_DEBUG", &cfg.Telegram.Debug)
+	e.chats("ADMIN_CHAT_IDS", &cfg.Telegram.AdminChats)
+
+	e.string("GOOGLE_SPREADSHEET_ID", &cfg.Sheets.SpreadsheetID)
+	e.string("GOOGLE_CREDENTIALS_FILE", &cfg.Sheets.CredentialsFile)
+	e.chat("LEGACY_CHAT_ID", &cfg.Sheets.LegacyChat)
+
+	e.list("AI_PROVIDERS", &cfg.AI.Providers)
+	e.duration("AI_CACHE_TTL", &cfg.AI.CacheTTL)
+	e.int("AI_CACHE_MAX_ENTRIES", &cfg.AI.CacheMaxEntries)
+	e.float("REVIEW_CONFIDENCE_THRESHOLD", &cfg.AI.ReviewThreshold)
+	e.int("AI_DAILY_TOKEN_QUOTA", &cfg.AI.DailyTokenQuota)
+	e.quotas("AI_TENANT_TOKEN_QUOTAS", &cfg.AI.TenantTokenQuotas)
+	e.float("AI_PRICE_PROMPT_PER_MTOK", &cfg.AI.PromptPricePerMTok)
+	e.float("AI_PRICE_RESPONSE_PER_MTOK", &cfg.AI.ResponsePricePerMTok)
+
+	e.string("GEMINI_API_KEY", &cfg.Gemini.APIKey)
+	e.string("GEMINI_MODEL", &cfg.Gemini.Model)
+	e.string("OPENAI_API_KEY", &cfg.OpenAI.APIKey)
+	e.string("OPENAI_BASE_URL", &cfg.OpenAI.BaseURL)
+	e.string("OPENAI_MODEL", &cfg.OpenAI.Model)
+	e.string("OLLAMA_BASE_URL", &cfg.Ollama.BaseURL)
+	e.string("OLLAMA_MODEL", &cfg.Ollama.Model)
+
+	e.string("DATA_DIR", &cfg.Storage.DataDir)
+	e.string("DOWNLOADS_DIR", &cfg.Storage.DownloadsDir)
+	e.string("CATEGORIES_FILE", &cfg.Storage.CategoriesFile)
+	e.string("TIMEZONE", &cfg.Timezone)
+	e.string("LOCALE", &cfg.Locale)
+	e.string("CURRENCY", &cfg.Currency)
+
+	e.string("LOG_FORMAT", &cfg.Log.Format)
+	e.string("LOG_LEVEL", &cfg.Log.Level)
+	e.string("TRACE_EXPORTER", &cfg.Trace.Exporter)
+	e.string("HTTP_ADDR", &cfg.HTTP.Addr)
+	e.duration("HEALTH_STALL_AFTER", &cfg.Health.StallAfter)
+	e.duration("READY_CHECK_TIMEOUT", &cfg.Health.CheckTimeout)
+	e.duration("READY_CHECK_TTL", &cfg.Health.CheckTTL)
+	e.int("BREAKER_FAILURE_THRESHOLD", &cfg.Breaker.FailureThreshold)
+	e.duration("BREAKER_COOLDOWN", &cfg.Breaker.Cooldown)
+}
+
+// lookup returns the trimmed value of name and whether it is set
+func (e envReader) lookup(name string) (string, bool) {
+	value := strings.TrimSpace(e.getenv(name))
+	return value, value != ""
+}
+
+func (e envReader) invalid(name, value, expected string) {
+	e.problems.add("%s must be %s, got %q", name, expected, value)
+}
+
+func (e envReader) string(name string, dst *string) {
+	if value, ok := e.lookup(name); ok {
+		*dst = value
+	}
+}
+
+func (e envReader) bool(name string, dst *bool) {
+	if value, ok := e.lookup(name); ok {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			e.invalid(name, value, "true or false")
+			return
+		}
+		*dst = b
+	}
+}
+
+func (e envReader) int(name string, dst *int) {
+	if value, ok := e.lookup(name); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			e.invalid(name, value, "a whole number")
+			return
+		}
+		*dst = n
+	}
+}
+
+func (e envReader) float(name string, dst *float64) {
+	if value, ok := e.lookup(name); ok {
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			e.invalid(name, value, "a number")
+			return
+		}
+		*dst = n
+	}
+}
+
+func (e envReader) duration(name string, dst *time.Duration) {
+	if value, ok := e.lookup(name); ok {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			e.invalid(name, value, "a duration such as 30s")
+			return
+		}
+		*dst = d
+	}
+}
+
+// list reads comma-separated values such as "gemini,ollama,offline"
+func (e envReader) list(name string, dst *[]string) {
+	if value, ok := e.lookup(name); ok {
+		*dst = strings.Split(value, ",")
+	}
+}
+
+// chat reads a single chat ID
+func (e envReader) chat(name string, dst *int64) {
+	if value, ok := e.lookup(name); ok {
+		chatID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			e.invalid(name, value, "a chat ID")
+			return
+		}
+		*dst = chatID
+	}
+}
+
+// chats reads comma-separated chat IDs
+func (e envReader) chats(name string, dst *[]int64) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	var chats []int64
+	for _, id := range strings.Split(value, ",") {
+		chatID, err := strconv.ParseInt(strings.TrimSpace(id), 10, 64)
+		if err != nil {
+			e.invalid(name, id, "comma-separated chat IDs")
+			return
+		}
+		chats = append(chats, chatID)
+	}
+	*dst = chats
+}
+
+// quotas reads chat=tokens pairs such as "-1001234=200000,42=0"
+func (e envReader) quotas(name string, dst *map[string]int) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	quotas := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		chat, tokens, found := strings.Cut(strings.TrimSpace(pair), "=")
+		n, err := strconv.Atoi(strings.TrimSpace(tokens))
+		if !found || err != nil {
+			e.invalid(name, pair, "chat=tokens pairs")
+			return
+		}
+		quotas[strings.TrimSpace(chat)] = n
+	}
+	*dst = quotas
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// env returns a getenv reading vars
+func env(vars map[string]string) func(string) string {
+	return func(name string) string { return vars[name] }
+}
+
+var required = map[string]string{
+	"TELEGRAM_BOT_TOKEN":    "bot-token",
+	"GOOGLE_SPREADSHEET_ID": "sheet-id",
+	"GEMINI_API_KEY":        "gemini-key",
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_Environment(t *testing.T) {
+	vars := map[string]string{
+		"AI_PROVIDERS":           " Ollama, openai ,offline",
+		"OPENAI_API_KEY":         "openai-key",
+		"AI_CACHE_TTL":           "0",
+		"AI_TENANT_TOKEN_QUOTAS": "-1001234=200000, 42=0",
+		"ADMIN_CHAT_IDS":         "12345, -1001234",
+		"LEGACY_CHAT_ID":         "-1001234",
+		"TELEGRAM_DEBUG":         "true",
+		"BREAKER_COOLDOWN":       "2m",
+		"TIMEZONE":               "Asia/Jakarta",
+		"LOCALE":                 "id_id",
+		"CURRENCY":               "sgd",
+		"DOWNLOADS_DIR":          "/var/receipts",
+	}
+	for k, v := range required {
+		vars[k] = v
+	}
+	cfg, err := Load("", env(vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(cfg.AI.Providers, ",") != "ollama,openai,offline" || cfg.AI.CacheTTL != 0 || !cfg.Telegram.Debug {
+		t.Errorf("unexpected AI settings %+v", cfg.AI)
+	}
+	if len(cfg.Telegram.AdminChats) != 2 || cfg.AI.TenantQuotas()[-1001234] != 200000 || cfg.Sheets.LegacyChat != -1001234 {
+		t.Errorf("unexpected chats %v, %v", cfg.Telegram.AdminChats, cfg.AI.TenantTokenQuotas)
+	}
+	if cfg.Breaker.Cooldown != 2*time.Minute || cfg.Location.String() != "Asia/Jakarta" || cfg.Storage.DownloadsDir != "/var/receipts" {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+	if cfg.Locale != "id-ID" || cfg.Currency != "SGD" {
+		t.Errorf("expected the locale and currency to be normalized, got %q, %q", cfg.Locale, cfg.Currency)
+	}
+	if cfg.Sheets.CredentialsFile != "google-service-account.json" || cfg.Storage.DataDir != "data" {
+		t.Errorf("expected defaults for unset variables, got %+v", cfg)
+	}
+}
+
+func TestLoad_ReportsEveryProblem(t *testing.T) {
+	cfg, err := Load("", env(map[string]string{
+		"AI_CACHE_TTL":              "a week",
+		"AI_CACHE_MAX_ENTRIES":      "many",
+		"BREAKER_FAILURE_THRESHOLD": "0",
+		"ADMIN_CHAT_IDS":            "me",
+	}))
+	if cfg != nil || err == nil {
+		t.Fatalf("expected an error, got %+v", cfg)
+	}
+	problems := problemsOf(t, err)
+	// Missing token, spreadsheet and Gemini key, three unparsable values and a zero threshold
+	if len(problems) != 7 {
+		t.Errorf("expected 7 problems, got %d: %v", len(problems), problems)
+	}
+	if !strings.Contains(err.Error(), `AI_CACHE_TTL must be a duration such as 30s, got "a week"`) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestLoad_YAML(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+telegram:
+  token: file-token
+  admin_chats: [42]
+sheets:
+  spreadsheet_id: file-sheet
+gemini:
+  api_key: file-key
+  model: gemini-1.5-pro
+ai:
+  providers: [gemini, offline]
+  cache_ttl: 24h
+  tenant_token_quotas:
+    "-100": 5000
+timezone: Asia/Jakarta
+`)
+	cfg, err := Load(path, env(map[string]string{"TELEGRAM_BOT_TOKEN": "env-token"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Telegram.Token != "env-token" {
+		t.Errorf("the environment should override the file, got %q", cfg.Telegram.Token)
+	}
+	if cfg.Sheets.SpreadsheetID != "file-sheet" || cfg.Gemini.Model != "gemini-1.5-pro" || cfg.AI.CacheTTL != 24*time.Hour || cfg.Telegram.AdminChats[0] != 42 {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+	if cfg.AI.TenantQuotas()[-100] != 5000 || cfg.Location.String() != "Asia/Jakarta" {
+		t.Errorf("unexpected quotas or timezone %v, %v", cfg.AI.TenantTokenQuotas, cfg.Location)
+	}
+
+	typo := writeFile(t, "typo.yml", "telegram:\n  tokn: x\n")
+	if _, err := Load(typo, env(required)); err == nil || !strings.Contains(err.Error(), "tokn") {
+		t.Errorf("expected an error naming the unknown setting, got %v", err)
+	}
+}
+
+func TestLoad_TOML(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+timezone = "UTC"
+
+[telegram]
+token = "file-token"
+
+[sheets]
+spreadsheet_id = "file-sheet"
+credentials_file = "/etc/bot/sa.json"
+
+[ai]
+providers = ["ollama"]
+review_threshold = 0
+cache_ttl = "1h"
+
+[ai.tenant_token_quotas]
+"-100" = 5000
+
+[health]
+stall_after = "10m"
+`)
+	cfg, err := Load(path, env(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Sheets.CredentialsFile != "/etc/bot/sa.json" || cfg.AI.ReviewThreshold != 0 || cfg.AI.CacheTTL != time.Hour || cfg.Health.StallAfter != 10*time.Minute {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+	if cfg.AI.TenantQuotas()[-100] != 5000 {
+		t.Errorf("unexpected quotas %v", cfg.AI.TenantTokenQuotas)
+	}
+
+	typo := writeFile(t, "typo.toml", "[telegram]\ntoken = \"x\"\ndebugg = true\n")
+	if _, err := Load(typo, env(required)); err == nil || !strings.Contains(err.Error(), "telegram.debugg") {
+		t.Errorf("expected an error naming the unknown setting, got %v", err)
+	}
+}
+
+func TestLoad_FileErrors(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml"), env(required)); err == nil {
+		t.Error("expected error for a missing file")
+	}
+	if _, err := Load(writeFile(t, "config.json", "{}"), env(required)); err == nil {
+		t.Error("expected error for an unknown file type")
+	}
+	if _, err := Load(writeFile(t, "config.yaml", "ai: [broken"), env(required)); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
+
+func TestLoad_ExampleFile(t *testing.T) {
+	cfg, err := Load(filepath.Join("..", "..", "config.example.yaml"), env(nil))
+	if err != nil {
+		t.Fatalf("the example config should load, got %v", err)
+	}
+	if cfg.Telegram.Token != "YOUR_TELEGRAM_BOT_TOKEN" || cfg.Storage.DownloadsDir != "downloads" {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+}
@@ -0,0 +1,143 @@
+package advice_domain
+
+import (
+	"math"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"sort"
+	"time"
+)
+
+// Months is how many calendar months, the current one included, a summary covers
+const Months = 3
+
+// MonthSpending is the spending of one calendar month by category
+type MonthSpending struct {
+	// Month is formatted YYYY-MM
+	Month      string
+	Total      float64
+	Categories map[string]float64
+	// Partial marks the current month, which is not over yet
+	Partial bool
+	// Days counts the days of the month covered so far
+	Days int
+}
+
+// Budget is the monthly budget of a category from the summary sheet
+type Budget struct {
+	Category string
+	Monthly  float64
+	// Left is negative once the category is overspent this month
+	Left float64
+}
+
+// Trend compares a category's spending in the last two complete months
+type Trend struct {
+	Category string
+	Previous float64
+	Latest   float64
+}
+
+// Change returns the relative change from Previous to Latest, 1 meaning +100%.
+// A category new in the latest month reports +100%.
+func (t Trend) Change() float64 {
+	if t.Previous == 0 {
+		if t.Latest == 0 {
+			return 0
+		}
+		return 1
+	}
+	return (t.Latest - t.Previous) / t.Previous
+}
+
+// Summary is the anonymized spending history /advice sends to the AI. It
+// holds totals only: no notes, merchants, accounts or names.
+type Summary struct {
+	// Months holds the covered months, oldest first
+	Months  []MonthSpending
+	Budgets []Budget
+	// Trends are ordered by the size of the change, biggest first
+	Trends []Trend
+}
+
+// BuildSummary totals trxs by month and category for the Months calendar
+// months up to today. Savings goal contributions and transfers are not
+// spending and are left out.
+func BuildSummary(trxs []transaction_domain.Transaction, today time.Time, budgets []Budget) Summary {
+	first := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+	summary := Summary{Budgets: budgets}
+	index := make(map[string]int, Months)
+	for i := Months - 1; i >= 0; i-- {
+		start := first.AddDate(0, -i, 0)
+		month := MonthSpending{
+			Month:      start.Format("2006-01"),
+			Categories: make(map[string]float64),
+			Days:       start.AddDate(0, 1, -1).Day(),
+		}
+		if i == 0 {
+			month.Partial = true
+			month.Days = today.Day()
+		}
+		index[month.Month] = len(summary.Months)
+		summary.Months = append(summary.Months, month)
+	}
+
+	lastDate := today.Format("2006-01-02")
+	for _, trx := range trxs {
+		if trx.Goal != "" || trx.IsTransfer() || len(trx.TransactionDate) < 7 || trx.TransactionDate > lastDate {
+			continue
+		}
+		i, ok := index[trx.TransactionDate[:7]]
+		if !ok {
+			continue
+		}
+		amount := trx.AmountValue()
+		category := trx.Category
+		if category == "" {
+			category = "Uncategorized"
+		}
+		summary.Months[i].Total += amount
+		summary.Months[i].Categories[category] += amount
+	}
+
+	if len(summary.Months) >= 3 {
+		previous, latest := summary.Months[len(summary.Months)-3], summary.Months[len(summary.Months)-2]
+		summary.Trends = buildTrends(previous, latest)
+	}
+	return summary
+}
+
+func buildTrends(previous, latest MonthSpending) []Trend {
+	seen := make(map[string]bool)
+	var trends []Trend
+	for _, month := range []MonthSpending{previous, latest} {
+		for category := range month.Categories {
+			if seen[category] {
+				continue
+			}
+			seen[category] = true
+			trends = append(trends, Trend{
+				Category: category,
+				Previous: previous.Categories[category],
+				Latest:   latest.Categories[category],
+			})
+		}
+	}
+	sort.Slice(trends, func(i, j int) bool {
+		a, b := math.Abs(trends[i].Latest-trends[i].Previous), math.Abs(trends[j].Latest-trends[j].Previous)
+		if a != b {
+			return a > b
+		}
+		return trends[i].Category < trends[j].Category
+	})
+	return trends
+}
+
+// Empty reports whether nothing was spent in the covered months
+func (s Summary) Empty() bool {
+	for _, m := range s.Months {
+		if m.Total > 0 {
+			return false
+		}
+	}
+	return true
+}
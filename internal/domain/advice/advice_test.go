@@ -0,0 +1,65 @@
+package advice_domain
+
+import (
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"testing"
+	"time"
+)
+
+func TestBuildSummary(t *testing.T) {
+	today := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	trxs := []transaction_domain.Transaction{
+		{TransactionDate: "2024-12-31", Amount: "999,000", Category: "Groceries"},
+		{TransactionDate: "2025-01-05", Amount: "1,000,000", Category: "Groceries"},
+		{TransactionDate: "2025-01-20", Amount: "200,000", Category: "Eating Out"},
+		{TransactionDate: "2025-02-03", Amount: "1,100,000", Category: "Groceries"},
+		{TransactionDate: "2025-02-14", Amount: "600,000", Category: "Eating Out"},
+		{TransactionDate: "2025-02-15", Amount: "500,000", Category: "Savings", Goal: "Bali"},
+		{TransactionDate: "2025-03-02", Amount: "150,000"},
+		{TransactionDate: "2025-03-11", Amount: "70,000", Category: "Groceries"},
+	}
+	budgets := []Budget{{Category: "Groceries", Monthly: 1500000, Left: 1350000}}
+
+	s := BuildSummary(trxs, today, budgets)
+	if len(s.Months) != Months || s.Months[0].Month != "2025-01" || s.Months[2].Month != "2025-03" {
+		t.Fatalf("unexpected months %+v", s.Months)
+	}
+	if s.Months[0].Total != 1200000 || s.Months[0].Days != 31 || s.Months[0].Partial {
+		t.Errorf("unexpected January %+v", s.Months[0])
+	}
+	if s.Months[1].Total != 1700000 {
+		t.Errorf("goal contributions are not spending, got February %+v", s.Months[1])
+	}
+	march := s.Months[2]
+	if !march.Partial || march.Days != 10 || march.Total != 150000 || march.Categories["Uncategorized"] != 150000 {
+		t.Errorf("unexpected March %+v", march)
+	}
+	if len(s.Budgets) != 1 {
+		t.Errorf("budgets should be kept, got %+v", s.Budgets)
+	}
+
+	if len(s.Trends) != 2 || s.Trends[0].Category != "Eating Out" {
+		t.Fatalf("expected the Eating Out jump first, got %+v", s.Trends)
+	}
+	if got := s.Trends[0].Change(); got != 2 {
+		t.Errorf("expected +200%%, got %v", got)
+	}
+	if s.Empty() {
+		t.Error("summary with spending should not be empty")
+	}
+	if !BuildSummary(nil, today, nil).Empty() {
+		t.Error("summary without transactions should be empty")
+	}
+}
+
+func TestTrend_Change(t *testing.T) {
+	if got := (Trend{Latest: 100}).Change(); got != 1 {
+		t.Errorf("a new category should report +100%%, got %v", got)
+	}
+	if got := (Trend{Previous: 200, Latest: 50}).Change(); got != -0.75 {
+		t.Errorf("expected -75%%, got %v", got)
+	}
+	if got := (Trend{}).Change(); got != 0 {
+		t.Errorf("expected no change, got %v", got)
+	}
+}
@@ -0,0 +1,294 @@
+package category_domain
+
+import (
+	"money-tracker-bot/internal/errors"
+	"strings"
+)
+
+// Separator joins a category and its subcategory in a path
+const Separator = " › "
+
+// Category is a spending category, or a subcategory when Parent is set.
+// Aliases are alternative names users and the AI may use for it.
+type Category struct {
+	Name        string   `json:"name"`
+	Parent      string   `json:"parent,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// Path returns the display path of the category.
+// Input: Category{Name: "Coffee", Parent: "Eating Out"}
+// Output: "Eating Out › Coffee"
+func (c Category) Path() string {
+	if c.Parent == "" {
+		return c.Name
+	}
+	return c.Parent + Separator + c.Name
+}
+
+// Ref returns where transactions of this category are recorded
+func (c Category) Ref() Ref {
+	if c.Parent == "" {
+		return Ref{Category: c.Name}
+	}
+	return Ref{Category: c.Parent, Subcategory: c.Name}
+}
+
+// Ref identifies the category and optional subcategory of a transaction
+type Ref struct {
+	Category    string
+	Subcategory string
+}
+
+func (r Ref) String() string {
+	if r.Subcategory == "" {
+		return r.Category
+	}
+	return r.Category + Separator + r.Subcategory
+}
+
+// SplitPath splits "Eating Out › Coffee" (or "Eating Out > Coffee") into its parts.
+// A path without a separator is a top-level category.
+func SplitPath(path string) (parent, name string) {
+	for _, sep := range []string{"›", ">"} {
+		if p, n, ok := strings.Cut(path, sep); ok {
+			return strings.TrimSpace(p), strings.TrimSpace(n)
+		}
+	}
+	return "", strings.TrimSpace(path)
+}
+
+// Set is the category list of one tenant, in display order
+type Set struct {
+	Categories []Category `json:"categories"`
+}
+
+// DefaultSet builds a flat set from category names
+func DefaultSet(names []string) Set {
+	s := Set{Categories: make([]Category, 0, len(names))}
+	for _, n := range names {
+		s.Categories = append(s.Categories, Category{Name: n})
+	}
+	return s
+}
+
+// Clone returns a deep copy that can be changed without affecting s
+func (s Set) Clone() Set {
+	out := Set{Categories: make([]Category, len(s.Categories))}
+	for i, c := range s.Categories {
+		c.Aliases = append([]string(nil), c.Aliases...)
+		out.Categories[i] = c
+	}
+	return out
+}
+
+// Find returns the category at path, compared case-insensitively.
+func (s Set) Find(path string) (Category, bool) {
+	i := s.index(path)
+	if i < 0 {
+		return Category{}, false
+	}
+	return s.Categories[i], true
+}
+
+func (s Set) index(path string) int {
+	parent, name := SplitPath(path)
+	for i, c := range s.Categories {
+		if strings.EqualFold(c.Name, name) && strings.EqualFold(c.Parent, parent) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Match resolves a name written by a user or the AI to a category. Paths
+// and top-level names win over subcategory names, which win over aliases.
+// Input: "kopi" with alias kopi on Eating Out › Coffee
+// Output: Ref{Category: "Eating Out", Subcategory: "Coffee"}, true
+func (s Set) Match(name string) (Ref, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Ref{}, false
+	}
+	if c, ok := s.Find(name); ok {
+		return c.Ref(), true
+	}
+	for _, c := range s.Categories {
+		if c.Parent != "" && strings.EqualFold(c.Name, name) {
+			return c.Ref(), true
+		}
+	}
+	for _, c := range s.Categories {
+		for _, a := range c.Aliases {
+			if strings.EqualFold(a, name) {
+				return c.Ref(), true
+			}
+		}
+	}
+	return Ref{}, false
+}
+
+// Children returns the subcategories of a top-level category
+func (s Set) Children(name string) []Category {
+	var out []Category
+	for _, c := range s.Categories {
+		if c.Parent != "" && strings.EqualFold(c.Parent, name) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Add appends a category after validating its name, parent and aliases.
+func (s *Set) Add(c Category) error {
+	c.Name = strings.TrimSpace(c.Name)
+	c.Parent = strings.TrimSpace(c.Parent)
+	if err := validName(c.Name); err != nil {
+		return err
+	}
+	if c.Parent != "" {
+		parent, ok := s.Find(c.Parent)
+		if !ok {
+			return errors.Messagef("invalid.category.unknown_parent", "unknown parent category %q", c.Parent)
+		}
+		if parent.Parent != "" {
+			return errors.Messagef("invalid.category.nested", "subcategories cannot be nested, %q is already a subcategory", parent.Path())
+		}
+		c.Parent = parent.Name
+	}
+	if _, exists := s.Find(c.Path()); exists {
+		return errors.Messagef("invalid.category.exists", "category %q already exists", c.Path())
+	}
+	for _, a := range c.Aliases {
+		if ref, taken := s.Match(a); taken {
+			return errors.Messagef("invalid.category.alias_taken", "alias %q already refers to %s", a, ref)
+		}
+	}
+	s.Categories = append(s.Categories, c)
+	return nil
+}
+
+// Rename gives the category at path a new name under the same parent. The
+// old name is kept as an alias so existing habits keep working.
+func (s *Set) Rename(path, newName string) (Migration, error) {
+	i := s.index(path)
+	if i < 0 {
+		return Migration{}, errors.Messagef("invalid.category.unknown", "unknown category %q", path)
+	}
+	newName = strings.TrimSpace(newName)
+	if err := validName(newName); err != nil {
+		return Migration{}, err
+	}
+	old := s.Categories[i]
+	renamed := old
+	renamed.Name = newName
+	if j := s.index(renamed.Path()); j >= 0 && j != i {
+		return Migration{}, errors.Messagef("invalid.category.exists", "category %q already exists", renamed.Path())
+	}
+	if !strings.EqualFold(old.Name, newName) {
+		renamed.Aliases = append(renamed.Aliases, old.Name)
+	}
+	s.Categories[i] = renamed
+	if old.Parent == "" {
+		for j := range s.Categories {
+			if strings.EqualFold(s.Categories[j].Parent, old.Name) {
+				s.Categories[j].Parent = newName
+			}
+		}
+	}
+	return Migration{From: old.Ref(), To: renamed.Ref()}, nil
+}
+
+// Merge folds the category at from into the category at into. The merged
+// name and aliases become aliases of into; subcategories of a merged
+// top-level category move under into.
+func (s *Set) Merge(from, into string) (Migration, error) {
+	i, j := s.index(from), s.index(into)
+	if i < 0 {
+		return Migration{}, errors.Messagef("invalid.category.unknown", "unknown category %q", from)
+	}
+	if j < 0 {
+		return Migration{}, errors.Messagef("invalid.category.unknown", "unknown category %q", into)
+	}
+	if i == j {
+		return Migration{}, errors.Messagef("invalid.category.merge_itself", "cannot merge %q into itself", from)
+	}
+	src, dst := s.Categories[i], s.Categories[j]
+	children := s.Children(src.Name)
+	if src.Parent == "" && len(children) > 0 {
+		if dst.Parent != "" {
+			return Migration{}, errors.Messagef("invalid.category.merge_parent", "%q has subcategories and can only be merged into a top-level category", src.Name)
+		}
+		for _, child := range children {
+			if _, clash := s.Find(dst.Name + Separator + child.Name); clash {
+				return Migration{}, errors.Messagef("invalid.category.merge_conflict", "%q already has a subcategory %q", dst.Name, child.Name)
+			}
+		}
+	}
+
+	s.Categories[j].Aliases = append(s.Categories[j].Aliases, src.Name)
+	s.Categories[j].Aliases = append(s.Categories[j].Aliases, src.Aliases...)
+	if src.Parent == "" {
+		for k := range s.Categories {
+			if strings.EqualFold(s.Categories[k].Parent, src.Name) {
+				s.Categories[k].Parent = dst.Name
+			}
+		}
+	}
+	s.Categories = append(s.Categories[:i], s.Categories[i+1:]...)
+	return Migration{From: src.Ref(), To: dst.Ref()}, nil
+}
+
+// PromptLines describes every category for the AI prompt, one per line.
+// Input: Eating Out › Coffee with alias "kopi" and description "cafes"
+// Output: "Eating Out › Coffee (also: kopi) – cafes"
+func (s Set) PromptLines() []string {
+	lines := make([]string, 0, len(s.Categories))
+	for _, c := range s.Categories {
+		line := c.Path()
+		if len(c.Aliases) > 0 {
+			line += " (also: " + strings.Join(c.Aliases, ", ") + ")"
+		}
+		if c.Description != "" {
+			line += " – " + c.Description
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func validName(name string) error {
+	if name == "" {
+		return errors.Messagef("invalid.category.name_required", "category name is required")
+	}
+	if strings.ContainsAny(name, "›>") {
+		return errors.Messagef("invalid.category.name_invalid", "category name %q cannot contain › or >", name)
+	}
+	return nil
+}
+
+// Migration describes how recorded transactions move after a rename or merge
+type Migration struct {
+	From Ref
+	To   Ref
+}
+
+// Apply returns the new category of a recorded transaction and whether it
+// changed. A top-level move keeps the subcategory of each row unless the
+// target is itself a subcategory.
+// Input: From "Eating Out", To "Dining" applied to "Eating Out › Coffee"
+// Output: "Dining › Coffee", true
+func (m Migration) Apply(r Ref) (Ref, bool) {
+	if !strings.EqualFold(r.Category, m.From.Category) {
+		return r, false
+	}
+	if m.From.Subcategory != "" && !strings.EqualFold(r.Subcategory, m.From.Subcategory) {
+		return r, false
+	}
+	out := Ref{Category: m.To.Category, Subcategory: m.To.Subcategory}
+	if m.From.Subcategory == "" && m.To.Subcategory == "" {
+		out.Subcategory = r.Subcategory
+	}
+	return out, out != r
+}
@@ -0,0 +1,181 @@
+package category_domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func testSet(t *testing.T) Set {
+	t.Helper()
+	s := DefaultSet([]string{"Groceries", "Eating Out", "Health", "Emergency"})
+	if err := s.Add(Category{Name: "Coffee", Parent: "eating out", Aliases: []string{"kopi"}, Description: "cafes and coffee shops"}); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	return s
+}
+
+func TestSet_Match(t *testing.T) {
+	s := testSet(t)
+	testCases := []struct {
+		name     string
+		expected Ref
+		found    bool
+	}{
+		{name: "groceries", expected: Ref{Category: "Groceries"}, found: true},
+		{name: "Eating Out › Coffee", expected: Ref{Category: "Eating Out", Subcategory: "Coffee"}, found: true},
+		{name: "eating out > coffee", expected: Ref{Category: "Eating Out", Subcategory: "Coffee"}, found: true},
+		{name: "coffee", expected: Ref{Category: "Eating Out", Subcategory: "Coffee"}, found: true},
+		{name: "KOPI", expected: Ref{Category: "Eating Out", Subcategory: "Coffee"}, found: true},
+		{name: "Pets", found: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := s.Match(tc.name)
+			if ok != tc.found || got != tc.expected {
+				t.Errorf("expected %+v, %v, got %+v, %v", tc.expected, tc.found, got, ok)
+			}
+		})
+	}
+}
+
+func TestSet_AddValidation(t *testing.T) {
+	s := testSet(t)
+	if err := s.Add(Category{Name: "groceries"}); err == nil {
+		t.Error("expected error for duplicate category")
+	}
+	if err := s.Add(Category{Name: "Tea", Parent: "Drinks"}); err == nil {
+		t.Error("expected error for unknown parent")
+	}
+	if err := s.Add(Category{Name: "Latte", Parent: "Eating Out › Coffee"}); err == nil {
+		t.Error("expected error for nested subcategory")
+	}
+	if err := s.Add(Category{Name: "Pets", Aliases: []string{"kopi"}}); err == nil {
+		t.Error("expected error for alias already in use")
+	}
+	if err := s.Add(Category{Name: "A > B"}); err == nil {
+		t.Error("expected error for separator in name")
+	}
+}
+
+func TestSet_RenameTopLevel(t *testing.T) {
+	s := testSet(t)
+	m, err := s.Rename("Eating Out", "Dining")
+	if err != nil {
+		t.Fatalf("rename failed: %v", err)
+	}
+	if m.From != (Ref{Category: "Eating Out"}) || m.To != (Ref{Category: "Dining"}) {
+		t.Errorf("unexpected migration: %+v", m)
+	}
+	if _, ok := s.Find("Dining › Coffee"); !ok {
+		t.Error("subcategories should follow their renamed parent")
+	}
+	if ref, ok := s.Match("eating out"); !ok || ref.Category != "Dining" {
+		t.Errorf("old name should stay as alias, got %+v", ref)
+	}
+	if _, err := s.Rename("Dining", "Groceries"); err == nil {
+		t.Error("expected error when renaming onto an existing category")
+	}
+}
+
+func TestSet_Merge(t *testing.T) {
+	s := testSet(t)
+	m, err := s.Merge("Emergency", "Health")
+	if err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+	if _, ok := s.Find("Emergency"); ok {
+		t.Error("merged category should be removed")
+	}
+	if ref, _ := s.Match("emergency"); ref.Category != "Health" {
+		t.Errorf("merged name should resolve to the target, got %+v", ref)
+	}
+	if m.To != (Ref{Category: "Health"}) {
+		t.Errorf("unexpected migration: %+v", m)
+	}
+
+	m, err = s.Merge("Eating Out › Coffee", "Eating Out")
+	if err != nil || m.From.Subcategory != "Coffee" || m.To != (Ref{Category: "Eating Out"}) {
+		t.Errorf("unexpected subcategory merge: %+v, %v", m, err)
+	}
+	if _, err := s.Merge("Health", "Health"); err == nil {
+		t.Error("expected error when merging into itself")
+	}
+}
+
+func TestSet_MergeWithChildren(t *testing.T) {
+	s := testSet(t)
+	s.Add(Category{Name: "Lunch", Parent: "Groceries"})
+	if _, err := s.Merge("Eating Out", "Groceries › Lunch"); err == nil {
+		t.Error("categories with subcategories cannot merge into a subcategory")
+	}
+	if _, err := s.Merge("Eating Out", "Groceries"); err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+	if _, ok := s.Find("Groceries › Coffee"); !ok {
+		t.Error("subcategories should move under the merge target")
+	}
+}
+
+func TestMigration_Apply(t *testing.T) {
+	testCases := []struct {
+		name      string
+		migration Migration
+		row       Ref
+		expected  Ref
+		changed   bool
+	}{
+		{
+			name:      "top-level rename keeps subcategory",
+			migration: Migration{From: Ref{Category: "Eating Out"}, To: Ref{Category: "Dining"}},
+			row:       Ref{Category: "eating out", Subcategory: "Coffee"},
+			expected:  Ref{Category: "Dining", Subcategory: "Coffee"},
+			changed:   true,
+		},
+		{
+			name:      "subcategory merge into parent clears subcategory",
+			migration: Migration{From: Ref{Category: "Eating Out", Subcategory: "Coffee"}, To: Ref{Category: "Eating Out"}},
+			row:       Ref{Category: "Eating Out", Subcategory: "Coffee"},
+			expected:  Ref{Category: "Eating Out"},
+			changed:   true,
+		},
+		{
+			name:      "other subcategory untouched",
+			migration: Migration{From: Ref{Category: "Eating Out", Subcategory: "Coffee"}, To: Ref{Category: "Eating Out"}},
+			row:       Ref{Category: "Eating Out", Subcategory: "Lunch"},
+			expected:  Ref{Category: "Eating Out", Subcategory: "Lunch"},
+		},
+		{
+			name:      "other category untouched",
+			migration: Migration{From: Ref{Category: "Emergency"}, To: Ref{Category: "Health"}},
+			row:       Ref{Category: "Groceries"},
+			expected:  Ref{Category: "Groceries"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, changed := tc.migration.Apply(tc.row)
+			if got != tc.expected || changed != tc.changed {
+				t.Errorf("expected %+v, %v, got %+v, %v", tc.expected, tc.changed, got, changed)
+			}
+		})
+	}
+}
+
+func TestSet_PromptLines(t *testing.T) {
+	lines := testSet(t).PromptLines()
+	if got := lines[len(lines)-1]; got != "Eating Out › Coffee (also: kopi) – cafes and coffee shops" {
+		t.Errorf("unexpected prompt line: %s", got)
+	}
+	if !strings.Contains(strings.Join(lines, "\n"), "Groceries") {
+		t.Error("top-level categories should be listed")
+	}
+}
+
+func TestSet_CloneIsIndependent(t *testing.T) {
+	s := testSet(t)
+	c := s.Clone()
+	c.Rename("Eating Out › Coffee", "Cafe")
+	if _, ok := s.Find("Eating Out › Coffee"); !ok {
+		t.Error("changing a clone must not change the original")
+	}
+}
@@ -0,0 +1,105 @@
+package currency_domain
+
+import (
+	"encoding/csv"
+	"io"
+	"money-tracker-bot/internal/errors"
+	"strings"
+)
+
+// ParseCSV reads exchange rates for base from CSV with the columns date,
+// currency and rate, such as "2025-07-01,USD,16200". A header row naming the
+// columns is optional and may order them differently. Blank lines are
+// skipped; the first invalid line fails the whole import with its number.
+func ParseCSV(r io.Reader, base string) ([]Rate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	columns := map[string]int{"date": 0, "currency": 1, "rate": 2}
+	var rates []Rate
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if parseErr, ok := err.(*csv.ParseError); ok {
+			return nil, errors.Messagef("invalid.currency.csv_malformed", "line %d is not valid CSV", parseErr.Line)
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if first {
+			// Spreadsheet apps often start exported CSV with a byte order mark
+			record[0] = strings.TrimPrefix(record[0], "\ufeff")
+		}
+		if blank(record) {
+			continue
+		}
+		if first && isHeader(record) {
+			columns = headerColumns(record)
+			if len(columns) != 3 {
+				return nil, errors.Messagef("invalid.currency.csv_header", "line %d: header must name the date, currency and rate columns", line)
+			}
+			continue
+		}
+		rate, err := parseRecord(record, columns, base)
+		if err != nil {
+			return nil, errors.Messagef("invalid.currency.csv_line", "line %d: %s", line, err)
+		}
+		rates = append(rates, rate)
+	}
+	if len(rates) == 0 {
+		return nil, errors.Messagef("invalid.currency.no_rates", "no rates found")
+	}
+	return rates, nil
+}
+
+func parseRecord(record []string, columns map[string]int, base string) (Rate, error) {
+	field := func(name string) string {
+		if i := columns[name]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	code, ok := ParseCode(field("currency"))
+	if !ok {
+		return Rate{}, errors.Messagef("invalid.currency.unknown", "unknown currency %q", field("currency"))
+	}
+	value, err := ParseRate(field("rate"))
+	if err != nil {
+		return Rate{}, err
+	}
+	rate := Rate{Base: base, Currency: code, Rate: value, Date: field("date"), Source: SourceCSV}
+	return rate, rate.Validate()
+}
+
+func blank(record []string) bool {
+	for _, f := range record {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func isHeader(record []string) bool {
+	for _, f := range record {
+		if strings.EqualFold(strings.TrimSpace(f), "rate") {
+			return true
+		}
+	}
+	return false
+}
+
+func headerColumns(record []string) map[string]int {
+	columns := make(map[string]int)
+	for i, f := range record {
+		switch name := strings.ToLower(strings.TrimSpace(f)); name {
+		case "date", "currency", "rate":
+			columns[name] = i
+		}
+	}
+	return columns
+}
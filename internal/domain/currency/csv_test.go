@@ -0,0 +1,48 @@
+package currency_domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	input := "\ufeffcurrency,date,rate\nusd,2025-07-01,\"16,200\"\n\nS$, 2025-07-02 ,12500.5\n"
+	rates, err := ParseCSV(strings.NewReader(input), "IDR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rates) != 2 {
+		t.Fatalf("expected 2 rates, got %+v", rates)
+	}
+	want := Rate{Base: "IDR", Currency: "USD", Rate: 16200, Date: "2025-07-01", Source: SourceCSV}
+	if rates[0] != want {
+		t.Errorf("expected %+v, got %+v", want, rates[0])
+	}
+	if rates[1].Currency != "SGD" || rates[1].Rate != 12500.5 || rates[1].Date != "2025-07-02" {
+		t.Errorf("unexpected rate %+v", rates[1])
+	}
+}
+
+func TestParseCSV_WithoutHeader(t *testing.T) {
+	rates, err := ParseCSV(strings.NewReader("2025-07-01,EUR,17600\n"), "IDR")
+	if err != nil || len(rates) != 1 || rates[0].Currency != "EUR" {
+		t.Errorf("unexpected rates %+v, %v", rates, err)
+	}
+}
+
+func TestParseCSV_Errors(t *testing.T) {
+	cases := map[string]string{
+		"2025-07-01,USD,16200\n2025-07-02,XYZ,1\n": "line 2: unknown currency",
+		"date,currency,rate\n\n2025-13-01,USD,1\n": "line 3: invalid date",
+		"date,amount,rate\n2025-07-01,USD,1\n":     "line 1: header",
+		"2025-07-01,USD,lots\n":                    "line 1: invalid rate",
+		"date,currency,rate\n":                     "no rates",
+		"2025-07-01,\"USD,1\n":                     "line 1 is not valid CSV",
+	}
+	for input, want := range cases {
+		_, err := ParseCSV(strings.NewReader(input), "IDR")
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseCSV(%q) error = %v; want %q", input, err, want)
+		}
+	}
+}
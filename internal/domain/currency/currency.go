@@ -0,0 +1,131 @@
+package currency_domain
+
+// Package currency models currencies and the exchange rates each chat
+// keeps, so amounts paid in other currencies can be converted to the base
+// currency of the spreadsheet.
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Default is the base currency of the spreadsheet unless configured otherwise
+const Default = "IDR"
+
+// codes are the ISO 4217 codes the bot recognizes. An allow list keeps
+// three-letter words such as "THE" from being read as currencies.
+var codes = map[string]bool{
+	"IDR": true, "USD": true, "SGD": true, "MYR": true, "EUR": true, "GBP": true,
+	"JPY": true, "AUD": true, "THB": true, "CNY": true, "HKD": true, "KRW": true,
+	"PHP": true, "VND": true, "INR": true, "SAR": true, "AED": true, "CHF": true,
+	"CAD": true, "NZD": true, "TWD": true,
+}
+
+// symbols maps currency symbols and local abbreviations, upper-cased, to codes
+var symbols = map[string]string{
+	"RP":  "IDR",
+	"$":   "USD",
+	"US$": "USD",
+	"S$":  "SGD",
+	"A$":  "AUD",
+	"HK$": "HKD",
+	"NZ$": "NZD",
+	"RM":  "MYR",
+	"€":   "EUR",
+	"£":   "GBP",
+	"¥":   "JPY",
+	"฿":   "THB",
+	"₩":   "KRW",
+	"₱":   "PHP",
+	"₫":   "VND",
+	"₹":   "INR",
+}
+
+// zeroDecimal are the currencies without minor units
+var zeroDecimal = map[string]bool{"IDR": true, "JPY": true, "KRW": true, "VND": true}
+
+// Codes returns the recognized currency codes in alphabetical order
+func Codes() []string {
+	out := make([]string, 0, len(codes))
+	for code := range codes {
+		out = append(out, code)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// ParseCode returns the ISO code of a code or symbol, ignoring case and
+// surrounding spaces.
+// Input: "usd", "S$", "Rp"
+// Output: "USD", "SGD", "IDR"
+func ParseCode(s string) (string, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if codes[s] {
+		return s, true
+	}
+	code, ok := symbols[s]
+	return code, ok
+}
+
+var (
+	// symbolAmount is a symbol or abbreviation written before a number
+	symbolAmount = regexp.MustCompile(`(?i)(?:^|[^\p{L}])(us\$|s\$|a\$|hk\$|nz\$|\$|€|£|¥|฿|₩|₱|₫|₹|rm|rp)\s?\d`)
+	// codeAmount is an upper-case code written before or after a number
+	codeAmount = regexp.MustCompile(`\b([A-Z]{3})\s?\d|\d\s?([A-Z]{3})\b`)
+)
+
+// Detect returns the currency an amount in free text is written in, such as
+// "S$12.50", "$5", "USD 20" or "20 EUR". It reports false when the text names
+// no currency, which callers read as the base currency.
+func Detect(text string) (string, bool) {
+	type match struct {
+		at   int
+		code string
+	}
+	var found []match
+	for _, m := range symbolAmount.FindAllStringSubmatchIndex(text, -1) {
+		if code, ok := ParseCode(text[m[2]:m[3]]); ok {
+			found = append(found, match{m[2], code})
+		}
+	}
+	for _, m := range codeAmount.FindAllStringSubmatchIndex(text, -1) {
+		for g := 2; g < len(m); g += 2 {
+			if m[g] < 0 {
+				continue
+			}
+			if code, ok := ParseCode(text[m[g]:m[g+1]]); ok {
+				found = append(found, match{m[g], code})
+			}
+		}
+	}
+	if len(found) == 0 {
+		return "", false
+	}
+	first := found[0]
+	for _, m := range found[1:] {
+		if m.at < first.at {
+			first = m
+		}
+	}
+	return first.code, true
+}
+
+// MinorUnits returns the number of decimals amounts in the currency have
+// Input: "IDR", "USD"
+// Output: 0, 2
+func MinorUnits(code string) int {
+	if zeroDecimal[code] {
+		return 0
+	}
+	return 2
+}
+
+// Round rounds an amount to the minor units of the currency
+// Input: 12.345, "USD"
+// Output: 12.35
+func Round(amount float64, code string) float64 {
+	scale := math.Pow(10, float64(MinorUnits(code)))
+	return math.Round(amount*scale) / scale
+}
@@ -0,0 +1,50 @@
+package currency_domain
+
+import "testing"
+
+func TestParseCode(t *testing.T) {
+	for in, want := range map[string]string{"usd": "USD", " S$ ": "SGD", "Rp": "IDR", "rm": "MYR", "€": "EUR", "JPY": "JPY"} {
+		if got, ok := ParseCode(in); !ok || got != want {
+			t.Errorf("ParseCode(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	for _, in := range []string{"", "THE", "dollars", "XYZ"} {
+		if got, ok := ParseCode(in); ok {
+			t.Errorf("ParseCode(%q) = %q; want not ok", in, got)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	cases := map[string]string{
+		"lunch S$12.50 at hawker":  "SGD",
+		"coffee $5":                "USD",
+		"taxi USD 20":              "USD",
+		"museum ticket 15 EUR":     "EUR",
+		"nasi padang rp 25.000":    "IDR",
+		"teh tarik RM4":            "MYR",
+		"hotel 300 SGD, tip US$10": "SGD",
+	}
+	for text, want := range cases {
+		if got, ok := Detect(text); !ok || got != want {
+			t.Errorf("Detect(%q) = %q, %v; want %q", text, got, ok, want)
+		}
+	}
+	for _, text := range []string{"lunch 50k", "THE 3 of us paid 90000", "perform 5 tasks"} {
+		if got, ok := Detect(text); ok {
+			t.Errorf("Detect(%q) = %q; want no currency", text, got)
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	if MinorUnits("IDR") != 0 || MinorUnits("USD") != 2 {
+		t.Error("unexpected minor units")
+	}
+	if got := Round(12.345, "USD"); got != 12.35 {
+		t.Errorf("expected 12.35, got %v", got)
+	}
+	if got := Round(202500.4, "IDR"); got != 202500 {
+		t.Errorf("expected 202500, got %v", got)
+	}
+}
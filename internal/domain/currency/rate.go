@@ -0,0 +1,128 @@
+package currency_domain
+
+import (
+	"fmt"
+	"money-tracker-bot/internal/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Sources of a rate
+const (
+	SourceManual = "manual"
+	SourceCSV    = "csv"
+)
+
+// Rate is the exchange rate a chat uses for one currency from Date on, until
+// a rate with a later date replaces it
+type Rate struct {
+	ChatID int64 `json:"chat_id"`
+	// Base is the currency amounts are converted to, so rates stay correct
+	// if the base currency is reconfigured
+	Base     string `json:"base"`
+	Currency string `json:"currency"`
+	// Rate is how many units of Base one unit of Currency buys
+	Rate float64 `json:"rate"`
+	// Date is the first day (YYYY-MM-DD) the rate applies to
+	Date   string `json:"date"`
+	SetBy  string `json:"set_by,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
+// Validate checks the currencies, the rate and the date
+func (r Rate) Validate() error {
+	if _, ok := ParseCode(r.Currency); !ok {
+		return errors.Messagef("invalid.currency.unknown", "unknown currency %q", r.Currency)
+	}
+	if _, ok := ParseCode(r.Base); !ok {
+		return errors.Messagef("invalid.currency.unknown_base", "unknown base currency %q", r.Base)
+	}
+	if r.Currency == r.Base {
+		return errors.Messagef("invalid.currency.is_base", "%s is the base currency", r.Currency)
+	}
+	if r.Rate <= 0 {
+		return errors.Messagef("invalid.currency.rate_positive", "rate must be positive")
+	}
+	if _, err := time.Parse(dateLayout, r.Date); err != nil {
+		return errors.Messagef("invalid.currency.invalid_date", "invalid date %q, expected YYYY-MM-DD", r.Date)
+	}
+	return nil
+}
+
+// Key identifies the rate a newer one with the same currencies and date replaces
+func (r Rate) Key() string {
+	return fmt.Sprintf("%d/%s/%s/%s", r.ChatID, r.Base, r.Currency, r.Date)
+}
+
+// Convert returns amount in the base currency, rounded to its minor units
+// Input: 12.5 with a USD→IDR rate of 16200
+// Output: 202500
+func (r Rate) Convert(amount float64) float64 {
+	return Round(amount*r.Rate, r.Base)
+}
+
+// ParseRate reads a rate written with optional thousands commas, or with
+// thousands dots when there are several. A single dot followed by three
+// digits could be either, so "16.200" is rejected rather than read as 16.2.
+// Input: "16,200", "1.234.567", "0.0091", "0.125"
+// Output: 16200, 1234567, 0.0091, 0.125
+func ParseRate(s string) (float64, error) {
+	t := strings.TrimSpace(s)
+	if strings.Count(t, ".") > 1 {
+		t = strings.ReplaceAll(t, ".", "")
+	} else if dot := strings.Index(t, "."); dot > 0 && len(t)-dot == 4 && !strings.Contains(t, ",") && t[:dot] != "0" {
+		return 0, errors.Messagef("invalid.currency.ambiguous_rate", "rate %q is ambiguous, write thousands without dots (16200) or decimals with fewer or more digits (16.2)", s)
+	}
+	v, err := strconv.ParseFloat(strings.ReplaceAll(t, ",", ""), 64)
+	if err != nil || v <= 0 {
+		return 0, errors.Messagef("invalid.currency.invalid_rate", "invalid rate %q", s)
+	}
+	return v, nil
+}
+
+// Find returns the rate of currency to base on date: the latest one dated on
+// or before it, else the earliest one after it, so a transaction older than
+// every rate still converts.
+func Find(rates []Rate, base, currency, date string) (Rate, bool) {
+	var before, after Rate
+	var hasBefore, hasAfter bool
+	for _, r := range rates {
+		if r.Base != base || r.Currency != currency {
+			continue
+		}
+		if r.Date <= date {
+			if !hasBefore || r.Date > before.Date {
+				before, hasBefore = r, true
+			}
+		} else if !hasAfter || r.Date < after.Date {
+			after, hasAfter = r, true
+		}
+	}
+	if hasBefore {
+		return before, true
+	}
+	return after, hasAfter
+}
+
+// Latest returns the newest rate of each currency to base, ordered by currency
+func Latest(rates []Rate, base string) []Rate {
+	latest := make(map[string]Rate)
+	for _, r := range rates {
+		if r.Base != base {
+			continue
+		}
+		if cur, ok := latest[r.Currency]; !ok || r.Date >= cur.Date {
+			latest[r.Currency] = r
+		}
+	}
+	out := make([]Rate, 0, len(latest))
+	for _, code := range Codes() {
+		if r, ok := latest[code]; ok {
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+package currency_domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRate_Validate(t *testing.T) {
+	valid := Rate{Base: "IDR", Currency: "USD", Rate: 16200, Date: "2025-07-01"}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid rate, got %v", err)
+	}
+	invalid := []Rate{
+		{Base: "IDR", Currency: "XYZ", Rate: 1, Date: "2025-07-01"},
+		{Base: "IDR", Currency: "IDR", Rate: 1, Date: "2025-07-01"},
+		{Base: "IDR", Currency: "USD", Rate: 0, Date: "2025-07-01"},
+		{Base: "IDR", Currency: "USD", Rate: 1, Date: "01/07/2025"},
+	}
+	for _, r := range invalid {
+		if err := r.Validate(); err == nil {
+			t.Errorf("expected error for %+v", r)
+		}
+	}
+}
+
+func TestRate_Convert(t *testing.T) {
+	r := Rate{Base: "IDR", Currency: "USD", Rate: 16200.5}
+	if got := r.Convert(12.5); got != 202506 {
+		t.Errorf("expected 202506, got %v", got)
+	}
+	r = Rate{Base: "SGD", Currency: "IDR", Rate: 0.0000823}
+	if got := r.Convert(150000); got != 12.35 {
+		t.Errorf("expected 12.35, got %v", got)
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	if v, err := ParseRate("16,200"); err != nil || v != 16200 {
+		t.Errorf("expected 16200, got %v, %v", v, err)
+	}
+	if v, err := ParseRate("0.0091"); err != nil || v != 0.0091 {
+		t.Errorf("expected 0.0091, got %v, %v", v, err)
+	}
+	for s, want := range map[string]float64{"1.234.567": 1234567, "0.125": 0.125, "1,085.125": 1085.125} {
+		if v, err := ParseRate(s); err != nil || v != want {
+			t.Errorf("expected %v for %q, got %v, %v", want, s, v, err)
+		}
+	}
+	// "16.200" may be sixteen thousand two hundred or 16.2
+	if v, err := ParseRate("16.200"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("expected an ambiguous rate error, got %v, %v", v, err)
+	}
+	for _, s := range []string{"", "abc", "-5", "0"} {
+		if _, err := ParseRate(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	rates := []Rate{
+		{Base: "IDR", Currency: "USD", Rate: 16000, Date: "2025-06-01"},
+		{Base: "IDR", Currency: "USD", Rate: 16200, Date: "2025-07-01"},
+		{Base: "IDR", Currency: "USD", Rate: 16400, Date: "2025-08-01"},
+		{Base: "IDR", Currency: "SGD", Rate: 12500, Date: "2025-07-15"},
+		{Base: "MYR", Currency: "USD", Rate: 4.2, Date: "2025-07-01"},
+	}
+	cases := []struct {
+		currency, date string
+		want           float64
+	}{
+		{"USD", "2025-07-20", 16200},
+		{"USD", "2025-08-01", 16400},
+		{"USD", "2025-05-01", 16000},
+		{"SGD", "2025-07-01", 12500},
+	}
+	for _, c := range cases {
+		r, ok := Find(rates, "IDR", c.currency, c.date)
+		if !ok || r.Rate != c.want {
+			t.Errorf("Find(%s, %s) = %v, %v; want %v", c.currency, c.date, r.Rate, ok, c.want)
+		}
+	}
+	if _, ok := Find(rates, "IDR", "EUR", "2025-07-01"); ok {
+		t.Error("expected no EUR rate")
+	}
+
+	latest := Latest(rates, "IDR")
+	if len(latest) != 2 || latest[0].Currency != "SGD" || latest[1].Rate != 16400 {
+		t.Errorf("unexpected latest rates %+v", latest)
+	}
+}
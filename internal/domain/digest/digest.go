@@ -0,0 +1,198 @@
+package digest_domain
+
+import (
+	"money-tracker-bot/internal/errors"
+	"sort"
+	"time"
+
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+const (
+	// DefaultTimezone matches the timezone used for spreadsheet timestamps
+	DefaultTimezone = "Asia/Bangkok"
+	// DefaultDailyTime is when the end-of-day summary is sent
+	DefaultDailyTime = "21:00"
+	// DefaultWeeklyTime is when the Sunday evening digest is sent
+	DefaultWeeklyTime = "19:00"
+
+	dateLayout = "2006-01-02"
+)
+
+// Kind identifies which digest is being built.
+type Kind string
+
+const (
+	KindDaily  Kind = "daily"
+	KindWeekly Kind = "weekly"
+)
+
+// Schedule holds the digest preferences of a single chat.
+// LastDaily and LastWeekly record the local date of the last delivery so a
+// digest is never sent twice on the same day, even across restarts.
+type Schedule struct {
+	ChatID        int64  `json:"chat_id"`
+	Timezone      string `json:"timezone"`
+	DailyEnabled  bool   `json:"daily_enabled"`
+	DailyTime     string `json:"daily_time"`
+	WeeklyEnabled bool   `json:"weekly_enabled"`
+	WeeklyTime    string `json:"weekly_time"`
+	LastDaily     string `json:"last_daily,omitempty"`
+	LastWeekly    string `json:"last_weekly,omitempty"`
+}
+
+// NewSchedule returns a disabled schedule with default times for chatID.
+func NewSchedule(chatID int64) Schedule {
+	return Schedule{
+		ChatID:     chatID,
+		Timezone:   DefaultTimezone,
+		DailyTime:  DefaultDailyTime,
+		WeeklyTime: DefaultWeeklyTime,
+	}
+}
+
+// Location resolves the schedule timezone, falling back to DefaultTimezone.
+func (s Schedule) Location() (*time.Location, error) {
+	tz := s.Timezone
+	if tz == "" {
+		tz = DefaultTimezone
+	}
+	return time.LoadLocation(tz)
+}
+
+// DailyDue reports whether the daily digest should be sent at now.
+func (s Schedule) DailyDue(now time.Time) (bool, error) {
+	if !s.DailyEnabled {
+		return false, nil
+	}
+	return s.due(now, s.DailyTime, DefaultDailyTime, s.LastDaily)
+}
+
+// WeeklyDue reports whether the weekly digest should be sent at now.
+// Weekly digests go out on Sundays in the schedule timezone.
+func (s Schedule) WeeklyDue(now time.Time) (bool, error) {
+	if !s.WeeklyEnabled {
+		return false, nil
+	}
+	loc, err := s.Location()
+	if err != nil {
+		return false, err
+	}
+	if now.In(loc).Weekday() != time.Sunday {
+		return false, nil
+	}
+	return s.due(now, s.WeeklyTime, DefaultWeeklyTime, s.LastWeekly)
+}
+
+// LocalDate returns now as a YYYY-MM-DD date in the schedule timezone.
+func (s Schedule) LocalDate(now time.Time) (string, error) {
+	loc, err := s.Location()
+	if err != nil {
+		return "", err
+	}
+	return now.In(loc).Format(dateLayout), nil
+}
+
+func (s Schedule) due(now time.Time, at, fallback, lastSent string) (bool, error) {
+	loc, err := s.Location()
+	if err != nil {
+		return false, err
+	}
+	if at == "" {
+		at = fallback
+	}
+	hour, minute, err := ParseTimeOfDay(at)
+	if err != nil {
+		return false, err
+	}
+	local := now.In(loc)
+	if lastSent == local.Format(dateLayout) {
+		return false, nil
+	}
+	sendAt := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+	return !local.Before(sendAt), nil
+}
+
+// ParseTimeOfDay parses a 24-hour "HH:MM" string.
+// Input: "21:30"
+// Output: 21, 30, nil
+func ParseTimeOfDay(value string) (int, int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, 0, errors.Messagef("invalid.digest.invalid_time", "invalid time of day %q, expected HH:MM", value)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// CategoryTotal is the amount spent in one category during the digest period.
+type CategoryTotal struct {
+	Category string
+	Amount   float64
+}
+
+// Report is the content of a digest before it is rendered for the user.
+type Report struct {
+	Kind       Kind
+	From       string
+	To         string
+	Total      float64
+	Count      int
+	Categories []CategoryTotal
+	BudgetLeft float64
+	DaysLeft   int
+	PerDayLeft float64
+}
+
+// BuildReport aggregates trxs dated within [from, to] (inclusive, YYYY-MM-DD
+// dates) and spreads budgetLeft over the days remaining in the month of to.
+// Savings goal contributions and transfers are excluded from spending.
+func BuildReport(kind Kind, trxs []transaction_domain.Transaction, from, to time.Time, budgetLeft float64) Report {
+	fromDate := from.Format(dateLayout)
+	toDate := to.Format(dateLayout)
+	report := Report{
+		Kind:       kind,
+		From:       fromDate,
+		To:         toDate,
+		BudgetLeft: budgetLeft,
+	}
+
+	totals := make(map[string]float64)
+	for _, trx := range trxs {
+		if trx.TransactionDate < fromDate || trx.TransactionDate > toDate {
+			continue
+		}
+		// Money put aside for a savings goal or paid back to someone is not spending
+		if trx.Goal != "" || trx.IsTransfer() {
+			continue
+		}
+		amount := trx.AmountValue()
+		report.Total += amount
+		report.Count++
+		totals[trx.Category] += amount
+	}
+	for category, amount := range totals {
+		report.Categories = append(report.Categories, CategoryTotal{Category: category, Amount: amount})
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		if report.Categories[i].Amount != report.Categories[j].Amount {
+			return report.Categories[i].Amount > report.Categories[j].Amount
+		}
+		return report.Categories[i].Category < report.Categories[j].Category
+	})
+
+	report.DaysLeft = DaysLeftInMonth(to)
+	if report.DaysLeft > 0 {
+		report.PerDayLeft = budgetLeft / float64(report.DaysLeft)
+	} else {
+		report.PerDayLeft = budgetLeft
+	}
+	return report
+}
+
+// DaysLeftInMonth counts the days after day until the end of its month.
+// Input: 2025-07-29
+// Output: 2
+func DaysLeftInMonth(day time.Time) int {
+	lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+	return lastDay - day.Day()
+}
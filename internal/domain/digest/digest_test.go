@@ -0,0 +1,125 @@
+package digest_domain
+
+import (
+	"testing"
+	"time"
+
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+var bangkok = time.FixedZone("UTC+7", 7*60*60)
+
+func TestSchedule_DailyDue(t *testing.T) {
+	s := NewSchedule(1)
+	s.DailyEnabled = true
+
+	testCases := []struct {
+		name      string
+		now       time.Time
+		lastDaily string
+		expected  bool
+	}{
+		{name: "before send time", now: time.Date(2025, 7, 10, 20, 59, 0, 0, bangkok), expected: false},
+		{name: "at send time", now: time.Date(2025, 7, 10, 21, 0, 0, 0, bangkok), expected: true},
+		{name: "already sent today", now: time.Date(2025, 7, 10, 22, 0, 0, 0, bangkok), lastDaily: "2025-07-10", expected: false},
+		{name: "utc instant converted to local", now: time.Date(2025, 7, 10, 14, 30, 0, 0, time.UTC), expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s.LastDaily = tc.lastDaily
+			got, err := s.DailyDue(tc.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_DailyDisabled(t *testing.T) {
+	s := NewSchedule(1)
+	due, err := s.DailyDue(time.Date(2025, 7, 10, 23, 0, 0, 0, bangkok))
+	if err != nil || due {
+		t.Errorf("disabled schedule should never be due, got %v, %v", due, err)
+	}
+}
+
+func TestSchedule_WeeklyDueOnlyOnSunday(t *testing.T) {
+	s := NewSchedule(1)
+	s.WeeklyEnabled = true
+
+	saturday := time.Date(2025, 7, 12, 20, 0, 0, 0, bangkok)
+	if due, _ := s.WeeklyDue(saturday); due {
+		t.Error("weekly digest should not be due on Saturday")
+	}
+	sunday := time.Date(2025, 7, 13, 19, 30, 0, 0, bangkok)
+	if due, _ := s.WeeklyDue(sunday); !due {
+		t.Error("weekly digest should be due on Sunday evening")
+	}
+	s.LastWeekly = "2025-07-13"
+	if due, _ := s.WeeklyDue(sunday); due {
+		t.Error("weekly digest should not be sent twice on the same Sunday")
+	}
+}
+
+func TestSchedule_InvalidSettings(t *testing.T) {
+	s := NewSchedule(1)
+	s.DailyEnabled = true
+	s.DailyTime = "9pm"
+	if _, err := s.DailyDue(time.Now()); err == nil {
+		t.Error("expected error for invalid time of day")
+	}
+	s.DailyTime = DefaultDailyTime
+	s.Timezone = "Mars/Olympus"
+	if _, err := s.DailyDue(time.Now()); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+}
+
+func TestBuildReport(t *testing.T) {
+	trxs := []transaction_domain.Transaction{
+		{TransactionDate: "2025-07-10", Category: "Eating Out", Amount: "35,000"},
+		{TransactionDate: "2025-07-10", Category: "Groceries", Amount: "80000"},
+		{TransactionDate: "2025-07-10", Category: "Eating Out", Amount: "50000"},
+		{TransactionDate: "2025-07-09", Category: "Groceries", Amount: "10000"},
+		{TransactionDate: "2025-07-10", Category: "Savings", Amount: "1000000", Goal: "bali"},
+		{TransactionDate: "2025-07-10", Category: "Transfer", Amount: "150000"},
+	}
+	day := time.Date(2025, 7, 10, 21, 0, 0, 0, bangkok)
+	report := BuildReport(KindDaily, trxs, day, day, 2100000)
+
+	if report.Total != 165000 || report.Count != 3 {
+		t.Errorf("unexpected totals: %+v", report)
+	}
+	if len(report.Categories) != 2 || report.Categories[0].Category != "Eating Out" {
+		t.Errorf("categories should be sorted by amount, got %+v", report.Categories)
+	}
+	if report.DaysLeft != 21 {
+		t.Errorf("expected 21 days left in July, got %d", report.DaysLeft)
+	}
+	if report.PerDayLeft != 100000 {
+		t.Errorf("expected 100000 per day, got %v", report.PerDayLeft)
+	}
+}
+
+func TestDaysLeftInMonth(t *testing.T) {
+	if got := DaysLeftInMonth(time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)); got != 0 {
+		t.Errorf("expected 0 on the last day, got %d", got)
+	}
+	if got := DaysLeftInMonth(time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)); got != 1 {
+		t.Errorf("expected 1 in leap February, got %d", got)
+	}
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	h, m, err := ParseTimeOfDay("07:45")
+	if err != nil || h != 7 || m != 45 {
+		t.Errorf("unexpected result: %d, %d, %v", h, m, err)
+	}
+	if _, _, err := ParseTimeOfDay("25:00"); err == nil {
+		t.Error("expected error for invalid hour")
+	}
+}
@@ -0,0 +1,134 @@
+package goals_domain
+
+import (
+	"math"
+	"money-tracker-bot/internal/errors"
+	"strings"
+	"time"
+	"unicode"
+
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+// SavingsCategory is the category recorded for goal contributions
+const SavingsCategory = "Savings"
+
+const dateLayout = "2006-01-02"
+
+// Goal is a named savings target with a deadline.
+// Name is a short slug used as the #tag in messages (e.g. "bali-trip").
+type Goal struct {
+	ChatID    int64  `json:"chat_id"`
+	Name      string `json:"name"`
+	Title     string `json:"title"`
+	Target    string `json:"target"`
+	Deadline  string `json:"deadline"`
+	CreatedBy string `json:"created_by"`
+}
+
+// Validate checks the target amount and deadline.
+func (g Goal) Validate() error {
+	if g.Name == "" {
+		return errors.Messagef("invalid.goal.name_required", "name is required")
+	}
+	target, err := transaction_domain.ParseAmount(g.Target)
+	if err != nil || target <= 0 {
+		return errors.Messagef("invalid.goal.invalid_target", "invalid target %q", g.Target)
+	}
+	if _, err := time.Parse(dateLayout, g.Deadline); err != nil {
+		return errors.Messagef("invalid.goal.invalid_deadline", "invalid deadline %q, expected YYYY-MM-DD", g.Deadline)
+	}
+	return nil
+}
+
+// DisplayName returns the title, or the name when no title was given.
+func (g Goal) DisplayName() string {
+	if g.Title != "" {
+		return g.Title
+	}
+	return g.Name
+}
+
+// Progress is the state of a goal at a given day
+type Progress struct {
+	Goal            Goal
+	Saved           float64
+	Target          float64
+	Remaining       float64
+	Percent         float64
+	MonthsLeft      int
+	MonthlyRequired float64
+	Overdue         bool
+}
+
+// ComputeProgress sums the contributions tagged with the goal and spreads the
+// remaining amount over the months left until the deadline, counting the
+// current month.
+// Input: target 12,000,000, saved 3,000,000, today 2025-07-20, deadline 2025-12-31
+// Output: Remaining 9,000,000, MonthsLeft 6, MonthlyRequired 1,500,000
+func ComputeProgress(g Goal, trxs []transaction_domain.Transaction, today time.Time) Progress {
+	target, _ := transaction_domain.ParseAmount(g.Target)
+	p := Progress{Goal: g, Target: target}
+	for _, trx := range trxs {
+		if trx.Goal == g.Name {
+			p.Saved += trx.AmountValue()
+		}
+	}
+	p.Remaining = math.Max(target-p.Saved, 0)
+	if target > 0 {
+		p.Percent = math.Min(p.Saved/target*100, 100)
+	}
+
+	deadline, err := time.Parse(dateLayout, g.Deadline)
+	if err != nil {
+		return p
+	}
+	months := (deadline.Year()-today.Year())*12 + int(deadline.Month()-today.Month()) + 1
+	if deadline.Format(dateLayout) < today.Format(dateLayout) {
+		months = 0
+	}
+	p.MonthsLeft = months
+	if p.Remaining > 0 {
+		if months > 0 {
+			p.MonthlyRequired = p.Remaining / float64(months)
+		} else {
+			p.Overdue = true
+			p.MonthlyRequired = p.Remaining
+		}
+	}
+	return p
+}
+
+// NormalizeName turns a free-form name into a goal slug.
+// Input: "#Bali Trip"
+// Output: "bali-trip"
+func NormalizeName(name string) string {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "#")
+	var b strings.Builder
+	lastDash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			lastDash = false
+		case !lastDash && b.Len() > 0:
+			b.WriteRune('-')
+			lastDash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// ExtractTag returns the first #tag of a message as a goal slug.
+// Input: "nabung 500k #bali-trip"
+// Output: "bali-trip", true
+func ExtractTag(text string) (string, bool) {
+	for _, word := range strings.Fields(text) {
+		if strings.HasPrefix(word, "#") && len(word) > 1 {
+			if name := NormalizeName(word); name != "" {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
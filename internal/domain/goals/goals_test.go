@@ -0,0 +1,67 @@
+package goals_domain
+
+import (
+	"testing"
+	"time"
+
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+func TestComputeProgress(t *testing.T) {
+	g := Goal{Name: "bali", Target: "12,000,000", Deadline: "2025-12-31"}
+	trxs := []transaction_domain.Transaction{
+		{Goal: "bali", Amount: "2,000,000"},
+		{Goal: "bali", Amount: "1000000"},
+		{Goal: "car", Amount: "5000000"},
+		{Amount: "7000000"},
+	}
+	p := ComputeProgress(g, trxs, time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC))
+
+	if p.Saved != 3000000 || p.Remaining != 9000000 || p.Percent != 25 {
+		t.Errorf("unexpected totals: %+v", p)
+	}
+	if p.MonthsLeft != 6 || p.MonthlyRequired != 1500000 || p.Overdue {
+		t.Errorf("unexpected schedule: %+v", p)
+	}
+}
+
+func TestComputeProgress_OverdueAndReached(t *testing.T) {
+	g := Goal{Name: "laptop", Target: "10000000", Deadline: "2025-06-30"}
+	p := ComputeProgress(g, []transaction_domain.Transaction{{Goal: "laptop", Amount: "4000000"}}, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC))
+	if !p.Overdue || p.MonthsLeft != 0 || p.MonthlyRequired != 6000000 {
+		t.Errorf("expected overdue goal, got %+v", p)
+	}
+
+	p = ComputeProgress(g, []transaction_domain.Transaction{{Goal: "laptop", Amount: "12000000"}}, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC))
+	if p.Overdue || p.Remaining != 0 || p.Percent != 100 || p.MonthlyRequired != 0 {
+		t.Errorf("reached goal should not be overdue, got %+v", p)
+	}
+}
+
+func TestGoal_Validate(t *testing.T) {
+	valid := Goal{Name: "bali", Target: "5000000", Deadline: "2025-12-31"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid goal, got %v", err)
+	}
+	for _, g := range []Goal{
+		{Target: "5000000", Deadline: "2025-12-31"},
+		{Name: "bali", Target: "0", Deadline: "2025-12-31"},
+		{Name: "bali", Target: "5000000", Deadline: "December"},
+	} {
+		if err := g.Validate(); err == nil {
+			t.Errorf("expected validation error for %+v", g)
+		}
+	}
+}
+
+func TestNormalizeNameAndExtractTag(t *testing.T) {
+	if got := NormalizeName("#Bali Trip 2025!"); got != "bali-trip-2025" {
+		t.Errorf("unexpected slug: %s", got)
+	}
+	if name, ok := ExtractTag("nabung 500k #Bali-Trip"); !ok || name != "bali-trip" {
+		t.Errorf("unexpected tag: %q, %v", name, ok)
+	}
+	if _, ok := ExtractTag("makan siang 35rb # gopay"); ok {
+		t.Error("a lone # is not a tag")
+	}
+}
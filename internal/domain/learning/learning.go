@@ -0,0 +1,152 @@
+package learning_domain
+
+import (
+	"fmt"
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/errors"
+	"sort"
+	"strings"
+
+	category_domain "money-tracker-bot/internal/domain/category"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+// Mapping is a merchant→category pair learned from a user's correction
+type Mapping struct {
+	Merchant    string `json:"merchant"`
+	Category    string `json:"category"`
+	Subcategory string `json:"subcategory,omitempty"`
+	// Corrections counts how often users corrected this merchant
+	Corrections   int    `json:"corrections"`
+	LastCorrected string `json:"last_corrected,omitempty"`
+}
+
+// Ref returns the category the merchant maps to
+func (m Mapping) Ref() category_domain.Ref {
+	return category_domain.Ref{Category: m.Category, Subcategory: m.Subcategory}
+}
+
+// Example renders the mapping as a few-shot line for the AI prompt.
+// Input: Mapping{Merchant: "Superindo", Category: "Groceries"}
+// Output: `"Superindo" → Groceries`
+func (m Mapping) Example() string {
+	return fmt.Sprintf("%q → %s", m.Merchant, m.Ref())
+}
+
+// Stats measures how often users have to correct the extracted category
+type Stats struct {
+	// Categorized counts saved transactions that could be corrected
+	Categorized int `json:"categorized"`
+	// Corrected counts those transactions whose category a user changed
+	Corrected int `json:"corrected"`
+}
+
+// Rate returns the share of categorized transactions that were corrected, from 0 to 1.
+func (s Stats) Rate() float64 {
+	if s.Categorized == 0 {
+		return 0
+	}
+	return float64(s.Corrected) / float64(s.Categorized)
+}
+
+// Memory is everything a chat has taught the bot about categories
+type Memory struct {
+	Stats    Stats     `json:"stats"`
+	Mappings []Mapping `json:"mappings,omitempty"`
+}
+
+// Correction records a category change made by a user
+type Correction struct {
+	Merchant string
+	From     category_domain.Ref
+	To       category_domain.Ref
+}
+
+// NormalizeMerchant folds case and whitespace so spellings of a merchant compare equal.
+// Input: "  SUPER  Indo "
+// Output: "super indo"
+func NormalizeMerchant(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// MerchantOf returns the merchant of a transaction: the destination name when
+// the AI found one, the title otherwise.
+func MerchantOf(trx transaction_domain.Transaction) string {
+	if name := strings.TrimSpace(trx.DestinationName); name != "" {
+		return name
+	}
+	return strings.TrimSpace(trx.Title)
+}
+
+// Learn records that merchant belongs in ref. A merchant corrected again
+// moves to the new category and keeps its correction count.
+func (m *Memory) Learn(merchant string, ref category_domain.Ref, date string) (Mapping, error) {
+	key := NormalizeMerchant(merchant)
+	if key == "" {
+		return Mapping{}, errors.Messagef("invalid.learning.merchant_required", "merchant is required")
+	}
+	if ref.Category == "" {
+		return Mapping{}, errors.Messagef("invalid.learning.category_required", "category is required")
+	}
+	for i := range m.Mappings {
+		if NormalizeMerchant(m.Mappings[i].Merchant) == key {
+			mp := &m.Mappings[i]
+			mp.Category, mp.Subcategory = ref.Category, ref.Subcategory
+			mp.Corrections++
+			mp.LastCorrected = date
+			return *mp, nil
+		}
+	}
+	mp := Mapping{
+		Merchant:      strings.Join(strings.Fields(merchant), " "),
+		Category:      ref.Category,
+		Subcategory:   ref.Subcategory,
+		Corrections:   1,
+		LastCorrected: date,
+	}
+	m.Mappings = append(m.Mappings, mp)
+	return mp, nil
+}
+
+// Lookup returns the mapping whose merchant appears as whole words in the
+// destination name or title of trx, so "gas" does not match "gasket". The
+// longest merchant wins, so "superindo express" beats "superindo".
+func (m Memory) Lookup(trx transaction_domain.Transaction) (Mapping, bool) {
+	text := NormalizeMerchant(trx.DestinationName + "\n" + trx.Title)
+	var best Mapping
+	found := false
+	for _, mp := range m.Mappings {
+		key := NormalizeMerchant(mp.Merchant)
+		if !common.ContainsWord(text, key) {
+			continue
+		}
+		if !found || len(key) > len(NormalizeMerchant(best.Merchant)) {
+			best, found = mp, true
+		}
+	}
+	return best, found
+}
+
+// Ranked returns the mappings most corrected first, most recent first on ties.
+func (m Memory) Ranked() []Mapping {
+	ranked := append([]Mapping(nil), m.Mappings...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Corrections != ranked[j].Corrections {
+			return ranked[i].Corrections > ranked[j].Corrections
+		}
+		return ranked[i].LastCorrected > ranked[j].LastCorrected
+	})
+	return ranked
+}
+
+// Examples returns up to limit few-shot lines for the AI prompt, best first.
+func (m Memory) Examples(limit int) []string {
+	var lines []string
+	for _, mp := range m.Ranked() {
+		if len(lines) == limit {
+			break
+		}
+		lines = append(lines, mp.Example())
+	}
+	return lines
+}
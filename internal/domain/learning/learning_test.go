@@ -0,0 +1,93 @@
+package learning_domain
+
+import (
+	"testing"
+
+	category_domain "money-tracker-bot/internal/domain/category"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+func TestNormalizeMerchant(t *testing.T) {
+	if got := NormalizeMerchant("  SUPER  Indo "); got != "super indo" {
+		t.Errorf("unexpected normalization %q", got)
+	}
+}
+
+func TestMerchantOf(t *testing.T) {
+	if got := MerchantOf(transaction_domain.Transaction{DestinationName: " Superindo ", Title: "Weekly shop"}); got != "Superindo" {
+		t.Errorf("expected destination name, got %q", got)
+	}
+	if got := MerchantOf(transaction_domain.Transaction{Title: "Superindo"}); got != "Superindo" {
+		t.Errorf("expected title fallback, got %q", got)
+	}
+}
+
+func TestMemory_Learn(t *testing.T) {
+	var m Memory
+	groceries := category_domain.Ref{Category: "Groceries"}
+	if _, err := m.Learn("Superindo", groceries, "2025-07-01"); err != nil {
+		t.Fatal(err)
+	}
+	mp, err := m.Learn(" superindo ", category_domain.Ref{Category: "Household", Subcategory: "Cleaning"}, "2025-07-02")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Mappings) != 1 || mp.Corrections != 2 || mp.Ref().String() != "Household › Cleaning" || mp.Merchant != "Superindo" {
+		t.Errorf("expected the mapping to move and keep its count, got %+v", m.Mappings)
+	}
+	if _, err := m.Learn(" ", groceries, "2025-07-02"); err == nil {
+		t.Error("expected error for empty merchant")
+	}
+	if _, err := m.Learn("Indomaret", category_domain.Ref{}, "2025-07-02"); err == nil {
+		t.Error("expected error for empty category")
+	}
+}
+
+func TestMemory_Lookup(t *testing.T) {
+	m := Memory{Mappings: []Mapping{
+		{Merchant: "Superindo", Category: "Groceries"},
+		{Merchant: "Superindo Express", Category: "Eating Out"},
+	}}
+	mp, ok := m.Lookup(transaction_domain.Transaction{Title: "Lunch at SUPERINDO express"})
+	if !ok || mp.Category != "Eating Out" {
+		t.Errorf("expected the longest merchant to win, got %+v, %v", mp, ok)
+	}
+	mp, ok = m.Lookup(transaction_domain.Transaction{DestinationName: "PT Superindo"})
+	if !ok || mp.Category != "Groceries" {
+		t.Errorf("expected destination match, got %+v, %v", mp, ok)
+	}
+	if _, ok := m.Lookup(transaction_domain.Transaction{Title: "Indomaret"}); ok {
+		t.Error("expected no match")
+	}
+}
+
+func TestMemory_Lookup_WholeWords(t *testing.T) {
+	m := Memory{Mappings: []Mapping{{Merchant: "Gas", Category: "Utilities"}}}
+	if mp, ok := m.Lookup(transaction_domain.Transaction{Title: "Replace gasket"}); ok {
+		t.Errorf("a merchant inside a longer word must not match, got %+v", mp)
+	}
+	if _, ok := m.Lookup(transaction_domain.Transaction{Title: "Isi gas, 3kg"}); !ok {
+		t.Error("expected the merchant to match as a word")
+	}
+}
+
+func TestMemory_Examples(t *testing.T) {
+	m := Memory{Mappings: []Mapping{
+		{Merchant: "Indomaret", Category: "Groceries", Corrections: 1, LastCorrected: "2025-07-01"},
+		{Merchant: "Kopi Kenangan", Category: "Eating Out", Subcategory: "Coffee", Corrections: 1, LastCorrected: "2025-07-03"},
+		{Merchant: "Superindo", Category: "Groceries", Corrections: 3, LastCorrected: "2025-06-01"},
+	}}
+	got := m.Examples(2)
+	if len(got) != 2 || got[0] != `"Superindo" → Groceries` || got[1] != `"Kopi Kenangan" → Eating Out › Coffee` {
+		t.Errorf("unexpected examples %q", got)
+	}
+}
+
+func TestStats_Rate(t *testing.T) {
+	if (Stats{}).Rate() != 0 {
+		t.Error("expected zero rate without transactions")
+	}
+	if got := (Stats{Categorized: 8, Corrected: 2}).Rate(); got != 0.25 {
+		t.Errorf("expected 0.25, got %v", got)
+	}
+}
@@ -0,0 +1,175 @@
+package recurring_domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+const (
+	// MinMonths is how many distinct months an expense must appear in
+	MinMonths = 2
+	// AmountTolerance is the maximum relative spread between amounts
+	AmountTolerance = 0.10
+	// DayTolerance is the maximum spread between days of month
+	DayTolerance = 3
+)
+
+// Suggestion is a repeated expense that looks like a recurring bill
+type Suggestion struct {
+	Title      string
+	Category   string
+	Amount     float64
+	DayOfMonth int
+	Months     int
+}
+
+// Key identifies the suggestion in a callback button. It is derived from the
+// category, title, amount and day rather than the position in the list,
+// which shifts as transactions are recorded, and hashed to fit Telegram's
+// 64-byte callback data.
+func (s Suggestion) Key() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%.2f|%d", strings.ToLower(s.Category), s.Title, s.Amount, s.DayOfMonth)))
+	return hex.EncodeToString(sum[:6])
+}
+
+// DetectRecurring finds expenses repeated in several months on a similar day
+// with a similar amount that are not already covered by an existing entry.
+// Input: "Netflix 186,000" on 2025-05-03, 2025-06-03, 2025-07-04
+// Output: Suggestion{Title: "netflix", Amount: 186000, DayOfMonth: 3, Months: 3}
+func DetectRecurring(trxs []transaction_domain.Transaction, existing []Recurring) []Suggestion {
+	type occurrence struct {
+		amount float64
+		day    int
+		period string
+	}
+	groups := make(map[string][]occurrence)
+	titles := make(map[string]string)
+	categories := make(map[string]string)
+	for _, trx := range trxs {
+		date, err := time.Parse(dateLayout, trx.TransactionDate)
+		if err != nil {
+			continue
+		}
+		amount := trx.AmountValue()
+		label := normalizeLabel(trx.Notes)
+		if amount <= 0 || label == "" {
+			continue
+		}
+		key := strings.ToLower(trx.Category) + "|" + label
+		groups[key] = append(groups[key], occurrence{amount: amount, day: date.Day(), period: Period(date)})
+		titles[key] = label
+		categories[key] = trx.Category
+	}
+
+	var suggestions []Suggestion
+	for key, occs := range groups {
+		periods := make(map[string]bool)
+		minAmount, maxAmount := math.MaxFloat64, 0.0
+		minDay, maxDay := 31, 1
+		var days []int
+		for _, o := range occs {
+			periods[o.period] = true
+			minAmount = math.Min(minAmount, o.amount)
+			maxAmount = math.Max(maxAmount, o.amount)
+			if o.day < minDay {
+				minDay = o.day
+			}
+			if o.day > maxDay {
+				maxDay = o.day
+			}
+			days = append(days, o.day)
+		}
+		if len(periods) < MinMonths || len(occs) != len(periods) {
+			continue
+		}
+		if (maxAmount-minAmount)/maxAmount > AmountTolerance || maxDay-minDay > DayTolerance {
+			continue
+		}
+		sort.Ints(days)
+		s := Suggestion{
+			Title:      titles[key],
+			Category:   categories[key],
+			Amount:     maxAmount,
+			DayOfMonth: days[len(days)/2],
+			Months:     len(periods),
+		}
+		if !covered(s, existing) {
+			suggestions = append(suggestions, s)
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Amount != suggestions[j].Amount {
+			return suggestions[i].Amount > suggestions[j].Amount
+		}
+		return suggestions[i].Title < suggestions[j].Title
+	})
+	return suggestions
+}
+
+// covered reports whether an existing entry already tracks the suggestion
+func covered(s Suggestion, existing []Recurring) bool {
+	for _, r := range existing {
+		if !strings.EqualFold(r.Category, s.Category) {
+			continue
+		}
+		amount, err := transaction_domain.ParseAmount(r.Amount)
+		if err != nil {
+			continue
+		}
+		if math.Abs(amount-s.Amount)/math.Max(amount, s.Amount) <= AmountTolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeLabel keeps the first words of the notes, lowercased and without
+// digits or punctuation, so "Netflix July 2025" and "netflix aug" match.
+func normalizeLabel(notes string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsSpace(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, notes)
+	words := strings.Fields(cleaned)
+	var kept []string
+	for _, w := range words {
+		if isMonthWord(w) {
+			continue
+		}
+		kept = append(kept, w)
+		if len(kept) == 2 {
+			break
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// monthWords are English and Indonesian month names commonly found in bill notes
+var monthWords = map[string]bool{
+	"jan": true, "january": true, "januari": true,
+	"feb": true, "february": true, "februari": true,
+	"mar": true, "march": true, "maret": true,
+	"apr": true, "april": true,
+	"may": true, "mei": true,
+	"jun": true, "june": true, "juni": true,
+	"jul": true, "july": true, "juli": true,
+	"aug": true, "august": true, "agu": true, "agustus": true,
+	"sep": true, "sept": true, "september": true,
+	"oct": true, "october": true, "okt": true, "oktober": true,
+	"nov": true, "november": true,
+	"dec": true, "december": true, "des": true, "desember": true,
+}
+
+func isMonthWord(w string) bool {
+	return monthWords[w]
+}
@@ -0,0 +1,65 @@
+package recurring_domain
+
+import (
+	"testing"
+
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+func TestDetectRecurring(t *testing.T) {
+	trxs := []transaction_domain.Transaction{
+		{TransactionDate: "2025-05-03", Category: "Entertainment", Notes: "Netflix May", Amount: "186,000"},
+		{TransactionDate: "2025-06-03", Category: "Entertainment", Notes: "Netflix June", Amount: "186,000"},
+		{TransactionDate: "2025-07-04", Category: "Entertainment", Notes: "netflix juli 2025", Amount: "186000"},
+		// varying amounts are not a bill
+		{TransactionDate: "2025-05-10", Category: "Groceries", Notes: "Superindo", Amount: "80000"},
+		{TransactionDate: "2025-06-11", Category: "Groceries", Notes: "Superindo", Amount: "250000"},
+		// a single month is not enough
+		{TransactionDate: "2025-07-01", Category: "Health", Notes: "Dentist", Amount: "500000"},
+	}
+
+	got := DetectRecurring(trxs, nil)
+	if len(got) != 1 {
+		t.Fatalf("expected one suggestion, got %+v", got)
+	}
+	s := got[0]
+	if s.Title != "netflix" || s.Category != "Entertainment" || s.Amount != 186000 || s.DayOfMonth != 3 || s.Months != 3 {
+		t.Errorf("unexpected suggestion: %+v", s)
+	}
+}
+
+func TestSuggestion_Key(t *testing.T) {
+	s := Suggestion{Title: "netflix", Category: "Entertainment", Amount: 186000, DayOfMonth: 3, Months: 3}
+	later := s
+	later.Months = 4
+	if s.Key() != later.Key() || len(s.Key()) != 12 {
+		t.Errorf("the key should only depend on what is suggested, got %q and %q", s.Key(), later.Key())
+	}
+	other := s
+	other.DayOfMonth = 4
+	if s.Key() == other.Key() {
+		t.Error("suggestions for another day should have another key")
+	}
+}
+
+func TestDetectRecurring_SkipsExisting(t *testing.T) {
+	trxs := []transaction_domain.Transaction{
+		{TransactionDate: "2025-06-01", Category: "Rent House", Notes: "Rent", Amount: "5000000"},
+		{TransactionDate: "2025-07-01", Category: "Rent House", Notes: "Rent", Amount: "5000000"},
+	}
+	existing := []Recurring{{Category: "Rent House", Amount: "5,000,000", DayOfMonth: 1}}
+	if got := DetectRecurring(trxs, existing); len(got) != 0 {
+		t.Errorf("expected covered expense to be skipped, got %+v", got)
+	}
+}
+
+func TestDetectRecurring_SeveralTimesAMonthIsNotABill(t *testing.T) {
+	trxs := []transaction_domain.Transaction{
+		{TransactionDate: "2025-06-01", Category: "Eating Out", Notes: "Kopi kenangan", Amount: "25000"},
+		{TransactionDate: "2025-06-02", Category: "Eating Out", Notes: "Kopi kenangan", Amount: "25000"},
+		{TransactionDate: "2025-07-01", Category: "Eating Out", Notes: "Kopi kenangan", Amount: "25000"},
+	}
+	if got := DetectRecurring(trxs, nil); len(got) != 0 {
+		t.Errorf("expected no suggestion, got %+v", got)
+	}
+}
@@ -0,0 +1,137 @@
+package recurring_domain
+
+import (
+	"money-tracker-bot/internal/errors"
+	"strconv"
+	"time"
+
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+// Mode decides what happens when a recurring transaction falls due
+type Mode string
+
+const (
+	// ModeAuto records the transaction without asking
+	ModeAuto Mode = "auto"
+	// ModeRemind sends a reminder with a one-tap confirm button
+	ModeRemind Mode = "remind"
+
+	periodLayout = "2006-01"
+	dateLayout   = "2006-01-02"
+)
+
+// ParseMode validates a mode string, defaulting to ModeRemind when empty.
+func ParseMode(value string) (Mode, error) {
+	switch Mode(value) {
+	case "":
+		return ModeRemind, nil
+	case ModeAuto, ModeRemind:
+		return Mode(value), nil
+	default:
+		return "", errors.Messagef("invalid.recurring.unknown_mode", "unknown mode %q, expected auto or remind", value)
+	}
+}
+
+// Recurring is a transaction that repeats every month on a known day.
+// LastPosted is the last period (YYYY-MM) recorded in the spreadsheet and
+// LastReminded the last period a reminder was sent for, so neither happens
+// twice for the same month.
+type Recurring struct {
+	ID            string `json:"id"`
+	ChatID        int64  `json:"chat_id"`
+	Title         string `json:"title"`
+	Amount        string `json:"amount"`
+	Category      string `json:"category"`
+	Subcategory   string `json:"subcategory,omitempty"`
+	SourceAccount string `json:"source_account"`
+	DayOfMonth    int    `json:"day_of_month"`
+	Mode          Mode   `json:"mode"`
+	CreatedBy     string `json:"created_by"`
+	LastPosted    string `json:"last_posted,omitempty"`
+	LastReminded  string `json:"last_reminded,omitempty"`
+}
+
+// Validate checks the fields required to post the transaction.
+func (r Recurring) Validate() error {
+	if r.DayOfMonth < 1 || r.DayOfMonth > 31 {
+		return errors.Messagef("invalid.recurring.day_range", "day must be between 1 and 31")
+	}
+	if _, err := transaction_domain.ParseAmount(r.Amount); err != nil {
+		return errors.Messagef("invalid.recurring.invalid_amount", "invalid amount %q", r.Amount)
+	}
+	if r.Category == "" {
+		return errors.Messagef("invalid.recurring.category_required", "category is required")
+	}
+	if _, err := ParseMode(string(r.Mode)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Period returns the YYYY-MM period a date belongs to.
+func Period(day time.Time) string {
+	return day.Format(periodLayout)
+}
+
+// DueDate returns the due date in the month of day.
+// Days past the end of a short month fall on its last day (31 → 30 Apr).
+func (r Recurring) DueDate(day time.Time) time.Time {
+	lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+	dueDay := r.DayOfMonth
+	if dueDay > lastDay {
+		dueDay = lastDay
+	}
+	return time.Date(day.Year(), day.Month(), dueDay, 0, 0, 0, 0, day.Location())
+}
+
+// IsDue reports whether the entry still needs action for the month of day.
+func (r Recurring) IsDue(day time.Time) bool {
+	period := Period(day)
+	if r.LastPosted == period {
+		return false
+	}
+	if r.Mode == ModeRemind && r.LastReminded == period {
+		return false
+	}
+	return !day.Before(r.DueDate(day))
+}
+
+// NormalizeAmount rewrites Amount as a plain number, the way extracted
+// transactions carry it, so the list and the spreadsheet read it back.
+// Input: "1.500.000", "Rp 150,000"
+// Output: "1500000", "150000"
+func (r *Recurring) NormalizeAmount() {
+	r.Amount = r.plainAmount()
+}
+
+// plainAmount is Amount as a plain number, or as is when it does not parse
+func (r Recurring) plainAmount() string {
+	v, err := transaction_domain.ParseAmount(r.Amount)
+	if err != nil {
+		return r.Amount
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Transaction builds the transaction to record for the given period.
+func (r Recurring) Transaction(period string) (transaction_domain.Transaction, error) {
+	month, err := time.Parse(periodLayout, period)
+	if err != nil {
+		return transaction_domain.Transaction{}, errors.Messagef("invalid.recurring.invalid_period", "invalid period %q", period)
+	}
+	title := r.Title
+	if title == "" {
+		title = r.Category
+	}
+	return transaction_domain.Transaction{
+		TransactionDate: r.DueDate(month).Format(dateLayout),
+		Amount:          r.plainAmount(),
+		Category:        r.Category,
+		Subcategory:     r.Subcategory,
+		SourceAccount:   r.SourceAccount,
+		Title:           title,
+		Notes:           title + " (recurring)",
+		CreatedBy:       r.CreatedBy,
+	}, nil
+}
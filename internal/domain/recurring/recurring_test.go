@@ -0,0 +1,78 @@
+package recurring_domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurring_IsDue(t *testing.T) {
+	r := Recurring{DayOfMonth: 5, Mode: ModeAuto}
+
+	if r.IsDue(time.Date(2025, 7, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Error("should not be due before the due day")
+	}
+	if !r.IsDue(time.Date(2025, 7, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Error("should be due on the due day")
+	}
+	r.LastPosted = "2025-07"
+	if r.IsDue(time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Error("should not be due once posted for the month")
+	}
+	if !r.IsDue(time.Date(2025, 8, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Error("should be due again next month")
+	}
+
+	reminder := Recurring{DayOfMonth: 5, Mode: ModeRemind, LastReminded: "2025-07"}
+	if reminder.IsDue(time.Date(2025, 7, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Error("reminder should only be sent once per month")
+	}
+}
+
+func TestRecurring_DueDateClampsToMonthEnd(t *testing.T) {
+	r := Recurring{DayOfMonth: 31}
+	got := r.DueDate(time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC))
+	if got.Day() != 28 {
+		t.Errorf("expected 28 Feb, got %v", got)
+	}
+}
+
+func TestRecurring_Transaction(t *testing.T) {
+	r := Recurring{Title: "Rent", Amount: "5,000,000", Category: "Rent House", Subcategory: "Apartment", SourceAccount: "BCA", DayOfMonth: 1, CreatedBy: "rompi"}
+	trx, err := r.Transaction("2025-07")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trx.TransactionDate != "2025-07-01" || trx.Amount != "5000000" || trx.Category != "Rent House" || trx.Subcategory != "Apartment" || trx.SourceAccount != "BCA" || trx.CreatedBy != "rompi" {
+		t.Errorf("unexpected transaction: %+v", trx)
+	}
+	if _, err := r.Transaction("July"); err == nil {
+		t.Error("expected error for invalid period")
+	}
+}
+
+func TestRecurring_Validate(t *testing.T) {
+	valid := Recurring{Amount: "100000", Category: "Utilities", DayOfMonth: 10, Mode: ModeRemind}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid entry, got %v", err)
+	}
+	testCases := []Recurring{
+		{Amount: "100000", Category: "Utilities", DayOfMonth: 0},
+		{Amount: "abc", Category: "Utilities", DayOfMonth: 10},
+		{Amount: "100000", DayOfMonth: 10},
+		{Amount: "100000", Category: "Utilities", DayOfMonth: 10, Mode: "weekly"},
+	}
+	for _, tc := range testCases {
+		if err := tc.Validate(); err == nil {
+			t.Errorf("expected validation error for %+v", tc)
+		}
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	if m, err := ParseMode(""); err != nil || m != ModeRemind {
+		t.Errorf("expected remind by default, got %v, %v", m, err)
+	}
+	if _, err := ParseMode("later"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
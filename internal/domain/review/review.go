@@ -0,0 +1,28 @@
+package review_domain
+
+import (
+	"fmt"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"strings"
+	"time"
+)
+
+// Pending is an extracted transaction the AI was unsure about, waiting for
+// the user to confirm it before it is saved
+type Pending struct {
+	ID          string                         `json:"id"`
+	ChatID      int64                          `json:"chat_id"`
+	Transaction transaction_domain.Transaction `json:"transaction"`
+	FlaggedAt   time.Time                      `json:"flagged_at"`
+}
+
+// Doubts describes the low-confidence fields with their rating.
+// Input: LowConfidence ["amount", "category"], Confidence {"amount": 0.42, "category": 0.6}
+// Output: "amount (42%), category (60%)"
+func (p Pending) Doubts() string {
+	doubts := make([]string, 0, len(p.Transaction.LowConfidence))
+	for _, field := range p.Transaction.LowConfidence {
+		doubts = append(doubts, fmt.Sprintf("%s (%.0f%%)", field, p.Transaction.Confidence[field]*100))
+	}
+	return strings.Join(doubts, ", ")
+}
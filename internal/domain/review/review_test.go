@@ -0,0 +1,19 @@
+package review_domain
+
+import (
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"testing"
+)
+
+func TestPending_Doubts(t *testing.T) {
+	p := Pending{Transaction: transaction_domain.Transaction{
+		Confidence:    map[string]float64{"amount": 0.42, "category": 0.6, "title": 0.9},
+		LowConfidence: []string{"amount", "category"},
+	}}
+	if got := p.Doubts(); got != "amount (42%), category (60%)" {
+		t.Errorf("unexpected doubts %q", got)
+	}
+	if got := (Pending{}).Doubts(); got != "" {
+		t.Errorf("expected no doubts, got %q", got)
+	}
+}
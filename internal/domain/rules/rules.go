@@ -0,0 +1,142 @@
+package rules_domain
+
+import (
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/errors"
+	"regexp"
+	"strings"
+
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+// Kind decides what part of a transaction a rule looks at
+type Kind string
+
+const (
+	// KindKeyword matches a word or phrase anywhere in the message, title or notes
+	KindKeyword Kind = "keyword"
+	// KindRegex matches a regular expression against the message, title or notes
+	KindRegex Kind = "regex"
+	// KindMerchant matches part of the merchant (destination) name
+	KindMerchant Kind = "merchant"
+	// KindAccount matches the source account exactly
+	KindAccount Kind = "account"
+)
+
+// ParseKind validates a rule kind
+func ParseKind(value string) (Kind, error) {
+	switch k := Kind(strings.ToLower(value)); k {
+	case KindKeyword, KindRegex, KindMerchant, KindAccount:
+		return k, nil
+	default:
+		return "", errors.Messagef("invalid.rule.unknown_kind", "unknown rule type %q, expected keyword, regex, merchant or account", value)
+	}
+}
+
+// Rule sets the category, tags or account of transactions that match it.
+// Rules of a chat are evaluated in ID order and the first match wins.
+type Rule struct {
+	ID          int      `json:"id"`
+	ChatID      int64    `json:"chat_id"`
+	Kind        Kind     `json:"kind"`
+	Pattern     string   `json:"pattern"`
+	Category    string   `json:"category,omitempty"`
+	Subcategory string   `json:"subcategory,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Account     string   `json:"account,omitempty"`
+	CreatedBy   string   `json:"created_by"`
+	// Hits counts the saved transactions the rule was applied to
+	Hits    int    `json:"hits"`
+	LastHit string `json:"last_hit,omitempty"`
+}
+
+// Input is what rules are matched against
+type Input struct {
+	// Text is the message, title and notes of the transaction
+	Text     string
+	Merchant string
+	Account  string
+}
+
+// InputOf builds the rule input of a transaction and the message it came from
+func InputOf(message string, trx transaction_domain.Transaction) Input {
+	return Input{
+		Text:     strings.Join([]string{message, trx.Title, trx.Notes}, "\n"),
+		Merchant: trx.DestinationName,
+		Account:  trx.SourceAccount,
+	}
+}
+
+// Validate checks the pattern and that the rule changes something.
+func (r Rule) Validate() error {
+	if _, err := ParseKind(string(r.Kind)); err != nil {
+		return err
+	}
+	if strings.TrimSpace(r.Pattern) == "" {
+		return errors.Messagef("invalid.rule.pattern_required", "pattern is required")
+	}
+	if r.Kind == KindRegex {
+		if _, err := regexp.Compile("(?i)" + r.Pattern); err != nil {
+			return errors.Messagef("invalid.rule.invalid_regex", "invalid regex %q: %v", r.Pattern, err)
+		}
+	}
+	if r.Category == "" && len(r.Tags) == 0 && r.Account == "" {
+		return errors.Messagef("invalid.rule.action_required", "a rule needs a category, tags or account to set")
+	}
+	return nil
+}
+
+// Matches reports whether the rule applies to in. Matching is case-insensitive.
+// Input: keyword "grab" against "Grab to office 25k"
+// Output: true
+func (r Rule) Matches(in Input) bool {
+	pattern := strings.TrimSpace(r.Pattern)
+	switch r.Kind {
+	case KindKeyword:
+		return common.ContainsWord(strings.ToLower(in.Text), strings.ToLower(pattern))
+	case KindRegex:
+		re, err := regexp.Compile("(?i)" + pattern)
+		return err == nil && re.MatchString(in.Text)
+	case KindMerchant:
+		return in.Merchant != "" && strings.Contains(strings.ToLower(in.Merchant), strings.ToLower(pattern))
+	case KindAccount:
+		return strings.EqualFold(strings.TrimSpace(in.Account), pattern)
+	default:
+		return false
+	}
+}
+
+// Apply sets the fields configured on the rule, overriding the AI's answer.
+// Tags are added to the ones already on the transaction.
+func (r Rule) Apply(trx *transaction_domain.Transaction) {
+	if r.Category != "" {
+		trx.Category, trx.Subcategory = r.Category, r.Subcategory
+	}
+	if r.Account != "" {
+		trx.SourceAccount = r.Account
+	}
+	for _, tag := range r.Tags {
+		if !hasTag(trx.Tags, tag) {
+			trx.Tags = append(trx.Tags, tag)
+		}
+	}
+}
+
+// FirstMatch returns the first rule matching in, in the given order.
+func FirstMatch(rules []Rule, in Input) (Rule, bool) {
+	for _, r := range rules {
+		if r.Matches(in) {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package rules_domain
+
+import (
+	"testing"
+
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+func TestRule_Matches(t *testing.T) {
+	in := Input{Text: "Grab to office 25k", Merchant: "PT Grab Indonesia", Account: "gopay"}
+	testCases := []struct {
+		name     string
+		rule     Rule
+		expected bool
+	}{
+		{name: "keyword", rule: Rule{Kind: KindKeyword, Pattern: "grab"}, expected: true},
+		{name: "keyword phrase", rule: Rule{Kind: KindKeyword, Pattern: "to office"}, expected: true},
+		{name: "keyword inside word", rule: Rule{Kind: KindKeyword, Pattern: "gra"}, expected: false},
+		{name: "regex", rule: Rule{Kind: KindRegex, Pattern: `^grab\b`}, expected: true},
+		{name: "regex no match", rule: Rule{Kind: KindRegex, Pattern: `^indomaret`}, expected: false},
+		{name: "merchant", rule: Rule{Kind: KindMerchant, Pattern: "grab indonesia"}, expected: true},
+		{name: "account", rule: Rule{Kind: KindAccount, Pattern: "GOPAY"}, expected: true},
+		{name: "other account", rule: Rule{Kind: KindAccount, Pattern: "OVO"}, expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.rule.Matches(in); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRule_Validate(t *testing.T) {
+	valid := Rule{Kind: KindKeyword, Pattern: "grab", Category: "Transportation"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid rule, got %v", err)
+	}
+	invalid := []Rule{
+		{Kind: "fuzzy", Pattern: "grab", Category: "Transportation"},
+		{Kind: KindKeyword, Pattern: " ", Category: "Transportation"},
+		{Kind: KindRegex, Pattern: "(", Category: "Transportation"},
+		{Kind: KindKeyword, Pattern: "grab"},
+	}
+	for _, r := range invalid {
+		if err := r.Validate(); err == nil {
+			t.Errorf("expected error for %+v", r)
+		}
+	}
+}
+
+func TestRule_Apply(t *testing.T) {
+	trx := transaction_domain.Transaction{Category: "Eating Out", SourceAccount: "BCA", Tags: []string{"work"}}
+	Rule{Category: "Transportation", Account: "GOPAY", Tags: []string{"Work", "ride"}}.Apply(&trx)
+	if trx.Category != "Transportation" || trx.Subcategory != "" || trx.SourceAccount != "GOPAY" {
+		t.Errorf("rule should override category and account, got %+v", trx)
+	}
+	if len(trx.Tags) != 2 || trx.Tags[1] != "ride" {
+		t.Errorf("tags should be merged without duplicates, got %v", trx.Tags)
+	}
+
+	tagOnly := transaction_domain.Transaction{Category: "Groceries"}
+	Rule{Tags: []string{"monthly"}}.Apply(&tagOnly)
+	if tagOnly.Category != "Groceries" {
+		t.Errorf("rules without category must keep the category, got %+v", tagOnly)
+	}
+}
+
+func TestFirstMatch(t *testing.T) {
+	rules := []Rule{
+		{ID: 1, Kind: KindKeyword, Pattern: "indomaret", Category: "Groceries"},
+		{ID: 2, Kind: KindKeyword, Pattern: "grab", Category: "Transportation"},
+		{ID: 3, Kind: KindAccount, Pattern: "GOPAY", Tags: []string{"ewallet"}},
+	}
+	in := InputOf("grab 25k", transaction_domain.Transaction{SourceAccount: "GOPAY"})
+	if r, ok := FirstMatch(rules, in); !ok || r.ID != 2 {
+		t.Errorf("expected rule 2 to match first, got %+v, %v", r, ok)
+	}
+	if _, ok := FirstMatch(rules, Input{Text: "salary"}); ok {
+		t.Error("expected no match")
+	}
+}
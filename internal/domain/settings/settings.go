@@ -0,0 +1,32 @@
+package settings
+
+// Package settings models the preferences each tenant (a chat) can change,
+// such as its timezone, locale and language.
+
+import (
+	"money-tracker-bot/internal/errors"
+	"strings"
+	"time"
+)
+
+// Settings are the preferences of one chat. Empty fields use the bot's defaults.
+type Settings struct {
+	ChatID int64 `json:"chat_id"`
+	// Timezone is an IANA timezone such as "Asia/Jakarta"
+	Timezone string `json:"timezone,omitempty"`
+	// Locale is a supported locale tag such as "id-ID"
+	Locale string `json:"locale,omitempty"`
+	// Language is the language code replies are written in, such as "id".
+	// Empty follows the Telegram app of whoever writes.
+	Language string `json:"language,omitempty"`
+}
+
+// LoadTimezone resolves an IANA timezone name. Unlike time.LoadLocation it
+// rejects "" and "Local", which would follow the server instead of the chat.
+func LoadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "Local" {
+		return nil, errors.Messagef("invalid.settings.unknown_timezone", "unknown timezone %q", name)
+	}
+	return time.LoadLocation(name)
+}
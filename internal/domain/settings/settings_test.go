@@ -0,0 +1,15 @@
+package settings
+
+import "testing"
+
+func TestLoadTimezone(t *testing.T) {
+	loc, err := LoadTimezone(" Asia/Jakarta ")
+	if err != nil || loc.String() != "Asia/Jakarta" {
+		t.Errorf("expected Asia/Jakarta, got %v, %v", loc, err)
+	}
+	for _, invalid := range []string{"", "Local", "Mars/Olympus"} {
+		if _, err := LoadTimezone(invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package split_domain
+
+import (
+	"fmt"
+	"math/bits"
+	"money-tracker-bot/internal/errors"
+	"sort"
+	"strings"
+)
+
+// Share is the part of a shared expense owed by one member, in whole rupiah
+type Share struct {
+	Member string `json:"member"`
+	Amount int64  `json:"amount"`
+}
+
+// Entry is a shared expense paid by one member, or a settlement transfer
+// when Settlement is set (PaidBy paid the single share holder back).
+type Entry struct {
+	ID          string  `json:"id"`
+	ChatID      int64   `json:"chat_id"`
+	Date        string  `json:"date"`
+	Description string  `json:"description"`
+	PaidBy      string  `json:"paid_by"`
+	Amount      int64   `json:"amount"`
+	Shares      []Share `json:"shares"`
+	Settlement  bool    `json:"settlement,omitempty"`
+}
+
+// Transfer is a payment that settles balances between two members
+type Transfer struct {
+	From   string
+	To     string
+	Amount int64
+}
+
+// NormalizeMember makes member names comparable: "@Alice " becomes "alice".
+func NormalizeMember(name string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "@"))
+}
+
+// EqualShares splits amount equally between members. Whole rupiah left over
+// by the division go one each to the first members, so shares always add up.
+// Input: 100, [a b c]
+// Output: a:34, b:33, c:33
+func EqualShares(amount int64, members []string) ([]Share, error) {
+	if len(members) == 0 {
+		return nil, errors.Messagef("invalid.split.members_required", "at least one member is required")
+	}
+	seen := make(map[string]bool)
+	shares := make([]Share, 0, len(members))
+	base := amount / int64(len(members))
+	remainder := amount % int64(len(members))
+	for i, m := range members {
+		m = NormalizeMember(m)
+		if m == "" || seen[m] {
+			return nil, errors.Messagef("invalid.split.duplicate_member", "duplicate or empty member %q", members[i])
+		}
+		seen[m] = true
+		share := base
+		if int64(i) < remainder {
+			share++
+		}
+		shares = append(shares, Share{Member: m, Amount: share})
+	}
+	return shares, nil
+}
+
+// Validate checks that shares are positive, unique and add up to Amount.
+func (e Entry) Validate() error {
+	if e.PaidBy == "" {
+		return errors.Messagef("invalid.split.payer_required", "payer is required")
+	}
+	if e.Amount <= 0 {
+		return errors.Messagef("invalid.split.amount_positive", "amount must be positive")
+	}
+	if len(e.Shares) == 0 {
+		return errors.Messagef("invalid.split.members_required", "at least one member is required")
+	}
+	var total int64
+	seen := make(map[string]bool)
+	for _, s := range e.Shares {
+		if s.Member == "" || seen[s.Member] {
+			return errors.Messagef("invalid.split.duplicate_member", "duplicate or empty member %q", s.Member)
+		}
+		if s.Amount < 0 {
+			return errors.Messagef("invalid.split.negative_share", "share of %s cannot be negative", s.Member)
+		}
+		seen[s.Member] = true
+		total += s.Amount
+	}
+	if total != e.Amount {
+		return errors.Messagef("invalid.split.shares_mismatch", "shares add up to %d but the amount is %d", total, e.Amount)
+	}
+	return nil
+}
+
+// Balances returns the net position of every member: positive means the
+// member is owed money, negative means the member owes.
+func Balances(entries []Entry) map[string]int64 {
+	balances := make(map[string]int64)
+	for _, e := range entries {
+		balances[e.PaidBy] += e.Amount
+		for _, s := range e.Shares {
+			balances[s.Member] -= s.Amount
+		}
+	}
+	for member, b := range balances {
+		if b == 0 {
+			delete(balances, member)
+		}
+	}
+	return balances
+}
+
+// maxExactMembers bounds the members Settle searches settlement groups for;
+// the search takes 2^n steps, so larger households fall back to greedy matching.
+const maxExactMembers = 16
+
+// Settle computes settlement transfers with as few transfers as possible.
+// Members are split into the largest number of groups whose balances add up
+// to zero, and each group settles on its own with one transfer less than its
+// size. Above maxExactMembers members with a non-zero balance the groups are
+// not searched, and greedy matching still needs at most n-1 transfers.
+// Input: {alice: 200, bob: -150, carol: -50}
+// Output: bob→alice 150, carol→alice 50
+func Settle(balances map[string]int64) []Transfer {
+	members := make([]string, 0, len(balances))
+	for member, b := range balances {
+		if b != 0 {
+			members = append(members, member)
+		}
+	}
+	sort.Strings(members)
+	if len(members) > maxExactMembers {
+		return settleGreedy(balances, members)
+	}
+	var transfers []Transfer
+	for _, group := range zeroSumGroups(balances, members) {
+		transfers = append(transfers, settleGreedy(balances, group)...)
+	}
+	return transfers
+}
+
+// zeroSumGroups splits members into the largest number of groups whose
+// balances add up to zero. groups[mask] is the most zero-sum groups the
+// members in mask can be split into, built by adding one member at a time:
+// whenever the members so far add up to zero another group is closed.
+func zeroSumGroups(balances map[string]int64, members []string) [][]string {
+	n := len(members)
+	sums := make([]int64, 1<<n)
+	groups := make([]int, 1<<n)
+	last := make([]int, 1<<n)
+	for mask := 1; mask < 1<<n; mask++ {
+		for i := 0; i < n; i++ {
+			bit := 1 << i
+			if mask&bit == 0 {
+				continue
+			}
+			sums[mask] = sums[mask^bit] + balances[members[i]]
+			if last[mask] == 0 || groups[mask^bit] > groups[mask] {
+				groups[mask] = groups[mask^bit]
+				last[mask] = bit
+			}
+		}
+		if sums[mask] == 0 {
+			groups[mask]++
+		}
+	}
+
+	// Walk back from all members, closing a group each time the members
+	// left add up to zero
+	var result [][]string
+	var group []string
+	for mask := 1<<n - 1; mask != 0; {
+		bit := last[mask]
+		group = append(group, members[bits.TrailingZeros(uint(bit))])
+		mask ^= bit
+		if sums[mask] == 0 {
+			sort.Strings(group)
+			result = append(result, group)
+			group = nil
+		}
+	}
+	// Groups were closed from the last one added; keep the order stable
+	sort.Slice(result, func(i, j int) bool { return result[i][0] < result[j][0] })
+	return result
+}
+
+// settleGreedy settles the balances of members by repeatedly matching the
+// largest debtor with the largest creditor, which needs at most n-1
+// transfers for n members.
+func settleGreedy(balances map[string]int64, members []string) []Transfer {
+	type position struct {
+		member string
+		amount int64
+	}
+	var creditors, debtors []position
+	for _, member := range members {
+		b := balances[member]
+		if b > 0 {
+			creditors = append(creditors, position{member, b})
+		} else if b < 0 {
+			debtors = append(debtors, position{member, -b})
+		}
+	}
+	byAmount := func(p []position) func(i, j int) bool {
+		return func(i, j int) bool {
+			if p[i].amount != p[j].amount {
+				return p[i].amount > p[j].amount
+			}
+			return p[i].member < p[j].member
+		}
+	}
+
+	var transfers []Transfer
+	for len(creditors) > 0 && len(debtors) > 0 {
+		sort.Slice(creditors, byAmount(creditors))
+		sort.Slice(debtors, byAmount(debtors))
+		amount := min(creditors[0].amount, debtors[0].amount)
+		transfers = append(transfers, Transfer{From: debtors[0].member, To: creditors[0].member, Amount: amount})
+		creditors[0].amount -= amount
+		debtors[0].amount -= amount
+		if creditors[0].amount == 0 {
+			creditors = creditors[1:]
+		}
+		if debtors[0].amount == 0 {
+			debtors = debtors[1:]
+		}
+	}
+	return transfers
+}
+
+// SettlementEntry records a transfer in the ledger so balances reflect it.
+func SettlementEntry(chatID int64, date string, t Transfer) Entry {
+	return Entry{
+		ChatID:      chatID,
+		Date:        date,
+		Description: fmt.Sprintf("Settlement %s → %s", t.From, t.To),
+		PaidBy:      t.From,
+		Amount:      t.Amount,
+		Shares:      []Share{{Member: t.To, Amount: t.Amount}},
+		Settlement:  true,
+	}
+}
@@ -0,0 +1,88 @@
+package split_domain
+
+import "testing"
+
+func TestEqualShares(t *testing.T) {
+	shares, err := EqualShares(100, []string{"@Alice", "bob", "carol"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Share{{"alice", 34}, {"bob", 33}, {"carol", 33}}
+	for i, s := range shares {
+		if s != want[i] {
+			t.Errorf("share %d: expected %+v, got %+v", i, want[i], s)
+		}
+	}
+	if _, err := EqualShares(100, []string{"alice", "Alice"}); err == nil {
+		t.Error("expected error for duplicate members")
+	}
+	if _, err := EqualShares(100, nil); err == nil {
+		t.Error("expected error without members")
+	}
+}
+
+func TestEntry_Validate(t *testing.T) {
+	valid := Entry{PaidBy: "alice", Amount: 300, Shares: []Share{{"alice", 100}, {"bob", 200}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid entry, got %v", err)
+	}
+	invalid := Entry{PaidBy: "alice", Amount: 300, Shares: []Share{{"alice", 100}, {"bob", 100}}}
+	if err := invalid.Validate(); err == nil {
+		t.Error("expected error when shares do not add up")
+	}
+}
+
+func TestBalancesAndSettle(t *testing.T) {
+	entries := []Entry{
+		// alice paid dinner for three
+		{PaidBy: "alice", Amount: 300, Shares: []Share{{"alice", 100}, {"bob", 100}, {"carol", 100}}},
+		// bob paid groceries shared with alice
+		{PaidBy: "bob", Amount: 100, Shares: []Share{{"alice", 50}, {"bob", 50}}},
+		// dave paid his own share of nothing shared
+		{PaidBy: "dave", Amount: 40, Shares: []Share{{"carol", 40}}},
+	}
+	balances := Balances(entries)
+	want := map[string]int64{"alice": 150, "bob": -50, "carol": -140, "dave": 40}
+	for member, b := range want {
+		if balances[member] != b {
+			t.Errorf("balance of %s: expected %d, got %d", member, b, balances[member])
+		}
+	}
+
+	transfers := Settle(balances)
+	if len(transfers) > 3 {
+		t.Errorf("expected at most n-1 transfers, got %+v", transfers)
+	}
+	for _, tr := range transfers {
+		entries = append(entries, SettlementEntry(1, "2025-07-20", tr))
+	}
+	if after := Balances(entries); len(after) != 0 {
+		t.Errorf("balances should be zero after settlement, got %v", after)
+	}
+	if transfers[0] != (Transfer{From: "carol", To: "alice", Amount: 140}) {
+		t.Errorf("largest debtor should pay largest creditor first, got %+v", transfers[0])
+	}
+}
+
+func TestSettle_MinimalTransfers(t *testing.T) {
+	// Greedy matching pays alice from eve first and needs four transfers;
+	// {alice, carol, dave} and {bob, eve} settle separately with three.
+	balances := map[string]int64{"alice": 7, "bob": 5, "carol": -4, "dave": -3, "eve": -5}
+	transfers := Settle(balances)
+	want := []Transfer{
+		{From: "carol", To: "alice", Amount: 4},
+		{From: "dave", To: "alice", Amount: 3},
+		{From: "eve", To: "bob", Amount: 5},
+	}
+	if len(transfers) != len(want) {
+		t.Fatalf("expected %d transfers, got %+v", len(want), transfers)
+	}
+	for i := range want {
+		if transfers[i] != want[i] {
+			t.Errorf("transfer %d: expected %+v, got %+v", i, want[i], transfers[i])
+		}
+	}
+	if greedy := settleGreedy(balances, []string{"alice", "bob", "carol", "dave", "eve"}); len(greedy) != 4 {
+		t.Errorf("greedy matching should need 4 transfers here, got %+v", greedy)
+	}
+}
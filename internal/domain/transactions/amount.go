@@ -0,0 +1,47 @@
+package transaction_domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseAmount converts the amount strings produced by the AI and stored in the
+// spreadsheet into a number.
+// Input: "150,000", "Rp 150.000", "100.50", "-25000"
+// Output: 150000, 150000, 100.5, 25000
+func ParseAmount(amount string) (float64, error) {
+	s := strings.TrimSpace(amount)
+	s = strings.TrimPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "Rp"), "IDR")
+	s = strings.ReplaceAll(s, " ", "")
+	s = strings.ReplaceAll(s, ",", "")
+	// Indonesian notation uses dots as thousands separators ("150.000").
+	if strings.Count(s, ".") > 1 || (strings.Count(s, ".") == 1 && len(s)-strings.Index(s, ".") == 4) {
+		s = strings.ReplaceAll(s, ".", "")
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// ParseSignedAmount is ParseAmount keeping a leading minus, for spreadsheet
+// cells that go negative, such as the budget left of an overspent category.
+// Input: "-25,000", "-Rp 1.500.000", "150,000"
+// Output: -25000, -1500000, 150000
+func ParseSignedAmount(amount string) (float64, error) {
+	v, err := ParseAmount(amount)
+	if err != nil {
+		return 0, err
+	}
+	if strings.HasPrefix(strings.TrimSpace(amount), "-") {
+		return -v, nil
+	}
+	return v, nil
+}
+
+// AmountValue returns the parsed Amount, or zero when it cannot be parsed.
+func (t Transaction) AmountValue() float64 {
+	v, err := ParseAmount(t.Amount)
+	if err != nil {
+		return 0
+	}
+	return v
+}
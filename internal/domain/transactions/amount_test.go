@@ -0,0 +1,58 @@
+package transaction_domain
+
+import "testing"
+
+func TestParseAmount(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected float64
+		wantErr  bool
+	}{
+		{input: "150,000", expected: 150000},
+		{input: "Rp 150.000", expected: 150000},
+		{input: "1.500.000", expected: 1500000},
+		{input: "100.50", expected: 100.5},
+		{input: "-25000", expected: 25000},
+		{input: "IDR 2,500", expected: 2500},
+		{input: "abc", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseAmount(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected error for %q", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseSignedAmount(t *testing.T) {
+	for input, want := range map[string]float64{"-25,000": -25000, "-Rp 1.500.000": -1500000, "150,000": 150000} {
+		if got, err := ParseSignedAmount(input); err != nil || got != want {
+			t.Errorf("%q: expected %v, got %v, %v", input, want, got, err)
+		}
+	}
+	if _, err := ParseSignedAmount("-"); err == nil {
+		t.Error("expected error for a lone minus")
+	}
+}
+
+func TestTransaction_AmountValue(t *testing.T) {
+	if v := (Transaction{Amount: "35,000"}).AmountValue(); v != 35000 {
+		t.Errorf("expected 35000, got %v", v)
+	}
+	if v := (Transaction{Amount: "n/a"}).AmountValue(); v != 0 {
+		t.Errorf("expected 0 for unparsable amount, got %v", v)
+	}
+}
@@ -0,0 +1,34 @@
+package transaction_domain
+
+import "sort"
+
+// LowConfidenceFields returns the fields the AI rated below threshold, by
+// name. Fields without a rating are trusted.
+// Input: Confidence{"amount": 0.4, "category": 0.9}, 0.7
+// Output: ["amount"]
+func (t Transaction) LowConfidenceFields(threshold float64) []string {
+	var fields []string
+	for field, c := range t.Confidence {
+		if c < threshold {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// MarkCertain rates fields as certain, for values a person decided such as
+// a category set by the tenant's rules
+func (t *Transaction) MarkCertain(fields ...string) {
+	if t.Confidence == nil {
+		return
+	}
+	for _, field := range fields {
+		t.Confidence[field] = 1
+	}
+}
+
+// NeedsReview reports whether the transaction was flagged for confirmation
+func (t Transaction) NeedsReview() bool {
+	return len(t.LowConfidence) > 0
+}
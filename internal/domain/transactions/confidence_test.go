@@ -0,0 +1,35 @@
+package transaction_domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLowConfidenceFields(t *testing.T) {
+	trx := Transaction{Confidence: map[string]float64{"amount": 0.4, "category": 0.9, "transaction_date": 0.65}}
+	if got := trx.LowConfidenceFields(0.7); !reflect.DeepEqual(got, []string{"amount", "transaction_date"}) {
+		t.Errorf("unexpected fields %v", got)
+	}
+	if got := (Transaction{}).LowConfidenceFields(0.7); got != nil {
+		t.Errorf("unrated transactions should be trusted, got %v", got)
+	}
+}
+
+func TestMarkCertain(t *testing.T) {
+	trx := Transaction{Confidence: map[string]float64{"category": 0.3}}
+	trx.MarkCertain("category")
+	if trx.Confidence["category"] != 1 || len(trx.LowConfidenceFields(0.7)) != 0 {
+		t.Errorf("expected the category to be certain, got %v", trx.Confidence)
+	}
+	var unrated Transaction
+	unrated.MarkCertain("category")
+	if unrated.Confidence != nil {
+		t.Error("unrated transactions should stay unrated")
+	}
+}
+
+func TestNeedsReview(t *testing.T) {
+	if (Transaction{}).NeedsReview() || !(Transaction{LowConfidence: []string{"amount"}}).NeedsReview() {
+		t.Error("only flagged transactions need review")
+	}
+}
@@ -1,16 +1,64 @@
 package transaction_domain
 
 type Transaction struct {
-	TransactionDate   string `json:"transaction_date"`
-	Amount            string `json:"amount"`
-	AmountCurrency    string `json:"amount_currency"`
-	Notes             string `json:"notes"`
-	DestinationName   string `json:"destination_name"`
-	DestinationNumber string `json:"destination_number"`
-	SourceAccount     string `json:"source_account"`
-	Category          string `json:"category"`
-	Title             string `json:"title"`
-	FileID            string `json:"file_id"`
-	CreatedBy         string `json:"created_by"`
-	WarningMessage    string `json:"warning_message,omitempty"`
+	TransactionDate string `json:"transaction_date"`
+	Amount          string `json:"amount"`
+	AmountCurrency  string `json:"amount_currency"`
+	// OriginalAmount and OriginalCurrency are what was paid when it was not
+	// in the base currency. Amount and AmountCurrency then hold the converted
+	// amount and ExchangeRate the rate it was converted with.
+	OriginalAmount    string  `json:"original_amount,omitempty"`
+	OriginalCurrency  string  `json:"original_currency,omitempty"`
+	ExchangeRate      float64 `json:"exchange_rate,omitempty"`
+	Notes             string  `json:"notes"`
+	DestinationName   string  `json:"destination_name"`
+	DestinationNumber string  `json:"destination_number"`
+	SourceAccount     string  `json:"source_account"`
+	Category          string  `json:"category"`
+	// Subcategory is the optional child of Category, such as Coffee under Eating Out
+	Subcategory string `json:"subcategory,omitempty"`
+	Title       string `json:"title"`
+	FileID      string `json:"file_id"`
+	CreatedBy   string `json:"created_by"`
+	// Goal is the savings goal this transaction contributes to, if any
+	Goal string `json:"goal,omitempty"`
+	// Tags are free-form labels, for example set by categorization rules
+	Tags []string `json:"tags,omitempty"`
+	// PromptVersion is the prompt template the transaction was extracted with,
+	// or "offline" for the rule-based parser
+	PromptVersion string `json:"prompt_version,omitempty"`
+	// Confidence is the AI's certainty per field from 0 to 1, keyed by JSON
+	// field name such as "amount"
+	Confidence map[string]float64 `json:"confidence,omitempty"`
+	// LowConfidence lists the fields below the review threshold; such
+	// transactions wait for the user's confirmation before they are saved
+	LowConfidence []string `json:"low_confidence,omitempty"`
+	// RuleID is the categorization rule applied to the transaction, whose
+	// hit is counted once the transaction is saved
+	RuleID int `json:"rule_id,omitempty"`
+	// ChatID is the chat that recorded the transaction, as read back from
+	// the shared spreadsheet. Rows from before the chat column belong to the
+	// configured legacy chat, or to none (0).
+	ChatID int64 `json:"-"`
+}
+
+// OfChat returns the transactions recorded by chatID. The spreadsheet is
+// shared by every chat, so anything computed for one chat reads only these.
+func OfChat(trxs []Transaction, chatID int64) []Transaction {
+	var out []Transaction
+	for _, trx := range trxs {
+		if trx.ChatID == chatID {
+			out = append(out, trx)
+		}
+	}
+	return out
+}
+
+// TransferCategory marks money moved between people, such as a settlement of
+// shared expenses. Transfers are not spending.
+const TransferCategory = "Transfer"
+
+// IsTransfer reports whether the transaction moves money rather than spends it
+func (t Transaction) IsTransfer() bool {
+	return t.Category == TransferCategory
 }
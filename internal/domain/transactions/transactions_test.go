@@ -16,6 +16,22 @@ func TestTransactionStructFields(t *testing.T) {
 	trx.Title = "Lunch at ABC"
 	trx.FileID = "fileid123"
 	trx.CreatedBy = "user1"
-	trx.WarningMessage = "Warning!"
 	// If we reach here, the struct is usable
 }
+
+func TestTransaction_IsTransfer(t *testing.T) {
+	if !(Transaction{Category: TransferCategory}).IsTransfer() {
+		t.Error("expected transfer category to be a transfer")
+	}
+	if (Transaction{Category: "Groceries"}).IsTransfer() {
+		t.Error("expected groceries not to be a transfer")
+	}
+}
+
+func TestOfChat(t *testing.T) {
+	trxs := []Transaction{{Notes: "a", ChatID: 1}, {Notes: "b", ChatID: 2}, {Notes: "legacy"}, {Notes: "c", ChatID: 1}}
+	got := OfChat(trxs, 1)
+	if len(got) != 2 || got[0].Notes != "a" || got[1].Notes != "c" {
+		t.Errorf("expected only chat 1's transactions, got %+v", got)
+	}
+}
@@ -0,0 +1,95 @@
+package usage_domain
+
+import "sort"
+
+// Request types AI calls are attributed to
+const (
+	KindImage   = "image"
+	KindText    = "text"
+	KindContent = "content"
+)
+
+// Tokens counts the AI calls of one request type and the tokens they used
+type Tokens struct {
+	Requests int `json:"requests"`
+	Prompt   int `json:"prompt_tokens"`
+	Response int `json:"response_tokens"`
+}
+
+// Total returns the prompt and response tokens together
+func (t Tokens) Total() int {
+	return t.Prompt + t.Response
+}
+
+// Add returns the sum of both counts
+func (t Tokens) Add(o Tokens) Tokens {
+	return Tokens{
+		Requests: t.Requests + o.Requests,
+		Prompt:   t.Prompt + o.Prompt,
+		Response: t.Response + o.Response,
+	}
+}
+
+// Totals holds a tenant's usage of one day keyed by request type
+type Totals map[string]Tokens
+
+// Sum returns the usage of all request types
+func (t Totals) Sum() Tokens {
+	var sum Tokens
+	for _, tokens := range t {
+		sum = sum.Add(tokens)
+	}
+	return sum
+}
+
+// Kinds returns the request types in alphabetical order
+func (t Totals) Kinds() []string {
+	kinds := make([]string, 0, len(t))
+	for kind := range t {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// Merge adds the usage of o to t by request type
+func (t Totals) Merge(o Totals) Totals {
+	merged := make(Totals, len(t)+len(o))
+	for kind, tokens := range t {
+		merged[kind] = tokens
+	}
+	for kind, tokens := range o {
+		merged[kind] = merged[kind].Add(tokens)
+	}
+	return merged
+}
+
+// Pricing is the price of a million tokens in US dollars
+type Pricing struct {
+	PromptPerMillion   float64
+	ResponsePerMillion float64
+}
+
+// Cost estimates the price of the tokens in US dollars
+func (p Pricing) Cost(t Tokens) float64 {
+	return (float64(t.Prompt)*p.PromptPerMillion + float64(t.Response)*p.ResponsePerMillion) / 1e6
+}
+
+// Report is a tenant's AI usage as shown by /usage
+type Report struct {
+	Today Totals
+	// Week holds the last seven days including today
+	Week Totals
+	// Quota is the tenant's daily token quota, 0 when unlimited
+	Quota int
+}
+
+// Remaining returns the tokens left of today's quota, never below 0.
+// It is meaningless when Quota is 0.
+func (r Report) Remaining() int {
+	left := r.Quota - r.Today.Sum().Total()
+	if left < 0 {
+		return 0
+	}
+	return left
+}
@@ -0,0 +1,47 @@
+package usage_domain
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTotals_SumAndMerge(t *testing.T) {
+	day := Totals{
+		KindImage: {Requests: 1, Prompt: 1200, Response: 150},
+		KindText:  {Requests: 2, Prompt: 600, Response: 80},
+	}
+	if got := day.Sum(); got != (Tokens{Requests: 3, Prompt: 1800, Response: 230}) || got.Total() != 2030 {
+		t.Errorf("unexpected sum %+v", got)
+	}
+	if got := day.Kinds(); !reflect.DeepEqual(got, []string{KindImage, KindText}) {
+		t.Errorf("unexpected kinds %v", got)
+	}
+
+	merged := day.Merge(Totals{KindText: {Requests: 1, Prompt: 300, Response: 40}})
+	if merged[KindText] != (Tokens{Requests: 3, Prompt: 900, Response: 120}) {
+		t.Errorf("unexpected merged text usage %+v", merged[KindText])
+	}
+	if day[KindText].Requests != 2 {
+		t.Error("Merge must not change the receiver")
+	}
+}
+
+func TestPricing_Cost(t *testing.T) {
+	p := Pricing{PromptPerMillion: 0.1, ResponsePerMillion: 0.4}
+	got := p.Cost(Tokens{Prompt: 1_000_000, Response: 500_000})
+	if math.Abs(got-0.3) > 1e-9 {
+		t.Errorf("expected $0.30, got %v", got)
+	}
+}
+
+func TestReport_Remaining(t *testing.T) {
+	r := Report{Today: Totals{KindText: {Prompt: 700, Response: 100}}, Quota: 1000}
+	if got := r.Remaining(); got != 200 {
+		t.Errorf("expected 200 tokens left, got %d", got)
+	}
+	r.Quota = 500
+	if got := r.Remaining(); got != 0 {
+		t.Errorf("expected no tokens left, got %d", got)
+	}
+}
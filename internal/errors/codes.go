@@ -1,5 +1,7 @@
 package errors
 
+import "log/slog"
+
 // Error codes for categorizing different types of application errors
 const (
 	// Configuration and startup errors
@@ -8,12 +10,16 @@ const (
 	// External service errors
 	ErrCodeTelegram    = "TELEGRAM_ERROR"
 	ErrCodeGemini      = "GEMINI_ERROR"
+	ErrCodeAI          = "AI_ERROR"
+	ErrCodeAIRequest   = "AI_REQUEST_ERROR"
 	ErrCodeSpreadsheet = "SPREADSHEET_ERROR"
 
 	// Internal operation errors
 	ErrCodeFileOperation = "FILE_ERROR"
 	ErrCodeValidation    = "VALIDATION_ERROR"
 	ErrCodeTransaction   = "TRANSACTION_ERROR"
+	ErrCodeQuota         = "QUOTA_EXCEEDED"
+	ErrCodeCircuitOpen   = "CIRCUIT_OPEN"
 
 	// Network and connectivity errors
 	ErrCodeNetwork = "NETWORK_ERROR"
@@ -35,6 +41,21 @@ const (
 	SeverityCritical
 )
 
+// Level maps the severity to a slog level; critical errors log at
+// slog.LevelError+4 so they can be filtered apart from ordinary errors
+func (s Severity) Level() slog.Level {
+	switch s {
+	case SeverityInfo:
+		return slog.LevelInfo
+	case SeverityWarning:
+		return slog.LevelWarn
+	case SeverityCritical:
+		return slog.LevelError + 4
+	default:
+		return slog.LevelError
+	}
+}
+
 func (s Severity) String() string {
 	switch s {
 	case SeverityInfo:
@@ -10,7 +10,7 @@
 // # Key Features
 //
 // - Structured error types with codes, context, and severity levels
-// - Domain-specific error constructors (Telegram, Gemini, Spreadsheet)
+// - Domain-specific error constructors (Telegram, Gemini, other AI providers, Spreadsheet)
 // - Retry logic support with retryable error classification
 // - Panic recovery and conversion to structured errors
 // - Comprehensive logging with context information
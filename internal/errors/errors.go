@@ -14,6 +14,10 @@ type AppError struct {
 	Severity  Severity               `json:"severity"`
 	Timestamp time.Time              `json:"timestamp"`
 	Component string                 `json:"component"`
+	// Key and Args name the message catalog entry users are shown instead
+	// of Message, so adapters can word the error in the user's language
+	Key  string        `json:"key,omitempty"`
+	Args []interface{} `json:"args,omitempty"`
 }
 
 // Error implements the error interface
@@ -44,10 +48,38 @@ func (e *AppError) WithComponent(component string) *AppError {
 	return e
 }
 
+// WithKey sets the message catalog key and arguments users see the error as
+func (e *AppError) WithKey(key string, args ...interface{}) *AppError {
+	e.Key = key
+	e.Args = args
+	return e
+}
+
+// Message is an error users can fix, such as a broken domain rule, with the
+// message catalog key and arguments adapters word it with in the user's
+// language. Its Error text is the English wording.
+type Message struct {
+	Key  string
+	Args []interface{}
+	text string
+}
+
+func (m *Message) Error() string {
+	return m.text
+}
+
+// Messagef returns a Message with the catalog key and the English text
+// format formatted with args; the catalog entry takes the same args.
+// Input: "invalid.goal.invalid_target", "invalid target %q", "abc"
+// Output: an error reading `invalid target "abc"`
+func Messagef(key, format string, args ...interface{}) error {
+	return &Message{Key: key, Args: args, text: fmt.Sprintf(format, args...)}
+}
+
 // IsRetryable determines if the error indicates a retryable condition
 func (e *AppError) IsRetryable() bool {
 	switch e.Code {
-	case ErrCodeNetwork, ErrCodeTimeout, ErrCodeSpreadsheet, ErrCodeGemini:
+	case ErrCodeNetwork, ErrCodeTimeout, ErrCodeSpreadsheet, ErrCodeGemini, ErrCodeAI:
 		return true
 	default:
 		return false
@@ -97,6 +129,17 @@ func NewGeminiTimeoutError(message string, cause error) *AppError {
 	return newAppError(ErrCodeTimeout, message, "gemini", SeverityWarning, cause)
 }
 
+// Errors of other AI providers, such as OpenAI-compatible servers
+func NewAIError(message string, cause error) *AppError {
+	return newAppError(ErrCodeAI, message, "ai", SeverityError, cause)
+}
+
+// Requests an AI provider refused, such as a bad API key or model. Unlike
+// NewAIError they are not retryable: the same request gets the same answer.
+func NewAIRequestError(message string, cause error) *AppError {
+	return newAppError(ErrCodeAIRequest, message, "ai", SeverityError, cause)
+}
+
 // Google Spreadsheet errors
 func NewSpreadsheetError(message string, cause error) *AppError {
 	return newAppError(ErrCodeSpreadsheet, message, "spreadsheet", SeverityError, cause)
@@ -121,6 +164,17 @@ func NewTransactionError(message string, cause error) *AppError {
 	return newAppError(ErrCodeTransaction, message, "transaction", SeverityError, cause)
 }
 
+// Usage quota errors, such as a tenant's daily AI token allowance
+func NewQuotaError(message string, cause error) *AppError {
+	return newAppError(ErrCodeQuota, message, "quota", SeverityWarning, cause)
+}
+
+// Circuit breaker errors: calls to a failing dependency are skipped until it
+// recovers
+func NewCircuitOpenError(message string, cause error) *AppError {
+	return newAppError(ErrCodeCircuitOpen, message, "resilience", SeverityWarning, cause)
+}
+
 // Network errors
 func NewNetworkError(message string, cause error) *AppError {
 	return newAppError(ErrCodeNetwork, message, "network", SeverityWarning, cause)
@@ -130,3 +184,9 @@ func NewNetworkError(message string, cause error) *AppError {
 func NewDataAccessError(message string, cause error) *AppError {
 	return newAppError(ErrCodeDataAccess, message, "data", SeverityError, cause)
 }
+
+// Data format errors, such as an AI answer without the expected JSON. They
+// are not retryable: the dependency answered, just not usefully.
+func NewDataFormatError(message string, cause error) *AppError {
+	return newAppError(ErrCodeDataFormat, message, "data", SeverityError, cause)
+}
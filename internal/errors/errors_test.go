@@ -2,6 +2,7 @@ package errors
 
 import (
 	"fmt"
+	"log/slog"
 	"testing"
 )
 
@@ -74,6 +75,26 @@ func TestAppError_WithComponent(t *testing.T) {
 	}
 }
 
+func TestAppError_WithKey(t *testing.T) {
+	appErr := NewValidationError("Savings is reserved for savings goals", nil).WithKey("invalid.category.reserved", "Savings")
+
+	if appErr.Key != "invalid.category.reserved" || len(appErr.Args) != 1 || appErr.Args[0] != "Savings" {
+		t.Errorf("expected key and args to be set, got %q %v", appErr.Key, appErr.Args)
+	}
+}
+
+func TestMessagef(t *testing.T) {
+	err := Messagef("invalid.goal.invalid_target", "invalid target %q", "abc")
+
+	if err.Error() != `invalid target "abc"` {
+		t.Errorf("expected the English text, got %q", err.Error())
+	}
+	m, ok := err.(*Message)
+	if !ok || m.Key != "invalid.goal.invalid_target" || len(m.Args) != 1 || m.Args[0] != "abc" {
+		t.Errorf("expected a Message with key and args, got %#v", err)
+	}
+}
+
 func TestAppError_IsRetryable(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -84,8 +105,12 @@ func TestAppError_IsRetryable(t *testing.T) {
 		{"timeout error", ErrCodeTimeout, true},
 		{"spreadsheet error", ErrCodeSpreadsheet, true},
 		{"gemini error", ErrCodeGemini, true},
+		{"ai error", ErrCodeAI, true},
+		{"ai request error", ErrCodeAIRequest, false},
+		{"data format error", ErrCodeDataFormat, false},
 		{"config error", ErrCodeConfig, false},
 		{"validation error", ErrCodeValidation, false},
+		{"quota error", ErrCodeQuota, false},
 	}
 
 	for _, tt := range tests {
@@ -133,9 +158,14 @@ func TestErrorConstructors(t *testing.T) {
 		{"NewConfigError", NewConfigError, ErrCodeConfig, SeverityCritical, "config"},
 		{"NewTelegramError", NewTelegramError, ErrCodeTelegram, SeverityError, "telegram"},
 		{"NewGeminiError", NewGeminiError, ErrCodeGemini, SeverityError, "gemini"},
+		{"NewAIError", NewAIError, ErrCodeAI, SeverityError, "ai"},
+		{"NewAIRequestError", NewAIRequestError, ErrCodeAIRequest, SeverityError, "ai"},
 		{"NewSpreadsheetError", NewSpreadsheetError, ErrCodeSpreadsheet, SeverityError, "spreadsheet"},
 		{"NewFileError", NewFileError, ErrCodeFileOperation, SeverityError, "file"},
 		{"NewValidationError", NewValidationError, ErrCodeValidation, SeverityError, "validation"},
+		{"NewQuotaError", NewQuotaError, ErrCodeQuota, SeverityWarning, "quota"},
+		{"NewCircuitOpenError", NewCircuitOpenError, ErrCodeCircuitOpen, SeverityWarning, "resilience"},
+		{"NewDataFormatError", NewDataFormatError, ErrCodeDataFormat, SeverityError, "data"},
 	}
 
 	for _, tt := range tests {
@@ -190,3 +220,21 @@ func TestSeverity_String(t *testing.T) {
 		})
 	}
 }
+
+func TestSeverity_Level(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		expected slog.Level
+	}{
+		{SeverityInfo, slog.LevelInfo},
+		{SeverityWarning, slog.LevelWarn},
+		{SeverityError, slog.LevelError},
+		{SeverityCritical, slog.LevelError + 4},
+	}
+
+	for _, tt := range tests {
+		if got := tt.severity.Level(); got != tt.expected {
+			t.Errorf("expected %s to log at %v, got %v", tt.severity, tt.expected, got)
+		}
+	}
+}
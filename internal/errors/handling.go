@@ -1,10 +1,13 @@
 package errors
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"runtime"
+	"sort"
 	"strings"
 )
 
@@ -14,6 +17,15 @@ type Logger interface {
 	Println(v ...interface{})
 }
 
+// ContextLogger is a Logger that records errors as structured attributes
+// instead of a formatted line. Attributes carried by the context, such as the
+// chat of the current request, are up to the implementation.
+// *slog.Logger provides LogAttrs; see the logging package.
+type ContextLogger interface {
+	Logger
+	LogAttrs(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr)
+}
+
 // DefaultLogger uses the standard log package
 type DefaultLogger struct{}
 
@@ -25,9 +37,17 @@ func (d DefaultLogger) Println(v ...interface{}) {
 	log.Println(v...)
 }
 
+// Observer is told about every handled error, e.g. to count errors by
+// severity in metrics
+type Observer interface {
+	ObserveError(err *AppError)
+}
+
 var (
 	// Global logger instance (can be replaced for testing)
 	logger Logger = DefaultLogger{}
+	// observer is optional and set with SetObserver
+	observer Observer
 )
 
 // SetLogger allows replacing the default logger
@@ -35,28 +55,39 @@ func SetLogger(l Logger) {
 	logger = l
 }
 
+// SetObserver registers the Observer of handled errors; nil removes it
+func SetObserver(o Observer) {
+	observer = o
+}
+
 // HandleCriticalError handles critical errors that may require application shutdown
-func HandleCriticalError(err error, context string) {
+func HandleCriticalError(err error, operation string) {
 	appErr := toAppError(err)
 
 	// Log the critical error with full context
-	logErrorWithContext(appErr, context, true)
+	logErrorWithContext(context.Background(), appErr, operation, true)
 
 	// If it's truly critical, we may need to exit
 	if appErr.IsCritical() {
-		logger.Printf("CRITICAL ERROR: Application may need to shutdown - %s", context)
+		logger.Printf("CRITICAL ERROR: Application may need to shutdown - %s", operation)
 		// Don't exit immediately - let the caller decide
 	}
 }
 
 // HandleError handles non-critical errors with appropriate logging
-func HandleError(err error, context string) {
+func HandleError(err error, operation string) {
+	HandleErrorContext(context.Background(), err, operation)
+}
+
+// HandleErrorContext is HandleError for code that has a request context, so a
+// ContextLogger can add the request's attributes
+func HandleErrorContext(ctx context.Context, err error, operation string) {
 	if err == nil {
 		return
 	}
 
 	appErr := toAppError(err)
-	logErrorWithContext(appErr, context, false)
+	logErrorWithContext(ctx, appErr, operation, false)
 }
 
 // LogError logs an error with appropriate formatting
@@ -66,11 +97,26 @@ func LogError(err error) {
 	}
 
 	appErr := toAppError(err)
-	logErrorWithContext(appErr, "", false)
+	logErrorWithContext(context.Background(), appErr, "", false)
 }
 
 // logErrorWithContext logs an error with full context information
-func logErrorWithContext(err *AppError, context string, includeStackTrace bool) {
+func logErrorWithContext(ctx context.Context, err *AppError, operation string, includeStackTrace bool) {
+	if observer != nil {
+		observer.ObserveError(err)
+	}
+	if structured, ok := logger.(ContextLogger); ok {
+		attrs := err.LogAttrs()
+		if operation != "" {
+			attrs = append(attrs, slog.String("operation", operation))
+		}
+		if includeStackTrace || err.IsCritical() {
+			attrs = append(attrs, slog.String("stack_trace", getStackTrace()))
+		}
+		structured.LogAttrs(ctx, err.Severity.Level(), err.Message, attrs...)
+		return
+	}
+
 	// Build log message
 	var parts []string
 
@@ -88,9 +134,9 @@ func logErrorWithContext(err *AppError, context string, includeStackTrace bool)
 	// Add message
 	parts = append(parts, err.Message)
 
-	// Add context if provided
-	if context != "" {
-		parts = append(parts, fmt.Sprintf("Context: %s", context))
+	// Add operation if provided
+	if operation != "" {
+		parts = append(parts, fmt.Sprintf("Context: %s", operation))
 	}
 
 	// Add error context
@@ -114,19 +160,52 @@ func logErrorWithContext(err *AppError, context string, includeStackTrace bool)
 	}
 }
 
-// formatContext converts context map to readable string
-func formatContext(context map[string]interface{}) string {
-	if len(context) == 0 {
+// formatContext converts context map to readable string, keys in alphabetical order
+func formatContext(fields map[string]interface{}) string {
+	if len(fields) == 0 {
 		return ""
 	}
 
 	var parts []string
-	for key, value := range context {
-		parts = append(parts, fmt.Sprintf("%s=%v", key, value))
+	for _, key := range sortedKeys(fields) {
+		parts = append(parts, fmt.Sprintf("%s=%v", key, fields[key]))
 	}
 	return strings.Join(parts, ", ")
 }
 
+func sortedKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LogAttrs returns the error's fields as structured log attributes: code,
+// severity, component, the error context as a "details" group with keys in
+// alphabetical order, and the cause.
+func (e *AppError) LogAttrs() []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("code", e.Code),
+		slog.String("severity", e.Severity.String()),
+	}
+	if e.Component != "" {
+		attrs = append(attrs, slog.String("component", e.Component))
+	}
+	if len(e.Context) > 0 {
+		details := make([]any, 0, len(e.Context))
+		for _, key := range sortedKeys(e.Context) {
+			details = append(details, slog.Any(key, e.Context[key]))
+		}
+		attrs = append(attrs, slog.Group("details", details...))
+	}
+	if e.Cause != nil {
+		attrs = append(attrs, slog.String("cause", e.Cause.Error()))
+	}
+	return attrs
+}
+
 // getStackTrace returns a formatted stack trace
 func getStackTrace() string {
 	buf := make([]byte, 1024)
@@ -190,7 +269,7 @@ func RecoverFromPanic() error {
 }
 
 // SafeExecute executes a function with panic recovery
-func SafeExecute(fn func() error, context string) (returnErr error) {
+func SafeExecute(fn func() error, operation string) (returnErr error) {
 	defer func() {
 		if r := recover(); r != nil {
 			var err error
@@ -206,9 +285,9 @@ func SafeExecute(fn func() error, context string) (returnErr error) {
 			appErr := NewTransactionError("recovered from panic", err)
 			appErr.WithContext("panic_value", r)
 			appErr.WithContext("stack_trace", getStackTrace())
-			appErr.WithContext("function_context", context)
+			appErr.WithContext("function_context", operation)
 
-			HandleError(appErr, context)
+			HandleError(appErr, operation)
 			returnErr = appErr
 		}
 	}()
@@ -1,7 +1,11 @@
 package errors
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strings"
 	"testing"
 )
@@ -235,7 +239,7 @@ func TestFormatContext(t *testing.T) {
 				"user_id": "12345",
 				"action":  "send_message",
 			},
-			// Note: map iteration order is not guaranteed, so we check both contain the items
+			expected: "action=send_message, user_id=12345",
 		},
 	}
 
@@ -246,12 +250,96 @@ func TestFormatContext(t *testing.T) {
 				t.Errorf("expected %q, got %q", tt.expected, result)
 			} else if tt.name == "single context item" && result != tt.expected {
 				t.Errorf("expected %q, got %q", tt.expected, result)
-			} else if tt.name == "multiple context items" {
-				// For multiple items, just check that both values are present
-				if !strings.Contains(result, "user_id=12345") || !strings.Contains(result, "action=send_message") {
-					t.Errorf("expected result to contain both context items, got: %q", result)
-				}
+			} else if tt.name == "multiple context items" && result != tt.expected {
+				t.Errorf("expected keys in alphabetical order %q, got %q", tt.expected, result)
 			}
 		})
 	}
 }
+
+// slogLogger is a ContextLogger writing JSON records to a buffer
+type slogLogger struct {
+	*slog.Logger
+	buf *bytes.Buffer
+}
+
+func newSlogLogger() *slogLogger {
+	buf := &bytes.Buffer{}
+	return &slogLogger{Logger: slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})), buf: buf}
+}
+
+func (l *slogLogger) Printf(format string, v ...interface{}) { l.Info(fmt.Sprintf(format, v...)) }
+func (l *slogLogger) Println(v ...interface{})               { l.Info(fmt.Sprint(v...)) }
+
+func TestHandleErrorContext_StructuredLogger(t *testing.T) {
+	l := newSlogLogger()
+	SetLogger(l)
+	defer SetLogger(DefaultLogger{})
+
+	err := NewNetworkError("failed to reach Gemini", fmt.Errorf("connection reset")).
+		WithContext("model", "gemini-2.0-flash").
+		WithContext("attempt", 2).
+		WithComponent("gemini-client")
+	HandleErrorContext(context.Background(), err, "parsing text")
+
+	var record map[string]interface{}
+	if jsonErr := json.Unmarshal(l.buf.Bytes(), &record); jsonErr != nil {
+		t.Fatalf("expected one JSON record, got %q: %v", l.buf.String(), jsonErr)
+	}
+	want := map[string]interface{}{
+		"level":     "WARN",
+		"msg":       "failed to reach Gemini",
+		"code":      ErrCodeNetwork,
+		"severity":  "WARNING",
+		"component": "gemini-client",
+		"operation": "parsing text",
+		"cause":     "connection reset",
+	}
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("expected %s=%v, got %v", key, value, record[key])
+		}
+	}
+	details, _ := record["details"].(map[string]interface{})
+	if details["model"] != "gemini-2.0-flash" || details["attempt"] != float64(2) {
+		t.Errorf("expected the error context as details, got %v", record["details"])
+	}
+	if _, ok := record["stack_trace"]; ok {
+		t.Error("only critical errors should carry a stack trace")
+	}
+}
+
+func TestHandleCriticalError_StructuredLogger(t *testing.T) {
+	l := newSlogLogger()
+	SetLogger(l)
+	defer SetLogger(DefaultLogger{})
+
+	HandleCriticalError(NewConfigError("missing token", nil), "application startup")
+	first := strings.SplitN(l.buf.String(), "\n", 2)[0]
+	if !strings.Contains(first, `"level":"ERROR+4"`) || !strings.Contains(first, `"stack_trace":`) {
+		t.Errorf("expected a critical record with stack trace, got %s", first)
+	}
+}
+
+type countingObserver struct {
+	codes []string
+}
+
+func (c *countingObserver) ObserveError(err *AppError) {
+	c.codes = append(c.codes, err.Code)
+}
+
+func TestSetObserver(t *testing.T) {
+	SetLogger(&MockLogger{})
+	defer SetLogger(DefaultLogger{})
+	observer := &countingObserver{}
+	SetObserver(observer)
+	defer SetObserver(nil)
+
+	HandleError(NewSpreadsheetError("append failed", nil), "saving transaction")
+	HandleCriticalError(NewConfigError("missing token", nil), "application startup")
+	HandleError(nil, "nothing happened")
+	if len(observer.codes) != 2 || observer.codes[0] != ErrCodeSpreadsheet || observer.codes[1] != ErrCodeConfig {
+		t.Errorf("unexpected observed errors %v", observer.codes)
+	}
+}
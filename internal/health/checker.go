@@ -0,0 +1,121 @@
+package health
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/errors"
+	"sync"
+	"time"
+)
+
+// Defaults used by NewChecker for values of 0 or less
+const (
+	DefaultTimeout = 5 * time.Second
+	DefaultTTL     = 30 * time.Second
+)
+
+// Probe returns nil when a dependency answers as expected
+type Probe func(ctx context.Context) error
+
+// Pinger is a client that can check its dependency, such as the Gemini
+// client checking its API key
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Check is a named dependency probe, such as "sheets"
+type Check struct {
+	Name  string
+	Probe Probe
+}
+
+// Result is the outcome of a check
+type Result struct {
+	Name      string
+	Err       error
+	CheckedAt time.Time
+}
+
+// Checker runs checks with a timeout and reuses their results for TTL, so
+// frequent readiness probes do not call Telegram, Sheets or the AI service
+// every time
+type Checker struct {
+	Checks  []Check
+	Timeout time.Duration
+	TTL     time.Duration
+	Clock   common.Clock
+
+	mu      sync.Mutex
+	results map[string]Result
+}
+
+// NewChecker returns a Checker for checks
+func NewChecker(clock common.Clock, timeout, ttl time.Duration, checks ...Check) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Checker{Checks: checks, Timeout: timeout, TTL: ttl, Clock: clock}
+}
+
+// Run returns the result of every check, in order. Checks whose result is
+// older than TTL run again, concurrently; a failure is logged once per run.
+func (c *Checker) Run(ctx context.Context) []Result {
+	results := make([]Result, len(c.Checks))
+	var wg sync.WaitGroup
+	for i, check := range c.Checks {
+		if cached, ok := c.cached(check.Name); ok {
+			results[i] = cached
+			continue
+		}
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			result := Result{Name: check.Name, Err: c.probe(ctx, check), CheckedAt: c.Clock.Now()}
+			if result.Err != nil {
+				errors.HandleErrorContext(ctx, result.Err, "checking "+check.Name)
+			}
+			c.store(result)
+			results[i] = result
+		}(i, check)
+	}
+	wg.Wait()
+	return results
+}
+
+// probe runs check within Timeout, even when the probe ignores ctx
+func (c *Checker) probe(ctx context.Context, check Check) error {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- check.Probe(ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return errors.NewNetworkError(check.Name+" did not answer in time", ctx.Err()).
+			WithContext("timeout", c.Timeout.String()).
+			WithComponent("health")
+	}
+}
+
+func (c *Checker) cached(name string) (Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, ok := c.results[name]
+	if !ok || c.Clock.Now().Sub(result.CheckedAt) >= c.TTL {
+		return Result{}, false
+	}
+	return result, true
+}
+
+func (c *Checker) store(result Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.results == nil {
+		c.results = map[string]Result{}
+	}
+	c.results[result.Name] = result
+}
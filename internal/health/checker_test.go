@@ -0,0 +1,52 @@
+package health
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/errors"
+	"testing"
+	"time"
+)
+
+func TestChecker_CachesResults(t *testing.T) {
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	calls := 0
+	checker := NewChecker(clock, time.Second, time.Minute, Check{Name: "sheets", Probe: func(ctx context.Context) error {
+		calls++
+		return errors.NewSpreadsheetError("spreadsheet not found", nil)
+	}})
+
+	checker.Run(context.Background())
+	clock.Advance(30 * time.Second)
+	results := checker.Run(context.Background())
+	if calls != 1 || len(results) != 1 || results[0].Err == nil {
+		t.Fatalf("expected the cached failure, got %d calls and %+v", calls, results)
+	}
+	clock.Advance(30 * time.Second)
+	checker.Run(context.Background())
+	if calls != 2 {
+		t.Errorf("expected the check to run again after the TTL, got %d calls", calls)
+	}
+}
+
+func TestChecker_TimesOut(t *testing.T) {
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	block := make(chan struct{})
+	defer close(block)
+	checker := NewChecker(clock, 10*time.Millisecond, time.Minute,
+		Check{Name: "telegram", Probe: func(ctx context.Context) error {
+			<-block // ignores ctx, like a client without context support
+			return nil
+		}},
+		Check{Name: "ai", Probe: func(ctx context.Context) error { return nil }},
+	)
+
+	results := checker.Run(context.Background())
+	appErr, ok := results[0].Err.(*errors.AppError)
+	if !ok || appErr.Code != errors.ErrCodeNetwork || appErr.Context["timeout"] != "10ms" {
+		t.Errorf("expected a timeout error, got %v", results[0].Err)
+	}
+	if results[1].Name != "ai" || results[1].Err != nil {
+		t.Errorf("expected the other check to pass, got %+v", results[1])
+	}
+}
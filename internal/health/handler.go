@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"money-tracker-bot/internal/errors"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxAge is how long the update loop may go without progress before
+// the bot counts as stalled: a long poll lasts up to a minute, and handling
+// a receipt can take as long again
+const DefaultMaxAge = 3 * time.Minute
+
+type liveResponse struct {
+	Status       string    `json:"status"`
+	LastProgress time.Time `json:"last_progress"`
+}
+
+// Live serves /healthz: 200 while the loop behind heartbeat made progress in
+// the last maxAge, 503 once it stalled
+func Live(heartbeat *Heartbeat, maxAge time.Duration) http.Handler {
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := liveResponse{Status: "ok", LastProgress: heartbeat.Last()}
+		status := http.StatusOK
+		if heartbeat.Age() > maxAge {
+			resp.Status = "stalled"
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, resp)
+	})
+}
+
+type checkResponse struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type readyResponse struct {
+	Status string          `json:"status"`
+	Checks []checkResponse `json:"checks"`
+}
+
+// Ready serves /readyz: 200 when every check of checker passes, 503 otherwise.
+// Failures show the error message only; the full error is in the logs.
+func Ready(checker *Checker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := readyResponse{Status: "ready", Checks: []checkResponse{}}
+		status := http.StatusOK
+		// A client hanging up must not cache failed checks for everyone else
+		for _, result := range checker.Run(context.WithoutCancel(r.Context())) {
+			check := checkResponse{Name: result.Name, Status: "ok", CheckedAt: result.CheckedAt}
+			if result.Err != nil {
+				check.Status = "failing"
+				check.Error = message(result.Err)
+				resp.Status = "not ready"
+				status = http.StatusServiceUnavailable
+			}
+			resp.Checks = append(resp.Checks, check)
+		}
+		writeJSON(w, status, resp)
+	})
+}
+
+// message leaves out the cause of AppErrors, which may quote URLs or
+// responses of the dependency
+func message(err error) string {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return appErr.Message
+	}
+	return err.Error()
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
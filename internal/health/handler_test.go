@@ -0,0 +1,63 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLive(t *testing.T) {
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	h := NewHeartbeat(clock)
+	handler := Live(h, time.Minute)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 while the loop progresses, got %d", rec.Code)
+	}
+
+	clock.Advance(2 * time.Minute)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	var body liveResponse
+	json.NewDecoder(rec.Body).Decode(&body)
+	if rec.Code != http.StatusServiceUnavailable || body.Status != "stalled" {
+		t.Errorf("expected 503 once the loop stalled, got %d %+v", rec.Code, body)
+	}
+}
+
+func TestReady(t *testing.T) {
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	var sheetsErr error = errors.NewSpreadsheetError("spreadsheet not reachable", errors.NewNetworkError("GET https://sheets.example/secret", nil))
+	checker := NewChecker(clock, time.Second, time.Minute,
+		Check{Name: "telegram", Probe: func(ctx context.Context) error { return nil }},
+		Check{Name: "sheets", Probe: func(ctx context.Context) error { return sheetsErr }},
+	)
+
+	rec := httptest.NewRecorder()
+	Ready(checker).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var body readyResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusServiceUnavailable || body.Status != "not ready" || len(body.Checks) != 2 {
+		t.Fatalf("expected 503 with both checks, got %d %+v", rec.Code, body)
+	}
+	if body.Checks[0].Status != "ok" || body.Checks[1].Status != "failing" || body.Checks[1].Error != "spreadsheet not reachable" {
+		t.Errorf("unexpected checks %+v", body.Checks)
+	}
+
+	sheetsErr = nil
+	clock.Advance(time.Minute)
+	rec = httptest.NewRecorder()
+	Ready(checker).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 once every check passes, got %d", rec.Code)
+	}
+}
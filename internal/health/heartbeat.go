@@ -0,0 +1,47 @@
+// Package health tells an orchestrator whether the bot is alive, because its
+// update loop keeps making progress, and ready, because the services it
+// depends on answer.
+package health
+
+import (
+	"money-tracker-bot/internal/common"
+	"sync"
+	"time"
+)
+
+// Heartbeat records when a loop, such as the Telegram update loop, last made
+// progress
+type Heartbeat struct {
+	Clock common.Clock
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// NewHeartbeat returns a heartbeat that counts its creation as progress, so
+// the loop has time to start
+func NewHeartbeat(clock common.Clock) *Heartbeat {
+	return &Heartbeat{Clock: clock, last: clock.Now()}
+}
+
+// Beat records progress. It does nothing on a nil *Heartbeat.
+func (h *Heartbeat) Beat() {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.last = h.Clock.Now()
+}
+
+// Last returns when the loop last made progress
+func (h *Heartbeat) Last() time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.last
+}
+
+// Age returns how long ago the loop last made progress
+func (h *Heartbeat) Age() time.Duration {
+	return h.Clock.Now().Sub(h.Last())
+}
@@ -0,0 +1,22 @@
+package health
+
+import (
+	"money-tracker-bot/internal/common"
+	"testing"
+	"time"
+)
+
+func TestHeartbeat(t *testing.T) {
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	h := NewHeartbeat(clock)
+	clock.Advance(time.Minute)
+	if h.Age() != time.Minute {
+		t.Errorf("expected the creation to count as progress, got age %v", h.Age())
+	}
+	h.Beat()
+	if h.Age() != 0 || !h.Last().Equal(clock.Now()) {
+		t.Errorf("expected a beat to reset the age, got %v", h.Age())
+	}
+	var none *Heartbeat
+	none.Beat()
+}
@@ -0,0 +1,347 @@
+package i18n
+
+// english is the catalog the other languages are checked against
+var english = catalog{
+	// Commands and files
+	"command.unknown":      "Unknown command.",
+	"callback.unsupported": "This button is no longer supported.",
+	"files.none":           "No files received yet.",
+	"files.item":           "%d. %s (from @%s, %s)\n",
+	"files.saved":          "Saved %s ✅",
+	"files.view_usage":     "Usage: /view <number>",
+	"files.viewing":        "Viewing: %s",
+	"files.download_usage": "Usage: /download <number>",
+	"files.download":       "Download: %s",
+
+	// Saved transactions
+	"saved.photo":           "Saved photo ✅",
+	"saved.text":            "Saved text ✅",
+	"saved.kept_for_review": "The spreadsheet is unavailable, so I kept the transaction here (%s). Save it with /review once the spreadsheet is back.",
+	"saved.not_saved":       "%s The transaction was not saved.",
+	"saved.converted":       "%s (%s %s at %s)",
+	"saved.summary": "%s\nCategory: %s\nAmount: %s\nNotes: %s\nLink: %s\n" +
+		"Monthly Expenses: %s\nMonthly Budget: %s\nBudget Left: %s\n" +
+		"Monthly Quota: %s\nQuota Left: %s",
+
+	// Errors
+	"error.try_later":          "please try again later",
+	"error.generic":            "Something went wrong. Please try again later.",
+	"error.critical":           "Something went wrong on my side. Please contact support with the reference below.",
+	"error.reference":          "%s\n\nReference: %s",
+	"error.unreadable_text":    "I couldn't understand that transaction. Try something like \"makan siang 35rb\".",
+	"error.unreadable_receipt": "I couldn't read that receipt. Try a clearer photo, or type the transaction instead.",
+	"error.quota":              "You've used today's AI quota. Type the transaction instead, e.g. \"makan siang 35rb\", or try again tomorrow.",
+	"error.spreadsheet":        "The spreadsheet is unavailable right now. Please try again in a few minutes.",
+	"error.circuit_open":       "One of the services I depend on is down. Please try again in a few minutes.",
+	"error.network":            "I couldn't reach one of the services I depend on. Please try again in a few minutes.",
+	"error.timeout":            "That took too long. Please try again in a few minutes.",
+	"error.file":               "I couldn't download that file. Please send it again.",
+	"error.telegram":           "Telegram had a hiccup. Please send that again.",
+
+	// /advice
+	"advice.disabled":         "Saving advice is not enabled on this bot.",
+	"advice.thinking":         "Looking at your last three months… 🔎",
+	"advice.failed":           "Could not get saving advice: %s",
+	"advice.title":            "💡 Saving suggestions\n\n%s",
+	"advice.over_budget":      "⚠️ This category is over budget.",
+	"advice.over_budget_hint": "⚠️ This category is over budget. Try /advice for saving suggestions.",
+
+	// /reparse
+	"reparse.usage": "Usage: reply /reparse to a message or photo, or send /reparse <text>\n" +
+		"Extracts the transaction again without using cached answers and saves it.",
+
+	// /status
+	"status.admins_only":     "Only admins can see the bot status.",
+	"status.none":            "No dependency is monitored on this bot.",
+	"status.title":           "🩺 Status",
+	"status.state.closed":    "closed",
+	"status.state.open":      "open",
+	"status.state.half-open": "half-open",
+	"status.since":           " since %s",
+	"status.failures":        " (%d failures in a row)",
+	"status.opens":           "\n  opened %d times, %d calls skipped",
+	"status.last_error":      "\n  last error: %s",
+
+	// /categories
+	"categories.usage":         "Usage:\n/categories – list categories\n/categories add name=\"<name>\" [parent=\"<category>\"] [aliases=\"<alias>,<alias>\"] [description=\"<text>\"]\n/categories rename \"<category>\" \"<new name>\"\n/categories merge \"<category>\" \"<into category>\"\nSubcategories are written as \"Eating Out › Coffee\" (or Eating Out > Coffee).",
+	"categories.defaults":      "Categories: %s",
+	"categories.add_failed":    "Could not add the category: %s",
+	"categories.added":         "Category added ✅ %s",
+	"categories.rename_failed": "Could not rename the category: %s",
+	"categories.merge_failed":  "Could not merge the category: %s",
+	"categories.migrated":      "%s → %s ✅\nUpdated %d recorded transaction(s).",
+	"categories.load_failed":   "Could not load your categories, please try again later.",
+	"categories.title":         "🗂 Categories",
+	"categories.also":          " (also: %s)",
+
+	// /correct and /stats
+	"correct.usage":            "Usage: /correct <category> [merchant=\"<name>\"]\nMoves your last transaction to another category and remembers the merchant for next time.",
+	"correct.disabled":         "Category corrections are not enabled on this bot.",
+	"correct.unknown_category": "Unknown category %q, choose one of: %s",
+	"correct.failed":           "Could not correct the category: %s",
+	"correct.done":             "Corrected ✅ %[1]s → %[2]s\nI'll file %[3]q under %[2]s from now on.",
+	"correct.hint":             "\nWrong category? /correct <category>",
+	"stats.load_failed":        "Could not load your stats, please try again later.",
+	"stats.title":              "🧠 Categorization stats",
+	"stats.summary":            "Saved: %d · corrected: %d (%.1f%%)",
+	"stats.none":               "No corrections learned yet. Use /correct <category> after a wrong guess.",
+	"stats.merchants":          "Learned merchants:",
+	"stats.last":               ", last %s",
+
+	// /digest
+	"digest.usage":            "Usage:\n/digest – show settings\n/digest on|off – toggle daily and weekly digests\n/digest daily on|off|HH:MM\n/digest weekly on|off|HH:MM (sent on Sundays)\n/digest tz <Area/City>\n/digest now [daily|weekly] – preview a digest",
+	"digest.disabled":         "Digests are not enabled on this bot.",
+	"digest.load_failed":      "Could not load digest settings, please try again later.",
+	"digest.build_failed":     "Could not build the digest, please try again later.",
+	"digest.save_failed":      "Could not save digest settings: check the time and timezone.",
+	"digest.updated":          "Digest settings updated ✅",
+	"digest.missing_timezone": "missing timezone",
+	"digest.missing_value":    "missing value for %s",
+	"digest.unknown_option":   "unknown option %q",
+	"digest.schedule":         "Daily summary: %s at %s\nWeekly digest: %s on Sundays at %s\nTimezone: %s",
+	"digest.on":               "on",
+	"digest.off":              "off",
+
+	// /goals
+	"goals.usage":             "Usage:\n/goals – show progress of every goal\n/goals add name=<name> target=<amount> deadline=YYYY-MM-DD [title=\"<title>\"]\n/goals save <name> <amount> – record a contribution\n/goals remove <name>\nTip: add #<name> to a message or photo caption to save it towards a goal.",
+	"goals.disabled":          "Savings goals are not enabled on this bot.",
+	"goals.add_failed":        "Could not add the goal: %s",
+	"goals.added":             "Goal added ✅\nUse #%s in a message to save towards %s.",
+	"goals.contribute_failed": "Could not record the contribution: %s",
+	"goals.saved":             "Saved towards goal ✅",
+	"goals.remove_failed":     "Could not remove %s: %s",
+	"goals.removed":           "Removed goal %s ✅",
+	"goals.load_failed":       "Could not load your goals, please try again later.",
+	"goals.none":              "No savings goals yet.",
+	"goals.title":             "🎯 Savings goals",
+	"goals.saved_amount":      "Saved towards goal ✅\nAmount: %s\nNotes: %s",
+	"goals.progress":          "%s (#%s)\n%s / %s (%.0f%%)\nDeadline: %s",
+	"goals.reached":           "\nGoal reached 🎉",
+	"goals.overdue":           "\nDeadline passed, %s still missing",
+	"goals.needed":            "\nNeeded: %s/month for %d month(s)",
+
+	// /recurring
+	"recurring.usage":             "Usage:\n/recurring – list recurring transactions\n/recurring add day=<1-31> amount=<amount> category=\"<category>\" [account=<account>] [mode=auto|remind] [title=\"<title>\"]\n/recurring remove <id>\n/recurring suggest – find repeated expenses",
+	"recurring.disabled":          "Recurring transactions are not enabled on this bot.",
+	"recurring.add_failed":        "Could not add the recurring transaction: %s",
+	"recurring.added":             "Recurring transaction added ✅",
+	"recurring.remove_failed":     "Could not remove %s: %s",
+	"recurring.removed":           "Removed %s ✅",
+	"recurring.load_failed":       "Could not load recurring transactions, please try again later.",
+	"recurring.none":              "No recurring transactions yet.",
+	"recurring.suggest_failed":    "Could not analyse your transactions, please try again later.",
+	"recurring.no_suggestions":    "No repeated expenses found.",
+	"recurring.suggestion":        "%q (%s) looks recurring: %s around day %d, seen in %d months.",
+	"recurring.add_reminder":      "➕ Add reminder",
+	"recurring.callback_disabled": "Recurring transactions are not enabled.",
+	"recurring.not_recorded":      "Not recorded: %s",
+	"recurring.confirmed":         "Saved ✅ %s on %s",
+	"recurring.saved":             "Saved",
+	"recurring.skip_failed":       "Could not skip: %s",
+	"recurring.skipped_month":     "Skipped for this month",
+	"recurring.skipped":           "Skipped",
+	"recurring.not_added":         "Not added: %s",
+	"recurring.added_as":          "Added as %s ✅",
+	"recurring.added_short":       "Added",
+	"recurring.unknown_action":    "Unknown action",
+	"recurring.day_required":      "day is required",
+	"recurring.amount_required":   "amount is required",
+	"recurring.unknown_category":  "unknown category %q, choose one of: %s",
+	"recurring.entry":             "%s. %s – %s (%s) on day %d, %s",
+	"recurring.via":               " via %s",
+
+	// /review
+	"review.save":              "✅ Save",
+	"review.discard":           "🗑 Discard",
+	"review.check":             "Please check before I save it ⚠️ (%s)\nDate: %s\nCategory: %s\nAmount: %s\nNotes: %s",
+	"review.doubts":            "\nNot sure about: %s",
+	"review.disabled":          "Transaction review is not enabled on this bot.",
+	"review.load_failed":       "Could not load the review queue: %s",
+	"review.none":              "Nothing to review ✅",
+	"review.pending":           "%d transaction(s) awaiting review:",
+	"review.callback_disabled": "Transaction review is not enabled.",
+	"review.unknown_action":    "Unknown action",
+	"review.not_saved":         "Not saved: %s",
+	"review.checked":           "Checked ✅",
+	"review.saved_title":       "Saved ✅",
+	"review.saved":             "Saved",
+	"review.not_discarded":     "Not discarded: %s",
+	"review.discarded_hint":    "Discarded 🗑 Send it again or type the transaction, e.g. \"makan siang 35rb\"",
+	"review.discarded":         "Discarded",
+
+	// /rule
+	"rule.usage":            "Usage:\n/rule – list rules and how often they fired\n/rule add <keyword|regex|merchant|account> \"<pattern>\" [category=\"<category>\"] [tags=\"<tag>,<tag>\"] [account=<account>]\n/rule test <message> [merchant=\"<name>\"] [account=<account>]\n/rule report – rules ordered by hits\n/rule remove <id>\nRules are checked in order and override the AI; the first match wins.",
+	"rule.disabled":         "Categorization rules are not enabled on this bot.",
+	"rule.add_failed":       "Could not add the rule: %s",
+	"rule.added":            "Rule added ✅",
+	"rule.test_failed":      "Could not test your rules, please try again later.",
+	"rule.no_match":         "No rule matches; the AI will decide.",
+	"rule.matches":          "Matches rule:",
+	"rule.id_not_number":    "Rule ID must be a number.",
+	"rule.remove_failed":    "Could not remove rule %s: %s",
+	"rule.removed":          "Removed rule %s ✅",
+	"rule.load_failed":      "Could not load your rules, please try again later.",
+	"rule.none":             "No rules yet.",
+	"rule.title":            "📏 Rules",
+	"rule.report_title":     "📊 Rule report",
+	"rule.unknown_category": "unknown category %q, choose one of: %s",
+	"rule.account":          "account %s",
+	"rule.tags":             "tags %s",
+	"rule.entry":            "%d. %s %q → %s · %d hit(s)",
+	"rule.last":             ", last %s",
+
+	// /split, /balances and /settle
+	"split.usage":           "Usage:\n/split <member> <member>… – share your last transaction equally\n/split <member>=<amount> <member>=<amount>… – share it by custom amounts\n/balances – who owes whom\n/settle – record the transfers that settle every balance\nInclude the payer in the list when they share the cost too.",
+	"split.disabled":        "Shared expenses are not enabled on this bot.",
+	"split.failed":          "Could not split the transaction: %s",
+	"split.shared":          "Shared ✅ %s paid by %s",
+	"split.for":             " for %s",
+	"split.balances_failed": "Could not load balances, please try again later.",
+	"split.settle_failed":   "Could not settle balances: %s",
+	"split.settled_up":      "Everyone is settled up 🎉",
+	"split.settlement":      "🤝 Settlement recorded",
+	"split.balances":        "⚖️ Balances",
+	"split.owed":            "• %s is owed %s",
+	"split.owes":            "• %s owes %s",
+	"split.transfers":       "Suggested transfers:",
+
+	// /usage
+	"usage.disabled":    "AI usage tracking is not enabled on this bot.",
+	"usage.usage":       "Usage: /usage (admins: /usage <chat id>)",
+	"usage.load_failed": "Could not load the AI usage, please try again later.",
+	"usage.chat":        "Chat %d\n%s",
+	"usage.title":       "📊 AI usage",
+	"usage.today":       "Today: %s",
+	"usage.week":        "Last 7 days: %s",
+	"usage.quota":       "Quota: %s of %s tokens left today",
+	"usage.no_calls":    "No AI calls from any chat today.",
+	"usage.all_chats":   "All chats today:",
+	"usage.scheduled":   "scheduled jobs",
+	"usage.total":       "Total: %s",
+	"usage.call":        "call",
+	"usage.calls":       "calls",
+	"usage.tokens":      "%d %s · %s tokens (~$%.4f)",
+
+	// /rate
+	"rate.usage":         "Usage:\n/rate – list the latest exchange rates\n/rate <currency> <rate> [YYYY-MM-DD] – how many %s one unit buys from that day on, such as /rate USD 16200\nSend a CSV file with the caption /rate to import many rates at once, with the columns date,currency,rate.",
+	"rate.disabled":      "Exchange rates are not enabled on this bot.",
+	"rate.invalid":       "Rate must be a positive number, such as 16200.",
+	"rate.set_failed":    "Could not set the rate: %s",
+	"rate.set":           "1 %s = %s %s from %s ✅",
+	"rate.load_failed":   "Could not load your exchange rates, please try again later.",
+	"rate.none":          "No exchange rates yet. Transactions in other currencies are not saved until their currency has a rate.",
+	"rate.title":         "💱 Exchange rates to %s",
+	"rate.entry":         "1 %s = %s %s · since %s",
+	"rate.not_csv":       "Send the rates as a .csv file with the columns date,currency,rate.",
+	"rate.import_failed": "Could not import the rates: %s",
+	"rate.imported":      "Imported %d rate(s) ✅",
+
+	// /timezone, /locale and /language
+	"settings.disabled":           "Chat settings are not enabled on this bot.",
+	"settings.timezone_usage":     "Usage: /timezone <Area/City>, such as /timezone Asia/Jakarta",
+	"settings.timezone_failed":    "Could not change the timezone: %s",
+	"settings.timezone_set":       "Timezone set to %s ✅",
+	"settings.locale_usage":       "Usage: /locale <%s>",
+	"settings.locale_failed":      "Could not change the locale: %s",
+	"settings.locale_set":         "Locale set to %s ✅\nExample: %s",
+	"settings.language_usage":     "Usage: /language <%s|auto>\nauto follows the language of your Telegram app.",
+	"settings.language_failed":    "Could not change the language: %s",
+	"settings.language_set":       "Language set to %s ✅",
+	"settings.language_auto":      "Language follows your Telegram app ✅",
+	"settings.load_failed":        "Could not load the chat settings, please try again later.",
+	"settings.summary":            "Timezone: %s\nLocale: %s (%s)\nLanguage: %s",
+	"settings.language_auto_name": "auto",
+
+	// Language names
+	"language.name.en": "English",
+	"language.name.id": "Bahasa Indonesia",
+
+	// Scheduled digests
+	"digest.weekly_title": "📅 Weekly digest (%s – %s)",
+	"digest.daily_title":  "🌙 Daily summary (%s)",
+	"digest.spent":        "Spent: %s in %d transaction(s)",
+	"digest.budget_left":  "Budget Left: %s",
+	"digest.per_day_left": "Per Day Left: %s for the remaining %d day(s)",
+	"digest.last_day":     "Last day of the month",
+
+	// Recurring reminders
+	"recurring.recorded": "Recorded recurring ✅\n%s\nAmount: %s\nDate: %s",
+	"recurring.due":      "🔔 %s is due\nAmount: %s\nCategory: %s\nRecord it now?",
+	"recurring.confirm":  "✅ Confirm",
+	"recurring.skip":     "Skip",
+
+	// Command arguments
+	"args.unterminated_quote": "unterminated quote",
+
+	// Validation errors of services and domains, shown inside the replies above
+	"invalid.advice.no_spending":          "no spending recorded in the last three months yet",
+	"invalid.category.unknown_parent":     "unknown parent category %q",
+	"invalid.category.nested":             "subcategories cannot be nested, %q is already a subcategory",
+	"invalid.category.exists":             "category %q already exists",
+	"invalid.category.alias_taken":        "alias %q already refers to %s",
+	"invalid.category.unknown":            "unknown category %q",
+	"invalid.category.merge_itself":       "cannot merge %q into itself",
+	"invalid.category.merge_parent":       "%q has subcategories and can only be merged into a top-level category",
+	"invalid.category.merge_conflict":     "%q already has a subcategory %q",
+	"invalid.category.name_required":      "category name is required",
+	"invalid.category.name_invalid":       "category name %q cannot contain › or >",
+	"invalid.category.reserved":           "%s is reserved for savings goals",
+	"invalid.currency.unknown":            "unknown currency %q",
+	"invalid.currency.unknown_base":       "unknown base currency %q",
+	"invalid.currency.is_base":            "%s is the base currency",
+	"invalid.currency.rate_positive":      "rate must be positive",
+	"invalid.currency.invalid_date":       "invalid date %q, expected YYYY-MM-DD",
+	"invalid.currency.invalid_rate":       "invalid rate %q",
+	"invalid.currency.ambiguous_rate":     "rate %q is ambiguous, write thousands without dots (16200) or decimals with fewer or more digits (16.2)",
+	"invalid.currency.invalid_amount":     "invalid amount",
+	"invalid.currency.no_rate":            "no exchange rate for %[1]s, set one with /rate %[1]s <rate in %[2]s>",
+	"invalid.currency.csv_malformed":      "line %d is not valid CSV",
+	"invalid.currency.csv_header":         "line %d: header must name the date, currency and rate columns",
+	"invalid.currency.csv_line":           "line %d: %s",
+	"invalid.currency.no_rates":           "no rates found",
+	"invalid.digest.invalid_time":         "invalid time of day %q, expected HH:MM",
+	"invalid.digest.unknown_timezone":     "unknown timezone",
+	"invalid.goal.name_required":          "name is required",
+	"invalid.goal.invalid_target":         "invalid target %q",
+	"invalid.goal.invalid_deadline":       "invalid deadline %q, expected YYYY-MM-DD",
+	"invalid.goal.invalid_amount":         "invalid amount",
+	"invalid.goal.not_found":              "goal not found",
+	"invalid.goal.exists":                 "goal %s already exists, remove it first to change it",
+	"invalid.learning.merchant_required":  "merchant is required",
+	"invalid.learning.category_required":  "category is required",
+	"invalid.learning.no_recent":          "no recent transaction to correct, send one first",
+	"invalid.learning.already_in":         "the last transaction is already in %s",
+	"invalid.learning.no_merchant":        "could not tell the merchant, add merchant=<name>",
+	"invalid.learning.not_in_sheet":       "the last transaction is no longer in the spreadsheet",
+	"invalid.offline.no_generate":         "the offline parser cannot generate content",
+	"invalid.offline.no_receipts":         "receipts cannot be read offline, please type the transaction instead",
+	"invalid.offline.no_amount":           "no amount found in the message, try something like \"makan siang 35rb\"",
+	"invalid.recurring.unknown_mode":      "unknown mode %q, expected auto or remind",
+	"invalid.recurring.day_range":         "day must be between 1 and 31",
+	"invalid.recurring.invalid_amount":    "invalid amount %q",
+	"invalid.recurring.category_required": "category is required",
+	"invalid.recurring.invalid_period":    "invalid period %q",
+	"invalid.recurring.already_recorded":  "already recorded for this month",
+	"invalid.recurring.suggestion_gone":   "suggestion no longer available",
+	"invalid.recurring.not_found":         "recurring transaction not found",
+	"invalid.review.not_pending":          "this transaction is no longer awaiting review",
+	"invalid.rule.unknown_kind":           "unknown rule type %q, expected keyword, regex, merchant or account",
+	"invalid.rule.pattern_required":       "pattern is required",
+	"invalid.rule.invalid_regex":          "invalid regex %q: %v",
+	"invalid.rule.action_required":        "a rule needs a category, tags or account to set",
+	"invalid.rule.not_found":              "rule not found",
+	"invalid.settings.unknown_timezone":   "unknown timezone %q",
+	"invalid.settings.timezone":           "unknown timezone, expected an IANA name such as Asia/Jakarta",
+	"invalid.settings.locale":             "unsupported locale, expected one of %s",
+	"invalid.settings.language":           "unsupported language, expected one of %s or auto",
+	"invalid.split.members_required":      "at least one member is required",
+	"invalid.split.duplicate_member":      "duplicate or empty member %q",
+	"invalid.split.payer_required":        "payer is required",
+	"invalid.split.amount_positive":       "amount must be positive",
+	"invalid.split.negative_share":        "share of %s cannot be negative",
+	"invalid.split.shares_mismatch":       "shares add up to %d but the amount is %d",
+	"invalid.split.mixed_shares":          "use either member names or member=amount shares, not both",
+	"invalid.split.invalid_share":         "invalid share %q for %s",
+	"invalid.split.no_recent":             "no recent transaction to split, send one first (the last transaction is forgotten when the bot restarts)",
+	"invalid.split.invalid_amount":        "the last transaction has no valid amount",
+}
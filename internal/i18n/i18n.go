@@ -0,0 +1,126 @@
+// Package i18n holds the bot's replies in every language it speaks and
+// picks the language of each chat.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Languages the bot replies in
+const (
+	English    = "en"
+	Indonesian = "id"
+)
+
+// Default is used when a chat has no language and Telegram does not send one
+const Default = English
+
+// catalog maps message keys to fmt templates
+type catalog map[string]string
+
+// catalogs holds one catalog per language; every language has every key
+var catalogs = map[string]catalog{
+	English:    english,
+	Indonesian: indonesian,
+}
+
+// Languages returns the supported language codes in alphabetical order
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Parse returns the supported language of a language code such as
+// Telegram's LanguageCode, ignoring the region and case. "in" is the old
+// code for Indonesian.
+// Input: "id-ID"
+// Output: "id", true
+func Parse(code string) (string, bool) {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(code)), "-")
+	base, _, _ = strings.Cut(base, "_")
+	if base == "in" {
+		base = Indonesian
+	}
+	if _, ok := catalogs[base]; ok {
+		return base, true
+	}
+	return "", false
+}
+
+// Printer renders messages in one language
+type Printer struct {
+	lang string
+}
+
+// For returns the Printer of lang, or of Default when lang is not supported
+func For(lang string) Printer {
+	if l, ok := Parse(lang); ok {
+		return Printer{lang: l}
+	}
+	return Printer{lang: Default}
+}
+
+// Lang returns the language code of the printer
+func (p Printer) Lang() string {
+	if p.lang == "" {
+		return Default
+	}
+	return p.lang
+}
+
+// T renders the message key with args. A key missing from the language
+// falls back to English, and a key missing from English is returned as is,
+// so a reply is never empty.
+// Input: T("settings.timezone_set", "Asia/Jakarta") in Indonesian
+// Output: "Zona waktu diatur ke Asia/Jakarta ✅"
+func (p Printer) T(key string, args ...any) string {
+	template, ok := catalogs[p.Lang()][key]
+	if !ok {
+		template, ok = catalogs[Default][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return template
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+type languageKey struct{}
+
+// WithLanguage returns a copy of ctx carrying the language of the request
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// LanguageFrom returns the language stored by WithLanguage
+func LanguageFrom(ctx context.Context) (string, bool) {
+	lang, ok := ctx.Value(languageKey{}).(string)
+	return lang, ok && lang != ""
+}
+
+// TenantLanguages resolves the language each tenant has chosen, "" when it
+// has not chosen one
+type TenantLanguages interface {
+	Language(chatID int64) string
+}
+
+// Tenant returns the Printer for a message to chatID: the language in ctx,
+// else the one languages gives the chat, else Default. languages may be nil.
+func Tenant(ctx context.Context, languages TenantLanguages, chatID int64) Printer {
+	if lang, ok := LanguageFrom(ctx); ok {
+		return For(lang)
+	}
+	if languages != nil {
+		return For(languages.Language(chatID))
+	}
+	return For(Default)
+}
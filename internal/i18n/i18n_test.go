@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCatalogs_EveryKeyInEveryLanguage(t *testing.T) {
+	for lang, c := range catalogs {
+		for key := range catalogs[Default] {
+			if _, ok := c[key]; !ok {
+				t.Errorf("%s is missing %q", lang, key)
+			}
+		}
+		for key := range c {
+			if _, ok := catalogs[Default][key]; !ok {
+				t.Errorf("%s has %q, which %s does not", lang, key, Default)
+			}
+		}
+	}
+}
+
+func TestCatalogs_SameArguments(t *testing.T) {
+	for lang, c := range catalogs {
+		for key, template := range c {
+			want := verbs(catalogs[Default][key])
+			if got := verbs(template); !reflect.DeepEqual(got, want) {
+				t.Errorf("%s %q uses arguments %v, %s uses %v", lang, key, got, Default, want)
+			}
+		}
+	}
+}
+
+// verbs maps the argument number of every fmt verb in template to the verb,
+// following explicit indexes such as %[2]s
+func verbs(template string) map[int]byte {
+	out := make(map[int]byte)
+	arg := 0
+	for i := 0; i < len(template); i++ {
+		if template[i] != '%' {
+			continue
+		}
+		i++
+		if i < len(template) && template[i] == '%' {
+			continue
+		}
+		for i < len(template) && (template[i] == '[' || template[i] == ']' || template[i] == '.' || template[i] == '+' ||
+			template[i] == '-' || template[i] == '#' || template[i] == ' ' || (template[i] >= '0' && template[i] <= '9')) {
+			if template[i] == '[' {
+				n := 0
+				for i++; i < len(template) && template[i] != ']'; i++ {
+					n = n*10 + int(template[i]-'0')
+				}
+				arg = n - 1
+			}
+			i++
+		}
+		if i < len(template) {
+			out[arg] = template[i]
+			arg++
+		}
+	}
+	return out
+}
+
+func TestVerbs(t *testing.T) {
+	got := verbs("%[2]s → %[1]q, %[3].1f%% and %d")
+	want := map[int]byte{0: 'q', 1: 's', 2: 'f', 3: 'd'}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]string{"id": Indonesian, "id-ID": Indonesian, "in": Indonesian, "EN_gb": English, " en ": English}
+	for code, want := range tests {
+		if got, ok := Parse(code); !ok || got != want {
+			t.Errorf("Parse(%q) = %q, %v, expected %q", code, got, ok, want)
+		}
+	}
+	for _, code := range []string{"", "fr", "auto"} {
+		if got, ok := Parse(code); ok {
+			t.Errorf("Parse(%q) = %q, expected unsupported", code, got)
+		}
+	}
+}
+
+func TestPrinter_T(t *testing.T) {
+	id := For("id-ID")
+	if got := id.T("settings.timezone_set", "Asia/Jakarta"); got != "Zona waktu diatur ke Asia/Jakarta ✅" {
+		t.Errorf("unexpected Indonesian reply %q", got)
+	}
+	if got := For("fr").T("command.unknown"); got != "Unknown command." {
+		t.Errorf("expected English for an unsupported language, got %q", got)
+	}
+	if got := id.T("no.such.key"); got != "no.such.key" {
+		t.Errorf("expected the key for a missing message, got %q", got)
+	}
+	if got := (Printer{}).Lang(); got != Default {
+		t.Errorf("expected the zero Printer to use %s, got %s", Default, got)
+	}
+}
+
+type fixedLanguages map[int64]string
+
+func (f fixedLanguages) Language(chatID int64) string { return f[chatID] }
+
+func TestTenant(t *testing.T) {
+	languages := fixedLanguages{1: Indonesian}
+	if got := Tenant(context.Background(), languages, 1).Lang(); got != Indonesian {
+		t.Errorf("expected the chat's language, got %s", got)
+	}
+	if got := Tenant(context.Background(), languages, 2).Lang(); got != Default {
+		t.Errorf("expected the default for a chat without a language, got %s", got)
+	}
+	ctx := WithLanguage(context.Background(), English)
+	if got := Tenant(ctx, languages, 1).Lang(); got != English {
+		t.Errorf("expected the language in ctx to win, got %s", got)
+	}
+	if got := Tenant(context.Background(), nil, 1).Lang(); got != Default {
+		t.Errorf("expected the default without languages, got %s", got)
+	}
+}
@@ -0,0 +1,347 @@
+package i18n
+
+// indonesian is the Bahasa Indonesia catalog
+var indonesian = catalog{
+	// Commands and files
+	"command.unknown":      "Perintah tidak dikenal.",
+	"callback.unsupported": "Tombol ini sudah tidak berlaku.",
+	"files.none":           "Belum ada file yang diterima.",
+	"files.item":           "%d. %s (dari @%s, %s)\n",
+	"files.saved":          "%s disimpan ✅",
+	"files.view_usage":     "Cara pakai: /view <nomor>",
+	"files.viewing":        "Melihat: %s",
+	"files.download_usage": "Cara pakai: /download <nomor>",
+	"files.download":       "Unduh: %s",
+
+	// Saved transactions
+	"saved.photo":           "Foto disimpan ✅",
+	"saved.text":            "Teks disimpan ✅",
+	"saved.kept_for_review": "Spreadsheet sedang tidak bisa diakses, jadi transaksinya saya simpan di sini (%s). Simpan dengan /review setelah spreadsheet kembali normal.",
+	"saved.not_saved":       "%s Transaksi tidak disimpan.",
+	"saved.converted":       "%s (%s %s dengan kurs %s)",
+	"saved.summary": "%s\nKategori: %s\nJumlah: %s\nCatatan: %s\nTautan: %s\n" +
+		"Pengeluaran Bulanan: %s\nAnggaran Bulanan: %s\nSisa Anggaran: %s\n" +
+		"Kuota Bulanan: %s\nSisa Kuota: %s",
+
+	// Errors
+	"error.try_later":          "silakan coba lagi nanti",
+	"error.generic":            "Terjadi kesalahan. Silakan coba lagi nanti.",
+	"error.critical":           "Terjadi kesalahan di sistem saya. Silakan hubungi support dengan kode referensi di bawah.",
+	"error.reference":          "%s\n\nReferensi: %s",
+	"error.unreadable_text":    "Saya tidak memahami transaksi itu. Coba tulis seperti \"makan siang 35rb\".",
+	"error.unreadable_receipt": "Saya tidak bisa membaca struk itu. Coba foto yang lebih jelas, atau ketik transaksinya.",
+	"error.quota":              "Kuota AI hari ini sudah habis. Ketik transaksinya, misalnya \"makan siang 35rb\", atau coba lagi besok.",
+	"error.spreadsheet":        "Spreadsheet sedang tidak bisa diakses. Silakan coba lagi beberapa menit lagi.",
+	"error.circuit_open":       "Salah satu layanan yang saya gunakan sedang gangguan. Silakan coba lagi beberapa menit lagi.",
+	"error.network":            "Saya tidak bisa menghubungi salah satu layanan yang saya gunakan. Silakan coba lagi beberapa menit lagi.",
+	"error.timeout":            "Prosesnya terlalu lama. Silakan coba lagi beberapa menit lagi.",
+	"error.file":               "Saya tidak bisa mengunduh file itu. Silakan kirim ulang.",
+	"error.telegram":           "Telegram sedang bermasalah. Silakan kirim ulang.",
+
+	// /advice
+	"advice.disabled":         "Saran berhemat tidak diaktifkan di bot ini.",
+	"advice.thinking":         "Melihat tiga bulan terakhir kamu… 🔎",
+	"advice.failed":           "Tidak bisa mendapatkan saran berhemat: %s",
+	"advice.title":            "💡 Saran berhemat\n\n%s",
+	"advice.over_budget":      "⚠️ Kategori ini melebihi anggaran.",
+	"advice.over_budget_hint": "⚠️ Kategori ini melebihi anggaran. Coba /advice untuk saran berhemat.",
+
+	// /reparse
+	"reparse.usage": "Cara pakai: balas pesan atau foto dengan /reparse, atau kirim /reparse <teks>\n" +
+		"Membaca ulang transaksinya tanpa jawaban yang tersimpan lalu menyimpannya.",
+
+	// /status
+	"status.admins_only":     "Hanya admin yang bisa melihat status bot.",
+	"status.none":            "Tidak ada layanan yang dipantau di bot ini.",
+	"status.title":           "🩺 Status",
+	"status.state.closed":    "normal",
+	"status.state.open":      "terputus",
+	"status.state.half-open": "uji coba",
+	"status.since":           " sejak %s",
+	"status.failures":        " (%d kali gagal berturut-turut)",
+	"status.opens":           "\n  terputus %d kali, %d panggilan dilewati",
+	"status.last_error":      "\n  error terakhir: %s",
+
+	// /categories
+	"categories.usage":         "Cara pakai:\n/categories – daftar kategori\n/categories add name=\"<nama>\" [parent=\"<kategori>\"] [aliases=\"<alias>,<alias>\"] [description=\"<teks>\"]\n/categories rename \"<kategori>\" \"<nama baru>\"\n/categories merge \"<kategori>\" \"<ke kategori>\"\nSubkategori ditulis sebagai \"Eating Out › Coffee\" (atau Eating Out > Coffee).",
+	"categories.defaults":      "Kategori: %s",
+	"categories.add_failed":    "Kategori tidak bisa ditambahkan: %s",
+	"categories.added":         "Kategori ditambahkan ✅ %s",
+	"categories.rename_failed": "Kategori tidak bisa diganti namanya: %s",
+	"categories.merge_failed":  "Kategori tidak bisa digabung: %s",
+	"categories.migrated":      "%s → %s ✅\n%d transaksi tercatat diperbarui.",
+	"categories.load_failed":   "Kategori kamu tidak bisa dimuat, coba lagi nanti.",
+	"categories.title":         "🗂 Kategori",
+	"categories.also":          " (juga: %s)",
+
+	// /correct and /stats
+	"correct.usage":            "Cara pakai: /correct <kategori> [merchant=\"<nama>\"]\nMemindahkan transaksi terakhirmu ke kategori lain dan mengingat merchant-nya untuk berikutnya.",
+	"correct.disabled":         "Koreksi kategori tidak diaktifkan di bot ini.",
+	"correct.unknown_category": "Kategori %q tidak dikenal, pilih salah satu: %s",
+	"correct.failed":           "Kategori tidak bisa dikoreksi: %s",
+	"correct.done":             "Dikoreksi ✅ %[1]s → %[2]s\nMulai sekarang %[3]q akan saya catat sebagai %[2]s.",
+	"correct.hint":             "\nKategori salah? /correct <kategori>",
+	"stats.load_failed":        "Statistik kamu tidak bisa dimuat, coba lagi nanti.",
+	"stats.title":              "🧠 Statistik kategori",
+	"stats.summary":            "Tersimpan: %d · dikoreksi: %d (%.1f%%)",
+	"stats.none":               "Belum ada koreksi yang dipelajari. Pakai /correct <kategori> setelah tebakan yang salah.",
+	"stats.merchants":          "Merchant yang dipelajari:",
+	"stats.last":               ", terakhir %s",
+
+	// /digest
+	"digest.usage":            "Cara pakai:\n/digest – lihat pengaturan\n/digest on|off – nyalakan atau matikan ringkasan harian dan mingguan\n/digest daily on|off|HH:MM\n/digest weekly on|off|HH:MM (dikirim setiap Minggu)\n/digest tz <Area/Kota>\n/digest now [daily|weekly] – pratinjau ringkasan",
+	"digest.disabled":         "Ringkasan tidak diaktifkan di bot ini.",
+	"digest.load_failed":      "Pengaturan ringkasan tidak bisa dimuat, coba lagi nanti.",
+	"digest.build_failed":     "Ringkasan tidak bisa dibuat, coba lagi nanti.",
+	"digest.save_failed":      "Pengaturan ringkasan tidak bisa disimpan: periksa jam dan zona waktunya.",
+	"digest.updated":          "Pengaturan ringkasan diperbarui ✅",
+	"digest.missing_timezone": "zona waktu belum diisi",
+	"digest.missing_value":    "nilai untuk %s belum diisi",
+	"digest.unknown_option":   "opsi %q tidak dikenal",
+	"digest.schedule":         "Ringkasan harian: %s pukul %s\nRingkasan mingguan: %s setiap Minggu pukul %s\nZona waktu: %s",
+	"digest.on":               "aktif",
+	"digest.off":              "nonaktif",
+
+	// /goals
+	"goals.usage":             "Cara pakai:\n/goals – lihat progres setiap target\n/goals add name=<nama> target=<jumlah> deadline=YYYY-MM-DD [title=\"<judul>\"]\n/goals save <nama> <jumlah> – catat setoran\n/goals remove <nama>\nTips: tambahkan #<nama> di pesan atau keterangan foto untuk menabung ke sebuah target.",
+	"goals.disabled":          "Target tabungan tidak diaktifkan di bot ini.",
+	"goals.add_failed":        "Target tidak bisa ditambahkan: %s",
+	"goals.added":             "Target ditambahkan ✅\nPakai #%s di pesan untuk menabung ke %s.",
+	"goals.contribute_failed": "Setoran tidak bisa dicatat: %s",
+	"goals.saved":             "Tersimpan ke target ✅",
+	"goals.remove_failed":     "%s tidak bisa dihapus: %s",
+	"goals.removed":           "Target %s dihapus ✅",
+	"goals.load_failed":       "Target kamu tidak bisa dimuat, coba lagi nanti.",
+	"goals.none":              "Belum ada target tabungan.",
+	"goals.title":             "🎯 Target tabungan",
+	"goals.saved_amount":      "Tersimpan ke target ✅\nJumlah: %s\nCatatan: %s",
+	"goals.progress":          "%s (#%s)\n%s / %s (%.0f%%)\nTenggat: %s",
+	"goals.reached":           "\nTarget tercapai 🎉",
+	"goals.overdue":           "\nTenggat lewat, masih kurang %s",
+	"goals.needed":            "\nPerlu: %s/bulan selama %d bulan",
+
+	// /recurring
+	"recurring.usage":             "Cara pakai:\n/recurring – daftar transaksi rutin\n/recurring add day=<1-31> amount=<jumlah> category=\"<kategori>\" [account=<rekening>] [mode=auto|remind] [title=\"<judul>\"]\n/recurring remove <id>\n/recurring suggest – cari pengeluaran yang berulang",
+	"recurring.disabled":          "Transaksi rutin tidak diaktifkan di bot ini.",
+	"recurring.add_failed":        "Transaksi rutin tidak bisa ditambahkan: %s",
+	"recurring.added":             "Transaksi rutin ditambahkan ✅",
+	"recurring.remove_failed":     "%s tidak bisa dihapus: %s",
+	"recurring.removed":           "%s dihapus ✅",
+	"recurring.load_failed":       "Transaksi rutin tidak bisa dimuat, coba lagi nanti.",
+	"recurring.none":              "Belum ada transaksi rutin.",
+	"recurring.suggest_failed":    "Transaksimu tidak bisa dianalisis, coba lagi nanti.",
+	"recurring.no_suggestions":    "Tidak ada pengeluaran berulang yang ditemukan.",
+	"recurring.suggestion":        "%q (%s) sepertinya rutin: %s sekitar tanggal %d, terlihat dalam %d bulan.",
+	"recurring.add_reminder":      "➕ Tambah pengingat",
+	"recurring.callback_disabled": "Transaksi rutin tidak diaktifkan.",
+	"recurring.not_recorded":      "Tidak dicatat: %s",
+	"recurring.confirmed":         "Tersimpan ✅ %s pada %s",
+	"recurring.saved":             "Tersimpan",
+	"recurring.skip_failed":       "Tidak bisa dilewati: %s",
+	"recurring.skipped_month":     "Dilewati untuk bulan ini",
+	"recurring.skipped":           "Dilewati",
+	"recurring.not_added":         "Tidak ditambahkan: %s",
+	"recurring.added_as":          "Ditambahkan sebagai %s ✅",
+	"recurring.added_short":       "Ditambahkan",
+	"recurring.unknown_action":    "Aksi tidak dikenal",
+	"recurring.day_required":      "day wajib diisi",
+	"recurring.amount_required":   "amount wajib diisi",
+	"recurring.unknown_category":  "kategori %q tidak dikenal, pilih salah satu: %s",
+	"recurring.entry":             "%s. %s – %s (%s) setiap tanggal %d, %s",
+	"recurring.via":               " lewat %s",
+
+	// /review
+	"review.save":              "✅ Simpan",
+	"review.discard":           "🗑 Buang",
+	"review.check":             "Tolong cek dulu sebelum saya simpan ⚠️ (%s)\nTanggal: %s\nKategori: %s\nJumlah: %s\nCatatan: %s",
+	"review.doubts":            "\nKurang yakin soal: %s",
+	"review.disabled":          "Pemeriksaan transaksi tidak diaktifkan di bot ini.",
+	"review.load_failed":       "Antrean pemeriksaan tidak bisa dimuat: %s",
+	"review.none":              "Tidak ada yang perlu diperiksa ✅",
+	"review.pending":           "%d transaksi menunggu diperiksa:",
+	"review.callback_disabled": "Pemeriksaan transaksi tidak diaktifkan.",
+	"review.unknown_action":    "Aksi tidak dikenal",
+	"review.not_saved":         "Tidak disimpan: %s",
+	"review.checked":           "Sudah dicek ✅",
+	"review.saved_title":       "Tersimpan ✅",
+	"review.saved":             "Tersimpan",
+	"review.not_discarded":     "Tidak dibuang: %s",
+	"review.discarded_hint":    "Dibuang 🗑 Kirim ulang atau ketik transaksinya, misalnya \"makan siang 35rb\"",
+	"review.discarded":         "Dibuang",
+
+	// /rule
+	"rule.usage":            "Cara pakai:\n/rule – daftar aturan dan seberapa sering terpakai\n/rule add <keyword|regex|merchant|account> \"<pola>\" [category=\"<kategori>\"] [tags=\"<tag>,<tag>\"] [account=<rekening>]\n/rule test <pesan> [merchant=\"<nama>\"] [account=<rekening>]\n/rule report – aturan diurutkan menurut jumlah kecocokan\n/rule remove <id>\nAturan dicek berurutan dan mengalahkan AI; yang pertama cocok yang dipakai.",
+	"rule.disabled":         "Aturan kategori tidak diaktifkan di bot ini.",
+	"rule.add_failed":       "Aturan tidak bisa ditambahkan: %s",
+	"rule.added":            "Aturan ditambahkan ✅",
+	"rule.test_failed":      "Aturanmu tidak bisa diuji, coba lagi nanti.",
+	"rule.no_match":         "Tidak ada aturan yang cocok; AI yang akan menentukan.",
+	"rule.matches":          "Cocok dengan aturan:",
+	"rule.id_not_number":    "ID aturan harus berupa angka.",
+	"rule.remove_failed":    "Aturan %s tidak bisa dihapus: %s",
+	"rule.removed":          "Aturan %s dihapus ✅",
+	"rule.load_failed":      "Aturanmu tidak bisa dimuat, coba lagi nanti.",
+	"rule.none":             "Belum ada aturan.",
+	"rule.title":            "📏 Aturan",
+	"rule.report_title":     "📊 Laporan aturan",
+	"rule.unknown_category": "kategori %q tidak dikenal, pilih salah satu: %s",
+	"rule.account":          "rekening %s",
+	"rule.tags":             "tag %s",
+	"rule.entry":            "%d. %s %q → %s · %d kali cocok",
+	"rule.last":             ", terakhir %s",
+
+	// /split, /balances and /settle
+	"split.usage":           "Cara pakai:\n/split <anggota> <anggota>… – bagi rata transaksi terakhirmu\n/split <anggota>=<jumlah> <anggota>=<jumlah>… – bagi dengan jumlah tertentu\n/balances – siapa berutang ke siapa\n/settle – catat transfer yang melunasi semua saldo\nMasukkan juga yang membayar ke daftar kalau ikut menanggung biayanya.",
+	"split.disabled":        "Patungan tidak diaktifkan di bot ini.",
+	"split.failed":          "Transaksi tidak bisa dibagi: %s",
+	"split.shared":          "Dibagi ✅ %s dibayar oleh %s",
+	"split.for":             " untuk %s",
+	"split.balances_failed": "Saldo tidak bisa dimuat, coba lagi nanti.",
+	"split.settle_failed":   "Saldo tidak bisa dilunasi: %s",
+	"split.settled_up":      "Semua sudah lunas 🎉",
+	"split.settlement":      "🤝 Pelunasan dicatat",
+	"split.balances":        "⚖️ Saldo",
+	"split.owed":            "• %s dipiutangi %s",
+	"split.owes":            "• %s berutang %s",
+	"split.transfers":       "Saran transfer:",
+
+	// /usage
+	"usage.disabled":    "Pencatatan pemakaian AI tidak diaktifkan di bot ini.",
+	"usage.usage":       "Cara pakai: /usage (admin: /usage <id chat>)",
+	"usage.load_failed": "Pemakaian AI tidak bisa dimuat, coba lagi nanti.",
+	"usage.chat":        "Chat %d\n%s",
+	"usage.title":       "📊 Pemakaian AI",
+	"usage.today":       "Hari ini: %s",
+	"usage.week":        "7 hari terakhir: %s",
+	"usage.quota":       "Kuota: sisa %s dari %s token hari ini",
+	"usage.no_calls":    "Belum ada panggilan AI dari chat mana pun hari ini.",
+	"usage.all_chats":   "Semua chat hari ini:",
+	"usage.scheduled":   "tugas terjadwal",
+	"usage.total":       "Total: %s",
+	"usage.call":        "panggilan",
+	"usage.calls":       "panggilan",
+	"usage.tokens":      "%d %s · %s token (~$%.4f)",
+
+	// /rate
+	"rate.usage":         "Cara pakai:\n/rate – lihat kurs terbaru\n/rate <mata uang> <kurs> [YYYY-MM-DD] – berapa %s untuk satu unit mulai hari itu, misalnya /rate USD 16200\nKirim file CSV dengan caption /rate untuk mengimpor banyak kurs sekaligus, dengan kolom date,currency,rate.",
+	"rate.disabled":      "Kurs mata uang tidak diaktifkan di bot ini.",
+	"rate.invalid":       "Kurs harus berupa angka positif, misalnya 16200.",
+	"rate.set_failed":    "Tidak bisa menyimpan kurs: %s",
+	"rate.set":           "1 %s = %s %s mulai %s ✅",
+	"rate.load_failed":   "Tidak bisa memuat kurs, coba lagi nanti.",
+	"rate.none":          "Belum ada kurs. Transaksi dalam mata uang lain tidak disimpan sampai mata uangnya punya kurs.",
+	"rate.title":         "💱 Kurs ke %s",
+	"rate.entry":         "1 %s = %s %s · sejak %s",
+	"rate.not_csv":       "Kirim kurs sebagai file .csv dengan kolom date,currency,rate.",
+	"rate.import_failed": "Tidak bisa mengimpor kurs: %s",
+	"rate.imported":      "%d kurs diimpor ✅",
+
+	// /timezone, /locale and /language
+	"settings.disabled":           "Pengaturan chat tidak diaktifkan di bot ini.",
+	"settings.timezone_usage":     "Cara pakai: /timezone <Area/Kota>, misalnya /timezone Asia/Jakarta",
+	"settings.timezone_failed":    "Zona waktu tidak bisa diubah: %s",
+	"settings.timezone_set":       "Zona waktu diatur ke %s ✅",
+	"settings.locale_usage":       "Cara pakai: /locale <%s>",
+	"settings.locale_failed":      "Format tidak bisa diubah: %s",
+	"settings.locale_set":         "Format diatur ke %s ✅\nContoh: %s",
+	"settings.language_usage":     "Cara pakai: /language <%s|auto>\nauto mengikuti bahasa aplikasi Telegram-mu.",
+	"settings.language_failed":    "Bahasa tidak bisa diubah: %s",
+	"settings.language_set":       "Bahasa diatur ke %s ✅",
+	"settings.language_auto":      "Bahasa mengikuti aplikasi Telegram-mu ✅",
+	"settings.load_failed":        "Pengaturan chat tidak bisa dimuat, coba lagi nanti.",
+	"settings.summary":            "Zona waktu: %s\nFormat: %s (%s)\nBahasa: %s",
+	"settings.language_auto_name": "otomatis",
+
+	// Language names
+	"language.name.en": "Inggris",
+	"language.name.id": "Bahasa Indonesia",
+
+	// Scheduled digests
+	"digest.weekly_title": "📅 Ringkasan mingguan (%s – %s)",
+	"digest.daily_title":  "🌙 Ringkasan harian (%s)",
+	"digest.spent":        "Pengeluaran: %s dalam %d transaksi",
+	"digest.budget_left":  "Sisa Anggaran: %s",
+	"digest.per_day_left": "Sisa Per Hari: %s untuk %d hari tersisa",
+	"digest.last_day":     "Hari terakhir bulan ini",
+
+	// Recurring reminders
+	"recurring.recorded": "Transaksi rutin dicatat ✅\n%s\nJumlah: %s\nTanggal: %s",
+	"recurring.due":      "🔔 %s jatuh tempo\nJumlah: %s\nKategori: %s\nCatat sekarang?",
+	"recurring.confirm":  "✅ Catat",
+	"recurring.skip":     "Lewati",
+
+	// Command arguments
+	"args.unterminated_quote": "tanda kutip belum ditutup",
+
+	// Validation errors of services and domains, shown inside the replies above
+	"invalid.advice.no_spending":          "belum ada pengeluaran yang tercatat dalam tiga bulan terakhir",
+	"invalid.category.unknown_parent":     "kategori induk %q tidak dikenal",
+	"invalid.category.nested":             "subkategori tidak bisa bertingkat, %q sudah merupakan subkategori",
+	"invalid.category.exists":             "kategori %q sudah ada",
+	"invalid.category.alias_taken":        "alias %q sudah merujuk ke %s",
+	"invalid.category.unknown":            "kategori %q tidak dikenal",
+	"invalid.category.merge_itself":       "%q tidak bisa digabung ke dirinya sendiri",
+	"invalid.category.merge_parent":       "%q punya subkategori dan hanya bisa digabung ke kategori utama",
+	"invalid.category.merge_conflict":     "%q sudah punya subkategori %q",
+	"invalid.category.name_required":      "nama kategori wajib diisi",
+	"invalid.category.name_invalid":       "nama kategori %q tidak boleh berisi › atau >",
+	"invalid.category.reserved":           "%s khusus untuk target tabungan",
+	"invalid.currency.unknown":            "mata uang %q tidak dikenal",
+	"invalid.currency.unknown_base":       "mata uang dasar %q tidak dikenal",
+	"invalid.currency.is_base":            "%s adalah mata uang dasar",
+	"invalid.currency.rate_positive":      "kurs harus positif",
+	"invalid.currency.invalid_date":       "tanggal %q tidak valid, gunakan YYYY-MM-DD",
+	"invalid.currency.invalid_rate":       "kurs %q tidak valid",
+	"invalid.currency.ambiguous_rate":     "kurs %q ambigu, tulis ribuan tanpa titik (16200) atau desimal dengan jumlah digit lain (16.2)",
+	"invalid.currency.invalid_amount":     "jumlah tidak valid",
+	"invalid.currency.no_rate":            "belum ada kurs untuk %[1]s, atur dengan /rate %[1]s <kurs dalam %[2]s>",
+	"invalid.currency.csv_malformed":      "baris %d bukan CSV yang valid",
+	"invalid.currency.csv_header":         "baris %d: judul kolom harus menyebut kolom date, currency dan rate",
+	"invalid.currency.csv_line":           "baris %d: %s",
+	"invalid.currency.no_rates":           "tidak ada kurs yang ditemukan",
+	"invalid.digest.invalid_time":         "jam %q tidak valid, gunakan HH:MM",
+	"invalid.digest.unknown_timezone":     "zona waktu tidak dikenal",
+	"invalid.goal.name_required":          "nama wajib diisi",
+	"invalid.goal.invalid_target":         "target %q tidak valid",
+	"invalid.goal.invalid_deadline":       "tenggat %q tidak valid, gunakan YYYY-MM-DD",
+	"invalid.goal.invalid_amount":         "jumlah tidak valid",
+	"invalid.goal.not_found":              "target tidak ditemukan",
+	"invalid.goal.exists":                 "target %s sudah ada, hapus dulu untuk mengubahnya",
+	"invalid.learning.merchant_required":  "merchant wajib diisi",
+	"invalid.learning.category_required":  "kategori wajib diisi",
+	"invalid.learning.no_recent":          "belum ada transaksi terbaru untuk dikoreksi, kirim satu dulu",
+	"invalid.learning.already_in":         "transaksi terakhir sudah masuk %s",
+	"invalid.learning.no_merchant":        "merchant tidak bisa dikenali, tambahkan merchant=<nama>",
+	"invalid.learning.not_in_sheet":       "transaksi terakhir sudah tidak ada di spreadsheet",
+	"invalid.offline.no_generate":         "parser offline tidak bisa membuat konten",
+	"invalid.offline.no_receipts":         "struk tidak bisa dibaca secara offline, silakan ketik transaksinya",
+	"invalid.offline.no_amount":           "tidak ada jumlah dalam pesan, coba seperti \"makan siang 35rb\"",
+	"invalid.recurring.unknown_mode":      "mode %q tidak dikenal, gunakan auto atau remind",
+	"invalid.recurring.day_range":         "tanggal harus antara 1 dan 31",
+	"invalid.recurring.invalid_amount":    "jumlah %q tidak valid",
+	"invalid.recurring.category_required": "kategori wajib diisi",
+	"invalid.recurring.invalid_period":    "periode %q tidak valid",
+	"invalid.recurring.already_recorded":  "sudah dicatat untuk bulan ini",
+	"invalid.recurring.suggestion_gone":   "saran sudah tidak tersedia",
+	"invalid.recurring.not_found":         "transaksi rutin tidak ditemukan",
+	"invalid.review.not_pending":          "transaksi ini sudah tidak menunggu peninjauan",
+	"invalid.rule.unknown_kind":           "jenis aturan %q tidak dikenal, gunakan keyword, regex, merchant atau account",
+	"invalid.rule.pattern_required":       "pola wajib diisi",
+	"invalid.rule.invalid_regex":          "regex %q tidak valid: %v",
+	"invalid.rule.action_required":        "aturan perlu kategori, tag atau akun untuk diatur",
+	"invalid.rule.not_found":              "aturan tidak ditemukan",
+	"invalid.settings.unknown_timezone":   "zona waktu %q tidak dikenal",
+	"invalid.settings.timezone":           "zona waktu tidak dikenal, gunakan nama IANA seperti Asia/Jakarta",
+	"invalid.settings.locale":             "format wilayah tidak didukung, pilih salah satu dari %s",
+	"invalid.settings.language":           "bahasa tidak didukung, pilih salah satu dari %s atau auto",
+	"invalid.split.members_required":      "minimal satu anggota diperlukan",
+	"invalid.split.duplicate_member":      "anggota %q ganda atau kosong",
+	"invalid.split.payer_required":        "pembayar wajib diisi",
+	"invalid.split.amount_positive":       "jumlah harus positif",
+	"invalid.split.negative_share":        "bagian %s tidak boleh negatif",
+	"invalid.split.shares_mismatch":       "total bagian %d tetapi jumlahnya %d",
+	"invalid.split.mixed_shares":          "pakai nama anggota atau bagian anggota=jumlah, jangan keduanya",
+	"invalid.split.invalid_share":         "bagian %q untuk %s tidak valid",
+	"invalid.split.no_recent":             "belum ada transaksi terbaru untuk dibagi, kirim satu dulu (transaksi terakhir terlupa saat bot dimulai ulang)",
+	"invalid.split.invalid_amount":        "transaksi terakhir tidak punya jumlah yang valid",
+}
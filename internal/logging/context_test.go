@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithAttrs(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, _ := New(buf, FormatJSON, slog.LevelInfo, nil)
+
+	ctx := WithAttrs(context.Background(), slog.Int("update_id", 991))
+	ctx = WithAttrs(common.WithTenant(ctx, -1001234), slog.Int64("chat_id", -1001234))
+	l.InfoContext(ctx, "saved transaction")
+
+	record := decode(t, strings.TrimSpace(buf.String()))
+	if record["update_id"] != float64(991) || record["chat_id"] != float64(-1001234) || record["tenant"] != float64(-1001234) {
+		t.Errorf("expected request attributes, got %v", record)
+	}
+	if len(Attrs(context.Background())) != 0 {
+		t.Error("a plain context should carry no attributes")
+	}
+}
+
+func TestWithAttrs_HandledErrors(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, _ := New(buf, FormatJSON, slog.LevelInfo, nil)
+	errors.SetLogger(l)
+	defer errors.SetLogger(errors.DefaultLogger{})
+
+	ctx := WithAttrs(context.Background(), slog.Int("update_id", 7))
+	errors.HandleErrorContext(ctx, errors.NewValidationError("bad input", nil), "parsing command")
+	record := decode(t, strings.TrimSpace(buf.String()))
+	if record["update_id"] != float64(7) || record["code"] != errors.ErrCodeValidation {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func TestContextHandler_WithGroup(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, _ := New(buf, FormatJSON, slog.LevelInfo, nil)
+	l.With("component", "digest").WithGroup("job").InfoContext(WithAttrs(context.Background(), slog.Int("update_id", 1)), "sent", "chat", 5)
+	if line := buf.String(); !strings.Contains(line, `"component":"digest"`) || !strings.Contains(line, `"job":{`) {
+		t.Errorf("unexpected record %s", line)
+	}
+}
+
+func TestContextHandler_TraceIDs(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, _ := New(buf, FormatJSON, slog.LevelInfo, nil)
+	span := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	})
+	l.InfoContext(trace.ContextWithSpanContext(context.Background(), span), "saved transaction")
+
+	record := decode(t, strings.TrimSpace(buf.String()))
+	if record["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" || record["span_id"] != "00f067aa0ba902b7" {
+		t.Errorf("expected the trace and span IDs, got %v", record)
+	}
+}
@@ -0,0 +1,84 @@
+// Package logging provides the slog-based logger of the bot: JSON or text
+// records, a configurable level and request-scoped attributes taken from the
+// context.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"money-tracker-bot/internal/errors"
+	"strings"
+)
+
+// Formats of the log records
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Logger is a slog.Logger that also implements errors.Logger and
+// errors.ContextLogger, so AppErrors are logged as structured attributes
+type Logger struct {
+	*slog.Logger
+}
+
+var _ errors.ContextLogger = Logger{}
+
+// New returns a Logger writing records in format (FormatJSON or FormatText)
+// from level up to w. Every record gets the attributes of its context, see
+// WithAttrs, and is redacted by redactor; a nil redactor still masks the
+// credentials and personal data it recognizes.
+func New(w io.Writer, format string, level slog.Leveler, redactor *Redactor) (Logger, error) {
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: levelNames}
+	var handler slog.Handler
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case FormatJSON, "":
+		handler = slog.NewJSONHandler(w, opts)
+	case FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return Logger{}, errors.NewConfigError("unknown log format, expected json or text", nil).
+			WithContext("format", format).
+			WithComponent("logging")
+	}
+	if redactor == nil {
+		redactor = NewRedactor()
+	}
+	return Logger{Logger: slog.New(contextHandler{redactHandler{handler, redactor}})}, nil
+}
+
+// Printf logs a formatted message at info level
+func (l Logger) Printf(format string, v ...interface{}) {
+	l.Info(fmt.Sprintf(format, v...))
+}
+
+// Println logs the operands, separated by spaces, at info level
+func (l Logger) Println(v ...interface{}) {
+	l.Info(strings.TrimSuffix(fmt.Sprintln(v...), "\n"))
+}
+
+// ParseLevel reads a level name: debug, info, warn or error; empty means info.
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if strings.TrimSpace(name) == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+		return 0, errors.NewConfigError("unknown log level, expected debug, info, warn or error", err).
+			WithContext("level", name).
+			WithComponent("logging")
+	}
+	return level, nil
+}
+
+// levelNames logs the level of critical errors (errors.SeverityCritical) as
+// CRITICAL rather than ERROR+4
+func levelNames(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.LevelKey && len(groups) == 0 {
+		if level, ok := a.Value.Any().(slog.Level); ok && level == errors.SeverityCritical.Level() {
+			return slog.String(slog.LevelKey, "CRITICAL")
+		}
+	}
+	return a
+}
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"money-tracker-bot/internal/errors"
+	"strings"
+	"testing"
+)
+
+func decode(t *testing.T, line string) map[string]interface{} {
+	t.Helper()
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		t.Fatalf("expected a JSON record, got %q: %v", line, err)
+	}
+	return record
+}
+
+func TestNew_JSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, err := New(buf, FormatJSON, slog.LevelInfo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Debug("hidden")
+	l.Println("Authorized on account", "moneybot")
+	record := decode(t, strings.TrimSpace(buf.String()))
+	if record["level"] != "INFO" || record["msg"] != "Authorized on account moneybot" {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func TestNew_Text(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, err := New(buf, "TEXT", slog.LevelDebug, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Printf("loaded %d schedules", 3)
+	if line := buf.String(); !strings.Contains(line, "level=INFO") || !strings.Contains(line, `msg="loaded 3 schedules"`) {
+		t.Errorf("unexpected text record %q", line)
+	}
+	if _, err := New(buf, "xml", slog.LevelInfo, nil); err == nil {
+		t.Error("expected error for an unknown format")
+	}
+}
+
+func TestLogger_AppErrors(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l, _ := New(buf, FormatJSON, slog.LevelInfo, nil)
+	errors.SetLogger(l)
+	defer errors.SetLogger(errors.DefaultLogger{})
+
+	errors.HandleCriticalError(errors.NewConfigError("missing token", fmt.Errorf("not set")).WithComponent("main"), "application startup")
+	record := decode(t, strings.SplitN(buf.String(), "\n", 2)[0])
+	if record["level"] != "CRITICAL" || record["code"] != errors.ErrCodeConfig || record["component"] != "main" || record["operation"] != "application startup" {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for name, want := range map[string]slog.Level{"": slog.LevelInfo, "debug": slog.LevelDebug, "WARN": slog.LevelWarn, " error ": slog.LevelError} {
+		if got, err := ParseLevel(name); err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected error for an unknown level")
+	}
+}
@@ -0,0 +1,130 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces secrets and personal data in log records
+const Redacted = "[REDACTED]"
+
+// minSecretLength keeps short values, such as a placeholder "x", from
+// redacting unrelated text
+const minSecretLength = 8
+
+// secretPatterns match credentials even when they were not registered with
+// NewRedactor, such as the token in a failed Telegram request URL
+var secretPatterns = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	// Telegram bot tokens, also inside api.telegram.org/bot<token>/ URLs
+	{regexp.MustCompile(`\d{6,12}:[A-Za-z0-9_-]{30,}`), Redacted},
+	// Google API keys
+	{regexp.MustCompile(`AIza[0-9A-Za-z_-]{35}`), Redacted},
+	// OpenAI API keys
+	{regexp.MustCompile(`sk-[A-Za-z0-9_-]{20,}`), Redacted},
+	{regexp.MustCompile(`([?&](?:key|api_key|access_token|token)=)[^&\s"]+`), "${1}" + Redacted},
+	{regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`), "${1}" + Redacted},
+	// Account numbers and names in raw transaction JSON
+	{regexp.MustCompile(`("(?:destination_number|destination_name|created_by)"\s*:\s*)"(?:[^"\\]|\\.)*"`), `${1}"` + Redacted + `"`},
+}
+
+// sensitiveKeys are attributes whose values are always redacted
+var sensitiveKeys = map[string]bool{
+	"destination_number": true,
+	"destination_name":   true,
+	"created_by":         true,
+	"user":               true,
+	"username":           true,
+	"token":              true,
+	"api_key":            true,
+}
+
+// Redactor masks secrets and personal data: registered secrets such as the
+// bot token and API keys, credentials recognized by their shape, account
+// numbers and names in transaction JSON, and attributes with a sensitive key
+type Redactor struct {
+	secrets []string
+}
+
+// NewRedactor returns a Redactor that also masks every given secret.
+// Empty and very short secrets are ignored.
+func NewRedactor(secrets ...string) *Redactor {
+	r := &Redactor{}
+	for _, s := range secrets {
+		if s = strings.TrimSpace(s); len(s) >= minSecretLength {
+			r.secrets = append(r.secrets, s)
+		}
+	}
+	return r
+}
+
+// String returns s with every secret and personal value replaced by Redacted
+func (r *Redactor) String(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Redacted)
+	}
+	for _, p := range secretPatterns {
+		s = p.re.ReplaceAllString(s, p.replacement)
+	}
+	return s
+}
+
+// Attr redacts the value of a, and of every attribute of a group
+func (r *Redactor) Attr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if sensitiveKeys[strings.ToLower(a.Key)] && a.Value.Kind() != slog.KindGroup {
+		return slog.String(a.Key, Redacted)
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, r.String(a.Value.String()))
+	case slog.KindGroup:
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i, g := range group {
+			attrs[i] = r.Attr(g)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+	case slog.KindAny:
+		switch v := a.Value.Any().(type) {
+		case error:
+			return slog.String(a.Key, r.String(v.Error()))
+		case fmt.Stringer:
+			return slog.String(a.Key, r.String(v.String()))
+		}
+	}
+	return a
+}
+
+// redactHandler runs the message and attributes of every record through a
+// Redactor before they are written
+type redactHandler struct {
+	slog.Handler
+	redactor *Redactor
+}
+
+func (h redactHandler) Handle(ctx context.Context, r slog.Record) error {
+	out := slog.NewRecord(r.Time, r.Level, h.redactor.String(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(h.redactor.Attr(a))
+		return true
+	})
+	return h.Handler.Handle(ctx, out)
+}
+
+func (h redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redactor.Attr(a)
+	}
+	return redactHandler{h.Handler.WithAttrs(redacted), h.redactor}
+}
+
+func (h redactHandler) WithGroup(name string) slog.Handler {
+	return redactHandler{h.Handler.WithGroup(name), h.redactor}
+}
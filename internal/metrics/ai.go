@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"context"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	aiport "money-tracker-bot/internal/port/out/ai"
+	"time"
+)
+
+// AI operations, as labelled in the AI metrics
+const (
+	opGenerate = "generate"
+	opImage    = "image"
+	opText     = "text"
+)
+
+// instrumentedAI is an aiport.AiPort that times the calls of one provider
+type instrumentedAI struct {
+	metrics  *Metrics
+	provider string
+	next     aiport.AiPort
+}
+
+// InstrumentAI records the latency and failures of the calls to an AI
+// provider. It returns port unchanged on a nil *Metrics.
+func (m *Metrics) InstrumentAI(provider string, port aiport.AiPort) aiport.AiPort {
+	if m == nil {
+		return port
+	}
+	return &instrumentedAI{metrics: m, provider: provider, next: port}
+}
+
+func (a *instrumentedAI) observe(operation string, start time.Time, err error) {
+	a.metrics.aiDuration.WithLabelValues(a.provider, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		a.metrics.aiFailures.WithLabelValues(a.provider, operation, errorCode(err)).Inc()
+	}
+}
+
+func (a *instrumentedAI) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	start := time.Now()
+	text, err := a.next.GenerateContent(ctx, prompt)
+	a.observe(opGenerate, start, err)
+	return text, err
+}
+
+func (a *instrumentedAI) ReadImageToTransaction(ctx context.Context, imgPath string) (*transaction_domain.Transaction, error) {
+	start := time.Now()
+	trx, err := a.next.ReadImageToTransaction(ctx, imgPath)
+	a.observe(opImage, start, err)
+	return trx, err
+}
+
+func (a *instrumentedAI) TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error) {
+	start := time.Now()
+	trx, err := a.next.TextToTransaction(ctx, message)
+	a.observe(opText, start, err)
+	return trx, err
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"testing"
+)
+
+type stubAI struct {
+	err error
+}
+
+func (s stubAI) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return "tip", s.err
+}
+
+func (s stubAI) ReadImageToTransaction(ctx context.Context, imgPath string) (*transaction_domain.Transaction, error) {
+	return &transaction_domain.Transaction{}, s.err
+}
+
+func (s stubAI) TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error) {
+	return &transaction_domain.Transaction{}, s.err
+}
+
+func TestInstrumentAI(t *testing.T) {
+	m := New()
+	gemini := m.InstrumentAI("gemini", stubAI{err: errors.NewGeminiError("no transaction in Gemini response", nil)})
+	offline := m.InstrumentAI("offline", stubAI{})
+
+	gemini.ReadImageToTransaction(context.Background(), "receipt.jpg")
+	gemini.TextToTransaction(context.Background(), "kopi 25rb")
+	offline.TextToTransaction(context.Background(), "kopi 25rb")
+	if text, err := offline.GenerateContent(context.Background(), "advice"); err != nil || text != "tip" {
+		t.Errorf("the answer should pass through, got %q, %v", text, err)
+	}
+
+	expectMetrics(t, scrape(t, m),
+		`moneybot_ai_request_duration_seconds_count{operation="image",provider="gemini"} 1`,
+		`moneybot_ai_request_duration_seconds_count{operation="text",provider="offline"} 1`,
+		`moneybot_ai_request_duration_seconds_count{operation="generate",provider="offline"} 1`,
+		`moneybot_ai_failures_total{code="GEMINI_ERROR",operation="text",provider="gemini"} 1`,
+	)
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"money-tracker-bot/internal/resilience"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	breakerStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "circuit_breaker", "state"),
+		"Circuit breaker state by dependency: 0 closed, 1 open, 2 half-open.",
+		[]string{"dependency"}, nil)
+	breakerOpensDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "circuit_breaker", "opens_total"),
+		"Times the circuit breaker of a dependency opened.",
+		[]string{"dependency"}, nil)
+	breakerRejectedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "circuit_breaker", "rejected_total"),
+		"Calls skipped while the circuit breaker of a dependency was open.",
+		[]string{"dependency"}, nil)
+)
+
+// breakerCollector reads the snapshots of circuit breakers at scrape time
+type breakerCollector struct {
+	breakers []*resilience.Breaker
+}
+
+func (c breakerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- breakerStateDesc
+	ch <- breakerOpensDesc
+	ch <- breakerRejectedDesc
+}
+
+func (c breakerCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, b := range c.breakers {
+		s := b.Snapshot()
+		ch <- prometheus.MustNewConstMetric(breakerStateDesc, prometheus.GaugeValue, float64(s.State), s.Name)
+		ch <- prometheus.MustNewConstMetric(breakerOpensDesc, prometheus.CounterValue, float64(s.Opens), s.Name)
+		ch <- prometheus.MustNewConstMetric(breakerRejectedDesc, prometheus.CounterValue, float64(s.Rejected), s.Name)
+	}
+}
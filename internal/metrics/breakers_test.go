@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/resilience"
+	"testing"
+	"time"
+)
+
+func TestRegisterBreakers(t *testing.T) {
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	gemini := resilience.NewBreaker("gemini", 1, time.Minute, clock)
+	gemini.Allow()
+	gemini.Record(errors.NewGeminiTimeoutError("request timed out", nil))
+	gemini.Allow()
+	sheets := resilience.NewBreaker("sheets", 1, time.Minute, clock)
+
+	m := New()
+	m.RegisterBreakers(gemini, sheets)
+	expectMetrics(t, scrape(t, m),
+		`moneybot_circuit_breaker_state{dependency="gemini"} 1`,
+		`moneybot_circuit_breaker_state{dependency="sheets"} 0`,
+		`moneybot_circuit_breaker_opens_total{dependency="gemini"} 1`,
+		`moneybot_circuit_breaker_rejected_total{dependency="gemini"} 1`,
+	)
+}
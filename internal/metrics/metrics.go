@@ -0,0 +1,155 @@
+// Package metrics exposes how the bot behaves in production as Prometheus
+// metrics: updates, saved transactions, AI and Sheets latency, errors, the
+// review queue and circuit breakers.
+package metrics
+
+import (
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/resilience"
+	"net/http"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric name
+const namespace = "moneybot"
+
+// Update types counted by UpdateReceived
+const (
+	UpdateCommand  = "command"
+	UpdateCallback = "callback"
+	UpdatePhoto    = "photo"
+	UpdateDocument = "document"
+	UpdateText     = "text"
+)
+
+// Metrics holds the bot's collectors in their own registry. Its methods do
+// nothing on a nil *Metrics, so instrumented code works without metrics.
+type Metrics struct {
+	registry     *prometheus.Registry
+	updates      *prometheus.CounterVec
+	saved        *prometheus.CounterVec
+	aiDuration   *prometheus.HistogramVec
+	aiFailures   *prometheus.CounterVec
+	sheetsTiming *prometheus.HistogramVec
+	errorCount   *prometheus.CounterVec
+}
+
+var _ errors.Observer = (*Metrics)(nil)
+
+// New returns Metrics registered with a new registry, along with the Go
+// runtime and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		updates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "updates_received_total",
+			Help:      "Telegram updates received by type.",
+		}, []string{"type"}),
+		saved: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transactions_saved_total",
+			Help:      "Transactions saved to the spreadsheet by category and tenant (chat).",
+		}, []string{"category", "tenant"}),
+		aiDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "ai_request_duration_seconds",
+			Help:      "Latency of AI provider calls by provider and operation.",
+			Buckets:   []float64{0.25, 0.5, 1, 2, 4, 8, 15, 30, 60},
+		}, []string{"provider", "operation"}),
+		aiFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "ai_failures_total",
+			Help:      "Failed AI provider calls by provider, operation and error code.",
+		}, []string{"provider", "operation", "code"}),
+		sheetsTiming: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "sheets_request_duration_seconds",
+			Help:      "Latency of Google Sheets calls by operation and outcome.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "outcome"}),
+		errorCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "errors_total",
+			Help:      "Handled errors by severity and code.",
+		}, []string{"severity", "code"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.updates, m.saved, m.aiDuration, m.aiFailures, m.sheetsTiming, m.errorCount,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// UpdateReceived counts a Telegram update of the given type
+func (m *Metrics) UpdateReceived(kind string) {
+	if m == nil {
+		return
+	}
+	m.updates.WithLabelValues(kind).Inc()
+}
+
+// TransactionSaved counts a transaction saved for a chat
+func (m *Metrics) TransactionSaved(category string, chatID int64) {
+	if m == nil {
+		return
+	}
+	if category == "" {
+		category = "Uncategorized"
+	}
+	m.saved.WithLabelValues(category, strconv.FormatInt(chatID, 10)).Inc()
+}
+
+// ObserveError counts a handled error (errors.Observer)
+func (m *Metrics) ObserveError(err *errors.AppError) {
+	if m == nil {
+		return
+	}
+	m.errorCount.WithLabelValues(err.Severity.String(), err.Code).Inc()
+}
+
+// RegisterReviewQueue reports the number of low-confidence transactions
+// waiting for a user to confirm them as moneybot_review_queue_depth
+func (m *Metrics) RegisterReviewQueue(depth func() (int, error)) {
+	if m == nil {
+		return
+	}
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "review_queue_depth",
+		Help:      "Low-confidence transactions waiting for a user to confirm them before they are saved.",
+	}, func() float64 {
+		n, err := depth()
+		if err != nil {
+			errors.HandleError(err, "reading review queue depth")
+			return 0
+		}
+		return float64(n)
+	}))
+}
+
+// RegisterBreakers reports the state of circuit breakers
+func (m *Metrics) RegisterBreakers(breakers ...*resilience.Breaker) {
+	if m == nil || len(breakers) == 0 {
+		return
+	}
+	m.registry.MustRegister(breakerCollector{breakers: breakers})
+}
+
+// errorCode is the AppError code of err, for labels
+func errorCode(err error) string {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return appErr.Code
+	}
+	return "UNKNOWN"
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"money-tracker-bot/internal/errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// scrape returns the metrics served by m
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != 200 {
+		t.Fatalf("unexpected status %d", rec.Code)
+	}
+	return rec.Body.String()
+}
+
+func expectMetrics(t *testing.T, body string, want ...string) {
+	t.Helper()
+	for _, line := range want {
+		if !strings.Contains(body, line) {
+			t.Errorf("expected %q in:\n%s", line, body)
+		}
+	}
+}
+
+func TestMetrics_Counters(t *testing.T) {
+	m := New()
+	m.UpdateReceived(UpdateText)
+	m.UpdateReceived(UpdateText)
+	m.UpdateReceived(UpdateCommand)
+	m.TransactionSaved("Groceries", 42)
+	m.TransactionSaved("", 42)
+	m.ObserveError(errors.NewSpreadsheetError("append failed", nil))
+	m.RegisterReviewQueue(func() (int, error) { return 3, nil })
+
+	expectMetrics(t, scrape(t, m),
+		`moneybot_updates_received_total{type="text"} 2`,
+		`moneybot_updates_received_total{type="command"} 1`,
+		`moneybot_transactions_saved_total{category="Groceries",tenant="42"} 1`,
+		`moneybot_transactions_saved_total{category="Uncategorized",tenant="42"} 1`,
+		`moneybot_errors_total{code="SPREADSHEET_ERROR",severity="ERROR"} 1`,
+		`moneybot_review_queue_depth 3`,
+		"go_goroutines",
+	)
+}
+
+func TestMetrics_NilIsNoop(t *testing.T) {
+	var m *Metrics
+	m.UpdateReceived(UpdateText)
+	m.TransactionSaved("Groceries", 42)
+	m.ObserveError(errors.NewSpreadsheetError("append failed", nil))
+	m.RegisterReviewQueue(func() (int, error) { return 0, nil })
+	m.RegisterBreakers(nil)
+	if m.InstrumentAI("gemini", nil) != nil || m.InstrumentSheets(nil) != nil {
+		t.Error("nil metrics should leave ports unchanged")
+	}
+}
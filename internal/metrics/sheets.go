@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"context"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	category_domain "money-tracker-bot/internal/domain/category"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"time"
+)
+
+// instrumentedSheets is a spreadsheet.Service that times every call
+type instrumentedSheets struct {
+	metrics *Metrics
+	next    spreadsheet.Service
+}
+
+// InstrumentSheets records the latency of the calls to Google Sheets. It
+// returns service unchanged on a nil *Metrics.
+func (m *Metrics) InstrumentSheets(service spreadsheet.Service) spreadsheet.Service {
+	if m == nil {
+		return service
+	}
+	return &instrumentedSheets{metrics: m, next: service}
+}
+
+func (s *instrumentedSheets) observe(operation string, start time.Time, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	s.metrics.sheetsTiming.WithLabelValues(operation, outcome).Observe(time.Since(start).Seconds())
+}
+
+func (s *instrumentedSheets) AppendRow(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
+	start := time.Now()
+	summary, err := s.next.AppendRow(ctx, spreadsheetId, trx)
+	s.observe("append_row", start, err)
+	return summary, err
+}
+
+func (s *instrumentedSheets) ListCategorySummaries(ctx context.Context, spreadsheetId string) ([]spreadsheet.CategorySummary, error) {
+	start := time.Now()
+	summaries, err := s.next.ListCategorySummaries(ctx, spreadsheetId)
+	s.observe("list_category_summaries", start, err)
+	return summaries, err
+}
+
+func (s *instrumentedSheets) ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error) {
+	start := time.Now()
+	trxs, err := s.next.ListTransactions(ctx, spreadsheetId)
+	s.observe("list_transactions", start, err)
+	return trxs, err
+}
+
+func (s *instrumentedSheets) MigrateCategory(ctx context.Context, spreadsheetId string, m category_domain.Migration) (int, error) {
+	start := time.Now()
+	rows, err := s.next.MigrateCategory(ctx, spreadsheetId, m)
+	s.observe("migrate_category", start, err)
+	return rows, err
+}
+
+func (s *instrumentedSheets) Recategorize(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction, to category_domain.Ref) (bool, error) {
+	start := time.Now()
+	moved, err := s.next.Recategorize(ctx, spreadsheetId, trx, to)
+	s.observe("recategorize", start, err)
+	return moved, err
+}
+
+func (s *instrumentedSheets) GetCellValue(ctx context.Context, spreadsheetId string) error {
+	start := time.Now()
+	err := s.next.GetCellValue(ctx, spreadsheetId)
+	s.observe("get_cell_value", start, err)
+	return err
+}
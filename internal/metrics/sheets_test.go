@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	category_domain "money-tracker-bot/internal/domain/category"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"testing"
+)
+
+type stubSheets struct {
+	err error
+}
+
+func (s stubSheets) AppendRow(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
+	return spreadsheet.CategorySummary{}, s.err
+}
+
+func (s stubSheets) ListCategorySummaries(ctx context.Context, spreadsheetId string) ([]spreadsheet.CategorySummary, error) {
+	return nil, s.err
+}
+
+func (s stubSheets) ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error) {
+	return nil, s.err
+}
+
+func (s stubSheets) MigrateCategory(ctx context.Context, spreadsheetId string, m category_domain.Migration) (int, error) {
+	return 0, s.err
+}
+
+func (s stubSheets) Recategorize(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction, to category_domain.Ref) (bool, error) {
+	return false, s.err
+}
+
+func (s stubSheets) GetCellValue(ctx context.Context, spreadsheetId string) error {
+	return s.err
+}
+
+func TestInstrumentSheets(t *testing.T) {
+	m := New()
+	ctx := context.Background()
+	m.InstrumentSheets(stubSheets{}).AppendRow(ctx, "sheet", transaction_domain.Transaction{})
+	down := m.InstrumentSheets(stubSheets{err: fmt.Errorf("503")})
+	down.AppendRow(ctx, "sheet", transaction_domain.Transaction{})
+	down.ListTransactions(ctx, "sheet")
+
+	expectMetrics(t, scrape(t, m),
+		`moneybot_sheets_request_duration_seconds_count{operation="append_row",outcome="ok"} 1`,
+		`moneybot_sheets_request_duration_seconds_count{operation="append_row",outcome="error"} 1`,
+		`moneybot_sheets_request_duration_seconds_count{operation="list_transactions",outcome="error"} 1`,
+	)
+}
@@ -6,7 +6,8 @@ import (
 )
 
 type AiPort interface {
-	GenerateContent(ctx context.Context, prompt string) error
+	// GenerateContent answers a free-form prompt, such as a request for saving advice
+	GenerateContent(ctx context.Context, prompt string) (string, error)
 	ReadImageToTransaction(ctx context.Context, imgPath string) (*transaction_domain.Transaction, error)
 	TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error)
 }
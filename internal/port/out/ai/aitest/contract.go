@@ -0,0 +1,192 @@
+package aitest
+
+// Package aitest is the contract every remote aiport.AiPort adapter must pass.
+// Adapters run it against a local stub server speaking their wire protocol,
+// so the suite needs no network or API keys.
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	aiport "money-tracker-bot/internal/port/out/ai"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Call is what a stub server received: the prompt text and whether an image was attached
+type Call struct {
+	Prompt   string
+	HasImage bool
+}
+
+// Responder decides the answer of the stub server: an HTTP status and the text the model "wrote"
+type Responder func(call Call) (status int, text string)
+
+// Adapter describes how to run the contract against one implementation
+type Adapter struct {
+	// Handler serves the provider's protocol: it decodes each request into a
+	// Call, asks respond, and encodes the text as the provider would
+	Handler func(respond Responder) http.Handler
+	// New creates the adapter talking to the stub server at baseURL
+	New func(t *testing.T, baseURL string) aiport.AiPort
+}
+
+// stub records the calls of a test server and answers with the current responder
+type stub struct {
+	mu      sync.Mutex
+	calls   []Call
+	respond Responder
+}
+
+func (s *stub) answer(call Call) (int, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, call)
+	return s.respond(call)
+}
+
+func (s *stub) set(respond Responder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls, s.respond = nil, respond
+}
+
+func (s *stub) last(t *testing.T) Call {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.calls) == 0 {
+		t.Fatal("expected the adapter to call the server")
+	}
+	return s.calls[len(s.calls)-1]
+}
+
+const transactionJSON = "```json\n" + `{
+  "title": "Weekly shop at Superindo",
+  "transaction_date": "2025-07-10",
+  "amount": "-150000",
+  "notes": "vegetables and fruit",
+  "source_account": "GOPAY",
+  "category": "Groceries",
+  "file_id": ""
+}` + "\n```"
+
+func reply(status int, text string) Responder {
+	return func(Call) (int, string) { return status, text }
+}
+
+// Run checks that the adapter builds prompts from the context, decodes
+// answers, consumes images only on success and reports failures as errors.
+func Run(t *testing.T, adapter Adapter) {
+	s := &stub{respond: reply(http.StatusOK, transactionJSON)}
+	server := httptest.NewServer(adapter.Handler(s.answer))
+	defer server.Close()
+	port := adapter.New(t, server.URL)
+
+	t.Run("text to transaction", func(t *testing.T) {
+		s.set(reply(http.StatusOK, transactionJSON))
+		ctx := common.WithPromptCategories(context.Background(), []string{"Groceries", "Eating Out › Coffee"})
+		ctx = common.WithPromptExamples(ctx, []string{`"Superindo" → Groceries`})
+		trx, err := port.TextToTransaction(ctx, "superindo 150rb")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if trx.Title != "Weekly shop at Superindo" || trx.Category != "Groceries" || trx.SourceAccount != "GOPAY" || trx.TransactionDate != "2025-07-10" {
+			t.Errorf("unexpected transaction %+v", trx)
+		}
+		if trx.Amount != "150000" {
+			t.Errorf("amounts must be positive, got %q", trx.Amount)
+		}
+		if trx.PromptVersion != common.PromptVersion {
+			t.Errorf("expected the transaction to be tagged with prompt %s, got %q", common.PromptVersion, trx.PromptVersion)
+		}
+		call := s.last(t)
+		for _, want := range []string{"superindo 150rb", "Eating Out › Coffee", `"Superindo" → Groceries`} {
+			if !strings.Contains(call.Prompt, want) {
+				t.Errorf("prompt should contain %q, got:\n%s", want, call.Prompt)
+			}
+		}
+		if call.HasImage {
+			t.Error("text requests must not carry an image")
+		}
+	})
+
+	t.Run("image to transaction", func(t *testing.T) {
+		s.set(reply(http.StatusOK, transactionJSON))
+		img := writeImage(t)
+		trx, err := port.ReadImageToTransaction(context.Background(), img)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if trx.Category != "Groceries" || trx.Amount != "150000" {
+			t.Errorf("unexpected transaction %+v", trx)
+		}
+		if call := s.last(t); !call.HasImage || !strings.Contains(call.Prompt, "from the image") {
+			t.Errorf("expected an image request, got %+v", call)
+		}
+		if _, err := os.Stat(img); !os.IsNotExist(err) {
+			t.Error("the image should be removed once read")
+		}
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		s.set(reply(http.StatusBadRequest, "invalid request"))
+		if _, err := port.TextToTransaction(context.Background(), "kopi 25k"); err == nil {
+			t.Error("expected error from a failing server")
+		}
+		img := writeImage(t)
+		if _, err := port.ReadImageToTransaction(context.Background(), img); err == nil {
+			t.Error("expected error from a failing server")
+		}
+		if _, err := os.Stat(img); err != nil {
+			t.Error("the image must be kept for a fallback provider when reading fails")
+		}
+		if _, err := port.GenerateContent(context.Background(), "hello"); err == nil {
+			t.Error("expected error from a failing server")
+		}
+	})
+
+	t.Run("answer without transaction", func(t *testing.T) {
+		s.set(reply(http.StatusOK, "Sorry, I cannot help with that."))
+		if trx, err := port.TextToTransaction(context.Background(), "kopi 25k"); err == nil {
+			t.Errorf("expected error for an answer without JSON, got %+v", trx)
+		}
+	})
+
+	t.Run("generate content", func(t *testing.T) {
+		s.set(reply(http.StatusOK, "Save a little every day.\n"))
+		text, err := port.GenerateContent(context.Background(), "give me a tip")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if text != "Save a little every day." {
+			t.Errorf("expected the trimmed answer, got %q", text)
+		}
+		if call := s.last(t); !strings.Contains(call.Prompt, "give me a tip") {
+			t.Errorf("prompt not sent, got %+v", call)
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		s.set(reply(http.StatusOK, transactionJSON))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := port.TextToTransaction(ctx, "kopi 25k"); err == nil {
+			t.Error("expected error for a cancelled context")
+		}
+	})
+}
+
+// writeImage creates a small fake receipt image
+func writeImage(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "receipt.jpg")
+	if err := os.WriteFile(path, []byte{0xff, 0xd8, 0xff, 0xe0, 'J', 'F', 'I', 'F'}, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,20 @@
+package notify
+
+// Button is a one-tap action attached to a message.
+// Data is echoed back to the bot when the user taps the button and must stay
+// within Telegram's 64 byte callback limit.
+type Button struct {
+	Label string
+	Data  string
+}
+
+// Notifier pushes messages to a chat outside of a request/response cycle
+type Notifier interface {
+	SendText(chatID int64, text string) error
+}
+
+// ButtonNotifier additionally supports messages with inline action buttons
+type ButtonNotifier interface {
+	Notifier
+	SendWithButtons(chatID int64, text string, buttons []Button) error
+}
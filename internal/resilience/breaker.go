@@ -0,0 +1,172 @@
+// Package resilience keeps a failing dependency, such as Gemini or Google
+// Sheets, from slowing down every request: after repeated failures calls are
+// skipped until a probe shows the dependency has recovered.
+package resilience
+
+import (
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/errors"
+	"sync"
+	"time"
+)
+
+// State is the state of a circuit breaker
+type State int
+
+const (
+	// Closed lets every call through
+	Closed State = iota
+	// Open skips every call until the cooldown is over
+	Open
+	// HalfOpen lets one probe call through to test the dependency
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// Defaults used by NewBreaker for values below 1
+const (
+	DefaultThreshold = 5
+	DefaultCooldown  = 30 * time.Second
+)
+
+// Breaker is a circuit breaker for one dependency. It opens after Threshold
+// consecutive retryable errors (errors.IsRetryableError), rejects calls with
+// a CIRCUIT_OPEN error for Cooldown, then lets a single probe through: its
+// success closes the breaker, its failure opens it again.
+type Breaker struct {
+	Name      string
+	Threshold int
+	Cooldown  time.Duration
+	Clock     common.Clock
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	probing  bool
+	openedAt time.Time
+	lastErr  error
+	opens    int
+	rejected int
+}
+
+// NewBreaker returns a closed breaker for the dependency name
+func NewBreaker(name string, threshold int, cooldown time.Duration, clock common.Clock) *Breaker {
+	if threshold < 1 {
+		threshold = DefaultThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultCooldown
+	}
+	return &Breaker{Name: name, Threshold: threshold, Cooldown: cooldown, Clock: clock}
+}
+
+// Allow returns nil when a call may go ahead, and a CIRCUIT_OPEN error
+// wrapping the last failure when it should be skipped. Every allowed call
+// must be followed by Record.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == Open && b.Clock.Now().Sub(b.openedAt) >= b.Cooldown {
+		b.state = HalfOpen
+	}
+	switch {
+	case b.state == Closed:
+		return nil
+	case b.state == HalfOpen && !b.probing:
+		b.probing = true
+		return nil
+	}
+	b.rejected++
+	return errors.NewCircuitOpenError(b.Name+" is unavailable, skipping the call", b.lastErr).
+		WithContext("dependency", b.Name).
+		WithContext("retry_after", b.openedAt.Add(b.Cooldown).Sub(b.Clock.Now()).Round(time.Second).String()).
+		WithComponent("resilience")
+}
+
+// Record reports the result of an allowed call. Retryable errors count as
+// failures; success and other errors, such as validation errors, show the
+// dependency is answering and close the breaker. Quota errors are ignored:
+// the call was refused before it reached the dependency.
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if appErr, ok := err.(*errors.AppError); ok && appErr.Code == errors.ErrCodeQuota {
+		return
+	}
+	if err == nil || !errors.IsRetryableError(err) {
+		b.state = Closed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	b.lastErr = err
+	if b.state == HalfOpen || b.failures >= b.Threshold {
+		if b.state != Open {
+			b.opens++
+		}
+		b.state = Open
+		b.openedAt = b.Clock.Now()
+	}
+}
+
+// Call runs fn through the breaker b. A nil breaker just runs fn.
+func Call[T any](b *Breaker, fn func() (T, error)) (T, error) {
+	if b == nil {
+		return fn()
+	}
+	if err := b.Allow(); err != nil {
+		var zero T
+		return zero, err
+	}
+	result, err := fn()
+	b.Record(err)
+	return result, err
+}
+
+// Snapshot is the state of a breaker for /status and metrics
+type Snapshot struct {
+	Name  string
+	State State
+	// Failures counts the consecutive failures so far
+	Failures int
+	// OpenedAt is when the breaker last opened
+	OpenedAt  time.Time
+	LastError string
+	// Opens and Rejected count how often the breaker opened and how many
+	// calls it skipped since the start
+	Opens    int
+	Rejected int
+}
+
+// Snapshot returns the current state of the breaker
+func (b *Breaker) Snapshot() Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state := b.state
+	if state == Open && b.Clock.Now().Sub(b.openedAt) >= b.Cooldown {
+		state = HalfOpen
+	}
+	s := Snapshot{
+		Name:     b.Name,
+		State:    state,
+		Failures: b.failures,
+		OpenedAt: b.openedAt,
+		Opens:    b.opens,
+		Rejected: b.rejected,
+	}
+	if b.lastErr != nil {
+		s.LastError = b.lastErr.Error()
+	}
+	return s
+}
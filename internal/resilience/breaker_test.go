@@ -0,0 +1,135 @@
+package resilience
+
+import (
+	"fmt"
+	"money-tracker-bot/internal/common"
+	"money-tracker-bot/internal/errors"
+	"testing"
+	"time"
+)
+
+func newTestBreaker() (*Breaker, *common.FixedClock) {
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	return NewBreaker("gemini", 3, time.Minute, clock), clock
+}
+
+func fail(b *Breaker) {
+	if err := b.Allow(); err == nil {
+		b.Record(errors.NewGeminiTimeoutError("request timed out", nil))
+	}
+}
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	b, _ := newTestBreaker()
+	fail(b)
+	fail(b)
+	b.Record(nil)
+	fail(b)
+	fail(b)
+	if b.Snapshot().State != Closed {
+		t.Fatal("a success should reset the failure count")
+	}
+	fail(b)
+
+	err := b.Allow()
+	appErr, ok := err.(*errors.AppError)
+	if !ok || appErr.Code != errors.ErrCodeCircuitOpen || appErr.Cause == nil {
+		t.Fatalf("expected a circuit open error wrapping the last failure, got %v", err)
+	}
+	s := b.Snapshot()
+	if s.State != Open || s.Opens != 1 || s.Rejected != 1 || s.LastError == "" {
+		t.Errorf("unexpected snapshot %+v", s)
+	}
+}
+
+func TestBreaker_IgnoresNonRetryableErrors(t *testing.T) {
+	b, _ := newTestBreaker()
+	for i := 0; i < 5; i++ {
+		b.Allow()
+		b.Record(errors.NewValidationError("amount must be positive", nil))
+	}
+	if err := b.Allow(); err != nil {
+		t.Errorf("validation errors should not open the breaker, got %v", err)
+	}
+}
+
+func TestBreaker_IgnoresUnusableAnswers(t *testing.T) {
+	b, _ := newTestBreaker()
+	for i := 0; i < 5; i++ {
+		b.Allow()
+		b.Record(errors.NewDataFormatError("no transaction in Gemini response", nil))
+		b.Allow()
+		b.Record(errors.NewAIRequestError("openai returned 401 Unauthorized", nil))
+	}
+	if s := b.Snapshot(); s.State != Closed || s.Failures != 0 {
+		t.Errorf("answers the provider gave should not open the breaker, got %+v", s)
+	}
+	for i := 0; i < 3; i++ {
+		b.Allow()
+		b.Record(errors.NewAIError("openai returned 429 Too Many Requests", nil))
+	}
+	if b.Snapshot().State != Open {
+		t.Error("expected overload errors to open the breaker")
+	}
+}
+
+func TestBreaker_IgnoresQuotaErrors(t *testing.T) {
+	b, _ := newTestBreaker()
+	fail(b)
+	fail(b)
+	b.Allow()
+	b.Record(errors.NewQuotaError("daily AI token quota used up", nil))
+	if s := b.Snapshot(); s.State != Closed || s.Failures != 2 {
+		t.Fatalf("a quota error should neither count nor reset failures, got %+v", s)
+	}
+	fail(b)
+	if b.Snapshot().State != Open {
+		t.Error("expected the next failure to open the breaker")
+	}
+}
+
+func TestBreaker_HalfOpenProbe(t *testing.T) {
+	b, clock := newTestBreaker()
+	for i := 0; i < 3; i++ {
+		fail(b)
+	}
+	clock.Advance(time.Minute)
+	if b.Snapshot().State != HalfOpen {
+		t.Fatalf("expected half-open after the cooldown, got %v", b.Snapshot().State)
+	}
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected a probe to be let through, got %v", err)
+	}
+	if err := b.Allow(); err == nil {
+		t.Error("only one probe should be in flight")
+	}
+	b.Record(errors.NewGeminiError("still down", nil))
+	if s := b.Snapshot(); s.State != Open || s.Opens != 2 {
+		t.Fatalf("a failed probe should reopen the breaker, got %+v", s)
+	}
+
+	clock.Advance(time.Minute)
+	b.Allow()
+	b.Record(nil)
+	if err := b.Allow(); err != nil || b.Snapshot().State != Closed {
+		t.Errorf("a successful probe should close the breaker, got %v", err)
+	}
+}
+
+func TestCall(t *testing.T) {
+	b, _ := newTestBreaker()
+	calls := 0
+	down := func() (string, error) {
+		calls++
+		return "", errors.NewSpreadsheetError("backend error", fmt.Errorf("503"))
+	}
+	for i := 0; i < 5; i++ {
+		Call(b, down)
+	}
+	if calls != 3 {
+		t.Errorf("expected calls to stop once the breaker opened, got %d", calls)
+	}
+	if got, err := Call(nil, func() (string, error) { return "ok", nil }); err != nil || got != "ok" {
+		t.Errorf("a nil breaker should just run the call, got %q, %v", got, err)
+	}
+}
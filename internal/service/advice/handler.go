@@ -0,0 +1,152 @@
+package advice
+
+// Package advice turns the recorded spending into saving suggestions from the AI.
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	advice_domain "money-tracker-bot/internal/domain/advice"
+	goals_domain "money-tracker-bot/internal/domain/goals"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Generator answers free-form prompts
+type Generator interface {
+	GenerateContent(ctx context.Context, prompt string) (string, error)
+}
+
+// TransactionReader reads recorded transactions and budget summaries
+type TransactionReader interface {
+	ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error)
+	ListCategorySummaries(ctx context.Context, spreadsheetId string) ([]spreadsheet.CategorySummary, error)
+}
+
+type AdviceService struct {
+	AI       Generator
+	Reader   TransactionReader
+	Clock    common.Clock
+	Location *time.Location
+	// Zones is optional; it gives each chat its own timezone instead of Location
+	Zones common.Zones
+	// Locales is optional; it writes the amounts of the prompt in the
+	// spreadsheet's currency, else in rupiah
+	Locales       common.TenantLocales
+	SpreadsheetID string
+}
+
+func NewAdviceService(ai Generator, reader TransactionReader, clock common.Clock, loc *time.Location, spreadsheetID string) *AdviceService {
+	return &AdviceService{
+		AI:            ai,
+		Reader:        reader,
+		Clock:         clock,
+		Location:      loc,
+		SpreadsheetID: spreadsheetID,
+	}
+}
+
+func (s *AdviceService) Advise(ctx context.Context, chatID int64) (string, error) {
+	trxs, err := s.Reader.ListTransactions(ctx, s.SpreadsheetID)
+	if err != nil {
+		return "", err
+	}
+	summaries, err := s.Reader.ListCategorySummaries(ctx, s.SpreadsheetID)
+	if err != nil {
+		return "", err
+	}
+	summary := advice_domain.BuildSummary(transaction_domain.OfChat(trxs, chatID), s.today(chatID), budgets(summaries))
+	if summary.Empty() {
+		return "", errors.NewValidationError("no spending recorded in the last three months yet", nil).
+			WithKey("invalid.advice.no_spending").
+			WithContext("chat_id", chatID).
+			WithComponent("advice-service")
+	}
+	// The tenant lets the AI adapters attribute the tokens to the chat
+	return s.AI.GenerateContent(common.WithTenant(ctx, chatID), common.BuildAdvicePrompt(summaryLines(summary, common.TenantLocale(s.Locales, chatID)), answerLanguage(ctx)))
+}
+
+// answerLanguage names the language the chat reads in ctx, "" for English
+func answerLanguage(ctx context.Context) string {
+	lang, ok := i18n.LanguageFrom(ctx)
+	if !ok || lang == i18n.English {
+		return ""
+	}
+	return i18n.For(i18n.English).T("language.name." + lang)
+}
+
+func (s *AdviceService) today(chatID int64) time.Time {
+	return s.Clock.Now().In(common.TenantLocation(s.Zones, chatID, s.Location))
+}
+
+// budgets reads the spending budgets of the summary sheet. The savings
+// allocation is not spending budget and is left out, as are categories
+// without a budget.
+func budgets(summaries []spreadsheet.CategorySummary) []advice_domain.Budget {
+	var out []advice_domain.Budget
+	for _, s := range summaries {
+		if strings.EqualFold(s.Category, goals_domain.SavingsCategory) {
+			continue
+		}
+		monthly, err := transaction_domain.ParseSignedAmount(s.MonthlyBudget)
+		if err != nil || monthly <= 0 {
+			continue
+		}
+		// The budget left is negative once a category is overspent, and zero
+		// when the cell does not parse
+		left, _ := transaction_domain.ParseSignedAmount(s.BudgetLeft)
+		out = append(out, advice_domain.Budget{Category: s.Category, Monthly: monthly, Left: left})
+	}
+	return out
+}
+
+// summaryLines renders the summary for the advice prompt with the amounts
+// written by locale.
+// Output: ["2025-01: Rp 1,200,000 (Groceries Rp 1,000,000, Eating Out Rp 200,000)", …]
+func summaryLines(s advice_domain.Summary, locale common.Locale) []string {
+	var lines []string
+	for _, m := range s.Months {
+		label := m.Month
+		if m.Partial {
+			label += fmt.Sprintf(" (first %d days)", m.Days)
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s%s", label, locale.FormatAmount(m.Total), categoryList(m.Categories, locale)))
+	}
+	for _, b := range s.Budgets {
+		lines = append(lines, fmt.Sprintf("Budget %s: %s per month, %s left this month",
+			b.Category, locale.FormatAmount(b.Monthly), locale.FormatAmount(b.Left)))
+	}
+	for _, t := range s.Trends {
+		lines = append(lines, fmt.Sprintf("Trend %s: %s → %s (%+.0f%%)",
+			t.Category, locale.FormatAmount(t.Previous), locale.FormatAmount(t.Latest), t.Change()*100))
+	}
+	return lines
+}
+
+// categoryList lists category totals biggest first.
+// Output: " (Groceries Rp 1,000,000, Eating Out Rp 200,000)"
+func categoryList(categories map[string]float64, locale common.Locale) string {
+	if len(categories) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if categories[names[i]] != categories[names[j]] {
+			return categories[names[i]] > categories[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + " " + locale.FormatAmount(categories[name])
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,108 @@
+package advice
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stubReader struct {
+	trxs      []transaction_domain.Transaction
+	summaries []spreadsheet.CategorySummary
+	err       error
+}
+
+func (s *stubReader) ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error) {
+	return s.trxs, s.err
+}
+
+func (s *stubReader) ListCategorySummaries(ctx context.Context, spreadsheetId string) ([]spreadsheet.CategorySummary, error) {
+	return s.summaries, s.err
+}
+
+type recordingAI struct {
+	prompt string
+	tenant int64
+}
+
+func (r *recordingAI) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	r.prompt = prompt
+	r.tenant, _ = common.TenantFrom(ctx)
+	return "• Cook at home twice a week to save Rp 300,000", nil
+}
+
+func newTestService(ai Generator, reader TransactionReader) *AdviceService {
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)}
+	return NewAdviceService(ai, reader, clock, time.UTC, "sheet")
+}
+
+func TestAdvise_SendsAnonymizedSummary(t *testing.T) {
+	reader := &stubReader{
+		trxs: []transaction_domain.Transaction{
+			{TransactionDate: "2025-01-05", Amount: "1,000,000", Category: "Groceries", Notes: "Superindo weekly shop", CreatedBy: "alice", SourceAccount: "BCA", ChatID: 42},
+			{TransactionDate: "2025-02-14", Amount: "600,000", Category: "Eating Out", Title: "Valentine dinner at Sushi Tei", DestinationNumber: "0812345678", ChatID: 42},
+			{TransactionDate: "2025-03-02", Amount: "150,000", Category: "Groceries", ChatID: 42},
+			// Another chat sharing the sheet
+			{TransactionDate: "2025-03-03", Amount: "9,000,000", Category: "Travel", ChatID: 7},
+		},
+		summaries: []spreadsheet.CategorySummary{
+			{Category: "Groceries", MonthlyBudget: "1,500,000", BudgetLeft: "1,350,000"},
+			{Category: "Eating Out", MonthlyBudget: "500,000", BudgetLeft: "-100,000"},
+			{Category: "Savings", MonthlyBudget: "2,000,000", BudgetLeft: "2,000,000"},
+		},
+	}
+	ai := &recordingAI{}
+	svc := newTestService(ai, reader)
+
+	answer, err := svc.Advise(context.Background(), 42)
+	if err != nil || !strings.Contains(answer, "Cook at home") {
+		t.Fatalf("unexpected answer %q, %v", answer, err)
+	}
+	if ai.tenant != 42 {
+		t.Errorf("the AI call should be attributed to the chat, got %d", ai.tenant)
+	}
+	for _, want := range []string{
+		"- 2025-01: Rp 1,000,000 (Groceries Rp 1,000,000)",
+		"- 2025-03 (first 10 days): Rp 150,000 (Groceries Rp 150,000)",
+		"- Budget Eating Out: Rp 500,000 per month, -Rp 100,000 left this month",
+		"- Trend Eating Out: Rp 0 → Rp 600,000 (+100%)",
+	} {
+		if !strings.Contains(ai.prompt, want) {
+			t.Errorf("expected %q in prompt:\n%s", want, ai.prompt)
+		}
+	}
+	for _, private := range []string{"Superindo", "alice", "BCA", "Sushi Tei", "0812345678", "Savings"} {
+		if strings.Contains(ai.prompt, private) {
+			t.Errorf("prompt leaks %q:\n%s", private, ai.prompt)
+		}
+	}
+}
+
+func TestAdvise_NoHistory(t *testing.T) {
+	ai := &recordingAI{}
+	svc := newTestService(ai, &stubReader{trxs: []transaction_domain.Transaction{
+		{TransactionDate: "2024-10-01", Amount: "50,000", Category: "Groceries", ChatID: 42},
+		// Recent spending of another chat sharing the sheet
+		{TransactionDate: "2025-03-01", Amount: "50,000", Category: "Groceries", ChatID: 7},
+	}})
+	_, err := svc.Advise(context.Background(), 42)
+	if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.ErrCodeValidation {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if ai.prompt != "" {
+		t.Error("the AI should not be asked without any spending")
+	}
+}
+
+func TestAdvise_ReaderError(t *testing.T) {
+	svc := newTestService(&recordingAI{}, &stubReader{err: fmt.Errorf("sheets down")})
+	if _, err := svc.Advise(context.Background(), 42); err == nil {
+		t.Error("expected the reader error")
+	}
+}
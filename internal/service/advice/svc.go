@@ -0,0 +1,9 @@
+package advice
+
+import "context"
+
+type IAdvice interface {
+	// Advise asks the AI for saving suggestions based on an anonymized
+	// summary of the last three months of spending
+	Advise(ctx context.Context, chatID int64) (string, error)
+}
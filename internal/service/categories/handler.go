@@ -0,0 +1,141 @@
+package categories
+
+// Package categories manages the configurable, per-chat category list used
+// for AI extraction and keeps recorded transactions in line with it.
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	category_domain "money-tracker-bot/internal/domain/category"
+	goals_domain "money-tracker-bot/internal/domain/goals"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"strings"
+)
+
+// Repository persists the category set of each chat
+type Repository interface {
+	Get(chatID int64) (category_domain.Set, bool, error)
+	Save(chatID int64, set category_domain.Set) error
+}
+
+// RowMigrator rewrites the category of the transactions recorded by the
+// tenant in ctx
+type RowMigrator interface {
+	MigrateCategory(ctx context.Context, spreadsheetId string, m category_domain.Migration) (int, error)
+}
+
+type CategoryService struct {
+	Repo          Repository
+	Migrator      RowMigrator
+	Defaults      category_domain.Set
+	SpreadsheetID string
+}
+
+func NewCategoryService(repo Repository, migrator RowMigrator, defaults category_domain.Set, spreadsheetID string) *CategoryService {
+	return &CategoryService{
+		Repo:          repo,
+		Migrator:      migrator,
+		Defaults:      defaults,
+		SpreadsheetID: spreadsheetID,
+	}
+}
+
+func (s *CategoryService) List(chatID int64) (category_domain.Set, error) {
+	set, found, err := s.Repo.Get(chatID)
+	if err != nil {
+		return category_domain.Set{}, err
+	}
+	if !found {
+		return s.Defaults.Clone(), nil
+	}
+	return set, nil
+}
+
+func (s *CategoryService) Add(chatID int64, c category_domain.Category) (category_domain.Category, error) {
+	set, err := s.List(chatID)
+	if err != nil {
+		return c, err
+	}
+	if err := set.Add(c); err != nil {
+		return c, errors.NewValidationError(err.Error(), err).WithComponent("category-service")
+	}
+	added := set.Categories[len(set.Categories)-1]
+	return added, s.Repo.Save(chatID, set)
+}
+
+func (s *CategoryService) Rename(ctx context.Context, chatID int64, path, newName string) (category_domain.Migration, int, error) {
+	return s.change(ctx, chatID, path, func(set *category_domain.Set) (category_domain.Migration, error) {
+		return set.Rename(path, newName)
+	})
+}
+
+func (s *CategoryService) Merge(ctx context.Context, chatID int64, from, into string) (category_domain.Migration, int, error) {
+	if isReserved(into) {
+		return category_domain.Migration{}, 0, errors.NewValidationError(goals_domain.SavingsCategory+" is reserved for savings goals", nil).
+			WithKey("invalid.category.reserved", goals_domain.SavingsCategory).
+			WithComponent("category-service")
+	}
+	return s.change(ctx, chatID, from, func(set *category_domain.Set) (category_domain.Migration, error) {
+		return set.Merge(from, into)
+	})
+}
+
+// change applies a rename or merge. Recorded rows are migrated before the
+// new set is saved, so a failed save can simply be retried.
+func (s *CategoryService) change(ctx context.Context, chatID int64, path string, apply func(*category_domain.Set) (category_domain.Migration, error)) (category_domain.Migration, int, error) {
+	if isReserved(path) {
+		return category_domain.Migration{}, 0, errors.NewValidationError(goals_domain.SavingsCategory+" is reserved for savings goals", nil).
+			WithKey("invalid.category.reserved", goals_domain.SavingsCategory).
+			WithComponent("category-service")
+	}
+	set, err := s.List(chatID)
+	if err != nil {
+		return category_domain.Migration{}, 0, err
+	}
+	m, err := apply(&set)
+	if err != nil {
+		return m, 0, errors.NewValidationError(err.Error(), err).WithComponent("category-service")
+	}
+	rows, err := s.Migrator.MigrateCategory(common.WithTenant(ctx, chatID), s.SpreadsheetID, m)
+	if err != nil {
+		return m, 0, err
+	}
+	return m, rows, s.Repo.Save(chatID, set)
+}
+
+func (s *CategoryService) Match(chatID int64, name string) (category_domain.Ref, bool, error) {
+	set, err := s.List(chatID)
+	if err != nil {
+		return category_domain.Ref{}, false, err
+	}
+	ref, ok := set.Match(name)
+	return ref, ok, nil
+}
+
+func (s *CategoryService) PromptLines(chatID int64) ([]string, error) {
+	set, err := s.List(chatID)
+	if err != nil {
+		return nil, err
+	}
+	return set.PromptLines(), nil
+}
+
+func (s *CategoryService) Resolve(chatID int64, trx *transaction_domain.Transaction) error {
+	name := trx.Category
+	if trx.Subcategory != "" {
+		name += category_domain.Separator + trx.Subcategory
+	}
+	ref, ok, err := s.Match(chatID, name)
+	if err != nil || !ok {
+		return err
+	}
+	trx.Category, trx.Subcategory = ref.Category, ref.Subcategory
+	return nil
+}
+
+// isReserved reports whether path is the category goal contributions rely on
+func isReserved(path string) bool {
+	parent, name := category_domain.SplitPath(path)
+	return parent == "" && strings.EqualFold(name, goals_domain.SavingsCategory)
+}
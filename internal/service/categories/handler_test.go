@@ -0,0 +1,125 @@
+package categories
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	category_domain "money-tracker-bot/internal/domain/category"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"testing"
+)
+
+type memoryRepo struct {
+	sets map[int64]category_domain.Set
+}
+
+func (m *memoryRepo) Get(chatID int64) (category_domain.Set, bool, error) {
+	s, ok := m.sets[chatID]
+	return s, ok, nil
+}
+
+func (m *memoryRepo) Save(chatID int64, set category_domain.Set) error {
+	m.sets[chatID] = set
+	return nil
+}
+
+// migratorStub applies migrations to in-memory rows
+type migratorStub struct {
+	rows []category_domain.Ref
+	// chatID is the tenant of the last migration
+	chatID int64
+}
+
+func (m *migratorStub) MigrateCategory(ctx context.Context, spreadsheetId string, mig category_domain.Migration) (int, error) {
+	m.chatID, _ = common.TenantFrom(ctx)
+	changed := 0
+	for i, r := range m.rows {
+		if out, ok := mig.Apply(r); ok {
+			m.rows[i] = out
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+func newTestService() (*CategoryService, *memoryRepo, *migratorStub) {
+	repo := &memoryRepo{sets: make(map[int64]category_domain.Set)}
+	migrator := &migratorStub{}
+	defaults := category_domain.DefaultSet([]string{"Groceries", "Eating Out", "Health", "Emergency", "Savings"})
+	return NewCategoryService(repo, migrator, defaults, "sheet"), repo, migrator
+}
+
+func TestAddIsPerChat(t *testing.T) {
+	svc, _, _ := newTestService()
+	if _, err := svc.Add(1, category_domain.Category{Name: "Pets", Aliases: []string{"cat food"}}); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if ref, ok, _ := svc.Match(1, "cat food"); !ok || ref.Category != "Pets" {
+		t.Errorf("expected alias to match, got %+v, %v", ref, ok)
+	}
+	if _, ok, _ := svc.Match(2, "Pets"); ok {
+		t.Error("other chats must keep the default categories")
+	}
+	if len(svc.Defaults.Categories) != 5 {
+		t.Error("adding a category must not change the defaults")
+	}
+	if _, err := svc.Add(1, category_domain.Category{Name: "pets"}); err == nil {
+		t.Error("expected validation error for duplicate category")
+	}
+}
+
+func TestRenameMigratesRows(t *testing.T) {
+	svc, repo, migrator := newTestService()
+	migrator.rows = []category_domain.Ref{{Category: "Eating Out"}, {Category: "Groceries"}, {Category: "Eating Out", Subcategory: "Coffee"}}
+
+	m, rows, err := svc.Rename(context.Background(), 1, "eating out", "Dining")
+	if err != nil || rows != 2 {
+		t.Fatalf("unexpected rename result: %+v, %d, %v", m, rows, err)
+	}
+	if migrator.rows[2] != (category_domain.Ref{Category: "Dining", Subcategory: "Coffee"}) {
+		t.Errorf("rows should be migrated, got %+v", migrator.rows)
+	}
+	if migrator.chatID != 1 {
+		t.Errorf("only the rows of chat 1 should be migrated, got chat %d", migrator.chatID)
+	}
+	if _, ok := repo.sets[1].Find("Dining"); !ok {
+		t.Error("renamed set should be saved")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	svc, _, migrator := newTestService()
+	migrator.rows = []category_domain.Ref{{Category: "Emergency"}}
+	if _, rows, err := svc.Merge(context.Background(), 1, "Emergency", "Health"); err != nil || rows != 1 {
+		t.Fatalf("unexpected merge result: %d, %v", rows, err)
+	}
+	if _, _, err := svc.Merge(context.Background(), 1, "Savings", "Groceries"); err == nil {
+		t.Error("Savings must not be merged away")
+	}
+	if _, _, err := svc.Merge(context.Background(), 1, "Groceries", "savings"); err == nil {
+		t.Error("nothing may be merged into Savings")
+	}
+	if _, _, err := svc.Rename(context.Background(), 1, "Savings", "Tabungan"); err == nil {
+		t.Error("Savings must not be renamed")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	svc, _, _ := newTestService()
+	svc.Add(1, category_domain.Category{Name: "Coffee", Parent: "Eating Out", Aliases: []string{"kopi"}})
+
+	trx := transaction_domain.Transaction{Category: "Eating Out › Coffee"}
+	svc.Resolve(1, &trx)
+	if trx.Category != "Eating Out" || trx.Subcategory != "Coffee" {
+		t.Errorf("path should be split into category and subcategory, got %+v", trx)
+	}
+	trx = transaction_domain.Transaction{Category: "groceries"}
+	svc.Resolve(1, &trx)
+	if trx.Category != "Groceries" {
+		t.Errorf("category should use canonical spelling, got %+v", trx)
+	}
+	trx = transaction_domain.Transaction{Category: "Unknown"}
+	svc.Resolve(1, &trx)
+	if trx.Category != "Unknown" {
+		t.Errorf("unknown categories are left as the AI returned them, got %+v", trx)
+	}
+}
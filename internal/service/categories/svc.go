@@ -0,0 +1,23 @@
+package categories
+
+import (
+	"context"
+	category_domain "money-tracker-bot/internal/domain/category"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+type ICategories interface {
+	// List returns the categories of a chat, the defaults until it changes them
+	List(chatID int64) (category_domain.Set, error)
+	Add(chatID int64, c category_domain.Category) (category_domain.Category, error)
+	// Rename renames a category and migrates recorded transactions, returning
+	// the number of rows changed
+	Rename(ctx context.Context, chatID int64, path, newName string) (category_domain.Migration, int, error)
+	// Merge folds one category into another and migrates recorded transactions
+	Merge(ctx context.Context, chatID int64, from, into string) (category_domain.Migration, int, error)
+	// Match resolves a category name, path or alias for the chat
+	Match(chatID int64, name string) (category_domain.Ref, bool, error)
+	PromptLines(chatID int64) ([]string, error)
+	// Resolve sets the category and subcategory of trx from the chat's categories
+	Resolve(chatID int64, trx *transaction_domain.Transaction) error
+}
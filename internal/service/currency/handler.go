@@ -0,0 +1,161 @@
+package currency
+
+// Package currency keeps the exchange rates of each tenant and converts
+// transactions paid in other currencies to the base currency of the
+// spreadsheet, so totals never mix currencies.
+
+import (
+	"fmt"
+	"io"
+	"money-tracker-bot/internal/common"
+	currency_domain "money-tracker-bot/internal/domain/currency"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"strconv"
+	"time"
+)
+
+// Repository persists the exchange rates of each chat
+type Repository interface {
+	List(chatID int64) ([]currency_domain.Rate, error)
+	Save(rates ...currency_domain.Rate) error
+}
+
+type CurrencyService struct {
+	Repo     Repository
+	Clock    common.Clock
+	Location *time.Location
+	// BaseCurrency is the currency of the spreadsheet; currency_domain.Default when empty
+	BaseCurrency string
+	// Zones is optional; it gives each chat its own today instead of Location
+	Zones common.Zones
+}
+
+func NewCurrencyService(repo Repository, clock common.Clock, loc *time.Location, base string) *CurrencyService {
+	return &CurrencyService{
+		Repo:         repo,
+		Clock:        clock,
+		Location:     loc,
+		BaseCurrency: base,
+	}
+}
+
+func (s *CurrencyService) Base() string {
+	if code, ok := currency_domain.ParseCode(s.BaseCurrency); ok {
+		return code
+	}
+	return currency_domain.Default
+}
+
+func (s *CurrencyService) SetRate(chatID int64, code string, rate float64, date string, setBy string) (currency_domain.Rate, error) {
+	if date == "" {
+		date = s.today(chatID)
+	}
+	r := currency_domain.Rate{
+		ChatID:   chatID,
+		Base:     s.Base(),
+		Currency: code,
+		Rate:     rate,
+		Date:     date,
+		SetBy:    setBy,
+		Source:   currency_domain.SourceManual,
+	}
+	if parsed, ok := currency_domain.ParseCode(code); ok {
+		r.Currency = parsed
+	}
+	if err := r.Validate(); err != nil {
+		return r, errors.NewValidationError(err.Error(), err).
+			WithContext("currency", code).
+			WithComponent("currency-service")
+	}
+	return r, s.Repo.Save(r)
+}
+
+func (s *CurrencyService) Import(chatID int64, r io.Reader, setBy string) ([]currency_domain.Rate, error) {
+	rates, err := currency_domain.ParseCSV(r, s.Base())
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error(), err).WithComponent("currency-service")
+	}
+	for i := range rates {
+		rates[i].ChatID = chatID
+		rates[i].SetBy = setBy
+	}
+	return rates, s.Repo.Save(rates...)
+}
+
+func (s *CurrencyService) Rates(chatID int64) ([]currency_domain.Rate, error) {
+	rates, err := s.Repo.List(chatID)
+	if err != nil {
+		return nil, err
+	}
+	return currency_domain.Latest(rates, s.Base()), nil
+}
+
+// Convert replaces the amount of a transaction in another currency with the
+// base amount at the rate of its date, and keeps what was paid in
+// OriginalAmount and OriginalCurrency. Transactions without a currency are
+// in the base currency; converted ones are left alone, so reprocessing is
+// safe. A missing rate fails rather than saving a foreign amount as if it
+// were the base currency.
+func (s *CurrencyService) Convert(chatID int64, trx *transaction_domain.Transaction) error {
+	base := s.Base()
+	if trx.AmountCurrency == "" {
+		trx.AmountCurrency = base
+		return nil
+	}
+	code, ok := currency_domain.ParseCode(trx.AmountCurrency)
+	if !ok {
+		return errors.NewValidationError(fmt.Sprintf("unknown currency %q", trx.AmountCurrency), nil).
+			WithKey("invalid.currency.unknown", trx.AmountCurrency).
+			WithContext("currency", trx.AmountCurrency).
+			WithComponent("currency-service")
+	}
+	if code == base {
+		trx.AmountCurrency = base
+		return nil
+	}
+
+	amount, err := transaction_domain.ParseAmount(trx.Amount)
+	if err != nil {
+		return errors.NewValidationError("invalid amount", err).
+			WithKey("invalid.currency.invalid_amount").
+			WithContext("amount", trx.Amount).
+			WithComponent("currency-service")
+	}
+	date := trx.TransactionDate
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		date = s.today(chatID)
+	}
+	rates, err := s.Repo.List(chatID)
+	if err != nil {
+		return err
+	}
+	rate, ok := currency_domain.Find(rates, base, code, date)
+	if !ok {
+		return errors.NewValidationError(fmt.Sprintf("no exchange rate for %s, set one with /rate %s <rate in %s>", code, code, base), nil).
+			WithKey("invalid.currency.no_rate", code, base).
+			WithContext("currency", code).
+			WithContext("date", date).
+			WithComponent("currency-service")
+	}
+
+	trx.OriginalAmount = formatAmount(amount, code)
+	trx.OriginalCurrency = code
+	trx.ExchangeRate = rate.Rate
+	trx.Amount = formatAmount(rate.Convert(amount), base)
+	trx.AmountCurrency = base
+	return nil
+}
+
+// formatAmount writes an amount with the minor units of its currency and no
+// separators, which the spreadsheet reads as a number
+// Input: 12.5, "USD"
+// Output: "12.50"
+func formatAmount(amount float64, code string) string {
+	return strconv.FormatFloat(currency_domain.Round(amount, code), 'f', currency_domain.MinorUnits(code), 64)
+}
+
+func (s *CurrencyService) today(chatID int64) string {
+	loc := common.TenantLocation(s.Zones, chatID, s.Location)
+	return s.Clock.Now().In(loc).Format("2006-01-02")
+}
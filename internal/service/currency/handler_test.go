@@ -0,0 +1,119 @@
+package currency
+
+import (
+	"money-tracker-bot/internal/common"
+	currency_domain "money-tracker-bot/internal/domain/currency"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryRepo struct {
+	rates []currency_domain.Rate
+}
+
+func (m *memoryRepo) List(chatID int64) ([]currency_domain.Rate, error) {
+	var out []currency_domain.Rate
+	for _, r := range m.rates {
+		if r.ChatID == chatID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryRepo) Save(rates ...currency_domain.Rate) error {
+	m.rates = append(m.rates, rates...)
+	return nil
+}
+
+type zones map[int64]*time.Location
+
+func (z zones) Location(chatID int64) *time.Location { return z[chatID] }
+
+func newTestService() (*CurrencyService, *memoryRepo) {
+	repo := &memoryRepo{}
+	// 23:30 UTC on the 9th is already the 10th in Jakarta
+	clock := &common.FixedClock{Time: time.Date(2025, 7, 9, 23, 30, 0, 0, time.UTC)}
+	return NewCurrencyService(repo, clock, time.FixedZone("WIB", 7*60*60), ""), repo
+}
+
+func TestSetRate(t *testing.T) {
+	s, repo := newTestService()
+	s.Zones = zones{2: time.UTC}
+
+	r, err := s.SetRate(1, "usd", 16200, "", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := currency_domain.Rate{ChatID: 1, Base: "IDR", Currency: "USD", Rate: 16200, Date: "2025-07-10", SetBy: "alice", Source: currency_domain.SourceManual}
+	if r != want {
+		t.Errorf("expected %+v, got %+v", want, r)
+	}
+	if r, err := s.SetRate(2, "S$", 12500, "", "bob"); err != nil || r.Date != "2025-07-09" {
+		t.Errorf("expected the chat's own date 2025-07-09, got %+v, %v", r, err)
+	}
+
+	for _, code := range []string{"XYZ", "IDR"} {
+		_, err := s.SetRate(1, code, 1, "", "alice")
+		if appErr, ok := err.(*errors.AppError); !ok || appErr.Code != errors.ErrCodeValidation {
+			t.Errorf("expected a validation error for %s, got %v", code, err)
+		}
+	}
+	if len(repo.rates) != 2 {
+		t.Errorf("expected invalid rates not to be saved, got %+v", repo.rates)
+	}
+}
+
+func TestImportAndRates(t *testing.T) {
+	s, _ := newTestService()
+	csv := "date,currency,rate\n2025-07-01,USD,16200\n2025-07-05,USD,16300\n2025-07-01,SGD,12500\n"
+	rates, err := s.Import(1, strings.NewReader(csv), "alice")
+	if err != nil || len(rates) != 3 || rates[0].ChatID != 1 || rates[0].SetBy != "alice" {
+		t.Fatalf("unexpected import %+v, %v", rates, err)
+	}
+	if _, err := s.Import(1, strings.NewReader("2025-07-01,XYZ,1\n"), "alice"); err == nil {
+		t.Error("expected an invalid file to fail")
+	}
+
+	latest, err := s.Rates(1)
+	if err != nil || len(latest) != 2 || latest[0].Currency != "SGD" || latest[1].Rate != 16300 {
+		t.Errorf("unexpected latest rates %+v, %v", latest, err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	s, _ := newTestService()
+	if _, err := s.Import(1, strings.NewReader("2025-07-01,USD,16200\n2025-07-05,USD,16300\n"), "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	trx := &transaction_domain.Transaction{TransactionDate: "2025-07-03", Amount: "12.50", AmountCurrency: "usd"}
+	if err := s.Convert(1, trx); err != nil {
+		t.Fatal(err)
+	}
+	if trx.Amount != "202500" || trx.AmountCurrency != "IDR" || trx.OriginalAmount != "12.50" || trx.OriginalCurrency != "USD" || trx.ExchangeRate != 16200 {
+		t.Errorf("unexpected conversion %+v", trx)
+	}
+	if err := s.Convert(1, trx); err != nil || trx.Amount != "202500" {
+		t.Errorf("expected a converted transaction to be left alone, got %+v, %v", trx, err)
+	}
+
+	rupiah := &transaction_domain.Transaction{Amount: "150,000"}
+	if err := s.Convert(1, rupiah); err != nil || rupiah.Amount != "150,000" || rupiah.AmountCurrency != "IDR" || rupiah.OriginalCurrency != "" {
+		t.Errorf("expected a transaction without a currency to stay rupiah, got %+v, %v", rupiah, err)
+	}
+}
+
+func TestConvert_Errors(t *testing.T) {
+	s, _ := newTestService()
+	err := s.Convert(1, &transaction_domain.Transaction{TransactionDate: "2025-07-03", Amount: "20", AmountCurrency: "SGD"})
+	if err == nil || !strings.Contains(err.Error(), "/rate SGD") {
+		t.Errorf("expected a missing rate to explain /rate, got %v", err)
+	}
+	if err := s.Convert(1, &transaction_domain.Transaction{Amount: "20", AmountCurrency: "dollars"}); err == nil {
+		t.Error("expected an unknown currency to fail")
+	}
+}
@@ -0,0 +1,22 @@
+package currency
+
+import (
+	"io"
+	currency_domain "money-tracker-bot/internal/domain/currency"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+type ICurrency interface {
+	// Base returns the currency transactions are converted to and saved in
+	Base() string
+	// SetRate stores how many units of the base currency one unit of code
+	// buys from date (YYYY-MM-DD) on; an empty date is the chat's today
+	SetRate(chatID int64, code string, rate float64, date string, setBy string) (currency_domain.Rate, error)
+	// Import stores the rates of a CSV file and returns them
+	Import(chatID int64, r io.Reader, setBy string) ([]currency_domain.Rate, error)
+	// Rates returns the newest rate of each currency of the chat
+	Rates(chatID int64) ([]currency_domain.Rate, error)
+	// Convert converts a transaction in another currency to the base
+	// currency at its date, keeping the original amount
+	Convert(chatID int64, trx *transaction_domain.Transaction) error
+}
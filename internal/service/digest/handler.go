@@ -0,0 +1,288 @@
+package digest
+
+// Package digest schedules and renders the daily and weekly spending digests
+// that are pushed to each chat.
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	digest_domain "money-tracker-bot/internal/domain/digest"
+	goals_domain "money-tracker-bot/internal/domain/goals"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScheduleRepository persists digest schedules per chat
+type ScheduleRepository interface {
+	List() ([]digest_domain.Schedule, error)
+	Get(chatID int64) (digest_domain.Schedule, bool, error)
+	Save(schedule digest_domain.Schedule) error
+}
+
+// TransactionReader reads recorded transactions and budget summaries
+type TransactionReader interface {
+	ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error)
+	ListCategorySummaries(ctx context.Context, spreadsheetId string) ([]spreadsheet.CategorySummary, error)
+}
+
+// Notifier delivers a text message to a chat
+type Notifier interface {
+	SendText(chatID int64, text string) error
+}
+
+type DigestService struct {
+	Schedules     ScheduleRepository
+	Reader        TransactionReader
+	Notifier      Notifier
+	Clock         common.Clock
+	SpreadsheetID string
+	// Zones is optional; when set, schedules always follow the chat's
+	// timezone instead of their own Timezone
+	Zones common.Zones
+	// Locales is optional; digests use common.DefaultLocale when nil
+	Locales common.TenantLocales
+	// Languages is optional; scheduled digests are written in i18n.Default
+	// when nil or when the chat has not chosen a language
+	Languages i18n.TenantLanguages
+
+	// mu serializes schedule writes, so a delivery and a /digest change never
+	// overwrite each other
+	mu sync.Mutex
+}
+
+func NewDigestService(schedules ScheduleRepository, reader TransactionReader, notifier Notifier, clock common.Clock, spreadsheetID string) *DigestService {
+	return &DigestService{
+		Schedules:     schedules,
+		Reader:        reader,
+		Notifier:      notifier,
+		Clock:         clock,
+		SpreadsheetID: spreadsheetID,
+	}
+}
+
+func (d *DigestService) GetSchedule(chatID int64) (digest_domain.Schedule, error) {
+	schedule, found, err := d.Schedules.Get(chatID)
+	if err != nil {
+		return digest_domain.Schedule{}, err
+	}
+	if !found {
+		schedule = digest_domain.NewSchedule(chatID)
+	}
+	return d.zoned(schedule), nil
+}
+
+// zoned returns schedule in the chat's current timezone when Zones is set,
+// so a /timezone change applies to digests right away
+func (d *DigestService) zoned(schedule digest_domain.Schedule) digest_domain.Schedule {
+	if d.Zones == nil {
+		return schedule
+	}
+	if loc := d.Zones.Location(schedule.ChatID); loc != nil {
+		schedule.Timezone = loc.String()
+	}
+	return schedule
+}
+
+// SaveSchedule validates and persists a schedule. The last deliveries are
+// kept from the stored schedule, since only the scheduler records them.
+func (d *DigestService) SaveSchedule(schedule digest_domain.Schedule) error {
+	if _, err := schedule.Location(); err != nil {
+		return errors.NewValidationError("unknown timezone", err).
+			WithKey("invalid.digest.unknown_timezone").
+			WithContext("timezone", schedule.Timezone).
+			WithComponent("digest-service")
+	}
+	for _, at := range []string{schedule.DailyTime, schedule.WeeklyTime} {
+		if _, _, err := digest_domain.ParseTimeOfDay(at); err != nil {
+			return errors.NewValidationError("invalid digest time", err).
+				WithKey("invalid.digest.invalid_time", at).
+				WithContext("time", at).
+				WithComponent("digest-service")
+		}
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	stored, found, err := d.Schedules.Get(schedule.ChatID)
+	if err != nil {
+		return err
+	}
+	if found {
+		schedule.LastDaily = stored.LastDaily
+		schedule.LastWeekly = stored.LastWeekly
+	}
+	return d.Schedules.Save(schedule)
+}
+
+func (d *DigestService) BuildDigest(ctx context.Context, chatID int64, kind digest_domain.Kind) (string, error) {
+	schedule, err := d.GetSchedule(chatID)
+	if err != nil {
+		return "", err
+	}
+	return d.render(ctx, schedule, kind)
+}
+
+// RunDue sends every digest that is due at the current clock time and records
+// the delivery. A failure for one chat does not prevent the others.
+func (d *DigestService) RunDue(ctx context.Context) error {
+	schedules, err := d.Schedules.List()
+	if err != nil {
+		return err
+	}
+	now := d.Clock.Now()
+	for _, schedule := range schedules {
+		if err := d.runSchedule(ctx, d.zoned(schedule), now); err != nil {
+			errors.HandleError(err, "sending scheduled digest")
+		}
+	}
+	return nil
+}
+
+// Run checks for due digests every interval until ctx is cancelled.
+func (d *DigestService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := d.RunDue(ctx); err != nil {
+			errors.HandleError(err, "running digest scheduler")
+		}
+		select {
+		case <-ctx.Done():
+			log.Println("Digest scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *DigestService) runSchedule(ctx context.Context, schedule digest_domain.Schedule, now time.Time) error {
+	today, err := schedule.LocalDate(now)
+	if err != nil {
+		return err
+	}
+	dailyDue, err := schedule.DailyDue(now)
+	if err != nil {
+		return err
+	}
+	weeklyDue, err := schedule.WeeklyDue(now)
+	if err != nil {
+		return err
+	}
+
+	// Each delivery is recorded as soon as it is sent, so a failing weekly
+	// digest does not send the daily one again on the next run
+	if dailyDue {
+		if err := d.send(ctx, schedule, digest_domain.KindDaily); err != nil {
+			return err
+		}
+		if err := d.markSent(schedule.ChatID, digest_domain.KindDaily, today); err != nil {
+			return err
+		}
+	}
+	if weeklyDue {
+		if err := d.send(ctx, schedule, digest_domain.KindWeekly); err != nil {
+			return err
+		}
+		if err := d.markSent(schedule.ChatID, digest_domain.KindWeekly, today); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markSent records the delivery of a digest on the stored schedule. It is
+// read again rather than saved from the copy that was sent, which would undo
+// a /digest change made in the meantime.
+func (d *DigestService) markSent(chatID int64, kind digest_domain.Kind, date string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	schedule, found, err := d.Schedules.Get(chatID)
+	if err != nil || !found {
+		return err
+	}
+	if kind == digest_domain.KindWeekly {
+		schedule.LastWeekly = date
+	} else {
+		schedule.LastDaily = date
+	}
+	return d.Schedules.Save(schedule)
+}
+
+func (d *DigestService) send(ctx context.Context, schedule digest_domain.Schedule, kind digest_domain.Kind) error {
+	text, err := d.render(ctx, schedule, kind)
+	if err != nil {
+		return err
+	}
+	return d.Notifier.SendText(schedule.ChatID, text)
+}
+
+func (d *DigestService) render(ctx context.Context, schedule digest_domain.Schedule, kind digest_domain.Kind) (string, error) {
+	loc, err := schedule.Location()
+	if err != nil {
+		return "", errors.NewValidationError("unknown timezone", err).
+			WithKey("invalid.digest.unknown_timezone").
+			WithContext("timezone", schedule.Timezone).
+			WithComponent("digest-service")
+	}
+	trxs, err := d.Reader.ListTransactions(ctx, d.SpreadsheetID)
+	if err != nil {
+		return "", err
+	}
+	summaries, err := d.Reader.ListCategorySummaries(ctx, d.SpreadsheetID)
+	if err != nil {
+		return "", err
+	}
+
+	to := d.Clock.Now().In(loc)
+	from := to
+	if kind == digest_domain.KindWeekly {
+		from = to.AddDate(0, 0, -6)
+	}
+	report := digest_domain.BuildReport(kind, transaction_domain.OfChat(trxs, schedule.ChatID), from, to, totalBudgetLeft(summaries))
+	p := i18n.Tenant(ctx, d.Languages, schedule.ChatID)
+	return formatReport(p, report, common.TenantLocale(d.Locales, schedule.ChatID)), nil
+}
+
+// totalBudgetLeft sums the remaining spending budget across categories.
+// The budget left column can be negative once a category is overspent.
+// The savings allocation is not spending budget and is left out.
+func totalBudgetLeft(summaries []spreadsheet.CategorySummary) float64 {
+	var total float64
+	for _, s := range summaries {
+		if strings.EqualFold(s.Category, goals_domain.SavingsCategory) {
+			continue
+		}
+		v, err := transaction_domain.ParseSignedAmount(s.BudgetLeft)
+		if err != nil {
+			continue
+		}
+		total += v
+	}
+	return total
+}
+
+func formatReport(p i18n.Printer, r digest_domain.Report, locale common.Locale) string {
+	var b strings.Builder
+	if r.Kind == digest_domain.KindWeekly {
+		b.WriteString(p.T("digest.weekly_title", locale.FormatDate(r.From), locale.FormatDate(r.To)) + "\n")
+	} else {
+		b.WriteString(p.T("digest.daily_title", locale.FormatDate(r.To)) + "\n")
+	}
+	b.WriteString(p.T("digest.spent", locale.FormatAmount(r.Total), r.Count) + "\n")
+	for _, c := range r.Categories {
+		fmt.Fprintf(&b, "• %s: %s\n", c.Category, locale.FormatAmount(c.Amount))
+	}
+	b.WriteString(p.T("digest.budget_left", locale.FormatAmount(r.BudgetLeft)) + "\n")
+	if r.DaysLeft > 0 {
+		b.WriteString(p.T("digest.per_day_left", locale.FormatAmount(r.PerDayLeft), r.DaysLeft))
+	} else {
+		b.WriteString(p.T("digest.last_day"))
+	}
+	return b.String()
+}
@@ -0,0 +1,315 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	digest_domain "money-tracker-bot/internal/domain/digest"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryScheduleRepo struct {
+	schedules map[int64]digest_domain.Schedule
+}
+
+func (m *memoryScheduleRepo) List() ([]digest_domain.Schedule, error) {
+	var out []digest_domain.Schedule
+	for _, s := range m.schedules {
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+func (m *memoryScheduleRepo) Get(chatID int64) (digest_domain.Schedule, bool, error) {
+	s, ok := m.schedules[chatID]
+	return s, ok, nil
+}
+
+func (m *memoryScheduleRepo) Save(s digest_domain.Schedule) error {
+	m.schedules[s.ChatID] = s
+	return nil
+}
+
+type stubReader struct {
+	trxs      []transaction_domain.Transaction
+	summaries []spreadsheet.CategorySummary
+	err       error
+}
+
+func (s *stubReader) ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error) {
+	return s.trxs, s.err
+}
+
+func (s *stubReader) ListCategorySummaries(ctx context.Context, spreadsheetId string) ([]spreadsheet.CategorySummary, error) {
+	return s.summaries, s.err
+}
+
+type recordingNotifier struct {
+	sent map[int64][]string
+	// onSend runs before a message is recorded; an error fails the send
+	onSend func(text string) error
+}
+
+func (r *recordingNotifier) SendText(chatID int64, text string) error {
+	if r.onSend != nil {
+		if err := r.onSend(text); err != nil {
+			return err
+		}
+	}
+	if r.sent == nil {
+		r.sent = make(map[int64][]string)
+	}
+	r.sent[chatID] = append(r.sent[chatID], text)
+	return nil
+}
+
+func newTestService(now time.Time, schedules ...digest_domain.Schedule) (*DigestService, *recordingNotifier, *common.FixedClock) {
+	repo := &memoryScheduleRepo{schedules: make(map[int64]digest_domain.Schedule)}
+	for _, s := range schedules {
+		repo.schedules[s.ChatID] = s
+	}
+	// Every scheduled chat recorded the same spending; chat 99 shares the
+	// sheet and its spending must never show up
+	trxs := []transaction_domain.Transaction{
+		{TransactionDate: "2025-07-13", Category: "Eating Out", Amount: "900000", ChatID: 99},
+	}
+	for _, s := range schedules {
+		trxs = append(trxs,
+			transaction_domain.Transaction{TransactionDate: "2025-07-13", Category: "Eating Out", Amount: "35000", ChatID: s.ChatID},
+			transaction_domain.Transaction{TransactionDate: "2025-07-08", Category: "Groceries", Amount: "80000", ChatID: s.ChatID},
+		)
+	}
+	reader := &stubReader{
+		trxs: trxs,
+		summaries: []spreadsheet.CategorySummary{
+			{Category: "Eating Out", BudgetLeft: "500000"},
+			{Category: "Groceries", BudgetLeft: "-20000"},
+			{Category: "Savings", BudgetLeft: "3000000"},
+		},
+	}
+	notifier := &recordingNotifier{}
+	clock := &common.FixedClock{Time: now}
+	return NewDigestService(repo, reader, notifier, clock, "sheet"), notifier, clock
+}
+
+func TestRunDue_SendsDailyOncePerDay(t *testing.T) {
+	s := digest_domain.NewSchedule(7)
+	s.DailyEnabled = true
+	// Sunday 13 July 2025, 21:05 in Asia/Bangkok
+	svc, notifier, clock := newTestService(time.Date(2025, 7, 13, 14, 5, 0, 0, time.UTC), s)
+
+	if err := svc.RunDue(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifier.sent[7]) != 1 {
+		t.Fatalf("expected one digest, got %d", len(notifier.sent[7]))
+	}
+	msg := notifier.sent[7][0]
+	if !strings.Contains(msg, "Daily summary (2025-07-13)") || !strings.Contains(msg, "Rp 35,000") {
+		t.Errorf("unexpected digest text: %s", msg)
+	}
+	if !strings.Contains(msg, "Budget Left: Rp 480,000") {
+		t.Errorf("budget left should include overspent categories: %s", msg)
+	}
+
+	clock.Advance(30 * time.Minute)
+	svc.RunDue(context.Background())
+	if len(notifier.sent[7]) != 1 {
+		t.Errorf("daily digest should not be repeated on the same day, got %d", len(notifier.sent[7]))
+	}
+
+	clock.Advance(24 * time.Hour)
+	svc.RunDue(context.Background())
+	if len(notifier.sent[7]) != 2 {
+		t.Errorf("daily digest should be sent again the next day, got %d", len(notifier.sent[7]))
+	}
+}
+
+func TestRunDue_SendsWeeklyOnSunday(t *testing.T) {
+	s := digest_domain.NewSchedule(9)
+	s.WeeklyEnabled = true
+	svc, notifier, _ := newTestService(time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC), s)
+
+	svc.RunDue(context.Background())
+	if len(notifier.sent[9]) != 1 {
+		t.Fatalf("expected weekly digest, got %d", len(notifier.sent[9]))
+	}
+	msg := notifier.sent[9][0]
+	if !strings.Contains(msg, "Weekly digest (2025-07-07 – 2025-07-13)") || !strings.Contains(msg, "Rp 115,000") {
+		t.Errorf("unexpected weekly digest: %s", msg)
+	}
+	saved, _ := svc.GetSchedule(9)
+	if saved.LastWeekly != "2025-07-13" {
+		t.Errorf("expected delivery to be recorded, got %+v", saved)
+	}
+}
+
+func TestRunDue_ReaderFailureIsNotFatal(t *testing.T) {
+	s := digest_domain.NewSchedule(3)
+	s.DailyEnabled = true
+	svc, notifier, _ := newTestService(time.Date(2025, 7, 13, 15, 0, 0, 0, time.UTC), s)
+	svc.Reader.(*stubReader).err = fmt.Errorf("sheets down")
+
+	if err := svc.RunDue(context.Background()); err != nil {
+		t.Errorf("per-chat failures should not fail the run, got %v", err)
+	}
+	if len(notifier.sent[3]) != 0 {
+		t.Error("no digest should be sent when data cannot be read")
+	}
+	saved, _ := svc.GetSchedule(3)
+	if saved.LastDaily != "" {
+		t.Error("failed delivery must not be recorded as sent")
+	}
+}
+
+func TestRunDue_RecordsDailyWhenWeeklyFails(t *testing.T) {
+	s := digest_domain.NewSchedule(5)
+	s.DailyEnabled = true
+	s.WeeklyEnabled = true
+	// Sunday 13 July 2025, 21:05 in Asia/Bangkok: both digests are due
+	svc, notifier, _ := newTestService(time.Date(2025, 7, 13, 14, 5, 0, 0, time.UTC), s)
+	notifier.onSend = func(text string) error {
+		if strings.Contains(text, "Weekly digest") {
+			return fmt.Errorf("telegram down")
+		}
+		return nil
+	}
+
+	svc.RunDue(context.Background())
+	saved, _ := svc.GetSchedule(5)
+	if saved.LastDaily != "2025-07-13" || saved.LastWeekly != "" {
+		t.Fatalf("expected only the daily delivery to be recorded, got %+v", saved)
+	}
+
+	notifier.onSend = nil
+	svc.RunDue(context.Background())
+	if len(notifier.sent[5]) != 2 || !strings.Contains(notifier.sent[5][1], "Weekly digest") {
+		t.Fatalf("expected the retry to send only the weekly digest, got %q", notifier.sent[5])
+	}
+	saved, _ = svc.GetSchedule(5)
+	if saved.LastWeekly != "2025-07-13" {
+		t.Errorf("expected the weekly delivery to be recorded, got %+v", saved)
+	}
+}
+
+func TestRunDue_KeepsChangesMadeWhileSending(t *testing.T) {
+	s := digest_domain.NewSchedule(6)
+	s.DailyEnabled = true
+	svc, notifier, _ := newTestService(time.Date(2025, 7, 13, 14, 5, 0, 0, time.UTC), s)
+	notifier.onSend = func(text string) error {
+		// The chat runs /digest weekly on while its daily digest goes out
+		changed, _ := svc.GetSchedule(6)
+		changed.WeeklyEnabled = true
+		return svc.SaveSchedule(changed)
+	}
+
+	svc.RunDue(context.Background())
+	saved, _ := svc.GetSchedule(6)
+	if !saved.WeeklyEnabled || saved.LastDaily != "2025-07-13" {
+		t.Errorf("expected both the change and the delivery to be kept, got %+v", saved)
+	}
+}
+
+func TestSaveSchedule_Validation(t *testing.T) {
+	svc, _, _ := newTestService(time.Now())
+	s := digest_domain.NewSchedule(1)
+	s.Timezone = "Nowhere/City"
+	if err := svc.SaveSchedule(s); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+	s = digest_domain.NewSchedule(1)
+	s.DailyTime = "25:00"
+	if err := svc.SaveSchedule(s); err == nil {
+		t.Error("expected error for invalid time")
+	}
+	s = digest_domain.NewSchedule(1)
+	s.Timezone = "Asia/Jakarta"
+	if err := svc.SaveSchedule(s); err != nil {
+		t.Errorf("expected valid schedule to save, got %v", err)
+	}
+}
+
+func TestGetSchedule_DefaultsWhenMissing(t *testing.T) {
+	svc, _, _ := newTestService(time.Now())
+	s, err := svc.GetSchedule(5)
+	if err != nil || s.ChatID != 5 || s.DailyEnabled || s.DailyTime != digest_domain.DefaultDailyTime {
+		t.Errorf("unexpected default schedule: %+v, %v", s, err)
+	}
+}
+
+type chatZones map[int64]*time.Location
+
+func (z chatZones) Location(chatID int64) *time.Location { return z[chatID] }
+
+func TestGetSchedule_StartsInChatTimezone(t *testing.T) {
+	svc, _, _ := newTestService(time.Now())
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	svc.Zones = chatZones{5: jakarta}
+
+	if s, err := svc.GetSchedule(5); err != nil || s.Timezone != "Asia/Jakarta" {
+		t.Errorf("expected the chat's timezone, got %+v, %v", s, err)
+	}
+}
+
+func TestRunDue_FollowsChatTimezone(t *testing.T) {
+	s := digest_domain.NewSchedule(4)
+	s.DailyEnabled = true
+	// 21:05 in the stored Asia/Bangkok, but the chat has since moved to London
+	svc, notifier, clock := newTestService(time.Date(2025, 7, 13, 14, 5, 0, 0, time.UTC), s)
+	london, _ := time.LoadLocation("Europe/London")
+	svc.Zones = chatZones{4: london}
+
+	svc.RunDue(context.Background())
+	if len(notifier.sent[4]) != 0 {
+		t.Fatal("the digest should wait for 21:00 in the chat's timezone")
+	}
+	if saved, _ := svc.GetSchedule(4); saved.Timezone != "Europe/London" {
+		t.Errorf("expected the schedule to show the chat's timezone, got %s", saved.Timezone)
+	}
+
+	clock.Advance(6 * time.Hour)
+	svc.RunDue(context.Background())
+	if len(notifier.sent[4]) != 1 || !strings.Contains(notifier.sent[4][0], "Daily summary (2025-07-13)") {
+		t.Errorf("expected the daily digest at 21:05 in London, got %q", notifier.sent[4])
+	}
+}
+
+type chatLocales map[int64]string
+
+func (l chatLocales) Locale(chatID int64) common.Locale { return common.LocaleOf(l[chatID]) }
+
+func TestBuildDigest_UsesChatLocale(t *testing.T) {
+	s := digest_domain.NewSchedule(9)
+	svc, _, _ := newTestService(time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC), s)
+	svc.Locales = chatLocales{9: "id-ID"}
+
+	msg, err := svc.BuildDigest(context.Background(), 9, digest_domain.KindWeekly)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(msg, "Weekly digest (07/07/2025 – 13/07/2025)") || !strings.Contains(msg, "Rp 115.000") {
+		t.Errorf("expected id-ID dates and amounts, got: %s", msg)
+	}
+}
+
+type chatLanguages map[int64]string
+
+func (l chatLanguages) Language(chatID int64) string { return l[chatID] }
+
+func TestBuildDigest_UsesChatLanguage(t *testing.T) {
+	s := digest_domain.NewSchedule(9)
+	svc, _, _ := newTestService(time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC), s)
+	svc.Languages = chatLanguages{9: "id"}
+
+	msg, err := svc.BuildDigest(context.Background(), 9, digest_domain.KindDaily)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(msg, "🌙 Ringkasan harian (2025-07-13)\nPengeluaran:") || !strings.Contains(msg, "Sisa Anggaran:") {
+		t.Errorf("expected an Indonesian digest, got: %s", msg)
+	}
+}
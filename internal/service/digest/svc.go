@@ -0,0 +1,14 @@
+package digest
+
+import (
+	"context"
+	digest_domain "money-tracker-bot/internal/domain/digest"
+)
+
+type IDigest interface {
+	// GetSchedule returns the digest schedule of a chat, or a disabled default
+	GetSchedule(chatID int64) (digest_domain.Schedule, error)
+	SaveSchedule(schedule digest_domain.Schedule) error
+	// BuildDigest renders the digest of the given kind for a chat as of now
+	BuildDigest(ctx context.Context, chatID int64, kind digest_domain.Kind) (string, error)
+}
@@ -0,0 +1,185 @@
+package goals
+
+// Package goals tracks named savings goals and the transactions contributed to them.
+
+import (
+	"context"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	goals_domain "money-tracker-bot/internal/domain/goals"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"sync"
+	"time"
+)
+
+// Repository persists goals per chat
+type Repository interface {
+	List(chatID int64) ([]goals_domain.Goal, error)
+	Get(chatID int64, name string) (goals_domain.Goal, bool, error)
+	Save(g goals_domain.Goal) error
+	Delete(chatID int64, name string) error
+}
+
+// TransactionReader reads recorded transactions
+type TransactionReader interface {
+	ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error)
+}
+
+// TransactionSaver records a transaction in the spreadsheet
+type TransactionSaver interface {
+	SaveTransaction(ctx context.Context, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error)
+}
+
+type GoalService struct {
+	Repo     Repository
+	Reader   TransactionReader
+	Saver    TransactionSaver
+	Clock    common.Clock
+	Location *time.Location
+	// Zones is optional; it gives each chat its own timezone instead of Location
+	Zones         common.Zones
+	SpreadsheetID string
+
+	// mu serializes the duplicate check and save of Add
+	mu sync.Mutex
+}
+
+func NewGoalService(repo Repository, reader TransactionReader, saver TransactionSaver, clock common.Clock, loc *time.Location, spreadsheetID string) *GoalService {
+	return &GoalService{
+		Repo:          repo,
+		Reader:        reader,
+		Saver:         saver,
+		Clock:         clock,
+		Location:      loc,
+		SpreadsheetID: spreadsheetID,
+	}
+}
+
+func (s *GoalService) Add(g goals_domain.Goal) (goals_domain.Goal, error) {
+	g.Name = goals_domain.NormalizeName(g.Name)
+	if err := g.Validate(); err != nil {
+		return g, errors.NewValidationError(err.Error(), err).WithComponent("goal-service")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Adding a goal again would silently replace its target and deadline
+	if _, found, err := s.Repo.Get(g.ChatID, g.Name); err != nil {
+		return g, err
+	} else if found {
+		return g, errors.NewValidationError("goal "+g.Name+" already exists, remove it first to change it", nil).
+			WithKey("invalid.goal.exists", g.Name).
+			WithContext("goal", g.Name).
+			WithComponent("goal-service")
+	}
+	if err := s.Repo.Save(g); err != nil {
+		return g, err
+	}
+	return g, nil
+}
+
+func (s *GoalService) Remove(chatID int64, name string) error {
+	if _, err := s.get(chatID, name); err != nil {
+		return err
+	}
+	return s.Repo.Delete(chatID, goals_domain.NormalizeName(name))
+}
+
+func (s *GoalService) List(ctx context.Context, chatID int64) ([]goals_domain.Progress, error) {
+	goals, err := s.Repo.List(chatID)
+	if err != nil || len(goals) == 0 {
+		return nil, err
+	}
+	trxs, err := s.chatTransactions(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	today := s.today(chatID)
+	progress := make([]goals_domain.Progress, 0, len(goals))
+	for _, g := range goals {
+		progress = append(progress, goals_domain.ComputeProgress(g, trxs, today))
+	}
+	return progress, nil
+}
+
+func (s *GoalService) Progress(ctx context.Context, chatID int64, name string) (goals_domain.Progress, error) {
+	g, err := s.get(chatID, name)
+	if err != nil {
+		return goals_domain.Progress{}, err
+	}
+	trxs, err := s.chatTransactions(ctx, chatID)
+	if err != nil {
+		return goals_domain.Progress{}, err
+	}
+	return goals_domain.ComputeProgress(g, trxs, s.today(chatID)), nil
+}
+
+func (s *GoalService) Contribute(ctx context.Context, chatID int64, name, amount, user string) (goals_domain.Progress, error) {
+	g, err := s.get(chatID, name)
+	if err != nil {
+		return goals_domain.Progress{}, err
+	}
+	if v, err := transaction_domain.ParseAmount(amount); err != nil || v <= 0 {
+		return goals_domain.Progress{}, errors.NewValidationError("invalid amount", err).
+			WithKey("invalid.goal.invalid_amount").
+			WithContext("amount", amount).
+			WithComponent("goal-service")
+	}
+	trx := transaction_domain.Transaction{
+		TransactionDate: s.today(chatID).Format("2006-01-02"),
+		Amount:          amount,
+		Category:        goals_domain.SavingsCategory,
+		Title:           "Saving for " + g.DisplayName(),
+		Notes:           "Saving for " + g.DisplayName(),
+		CreatedBy:       user,
+		Goal:            g.Name,
+	}
+	// The tenant records the chat on the row, which Progress counts it by
+	if _, err := s.Saver.SaveTransaction(common.WithTenant(ctx, chatID), trx); err != nil {
+		return goals_domain.Progress{}, err
+	}
+	return s.Progress(ctx, chatID, g.Name)
+}
+
+// chatTransactions reads the transactions recorded by chatID. Goal names are
+// per chat, so another chat's goal of the same name must not count.
+func (s *GoalService) chatTransactions(ctx context.Context, chatID int64) ([]transaction_domain.Transaction, error) {
+	trxs, err := s.Reader.ListTransactions(ctx, s.SpreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+	return transaction_domain.OfChat(trxs, chatID), nil
+}
+
+func (s *GoalService) Tag(chatID int64, text string, trx *transaction_domain.Transaction) (bool, error) {
+	name, ok := goals_domain.ExtractTag(text)
+	if !ok {
+		return false, nil
+	}
+	g, found, err := s.Repo.Get(chatID, name)
+	if err != nil || !found {
+		return false, err
+	}
+	trx.Goal = g.Name
+	trx.Category = goals_domain.SavingsCategory
+	return true, nil
+}
+
+// get loads a goal of the chat by name or slug
+func (s *GoalService) get(chatID int64, name string) (goals_domain.Goal, error) {
+	g, found, err := s.Repo.Get(chatID, goals_domain.NormalizeName(name))
+	if err != nil {
+		return g, err
+	}
+	if !found {
+		return g, errors.NewValidationError("goal not found", nil).
+			WithKey("invalid.goal.not_found").
+			WithContext("goal", name).
+			WithComponent("goal-service")
+	}
+	return g, nil
+}
+
+func (s *GoalService) today(chatID int64) time.Time {
+	return s.Clock.Now().In(common.TenantLocation(s.Zones, chatID, s.Location))
+}
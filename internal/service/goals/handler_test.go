@@ -0,0 +1,166 @@
+package goals
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	goals_domain "money-tracker-bot/internal/domain/goals"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"testing"
+	"time"
+)
+
+type memoryRepo struct {
+	goals map[string]goals_domain.Goal
+}
+
+func (m *memoryRepo) key(chatID int64, name string) string {
+	return fmt.Sprintf("%d:%s", chatID, name)
+}
+
+func (m *memoryRepo) List(chatID int64) ([]goals_domain.Goal, error) {
+	var out []goals_domain.Goal
+	for _, g := range m.goals {
+		if g.ChatID == chatID {
+			out = append(out, g)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryRepo) Get(chatID int64, name string) (goals_domain.Goal, bool, error) {
+	g, ok := m.goals[m.key(chatID, name)]
+	return g, ok, nil
+}
+
+func (m *memoryRepo) Save(g goals_domain.Goal) error {
+	m.goals[m.key(g.ChatID, g.Name)] = g
+	return nil
+}
+
+func (m *memoryRepo) Delete(chatID int64, name string) error {
+	delete(m.goals, m.key(chatID, name))
+	return nil
+}
+
+// sheetStub stores saved transactions so they can be read back
+type sheetStub struct {
+	trxs []transaction_domain.Transaction
+}
+
+func (s *sheetStub) ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error) {
+	return s.trxs, nil
+}
+
+func (s *sheetStub) SaveTransaction(ctx context.Context, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
+	// The sheet records the chat of the tenant, as AppendRow does
+	trx.ChatID, _ = common.TenantFrom(ctx)
+	s.trxs = append(s.trxs, trx)
+	return spreadsheet.CategorySummary{}, nil
+}
+
+func newTestService() (*GoalService, *sheetStub) {
+	sheet := &sheetStub{}
+	clock := &common.FixedClock{Time: time.Date(2025, 7, 20, 10, 0, 0, 0, time.UTC)}
+	return NewGoalService(&memoryRepo{goals: make(map[string]goals_domain.Goal)}, sheet, sheet, clock, time.UTC, "sheet"), sheet
+}
+
+func TestAddAndContribute(t *testing.T) {
+	svc, sheet := newTestService()
+	g, err := svc.Add(goals_domain.Goal{ChatID: 1, Name: "Bali Trip", Target: "12,000,000", Deadline: "2025-12-31"})
+	if err != nil || g.Name != "bali-trip" {
+		t.Fatalf("unexpected goal: %+v, %v", g, err)
+	}
+
+	p, err := svc.Contribute(context.Background(), 1, "#bali-trip", "3,000,000", "rompi")
+	if err != nil {
+		t.Fatalf("contribute failed: %v", err)
+	}
+	if p.Saved != 3000000 || p.MonthlyRequired != 1500000 {
+		t.Errorf("unexpected progress: %+v", p)
+	}
+	saved := sheet.trxs[0]
+	if saved.Goal != "bali-trip" || saved.Category != goals_domain.SavingsCategory || saved.TransactionDate != "2025-07-20" || saved.CreatedBy != "rompi" {
+		t.Errorf("unexpected contribution: %+v", saved)
+	}
+}
+
+func TestProgress_OnlyCountsOwnChat(t *testing.T) {
+	svc, _ := newTestService()
+	for _, chatID := range []int64{1, 2} {
+		svc.Add(goals_domain.Goal{ChatID: chatID, Name: "bali", Target: "5000000", Deadline: "2025-12-31"})
+	}
+	svc.Contribute(context.Background(), 1, "bali", "1,000,000", "rompi")
+	svc.Contribute(context.Background(), 2, "bali", "4,000,000", "budi")
+
+	p, err := svc.Progress(context.Background(), 1, "bali")
+	if err != nil || p.Saved != 1000000 {
+		t.Errorf("expected only chat 1's contribution, got %+v, %v", p, err)
+	}
+	list, err := svc.List(context.Background(), 2)
+	if err != nil || len(list) != 1 || list[0].Saved != 4000000 {
+		t.Errorf("expected only chat 2's contribution, got %+v, %v", list, err)
+	}
+}
+
+func TestContribute_Validation(t *testing.T) {
+	svc, _ := newTestService()
+	svc.Add(goals_domain.Goal{ChatID: 1, Name: "car", Target: "100000000", Deadline: "2027-01-01"})
+	if _, err := svc.Contribute(context.Background(), 1, "car", "lots", "rompi"); err == nil {
+		t.Error("expected error for invalid amount")
+	}
+	if _, err := svc.Contribute(context.Background(), 2, "car", "1000", "rompi"); err == nil {
+		t.Error("expected error for another chat's goal")
+	}
+	if _, err := svc.Add(goals_domain.Goal{ChatID: 1, Name: "car", Target: "1000", Deadline: "soon"}); err == nil {
+		t.Error("expected error for invalid deadline")
+	}
+}
+
+func TestAdd_RejectsDuplicate(t *testing.T) {
+	svc, _ := newTestService()
+	svc.Add(goals_domain.Goal{ChatID: 1, Name: "car", Target: "100000000", Deadline: "2027-01-01"})
+	_, err := svc.Add(goals_domain.Goal{ChatID: 1, Name: "Car", Target: "1000", Deadline: "2026-01-01"})
+	if appErr, ok := err.(*errors.AppError); !ok || appErr.Key != "invalid.goal.exists" {
+		t.Fatalf("expected a duplicate goal error, got %v", err)
+	}
+	if p, _ := svc.Progress(context.Background(), 1, "car"); p.Target != 100000000 {
+		t.Errorf("the existing goal should be kept, got %+v", p)
+	}
+	if _, err := svc.Add(goals_domain.Goal{ChatID: 2, Name: "car", Target: "1000", Deadline: "2026-01-01"}); err != nil {
+		t.Errorf("another chat may use the same name, got %v", err)
+	}
+}
+
+func TestTag(t *testing.T) {
+	svc, _ := newTestService()
+	svc.Add(goals_domain.Goal{ChatID: 1, Name: "bali", Target: "5000000", Deadline: "2025-12-31"})
+
+	trx := transaction_domain.Transaction{Category: "Household"}
+	tagged, err := svc.Tag(1, "nabung 500k #Bali", &trx)
+	if err != nil || !tagged || trx.Goal != "bali" || trx.Category != goals_domain.SavingsCategory {
+		t.Errorf("expected transaction to be tagged, got %+v, %v, %v", trx, tagged, err)
+	}
+
+	other := transaction_domain.Transaction{Category: "Eating Out"}
+	if tagged, _ := svc.Tag(1, "dinner #weekend", &other); tagged || other.Goal != "" || other.Category != "Eating Out" {
+		t.Errorf("unknown tags must leave the transaction untouched, got %+v", other)
+	}
+}
+
+func TestListAndRemove(t *testing.T) {
+	svc, _ := newTestService()
+	svc.Add(goals_domain.Goal{ChatID: 1, Name: "bali", Target: "5000000", Deadline: "2025-12-31"})
+	list, err := svc.List(context.Background(), 1)
+	if err != nil || len(list) != 1 || list[0].Remaining != 5000000 {
+		t.Errorf("unexpected list: %+v, %v", list, err)
+	}
+	if err := svc.Remove(1, "bali"); err != nil {
+		t.Errorf("expected removal, got %v", err)
+	}
+	if err := svc.Remove(1, "bali"); err == nil {
+		t.Error("removing a missing goal should fail")
+	}
+}
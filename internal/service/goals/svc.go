@@ -0,0 +1,19 @@
+package goals
+
+import (
+	"context"
+	goals_domain "money-tracker-bot/internal/domain/goals"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+type IGoals interface {
+	Add(g goals_domain.Goal) (goals_domain.Goal, error)
+	Remove(chatID int64, name string) error
+	// List returns the progress of every goal of a chat
+	List(ctx context.Context, chatID int64) ([]goals_domain.Progress, error)
+	Progress(ctx context.Context, chatID int64, name string) (goals_domain.Progress, error)
+	// Contribute records a savings transaction tagged with the goal
+	Contribute(ctx context.Context, chatID int64, name, amount, user string) (goals_domain.Progress, error)
+	// Tag marks trx as a contribution when text carries the #tag of an existing goal
+	Tag(chatID int64, text string, trx *transaction_domain.Transaction) (bool, error)
+}
@@ -0,0 +1,165 @@
+package learning
+
+// Package learning remembers the category corrections of each chat and feeds
+// them back into extraction.
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	category_domain "money-tracker-bot/internal/domain/category"
+	learning_domain "money-tracker-bot/internal/domain/learning"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"sync"
+	"time"
+)
+
+// MaxPromptExamples caps the learned merchants sent with each prompt
+const MaxPromptExamples = 20
+
+// Repository persists what each chat has taught the bot
+type Repository interface {
+	Get(chatID int64) (learning_domain.Memory, error)
+	Save(chatID int64, m learning_domain.Memory) error
+}
+
+// RowCorrector changes the category of a recorded transaction
+type RowCorrector interface {
+	Recategorize(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction, to category_domain.Ref) (bool, error)
+}
+
+// observed is the last transaction saved in a chat
+type observed struct {
+	trx       transaction_domain.Transaction
+	corrected bool
+}
+
+type LearningService struct {
+	Repo     Repository
+	Sheets   RowCorrector
+	Clock    common.Clock
+	Location *time.Location
+	// Zones is optional; it gives each chat its own timezone instead of Location
+	Zones         common.Zones
+	SpreadsheetID string
+
+	// mu serializes reads and rewrites of a chat's memory and guards last
+	mu   sync.Mutex
+	last map[int64]*observed
+}
+
+func NewLearningService(repo Repository, sheets RowCorrector, clock common.Clock, loc *time.Location, spreadsheetID string) *LearningService {
+	return &LearningService{
+		Repo:          repo,
+		Sheets:        sheets,
+		Clock:         clock,
+		Location:      loc,
+		SpreadsheetID: spreadsheetID,
+		last:          make(map[int64]*observed),
+	}
+}
+
+func (s *LearningService) Observe(chatID int64, trx transaction_domain.Transaction) {
+	// Goal contributions and transfers have fixed categories
+	if trx.Goal != "" || trx.IsTransfer() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last[chatID] = &observed{trx: trx}
+
+	m, err := s.Repo.Get(chatID)
+	if err == nil {
+		m.Stats.Categorized++
+		err = s.Repo.Save(chatID, m)
+	}
+	if err != nil {
+		// The transaction is saved; only the statistics are lost
+		errors.HandleError(err, "counting categorized transaction")
+	}
+}
+
+func (s *LearningService) Correct(ctx context.Context, chatID int64, to category_domain.Ref, merchant string) (learning_domain.Correction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last, ok := s.last[chatID]
+	if !ok {
+		return learning_domain.Correction{}, errors.NewValidationError("no recent transaction to correct, send one first", nil).
+			WithKey("invalid.learning.no_recent").
+			WithComponent("learning-service")
+	}
+	from := category_domain.Ref{Category: last.trx.Category, Subcategory: last.trx.Subcategory}
+	if from == to {
+		return learning_domain.Correction{}, errors.NewValidationError("the last transaction is already in "+to.String(), nil).
+			WithKey("invalid.learning.already_in", to.String()).
+			WithComponent("learning-service")
+	}
+	if merchant == "" {
+		merchant = learning_domain.MerchantOf(last.trx)
+	}
+	if learning_domain.NormalizeMerchant(merchant) == "" {
+		return learning_domain.Correction{}, errors.NewValidationError("could not tell the merchant, add merchant=<name>", nil).
+			WithKey("invalid.learning.no_merchant").
+			WithComponent("learning-service")
+	}
+
+	// Only a row of this chat may be changed
+	found, err := s.Sheets.Recategorize(common.WithTenant(ctx, chatID), s.SpreadsheetID, last.trx, to)
+	if err != nil {
+		return learning_domain.Correction{}, err
+	}
+	if !found {
+		return learning_domain.Correction{}, errors.NewValidationError("the last transaction is no longer in the spreadsheet", nil).
+			WithKey("invalid.learning.not_in_sheet").
+			WithComponent("learning-service")
+	}
+	last.trx.Category, last.trx.Subcategory = to.Category, to.Subcategory
+
+	m, err := s.Repo.Get(chatID)
+	if err != nil {
+		return learning_domain.Correction{}, err
+	}
+	mapping, err := m.Learn(merchant, to, s.today(chatID).Format("2006-01-02"))
+	if err != nil {
+		return learning_domain.Correction{}, errors.NewValidationError(err.Error(), err).WithComponent("learning-service")
+	}
+	// Correcting the same transaction twice counts once towards the rate
+	if !last.corrected {
+		m.Stats.Corrected++
+		last.corrected = true
+	}
+	if err := s.Repo.Save(chatID, m); err != nil {
+		return learning_domain.Correction{}, err
+	}
+	return learning_domain.Correction{Merchant: mapping.Merchant, From: from, To: to}, nil
+}
+
+func (s *LearningService) Memory(chatID int64) (learning_domain.Memory, error) {
+	return s.Repo.Get(chatID)
+}
+
+func (s *LearningService) PromptExamples(chatID int64) ([]string, error) {
+	m, err := s.Repo.Get(chatID)
+	if err != nil {
+		return nil, err
+	}
+	return m.Examples(MaxPromptExamples), nil
+}
+
+func (s *LearningService) Recall(chatID int64, trx *transaction_domain.Transaction) (bool, error) {
+	m, err := s.Repo.Get(chatID)
+	if err != nil {
+		return false, err
+	}
+	mapping, ok := m.Lookup(*trx)
+	if !ok {
+		return false, nil
+	}
+	trx.Category, trx.Subcategory = mapping.Category, mapping.Subcategory
+	return true, nil
+}
+
+func (s *LearningService) today(chatID int64) time.Time {
+	return s.Clock.Now().In(common.TenantLocation(s.Zones, chatID, s.Location))
+}
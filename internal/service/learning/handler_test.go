@@ -0,0 +1,142 @@
+package learning
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/common"
+	category_domain "money-tracker-bot/internal/domain/category"
+	learning_domain "money-tracker-bot/internal/domain/learning"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"testing"
+	"time"
+)
+
+type memoryRepo struct {
+	memories map[int64]learning_domain.Memory
+}
+
+func (m *memoryRepo) Get(chatID int64) (learning_domain.Memory, error) {
+	return m.memories[chatID], nil
+}
+
+func (m *memoryRepo) Save(chatID int64, mem learning_domain.Memory) error {
+	m.memories[chatID] = mem
+	return nil
+}
+
+type mockSheets struct {
+	found   bool
+	err     error
+	calls   int
+	lastTrx transaction_domain.Transaction
+	lastTo  category_domain.Ref
+}
+
+func (m *mockSheets) Recategorize(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction, to category_domain.Ref) (bool, error) {
+	m.calls++
+	m.lastTrx, m.lastTo = trx, to
+	return m.found, m.err
+}
+
+func newTestService() (*LearningService, *memoryRepo, *mockSheets) {
+	repo := &memoryRepo{memories: make(map[int64]learning_domain.Memory)}
+	sheets := &mockSheets{found: true}
+	clock := &common.FixedClock{Time: time.Date(2025, 7, 20, 10, 0, 0, 0, time.UTC)}
+	return NewLearningService(repo, sheets, clock, time.UTC, "sheet"), repo, sheets
+}
+
+func TestCorrectLearnsMerchant(t *testing.T) {
+	svc, repo, sheets := newTestService()
+	svc.Observe(1, transaction_domain.Transaction{Title: "Superindo", Category: "Household", Amount: "150,000"})
+
+	groceries := category_domain.Ref{Category: "Groceries"}
+	c, err := svc.Correct(context.Background(), 1, groceries, "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if c.Merchant != "Superindo" || c.From.Category != "Household" || c.To != groceries {
+		t.Errorf("unexpected correction %+v", c)
+	}
+	if sheets.lastTrx.Category != "Household" || sheets.lastTo != groceries {
+		t.Errorf("the recorded row should be moved, got %+v → %+v", sheets.lastTrx, sheets.lastTo)
+	}
+
+	// Correcting again moves the row from its new category and counts once
+	coffee := category_domain.Ref{Category: "Eating Out", Subcategory: "Coffee"}
+	if _, err := svc.Correct(context.Background(), 1, coffee, "superindo"); err != nil {
+		t.Fatal(err)
+	}
+	if sheets.lastTrx.Category != "Groceries" {
+		t.Errorf("expected second correction to start from Groceries, got %+v", sheets.lastTrx)
+	}
+	m := repo.memories[1]
+	if m.Stats.Categorized != 1 || m.Stats.Corrected != 1 || len(m.Mappings) != 1 || m.Mappings[0].Ref() != coffee {
+		t.Errorf("unexpected memory %+v", m)
+	}
+	if m.Mappings[0].LastCorrected != "2025-07-20" {
+		t.Errorf("expected correction date, got %q", m.Mappings[0].LastCorrected)
+	}
+}
+
+func TestCorrectErrors(t *testing.T) {
+	svc, repo, sheets := newTestService()
+	groceries := category_domain.Ref{Category: "Groceries"}
+	if _, err := svc.Correct(context.Background(), 1, groceries, ""); err == nil {
+		t.Error("expected error without a transaction")
+	}
+
+	svc.Observe(1, transaction_domain.Transaction{Category: "Groceries", Title: "Superindo"})
+	if _, err := svc.Correct(context.Background(), 1, groceries, ""); err == nil {
+		t.Error("expected error when the category does not change")
+	}
+
+	svc.Observe(1, transaction_domain.Transaction{Category: "Household"})
+	if _, err := svc.Correct(context.Background(), 1, groceries, ""); err == nil {
+		t.Error("expected error without a merchant")
+	}
+
+	sheets.found = false
+	if _, err := svc.Correct(context.Background(), 1, groceries, "Superindo"); err == nil {
+		t.Error("expected error when the row is gone")
+	}
+	sheets.found, sheets.err = true, fmt.Errorf("sheets down")
+	if _, err := svc.Correct(context.Background(), 1, groceries, "Superindo"); err == nil {
+		t.Error("expected spreadsheet error")
+	}
+	if len(repo.memories[1].Mappings) != 0 || repo.memories[1].Stats.Corrected != 0 {
+		t.Errorf("failed corrections must not be learned, got %+v", repo.memories[1])
+	}
+}
+
+func TestObserveSkipsGoalsAndTransfers(t *testing.T) {
+	svc, repo, _ := newTestService()
+	svc.Observe(1, transaction_domain.Transaction{Category: "Savings", Goal: "bali"})
+	svc.Observe(1, transaction_domain.Transaction{Category: transaction_domain.TransferCategory})
+	if repo.memories[1].Stats.Categorized != 0 {
+		t.Errorf("goal contributions and transfers should not be counted, got %+v", repo.memories[1].Stats)
+	}
+	if _, err := svc.Correct(context.Background(), 1, category_domain.Ref{Category: "Groceries"}, "x"); err == nil {
+		t.Error("goal contributions and transfers should not be correctable")
+	}
+}
+
+func TestRecallAndPromptExamples(t *testing.T) {
+	svc, repo, _ := newTestService()
+	repo.memories[1] = learning_domain.Memory{Mappings: []learning_domain.Mapping{
+		{Merchant: "Superindo", Category: "Groceries", Corrections: 2},
+	}}
+
+	trx := transaction_domain.Transaction{DestinationName: "SUPERINDO Kemang", Category: "Household"}
+	if ok, err := svc.Recall(1, &trx); err != nil || !ok || trx.Category != "Groceries" {
+		t.Errorf("expected learned category, got %+v, %v, %v", trx, ok, err)
+	}
+	other := transaction_domain.Transaction{DestinationName: "Superindo", Category: "Household"}
+	if ok, _ := svc.Recall(2, &other); ok || other.Category != "Household" {
+		t.Error("another chat's corrections must not apply")
+	}
+
+	lines, err := svc.PromptExamples(1)
+	if err != nil || len(lines) != 1 || lines[0] != `"Superindo" → Groceries` {
+		t.Errorf("unexpected examples %v, %v", lines, err)
+	}
+}
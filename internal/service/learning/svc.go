@@ -0,0 +1,22 @@
+package learning
+
+import (
+	"context"
+	category_domain "money-tracker-bot/internal/domain/category"
+	learning_domain "money-tracker-bot/internal/domain/learning"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+type ILearning interface {
+	// Observe counts a saved transaction and makes it the one /correct changes
+	Observe(chatID int64, trx transaction_domain.Transaction)
+	// Correct moves the last observed transaction to another category and
+	// learns the merchant; an empty merchant is taken from the transaction
+	Correct(ctx context.Context, chatID int64, to category_domain.Ref, merchant string) (learning_domain.Correction, error)
+	// Memory returns the correction stats and learned merchants of a chat
+	Memory(chatID int64) (learning_domain.Memory, error)
+	// PromptExamples returns the learned merchants as few-shot prompt lines
+	PromptExamples(chatID int64) ([]string, error)
+	// Recall sets the learned category of a transaction's merchant
+	Recall(chatID int64, trx *transaction_domain.Transaction) (bool, error)
+}
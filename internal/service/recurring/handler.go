@@ -0,0 +1,311 @@
+package recurring
+
+// Package recurring posts monthly recurring transactions, sends bill reminders
+// and suggests new recurring entries from repeated expenses.
+
+import (
+	"context"
+	"log"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	recurring_domain "money-tracker-bot/internal/domain/recurring"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"money-tracker-bot/internal/port/out/notify"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CallbackPrefix namespaces the callback data of reminder buttons
+const CallbackPrefix = "recurring:"
+
+// Repository persists recurring entries
+type Repository interface {
+	List(chatID int64) ([]recurring_domain.Recurring, error)
+	ListAll() ([]recurring_domain.Recurring, error)
+	Get(id string) (recurring_domain.Recurring, bool, error)
+	// Save stores r, assigning a new ID when r.ID is empty
+	Save(r recurring_domain.Recurring) (recurring_domain.Recurring, error)
+	Delete(id string) error
+}
+
+// TransactionSaver records a transaction in the spreadsheet
+type TransactionSaver interface {
+	SaveTransaction(ctx context.Context, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error)
+}
+
+// TransactionReader reads recorded transactions
+type TransactionReader interface {
+	ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error)
+}
+
+type RecurringService struct {
+	Repo     Repository
+	Saver    TransactionSaver
+	Reader   TransactionReader
+	Notifier notify.ButtonNotifier
+	Clock    common.Clock
+	Location *time.Location
+	// Zones is optional; it gives each chat its own timezone instead of Location
+	Zones common.Zones
+	// Locales is optional; reminders use common.DefaultLocale when nil
+	Locales common.TenantLocales
+	// Languages is optional; reminders are written in i18n.Default when nil
+	// or when the chat has not chosen a language
+	Languages     i18n.TenantLanguages
+	SpreadsheetID string
+
+	// mu serializes entry writes, so the scheduler and /recurring changes
+	// never overwrite each other or hand out the same ID twice
+	mu sync.Mutex
+}
+
+func NewRecurringService(repo Repository, saver TransactionSaver, reader TransactionReader, notifier notify.ButtonNotifier, clock common.Clock, loc *time.Location, spreadsheetID string) *RecurringService {
+	return &RecurringService{
+		Repo:          repo,
+		Saver:         saver,
+		Reader:        reader,
+		Notifier:      notifier,
+		Clock:         clock,
+		Location:      loc,
+		SpreadsheetID: spreadsheetID,
+	}
+}
+
+func (s *RecurringService) Add(r recurring_domain.Recurring) (recurring_domain.Recurring, error) {
+	mode, err := recurring_domain.ParseMode(string(r.Mode))
+	if err != nil {
+		return r, errors.NewValidationError(err.Error(), err).WithComponent("recurring-service")
+	}
+	r.Mode = mode
+	r.ID = ""
+	if err := r.Validate(); err != nil {
+		return r, errors.NewValidationError(err.Error(), err).WithComponent("recurring-service")
+	}
+	r.NormalizeAmount()
+	// An entry created after this month's due date starts next month; one
+	// created on its due day is still processed today
+	today := s.today(r.ChatID)
+	if today.After(r.DueDate(today)) {
+		if r.Mode == recurring_domain.ModeAuto {
+			r.LastPosted = recurring_domain.Period(today)
+		} else {
+			r.LastReminded = recurring_domain.Period(today)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Repo.Save(r)
+}
+
+func (s *RecurringService) List(chatID int64) ([]recurring_domain.Recurring, error) {
+	return s.Repo.List(chatID)
+}
+
+func (s *RecurringService) Remove(chatID int64, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.get(chatID, id); err != nil {
+		return err
+	}
+	return s.Repo.Delete(id)
+}
+
+func (s *RecurringService) Confirm(ctx context.Context, chatID int64, id, period string) (transaction_domain.Transaction, error) {
+	r, err := s.get(chatID, id)
+	if err != nil {
+		return transaction_domain.Transaction{}, err
+	}
+	if r.LastPosted == period {
+		return transaction_domain.Transaction{}, errors.NewValidationError("already recorded for this month", nil).
+			WithKey("invalid.recurring.already_recorded").
+			WithContext("recurring_id", id).
+			WithContext("period", period).
+			WithComponent("recurring-service")
+	}
+	return s.post(ctx, r, period)
+}
+
+func (s *RecurringService) Skip(chatID int64, id, period string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, err := s.get(chatID, id)
+	if err != nil {
+		return err
+	}
+	r.LastPosted = period
+	_, err = s.Repo.Save(r)
+	return err
+}
+
+func (s *RecurringService) Suggest(ctx context.Context, chatID int64) ([]recurring_domain.Suggestion, error) {
+	trxs, err := s.Reader.ListTransactions(ctx, s.SpreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.Repo.List(chatID)
+	if err != nil {
+		return nil, err
+	}
+	return recurring_domain.DetectRecurring(transaction_domain.OfChat(trxs, chatID), existing), nil
+}
+
+// AcceptSuggestion adds the suggestion with key as detected now. A key that
+// no longer matches, because the suggestion was accepted or its amount
+// changed, is rejected rather than adding another one.
+func (s *RecurringService) AcceptSuggestion(ctx context.Context, chatID int64, key string, user string) (recurring_domain.Recurring, error) {
+	suggestions, err := s.Suggest(ctx, chatID)
+	if err != nil {
+		return recurring_domain.Recurring{}, err
+	}
+	var sg recurring_domain.Suggestion
+	found := false
+	for _, candidate := range suggestions {
+		if candidate.Key() == key {
+			sg, found = candidate, true
+			break
+		}
+	}
+	if !found {
+		return recurring_domain.Recurring{}, errors.NewValidationError("suggestion no longer available", nil).
+			WithKey("invalid.recurring.suggestion_gone").
+			WithContext("key", key).
+			WithComponent("recurring-service")
+	}
+	return s.Add(recurring_domain.Recurring{
+		ChatID:     chatID,
+		Title:      sg.Title,
+		Amount:     strconv.FormatFloat(sg.Amount, 'f', -1, 64),
+		Category:   sg.Category,
+		DayOfMonth: sg.DayOfMonth,
+		Mode:       recurring_domain.ModeRemind,
+		CreatedBy:  user,
+	})
+}
+
+// RunDue posts automatic entries and sends reminders for entries due today.
+// A failure for one entry does not prevent the others.
+func (s *RecurringService) RunDue(ctx context.Context) error {
+	entries, err := s.Repo.ListAll()
+	if err != nil {
+		return err
+	}
+	for _, r := range entries {
+		today := s.today(r.ChatID)
+		if !r.IsDue(today) {
+			continue
+		}
+		// The entry's chat decides the timezone of the spreadsheet row
+		if err := s.process(common.WithTenant(ctx, r.ChatID), r, recurring_domain.Period(today)); err != nil {
+			errors.HandleErrorContext(ctx, err, "processing recurring transaction")
+		}
+	}
+	return nil
+}
+
+// Run checks for due entries every interval until ctx is cancelled.
+func (s *RecurringService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.RunDue(ctx); err != nil {
+			errors.HandleError(err, "running recurring scheduler")
+		}
+		select {
+		case <-ctx.Done():
+			log.Println("Recurring scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *RecurringService) process(ctx context.Context, r recurring_domain.Recurring, period string) error {
+	p := i18n.Tenant(ctx, s.Languages, r.ChatID)
+	locale := common.TenantLocale(s.Locales, r.ChatID)
+	if r.Mode == recurring_domain.ModeAuto {
+		trx, err := s.post(ctx, r, period)
+		if err != nil {
+			return err
+		}
+		return s.Notifier.SendText(r.ChatID, p.T("recurring.recorded",
+			trx.Title, locale.FormatAmount(trx.AmountValue()), locale.FormatDate(trx.TransactionDate)))
+	}
+
+	text := p.T("recurring.due", titleOf(r), locale.FormatAmount(amountOf(r)), r.Category)
+	buttons := []notify.Button{
+		{Label: p.T("recurring.confirm"), Data: CallbackPrefix + "confirm:" + r.ID + ":" + period},
+		{Label: p.T("recurring.skip"), Data: CallbackPrefix + "skip:" + r.ID + ":" + period},
+	}
+	if err := s.Notifier.SendWithButtons(r.ChatID, text, buttons); err != nil {
+		return err
+	}
+	return s.mark(r.ID, func(stored *recurring_domain.Recurring) { stored.LastReminded = period })
+}
+
+func (s *RecurringService) post(ctx context.Context, r recurring_domain.Recurring, period string) (transaction_domain.Transaction, error) {
+	trx, err := r.Transaction(period)
+	if err != nil {
+		return trx, errors.NewValidationError("invalid recurring period", err).
+			WithKey("invalid.recurring.invalid_period", period).
+			WithContext("period", period).
+			WithComponent("recurring-service")
+	}
+	if _, err := s.Saver.SaveTransaction(ctx, trx); err != nil {
+		return trx, err
+	}
+	if err := s.mark(r.ID, func(stored *recurring_domain.Recurring) { stored.LastPosted = period }); err != nil {
+		return trx, err
+	}
+	return trx, nil
+}
+
+// mark applies a change to the stored entry with id. It is read again rather
+// than saved from the copy that was processed, which would undo a /recurring
+// change made in the meantime or bring back a removed entry.
+func (s *RecurringService) mark(id string, apply func(*recurring_domain.Recurring)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, found, err := s.Repo.Get(id)
+	if err != nil || !found {
+		return err
+	}
+	apply(&stored)
+	_, err = s.Repo.Save(stored)
+	return err
+}
+
+// get loads an entry and checks it belongs to the chat
+func (s *RecurringService) get(chatID int64, id string) (recurring_domain.Recurring, error) {
+	r, found, err := s.Repo.Get(id)
+	if err != nil {
+		return r, err
+	}
+	if !found || r.ChatID != chatID {
+		return r, errors.NewValidationError("recurring transaction not found", nil).
+			WithKey("invalid.recurring.not_found").
+			WithContext("recurring_id", id).
+			WithComponent("recurring-service")
+	}
+	return r, nil
+}
+
+func (s *RecurringService) today(chatID int64) time.Time {
+	loc := common.TenantLocation(s.Zones, chatID, s.Location)
+	now := s.Clock.Now().In(loc)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+}
+
+func titleOf(r recurring_domain.Recurring) string {
+	if r.Title != "" {
+		return r.Title
+	}
+	return r.Category
+}
+
+func amountOf(r recurring_domain.Recurring) float64 {
+	v, _ := transaction_domain.ParseAmount(r.Amount)
+	return v
+}
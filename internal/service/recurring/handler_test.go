@@ -0,0 +1,332 @@
+package recurring
+
+import (
+	"context"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	recurring_domain "money-tracker-bot/internal/domain/recurring"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/port/out/notify"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryRepo struct {
+	items  map[string]recurring_domain.Recurring
+	nextID int
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{items: make(map[string]recurring_domain.Recurring), nextID: 1}
+}
+
+func (m *memoryRepo) List(chatID int64) ([]recurring_domain.Recurring, error) {
+	var out []recurring_domain.Recurring
+	for _, r := range m.items {
+		if r.ChatID == chatID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryRepo) ListAll() ([]recurring_domain.Recurring, error) {
+	var out []recurring_domain.Recurring
+	for _, r := range m.items {
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func (m *memoryRepo) Get(id string) (recurring_domain.Recurring, bool, error) {
+	r, ok := m.items[id]
+	return r, ok, nil
+}
+
+func (m *memoryRepo) Save(r recurring_domain.Recurring) (recurring_domain.Recurring, error) {
+	if r.ID == "" {
+		r.ID = "r" + strconv.Itoa(m.nextID)
+		m.nextID++
+	}
+	m.items[r.ID] = r
+	return r, nil
+}
+
+func (m *memoryRepo) Delete(id string) error {
+	delete(m.items, id)
+	return nil
+}
+
+type recordingSaver struct {
+	saved   []transaction_domain.Transaction
+	tenants []int64
+}
+
+func (r *recordingSaver) SaveTransaction(ctx context.Context, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
+	r.saved = append(r.saved, trx)
+	if chatID, ok := common.TenantFrom(ctx); ok {
+		r.tenants = append(r.tenants, chatID)
+	}
+	return spreadsheet.CategorySummary{}, nil
+}
+
+type stubReader struct {
+	trxs []transaction_domain.Transaction
+}
+
+func (s *stubReader) ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error) {
+	return s.trxs, nil
+}
+
+type recordingNotifier struct {
+	texts   []string
+	buttons [][]notify.Button
+}
+
+func (r *recordingNotifier) SendText(chatID int64, text string) error {
+	r.texts = append(r.texts, text)
+	return nil
+}
+
+func (r *recordingNotifier) SendWithButtons(chatID int64, text string, buttons []notify.Button) error {
+	r.texts = append(r.texts, text)
+	r.buttons = append(r.buttons, buttons)
+	return nil
+}
+
+func newTestService(now time.Time) (*RecurringService, *recordingSaver, *recordingNotifier, *common.FixedClock) {
+	saver := &recordingSaver{}
+	notifier := &recordingNotifier{}
+	clock := &common.FixedClock{Time: now}
+	svc := NewRecurringService(newMemoryRepo(), saver, &stubReader{}, notifier, clock, time.UTC, "sheet")
+	return svc, saver, notifier, clock
+}
+
+func TestRunDue_AutoPostsOncePerMonth(t *testing.T) {
+	svc, saver, notifier, clock := newTestService(time.Date(2025, 7, 1, 8, 0, 0, 0, time.UTC))
+	if _, err := svc.Add(recurring_domain.Recurring{ChatID: 1, Title: "Rent", Amount: "5000000", Category: "Rent House", DayOfMonth: 2, Mode: recurring_domain.ModeAuto}); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+
+	svc.RunDue(context.Background())
+	if len(saver.saved) != 0 {
+		t.Fatal("nothing should be posted before the due day")
+	}
+
+	clock.Advance(24 * time.Hour)
+	svc.RunDue(context.Background())
+	svc.RunDue(context.Background())
+	if len(saver.saved) != 1 {
+		t.Fatalf("expected one posting, got %d", len(saver.saved))
+	}
+	if saver.saved[0].TransactionDate != "2025-07-02" {
+		t.Errorf("unexpected transaction: %+v", saver.saved[0])
+	}
+	if len(notifier.texts) != 1 || !strings.Contains(notifier.texts[0], "Recorded recurring") {
+		t.Errorf("expected posting notification, got %v", notifier.texts)
+	}
+}
+
+type zones map[int64]*time.Location
+
+func (z zones) Location(chatID int64) *time.Location { return z[chatID] }
+
+func TestRunDue_FollowsChatTimezone(t *testing.T) {
+	svc, saver, _, clock := newTestService(time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC))
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	svc.Zones = zones{1: jakarta}
+	for _, chatID := range []int64{1, 2} {
+		if _, err := svc.Add(recurring_domain.Recurring{ChatID: chatID, Title: "Rent", Amount: "5000000", Category: "Rent House", DayOfMonth: 2, Mode: recurring_domain.ModeAuto}); err != nil {
+			t.Fatalf("add failed: %v", err)
+		}
+	}
+
+	// 20:00 UTC on 1 July is already 2 July in Jakarta
+	clock.Advance(8 * time.Hour)
+	svc.RunDue(context.Background())
+	if len(saver.saved) != 1 || saver.saved[0].TransactionDate != "2025-07-02" {
+		t.Fatalf("expected only the Jakarta chat to be due, got %+v", saver.saved)
+	}
+	if len(saver.tenants) != 1 || saver.tenants[0] != 1 {
+		t.Errorf("expected the posting to carry its chat, got %v", saver.tenants)
+	}
+}
+
+func TestRunDue_RemindAndConfirm(t *testing.T) {
+	svc, saver, notifier, clock := newTestService(time.Date(2025, 6, 30, 8, 0, 0, 0, time.UTC))
+	r, _ := svc.Add(recurring_domain.Recurring{ChatID: 1, Title: "PLN", Amount: "450000", Category: "Utilities", DayOfMonth: 1})
+	if r.Mode != recurring_domain.ModeRemind {
+		t.Errorf("expected remind mode by default, got %s", r.Mode)
+	}
+
+	clock.Advance(24 * time.Hour)
+	svc.RunDue(context.Background())
+	svc.RunDue(context.Background())
+	if len(notifier.buttons) != 1 {
+		t.Fatalf("expected a single reminder, got %d", len(notifier.buttons))
+	}
+	if notifier.buttons[0][0].Data != "recurring:confirm:"+r.ID+":2025-07" {
+		t.Errorf("unexpected confirm data: %s", notifier.buttons[0][0].Data)
+	}
+	if len(saver.saved) != 0 {
+		t.Fatal("reminders must not post before confirmation")
+	}
+
+	trx, err := svc.Confirm(context.Background(), 1, r.ID, "2025-07")
+	if err != nil || trx.Category != "Utilities" || len(saver.saved) != 1 {
+		t.Fatalf("confirm failed: %+v, %v", trx, err)
+	}
+	if _, err := svc.Confirm(context.Background(), 1, r.ID, "2025-07"); err == nil {
+		t.Error("a second confirmation for the same month should be rejected")
+	}
+}
+
+// removingNotifier removes the entry it reminds about while the reminder is
+// sent, as a concurrent /recurring remove would
+type removingNotifier struct {
+	recordingNotifier
+	svc *RecurringService
+	id  string
+}
+
+func (r *removingNotifier) SendWithButtons(chatID int64, text string, buttons []notify.Button) error {
+	if err := r.svc.Remove(chatID, r.id); err != nil {
+		return err
+	}
+	return r.recordingNotifier.SendWithButtons(chatID, text, buttons)
+}
+
+func TestRunDue_RemovedWhileRemindingStaysRemoved(t *testing.T) {
+	svc, _, _, clock := newTestService(time.Date(2025, 6, 30, 8, 0, 0, 0, time.UTC))
+	r, _ := svc.Add(recurring_domain.Recurring{ChatID: 1, Title: "PLN", Amount: "450000", Category: "Utilities", DayOfMonth: 1})
+	svc.Notifier = &removingNotifier{svc: svc, id: r.ID}
+
+	clock.Advance(24 * time.Hour)
+	if err := svc.RunDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := svc.List(1); len(entries) != 0 {
+		t.Errorf("marking the reminder must not bring back the removed entry, got %+v", entries)
+	}
+}
+
+func TestAdd_AfterDueDateStartsNextMonth(t *testing.T) {
+	svc, saver, notifier, _ := newTestService(time.Date(2025, 7, 20, 8, 0, 0, 0, time.UTC))
+	svc.Add(recurring_domain.Recurring{ChatID: 1, Amount: "100000", Category: "Utilities", DayOfMonth: 5, Mode: recurring_domain.ModeAuto})
+	svc.RunDue(context.Background())
+	if len(saver.saved) != 0 || len(notifier.texts) != 0 {
+		t.Error("an entry added after its due day should wait for next month")
+	}
+}
+
+func TestAdd_OnDueDayIsProcessedToday(t *testing.T) {
+	svc, saver, notifier, _ := newTestService(time.Date(2025, 7, 5, 8, 0, 0, 0, time.UTC))
+	svc.Add(recurring_domain.Recurring{ChatID: 1, Amount: "100000", Category: "Utilities", DayOfMonth: 5, Mode: recurring_domain.ModeAuto})
+	svc.Add(recurring_domain.Recurring{ChatID: 1, Amount: "450000", Category: "Utilities", DayOfMonth: 5})
+	svc.RunDue(context.Background())
+	if len(saver.saved) != 1 || len(notifier.buttons) != 1 {
+		t.Errorf("entries added on their due day should be posted and reminded today, got %d posted, %d reminded", len(saver.saved), len(notifier.buttons))
+	}
+}
+
+func TestAdd_NormalizesAmount(t *testing.T) {
+	svc, _, _, _ := newTestService(time.Date(2025, 7, 1, 8, 0, 0, 0, time.UTC))
+	r, err := svc.Add(recurring_domain.Recurring{ChatID: 1, Amount: "1.500.000", Category: "Utilities", DayOfMonth: 5})
+	if err != nil || r.Amount != "1500000" {
+		t.Errorf("expected the amount stored as a plain number, got %q, %v", r.Amount, err)
+	}
+}
+
+func TestAdd_Validation(t *testing.T) {
+	svc, _, _, _ := newTestService(time.Now())
+	if _, err := svc.Add(recurring_domain.Recurring{ChatID: 1, Amount: "abc", Category: "Utilities", DayOfMonth: 5}); err == nil {
+		t.Error("expected validation error")
+	}
+	if _, err := svc.Add(recurring_domain.Recurring{ChatID: 1, Amount: "1000", Category: "Utilities", DayOfMonth: 5, Mode: "hourly"}); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestRemove_OtherChatIsRejected(t *testing.T) {
+	svc, _, _, _ := newTestService(time.Now())
+	r, _ := svc.Add(recurring_domain.Recurring{ChatID: 1, Amount: "1000", Category: "Utilities", DayOfMonth: 5})
+	if err := svc.Remove(2, r.ID); err == nil {
+		t.Error("a chat must not remove another chat's entry")
+	}
+	if err := svc.Remove(1, r.ID); err != nil {
+		t.Errorf("expected removal, got %v", err)
+	}
+}
+
+func TestSuggestAndAccept(t *testing.T) {
+	svc, _, _, _ := newTestService(time.Date(2025, 7, 20, 8, 0, 0, 0, time.UTC))
+	svc.Reader = &stubReader{trxs: []transaction_domain.Transaction{
+		{TransactionDate: "2025-06-03", Category: "Entertainment", Notes: "Spotify", Amount: "55000", ChatID: 1},
+		{TransactionDate: "2025-07-03", Category: "Entertainment", Notes: "Spotify", Amount: "55000", ChatID: 1},
+		// Another chat sharing the sheet pays the same bill
+		{TransactionDate: "2025-06-12", Category: "Entertainment", Notes: "Netflix", Amount: "186000", ChatID: 2},
+		{TransactionDate: "2025-07-12", Category: "Entertainment", Notes: "Netflix", Amount: "186000", ChatID: 2},
+	}}
+
+	suggestions, err := svc.Suggest(context.Background(), 1)
+	if err != nil || len(suggestions) != 1 {
+		t.Fatalf("expected one suggestion, got %+v, %v", suggestions, err)
+	}
+	r, err := svc.AcceptSuggestion(context.Background(), 1, suggestions[0].Key(), "rompi")
+	if err != nil || r.DayOfMonth != 3 || r.Amount != "55000" || r.CreatedBy != "rompi" {
+		t.Fatalf("unexpected accepted entry: %+v, %v", r, err)
+	}
+	if again, _ := svc.Suggest(context.Background(), 1); len(again) != 0 {
+		t.Errorf("accepted suggestion should not be offered again, got %+v", again)
+	}
+	if _, err := svc.AcceptSuggestion(context.Background(), 1, suggestions[0].Key(), "rompi"); err == nil {
+		t.Error("an accepted suggestion must not be added twice")
+	}
+}
+
+func TestAcceptSuggestion_ListChanged(t *testing.T) {
+	svc, _, _, _ := newTestService(time.Date(2025, 7, 20, 8, 0, 0, 0, time.UTC))
+	reader := &stubReader{trxs: []transaction_domain.Transaction{
+		{TransactionDate: "2025-06-03", Category: "Entertainment", Notes: "Spotify", Amount: "55000", ChatID: 1},
+		{TransactionDate: "2025-07-03", Category: "Entertainment", Notes: "Spotify", Amount: "55000", ChatID: 1},
+	}}
+	svc.Reader = reader
+	suggestions, _ := svc.Suggest(context.Background(), 1)
+	spotify := suggestions[0].Key()
+
+	// A bigger bill detected since is listed first now
+	reader.trxs = append(reader.trxs,
+		transaction_domain.Transaction{TransactionDate: "2025-06-10", Category: "Utilities", Notes: "PLN", Amount: "400000", ChatID: 1},
+		transaction_domain.Transaction{TransactionDate: "2025-07-10", Category: "Utilities", Notes: "PLN", Amount: "400000", ChatID: 1},
+	)
+	r, err := svc.AcceptSuggestion(context.Background(), 1, spotify, "rompi")
+	if err != nil || r.Title != "spotify" {
+		t.Fatalf("expected the suggestion the button was for, got %+v, %v", r, err)
+	}
+	if _, err := svc.AcceptSuggestion(context.Background(), 1, "000000000000", "rompi"); err == nil {
+		t.Error("expected an error for a key that matches no suggestion")
+	}
+}
+
+type chatLanguages map[int64]string
+
+func (l chatLanguages) Language(chatID int64) string { return l[chatID] }
+
+func TestRunDue_RemindsInChatLanguage(t *testing.T) {
+	svc, _, notifier, clock := newTestService(time.Date(2025, 6, 30, 8, 0, 0, 0, time.UTC))
+	svc.Languages = chatLanguages{1: "id"}
+	if _, err := svc.Add(recurring_domain.Recurring{ChatID: 1, Title: "PLN", Amount: "450000", Category: "Utilities", DayOfMonth: 1}); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+
+	clock.Advance(24 * time.Hour)
+	svc.RunDue(context.Background())
+	if len(notifier.texts) != 1 || !strings.HasPrefix(notifier.texts[0], "🔔 PLN jatuh tempo\nJumlah: Rp 450,000") {
+		t.Fatalf("expected an Indonesian reminder, got %q", notifier.texts)
+	}
+	if notifier.buttons[0][0].Label != "✅ Catat" || notifier.buttons[0][1].Label != "Lewati" {
+		t.Errorf("expected Indonesian buttons, got %+v", notifier.buttons[0])
+	}
+}
@@ -0,0 +1,21 @@
+package recurring
+
+import (
+	"context"
+	recurring_domain "money-tracker-bot/internal/domain/recurring"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+type IRecurring interface {
+	Add(r recurring_domain.Recurring) (recurring_domain.Recurring, error)
+	List(chatID int64) ([]recurring_domain.Recurring, error)
+	Remove(chatID int64, id string) error
+	// Confirm records a reminded transaction for the given YYYY-MM period
+	Confirm(ctx context.Context, chatID int64, id, period string) (transaction_domain.Transaction, error)
+	// Skip acknowledges a reminder without recording anything
+	Skip(chatID int64, id, period string) error
+	Suggest(ctx context.Context, chatID int64) ([]recurring_domain.Suggestion, error)
+	// AcceptSuggestion turns the suggestion with key (Suggestion.Key) into a
+	// reminder entry
+	AcceptSuggestion(ctx context.Context, chatID int64, key string, user string) (recurring_domain.Recurring, error)
+}
@@ -0,0 +1,90 @@
+package review
+
+// Package review holds back transactions the AI was unsure about until the
+// user confirms them.
+
+import (
+	"context"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	review_domain "money-tracker-bot/internal/domain/review"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+)
+
+// CallbackPrefix namespaces the callback data of review buttons
+const CallbackPrefix = "review:"
+
+// Repository persists the review queue
+type Repository interface {
+	List(chatID int64) ([]review_domain.Pending, error)
+	Get(id string) (review_domain.Pending, bool, error)
+	Add(item review_domain.Pending) (review_domain.Pending, error)
+	Delete(id string) error
+}
+
+// TransactionSaver records a transaction in the spreadsheet
+type TransactionSaver interface {
+	SaveTransaction(ctx context.Context, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error)
+}
+
+type ReviewService struct {
+	Repo  Repository
+	Saver TransactionSaver
+	Clock common.Clock
+}
+
+func NewReviewService(repo Repository, saver TransactionSaver, clock common.Clock) *ReviewService {
+	return &ReviewService{Repo: repo, Saver: saver, Clock: clock}
+}
+
+func (s *ReviewService) Flag(chatID int64, trx transaction_domain.Transaction) (review_domain.Pending, error) {
+	return s.Repo.Add(review_domain.Pending{ChatID: chatID, Transaction: trx, FlaggedAt: s.Clock.Now()})
+}
+
+func (s *ReviewService) List(chatID int64) ([]review_domain.Pending, error) {
+	return s.Repo.List(chatID)
+}
+
+// Approve saves the transaction first, so it stays queued when saving fails.
+// The confirmed fields are rated certain.
+func (s *ReviewService) Approve(ctx context.Context, chatID int64, id string) (review_domain.Pending, spreadsheet.CategorySummary, error) {
+	p, err := s.get(chatID, id)
+	if err != nil {
+		return p, spreadsheet.CategorySummary{}, err
+	}
+	p.Transaction.MarkCertain(p.Transaction.LowConfidence...)
+	p.Transaction.LowConfidence = nil
+	summary, err := s.Saver.SaveTransaction(ctx, p.Transaction)
+	if err != nil {
+		return p, spreadsheet.CategorySummary{}, err
+	}
+	if err := s.Repo.Delete(id); err != nil {
+		// The transaction is saved; a stale queue entry is only an annoyance
+		errors.HandleErrorContext(ctx, err, "removing reviewed transaction")
+	}
+	return p, summary, nil
+}
+
+func (s *ReviewService) Discard(chatID int64, id string) (review_domain.Pending, error) {
+	p, err := s.get(chatID, id)
+	if err != nil {
+		return p, err
+	}
+	return p, s.Repo.Delete(id)
+}
+
+// get returns a queued transaction of the chat; other chats' entries are not found
+func (s *ReviewService) get(chatID int64, id string) (review_domain.Pending, error) {
+	p, ok, err := s.Repo.Get(id)
+	if err != nil {
+		return p, err
+	}
+	if !ok || p.ChatID != chatID {
+		return review_domain.Pending{}, errors.NewValidationError("this transaction is no longer awaiting review", nil).
+			WithKey("invalid.review.not_pending").
+			WithContext("id", id).
+			WithComponent("review-service")
+	}
+	return p, nil
+}
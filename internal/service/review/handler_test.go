@@ -0,0 +1,133 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	review_domain "money-tracker-bot/internal/domain/review"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type memoryRepo struct {
+	next  int
+	items map[string]review_domain.Pending
+}
+
+func (m *memoryRepo) List(chatID int64) ([]review_domain.Pending, error) {
+	var out []review_domain.Pending
+	for i := 1; i <= m.next; i++ {
+		if p, ok := m.items["q"+strconv.Itoa(i)]; ok && p.ChatID == chatID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryRepo) Get(id string) (review_domain.Pending, bool, error) {
+	p, ok := m.items[id]
+	return p, ok, nil
+}
+
+func (m *memoryRepo) Add(item review_domain.Pending) (review_domain.Pending, error) {
+	if m.items == nil {
+		m.items = make(map[string]review_domain.Pending)
+	}
+	m.next++
+	item.ID = "q" + strconv.Itoa(m.next)
+	m.items[item.ID] = item
+	return item, nil
+}
+
+func (m *memoryRepo) Delete(id string) error {
+	delete(m.items, id)
+	return nil
+}
+
+type saverStub struct {
+	err  error
+	trxs []transaction_domain.Transaction
+}
+
+func (s *saverStub) SaveTransaction(ctx context.Context, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
+	if s.err != nil {
+		return spreadsheet.CategorySummary{}, s.err
+	}
+	s.trxs = append(s.trxs, trx)
+	return spreadsheet.CategorySummary{BudgetLeft: "100000"}, nil
+}
+
+func blurry() transaction_domain.Transaction {
+	return transaction_domain.Transaction{
+		Amount:        "45,000",
+		Confidence:    map[string]float64{"amount": 0.4, "category": 0.9},
+		LowConfidence: []string{"amount"},
+	}
+}
+
+func TestReviewService_FlagAndApprove(t *testing.T) {
+	repo := &memoryRepo{}
+	saver := &saverStub{}
+	now := time.Date(2025, 7, 10, 9, 0, 0, 0, time.UTC)
+	s := NewReviewService(repo, saver, &common.FixedClock{Time: now})
+
+	p, err := s.Flag(7, blurry())
+	if err != nil || p.ID != "q1" || !p.FlaggedAt.Equal(now) {
+		t.Fatalf("unexpected pending %+v, %v", p, err)
+	}
+	if pending, _ := s.List(7); len(pending) != 1 {
+		t.Errorf("expected one pending transaction, got %v", pending)
+	}
+	if len(saver.trxs) != 0 {
+		t.Fatal("flagged transactions must not be saved")
+	}
+
+	if _, _, err := s.Approve(context.Background(), 8, "q1"); err == nil {
+		t.Error("other chats must not approve the transaction")
+	}
+	p, summary, err := s.Approve(context.Background(), 7, "q1")
+	if err != nil || summary.BudgetLeft != "100000" {
+		t.Fatalf("unexpected approval %+v, %v", summary, err)
+	}
+	if len(saver.trxs) != 1 || saver.trxs[0].NeedsReview() || saver.trxs[0].Confidence["amount"] != 1 {
+		t.Errorf("the confirmed transaction should be saved as certain, got %+v", saver.trxs)
+	}
+	if pending, _ := s.List(7); len(pending) != 0 {
+		t.Errorf("approved transactions should leave the queue, got %v", pending)
+	}
+	if _, _, err := s.Approve(context.Background(), 7, "q1"); err == nil {
+		t.Error("a transaction can only be approved once")
+	}
+}
+
+func TestReviewService_ApproveFailureKeepsQueue(t *testing.T) {
+	repo := &memoryRepo{}
+	s := NewReviewService(repo, &saverStub{err: fmt.Errorf("sheets down")}, &common.FixedClock{})
+	s.Flag(7, blurry())
+
+	if _, _, err := s.Approve(context.Background(), 7, "q1"); err == nil {
+		t.Fatal("expected the save error")
+	}
+	if pending, _ := s.List(7); len(pending) != 1 {
+		t.Error("the transaction should stay queued when saving fails")
+	}
+}
+
+func TestReviewService_Discard(t *testing.T) {
+	saver := &saverStub{}
+	s := NewReviewService(&memoryRepo{}, saver, &common.FixedClock{})
+	s.Flag(7, blurry())
+
+	if _, err := s.Discard(8, "q1"); err == nil {
+		t.Error("other chats must not discard the transaction")
+	}
+	if p, err := s.Discard(7, "q1"); err != nil || p.Transaction.Amount != "45,000" {
+		t.Errorf("unexpected discard %+v, %v", p, err)
+	}
+	if pending, _ := s.List(7); len(pending) != 0 || len(saver.trxs) != 0 {
+		t.Error("discarded transactions should be dropped unsaved")
+	}
+}
@@ -0,0 +1,19 @@
+package review
+
+import (
+	"context"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	review_domain "money-tracker-bot/internal/domain/review"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+type IReview interface {
+	// Flag queues a low-confidence transaction instead of saving it
+	Flag(chatID int64, trx transaction_domain.Transaction) (review_domain.Pending, error)
+	// List returns the transactions of the chat awaiting review, oldest first
+	List(chatID int64) ([]review_domain.Pending, error)
+	// Approve saves a queued transaction and removes it from the queue
+	Approve(ctx context.Context, chatID int64, id string) (review_domain.Pending, spreadsheet.CategorySummary, error)
+	// Discard removes a queued transaction without saving it
+	Discard(chatID int64, id string) (review_domain.Pending, error)
+}
@@ -0,0 +1,122 @@
+package rules
+
+// Package rules runs user-defined categorization rules on extracted transactions.
+
+import (
+	"money-tracker-bot/internal/common"
+	rules_domain "money-tracker-bot/internal/domain/rules"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Repository persists rules per chat
+type Repository interface {
+	List(chatID int64) ([]rules_domain.Rule, error)
+	Save(rule rules_domain.Rule) (rules_domain.Rule, error)
+	Delete(chatID int64, id int) (bool, error)
+}
+
+type RuleService struct {
+	Repo     Repository
+	Clock    common.Clock
+	Location *time.Location
+	// Zones is optional; it gives each chat its own timezone instead of Location
+	Zones common.Zones
+
+	// mu serializes hit counting, which reads and rewrites a rule
+	mu sync.Mutex
+}
+
+func NewRuleService(repo Repository, clock common.Clock, loc *time.Location) *RuleService {
+	return &RuleService{
+		Repo:     repo,
+		Clock:    clock,
+		Location: loc,
+	}
+}
+
+func (s *RuleService) Add(r rules_domain.Rule) (rules_domain.Rule, error) {
+	r.ID, r.Hits, r.LastHit = 0, 0, ""
+	if err := r.Validate(); err != nil {
+		return r, errors.NewValidationError(err.Error(), err).WithComponent("rule-service")
+	}
+	return s.Repo.Save(r)
+}
+
+func (s *RuleService) List(chatID int64) ([]rules_domain.Rule, error) {
+	return s.Repo.List(chatID)
+}
+
+func (s *RuleService) Remove(chatID int64, id int) error {
+	found, err := s.Repo.Delete(chatID, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.NewValidationError("rule not found", nil).
+			WithKey("invalid.rule.not_found").
+			WithContext("rule_id", strconv.Itoa(id)).
+			WithComponent("rule-service")
+	}
+	return nil
+}
+
+func (s *RuleService) Test(chatID int64, in rules_domain.Input) (rules_domain.Rule, bool, error) {
+	rules, err := s.Repo.List(chatID)
+	if err != nil {
+		return rules_domain.Rule{}, false, err
+	}
+	r, ok := rules_domain.FirstMatch(rules, in)
+	return r, ok, nil
+}
+
+// Apply runs the first matching rule on trx and notes it in trx.RuleID. The
+// hit is only counted by RecordHit, once the transaction is saved.
+func (s *RuleService) Apply(chatID int64, message string, trx *transaction_domain.Transaction) (bool, error) {
+	r, ok, err := s.Test(chatID, rules_domain.InputOf(message, *trx))
+	if err != nil || !ok {
+		return false, err
+	}
+	r.Apply(trx)
+	trx.RuleID = r.ID
+	return true, nil
+}
+
+// RecordHit counts a hit of the rule with id. A rule removed in the meantime
+// is ignored.
+func (s *RuleService) RecordHit(chatID int64, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rules, err := s.Repo.List(chatID)
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		if r.ID != id {
+			continue
+		}
+		r.Hits++
+		r.LastHit = s.today(chatID).Format("2006-01-02")
+		_, err := s.Repo.Save(r)
+		return err
+	}
+	return nil
+}
+
+func (s *RuleService) Report(chatID int64) ([]rules_domain.Rule, error) {
+	rules, err := s.Repo.List(chatID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Hits > rules[j].Hits })
+	return rules, nil
+}
+
+func (s *RuleService) today(chatID int64) time.Time {
+	return s.Clock.Now().In(common.TenantLocation(s.Zones, chatID, s.Location))
+}
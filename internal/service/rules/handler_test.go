@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"money-tracker-bot/internal/common"
+	rules_domain "money-tracker-bot/internal/domain/rules"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"testing"
+	"time"
+)
+
+type memoryRepo struct {
+	rules  []rules_domain.Rule
+	nextID int
+}
+
+func (m *memoryRepo) List(chatID int64) ([]rules_domain.Rule, error) {
+	var out []rules_domain.Rule
+	for _, r := range m.rules {
+		if r.ChatID == chatID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryRepo) Save(rule rules_domain.Rule) (rules_domain.Rule, error) {
+	if rule.ID == 0 {
+		m.nextID++
+		rule.ID = m.nextID
+		m.rules = append(m.rules, rule)
+		return rule, nil
+	}
+	for i := range m.rules {
+		if m.rules[i].ID == rule.ID {
+			m.rules[i] = rule
+		}
+	}
+	return rule, nil
+}
+
+func (m *memoryRepo) Delete(chatID int64, id int) (bool, error) {
+	for i, r := range m.rules {
+		if r.ID == id && r.ChatID == chatID {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newTestService() *RuleService {
+	clock := &common.FixedClock{Time: time.Date(2025, 7, 20, 10, 0, 0, 0, time.UTC)}
+	return NewRuleService(&memoryRepo{}, clock, time.UTC)
+}
+
+func TestAddValidates(t *testing.T) {
+	svc := newTestService()
+	if _, err := svc.Add(rules_domain.Rule{ChatID: 1, Kind: rules_domain.KindRegex, Pattern: "([", Category: "Groceries"}); err == nil {
+		t.Error("expected validation error for invalid regex")
+	}
+	r, err := svc.Add(rules_domain.Rule{ChatID: 1, Kind: rules_domain.KindKeyword, Pattern: "grab", Category: "Transportation", Hits: 9})
+	if err != nil || r.ID != 1 || r.Hits != 0 {
+		t.Errorf("unexpected rule: %+v, %v", r, err)
+	}
+}
+
+func TestApplyAndRecordHit(t *testing.T) {
+	svc := newTestService()
+	svc.Add(rules_domain.Rule{ChatID: 1, Kind: rules_domain.KindKeyword, Pattern: "indomaret", Category: "Groceries"})
+	svc.Add(rules_domain.Rule{ChatID: 1, Kind: rules_domain.KindKeyword, Pattern: "grab", Category: "Transportation", Tags: []string{"ride"}})
+
+	for i := 0; i < 2; i++ {
+		trx := transaction_domain.Transaction{Category: "Eating Out"}
+		applied, err := svc.Apply(1, "grab 25k", &trx)
+		if err != nil || !applied || trx.Category != "Transportation" || len(trx.Tags) != 1 || trx.RuleID != 2 {
+			t.Fatalf("rule should override the AI category, got %+v, %v, %v", trx, applied, err)
+		}
+	}
+	if rules, _ := svc.List(1); rules[1].Hits != 0 {
+		t.Fatal("applying a rule must not count a hit before the transaction is saved")
+	}
+	for i := 0; i < 2; i++ {
+		if err := svc.RecordHit(1, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := svc.RecordHit(1, 99); err != nil {
+		t.Errorf("a removed rule should be ignored, got %v", err)
+	}
+	other := transaction_domain.Transaction{Category: "Eating Out"}
+	if applied, _ := svc.Apply(2, "grab 25k", &other); applied || other.Category != "Eating Out" {
+		t.Errorf("rules of another chat must not apply, got %+v", other)
+	}
+
+	report, _ := svc.Report(1)
+	if report[0].Pattern != "grab" || report[0].Hits != 2 || report[0].LastHit != "2025-07-20" || report[1].Hits != 0 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
+
+func TestTestDoesNotCountHits(t *testing.T) {
+	svc := newTestService()
+	svc.Add(rules_domain.Rule{ChatID: 1, Kind: rules_domain.KindMerchant, Pattern: "starbucks", Category: "Eating Out"})
+
+	r, ok, err := svc.Test(1, rules_domain.Input{Merchant: "Starbucks Plaza Senayan"})
+	if err != nil || !ok || r.ID != 1 {
+		t.Fatalf("expected merchant rule to match, got %+v, %v, %v", r, ok, err)
+	}
+	if rules, _ := svc.List(1); rules[0].Hits != 0 {
+		t.Error("testing a rule must not count as a hit")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	svc := newTestService()
+	svc.Add(rules_domain.Rule{ChatID: 1, Kind: rules_domain.KindAccount, Pattern: "OVO", Tags: []string{"ewallet"}})
+	if err := svc.Remove(2, 1); err == nil {
+		t.Error("expected error removing another chat's rule")
+	}
+	if err := svc.Remove(1, 1); err != nil {
+		t.Errorf("expected removal, got %v", err)
+	}
+}
@@ -0,0 +1,20 @@
+package rules
+
+import (
+	rules_domain "money-tracker-bot/internal/domain/rules"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+type IRules interface {
+	Add(r rules_domain.Rule) (rules_domain.Rule, error)
+	List(chatID int64) ([]rules_domain.Rule, error)
+	Remove(chatID int64, id int) error
+	// Test returns the rule that would apply to in, without counting a hit
+	Test(chatID int64, in rules_domain.Input) (rules_domain.Rule, bool, error)
+	// Apply runs the rules of the chat on an extracted transaction
+	Apply(chatID int64, message string, trx *transaction_domain.Transaction) (bool, error)
+	// RecordHit counts a hit of a rule applied to a saved transaction
+	RecordHit(chatID int64, id int) error
+	// Report returns the rules of the chat, most used first
+	Report(chatID int64) ([]rules_domain.Rule, error)
+}
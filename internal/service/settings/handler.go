@@ -0,0 +1,165 @@
+package settings
+
+// Package settings stores the timezone, locale and language each tenant has
+// chosen, so dates in prompts, spreadsheet timestamps, budget months, reports
+// and replies follow the chat rather than the server.
+
+import (
+	"money-tracker-bot/internal/common"
+	currency_domain "money-tracker-bot/internal/domain/currency"
+	settings_domain "money-tracker-bot/internal/domain/settings"
+	"money-tracker-bot/internal/errors"
+	"money-tracker-bot/internal/i18n"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Repository persists the settings of each chat
+type Repository interface {
+	Get(chatID int64) (settings_domain.Settings, bool, error)
+	Save(s settings_domain.Settings) error
+}
+
+type SettingsService struct {
+	Repo Repository
+	// DefaultLocation and DefaultLocale are used by chats that have not
+	// chosen their own
+	DefaultLocation *time.Location
+	DefaultLocale   common.Locale
+	// Currency is the ISO 4217 code of the spreadsheet's amounts, which every
+	// locale writes them in; "" is IDR
+	Currency string
+
+	// mu serializes the read-modify-write of the settings file
+	mu sync.Mutex
+}
+
+func NewSettingsService(repo Repository, loc *time.Location, locale string) *SettingsService {
+	return &SettingsService{
+		Repo:            repo,
+		DefaultLocation: loc,
+		DefaultLocale:   common.LocaleOf(locale),
+	}
+}
+
+func (s *SettingsService) Get(chatID int64) (settings_domain.Settings, error) {
+	stored, _, err := s.Repo.Get(chatID)
+	if err != nil {
+		return settings_domain.Settings{}, err
+	}
+	stored.ChatID = chatID
+	if stored.Timezone == "" {
+		stored.Timezone = s.defaultLocation().String()
+	}
+	if stored.Locale == "" {
+		stored.Locale = s.DefaultLocale.Tag
+	}
+	return stored, nil
+}
+
+func (s *SettingsService) SetTimezone(chatID int64, timezone string) (settings_domain.Settings, error) {
+	loc, err := settings_domain.LoadTimezone(timezone)
+	if err != nil {
+		return settings_domain.Settings{}, errors.NewValidationError("unknown timezone, expected an IANA name such as Asia/Jakarta", err).
+			WithKey("invalid.settings.timezone").
+			WithContext("timezone", timezone).
+			WithComponent("settings-service")
+	}
+	return s.update(chatID, func(stored *settings_domain.Settings) { stored.Timezone = loc.String() })
+}
+
+func (s *SettingsService) SetLocale(chatID int64, tag string) (settings_domain.Settings, error) {
+	locale, ok := common.ParseLocale(tag)
+	if !ok {
+		return settings_domain.Settings{}, errors.NewValidationError("unsupported locale, expected one of "+strings.Join(common.Locales(), ", "), nil).
+			WithKey("invalid.settings.locale", strings.Join(common.Locales(), ", ")).
+			WithContext("locale", tag).
+			WithComponent("settings-service")
+	}
+	return s.update(chatID, func(stored *settings_domain.Settings) { stored.Locale = locale.Tag })
+}
+
+func (s *SettingsService) SetLanguage(chatID int64, lang string) (settings_domain.Settings, error) {
+	if l := strings.ToLower(strings.TrimSpace(lang)); l == "" || l == "auto" {
+		return s.update(chatID, func(stored *settings_domain.Settings) { stored.Language = "" })
+	}
+	parsed, ok := i18n.Parse(lang)
+	if !ok {
+		return settings_domain.Settings{}, errors.NewValidationError("unsupported language, expected one of "+strings.Join(i18n.Languages(), ", ")+" or auto", nil).
+			WithKey("invalid.settings.language", strings.Join(i18n.Languages(), ", ")).
+			WithContext("language", lang).
+			WithComponent("settings-service")
+	}
+	return s.update(chatID, func(stored *settings_domain.Settings) { stored.Language = parsed })
+}
+
+// update changes the stored settings of a chat and returns them with the
+// defaults filled in
+func (s *SettingsService) update(chatID int64, change func(*settings_domain.Settings)) (settings_domain.Settings, error) {
+	s.mu.Lock()
+	stored, _, err := s.Repo.Get(chatID)
+	if err == nil {
+		stored.ChatID = chatID
+		change(&stored)
+		err = s.Repo.Save(stored)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return settings_domain.Settings{}, err
+	}
+	return s.Get(chatID)
+}
+
+// Location returns the timezone of a chat. A chat whose settings cannot be
+// read gets the default, so dates keep working.
+func (s *SettingsService) Location(chatID int64) *time.Location {
+	stored, _, err := s.Repo.Get(chatID)
+	if err != nil {
+		errors.HandleError(err, "reading chat timezone")
+		return s.defaultLocation()
+	}
+	if loc, err := settings_domain.LoadTimezone(stored.Timezone); err == nil {
+		return loc
+	}
+	return s.defaultLocation()
+}
+
+func (s *SettingsService) Locale(chatID int64) common.Locale {
+	stored, _, err := s.Repo.Get(chatID)
+	if err != nil {
+		errors.HandleError(err, "reading chat locale")
+		return s.withCurrency(s.DefaultLocale)
+	}
+	if locale, ok := common.ParseLocale(stored.Locale); ok {
+		return s.withCurrency(locale)
+	}
+	return s.withCurrency(s.DefaultLocale)
+}
+
+// withCurrency makes locale write amounts in the spreadsheet's currency
+func (s *SettingsService) withCurrency(locale common.Locale) common.Locale {
+	if s.Currency == "" {
+		return locale
+	}
+	return locale.WithCurrency(s.Currency, currency_domain.MinorUnits(s.Currency))
+}
+
+// Language returns the language of a chat, "" when it has not chosen one or
+// its settings cannot be read, so replies follow the Telegram app instead
+func (s *SettingsService) Language(chatID int64) string {
+	stored, _, err := s.Repo.Get(chatID)
+	if err != nil {
+		errors.HandleError(err, "reading chat language")
+		return ""
+	}
+	lang, _ := i18n.Parse(stored.Language)
+	return lang
+}
+
+func (s *SettingsService) defaultLocation() *time.Location {
+	if s.DefaultLocation == nil {
+		return time.UTC
+	}
+	return s.DefaultLocation
+}
@@ -0,0 +1,125 @@
+package settings
+
+import (
+	"money-tracker-bot/internal/common"
+	settings_domain "money-tracker-bot/internal/domain/settings"
+	"money-tracker-bot/internal/errors"
+	"testing"
+	"time"
+)
+
+type memoryRepo map[int64]settings_domain.Settings
+
+func (m memoryRepo) Get(chatID int64) (settings_domain.Settings, bool, error) {
+	s, ok := m[chatID]
+	return s, ok, nil
+}
+
+func (m memoryRepo) Save(s settings_domain.Settings) error {
+	m[s.ChatID] = s
+	return nil
+}
+
+type brokenRepo struct{}
+
+func (brokenRepo) Get(chatID int64) (settings_domain.Settings, bool, error) {
+	return settings_domain.Settings{}, false, errors.NewDataAccessError("disk full", nil)
+}
+
+func (brokenRepo) Save(s settings_domain.Settings) error {
+	return errors.NewDataAccessError("disk full", nil)
+}
+
+func isValidation(err error) bool {
+	appErr, ok := err.(*errors.AppError)
+	return ok && appErr.Code == errors.ErrCodeValidation
+}
+
+func newTestService() *SettingsService {
+	bangkok, _ := time.LoadLocation("Asia/Bangkok")
+	return NewSettingsService(memoryRepo{}, bangkok, "en")
+}
+
+func TestGet_Defaults(t *testing.T) {
+	s, err := newTestService().Get(42)
+	if err != nil || s.ChatID != 42 || s.Timezone != "Asia/Bangkok" || s.Locale != "en" {
+		t.Errorf("expected the defaults, got %+v, %v", s, err)
+	}
+}
+
+func TestSetTimezone(t *testing.T) {
+	svc := newTestService()
+	s, err := svc.SetTimezone(42, "America/New_York")
+	if err != nil || s.Timezone != "America/New_York" || s.Locale != "en" {
+		t.Fatalf("unexpected settings %+v, %v", s, err)
+	}
+	if loc := svc.Location(42); loc.String() != "America/New_York" {
+		t.Errorf("expected the chosen timezone, got %v", loc)
+	}
+	if loc := svc.Location(7); loc.String() != "Asia/Bangkok" {
+		t.Errorf("other chats should keep the default, got %v", loc)
+	}
+	if _, err := svc.SetTimezone(42, "Mars/Olympus"); !isValidation(err) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	svc := newTestService()
+	if _, err := svc.SetTimezone(42, "Asia/Jakarta"); err != nil {
+		t.Fatal(err)
+	}
+	s, err := svc.SetLocale(42, "id_ID")
+	if err != nil || s.Locale != "id-ID" || s.Timezone != "Asia/Jakarta" {
+		t.Fatalf("expected the locale next to the timezone, got %+v, %v", s, err)
+	}
+	if got := svc.Locale(42).FormatAmount(25000); got != "Rp 25.000" {
+		t.Errorf("expected Indonesian separators, got %q", got)
+	}
+	svc.Currency = "SGD"
+	if got := svc.Locale(42).FormatAmount(25000); got != "SGD 25.000,00" {
+		t.Errorf("expected the spreadsheet's currency, got %q", got)
+	}
+	if svc.Locale(7).Tag != common.DefaultLocale {
+		t.Errorf("other chats should keep the default locale, got %+v", svc.Locale(7))
+	}
+	if _, err := svc.SetLocale(42, "klingon"); !isValidation(err) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
+
+func TestDefaults_WhenRepositoryFails(t *testing.T) {
+	svc := NewSettingsService(brokenRepo{}, nil, "id-ID")
+	if loc := svc.Location(42); loc != time.UTC {
+		t.Errorf("expected UTC without a default timezone, got %v", loc)
+	}
+	if svc.Locale(42).Tag != "id-ID" {
+		t.Errorf("expected the default locale, got %+v", svc.Locale(42))
+	}
+	if _, err := svc.SetLocale(42, "en"); err == nil {
+		t.Error("expected the repository error")
+	}
+}
+
+func TestSetLanguage(t *testing.T) {
+	svc := newTestService()
+	if got := svc.Language(42); got != "" {
+		t.Errorf("expected no language before one is chosen, got %q", got)
+	}
+	s, err := svc.SetLanguage(42, "id-ID")
+	if err != nil || s.Language != "id" {
+		t.Fatalf("expected id, got %+v, %v", s, err)
+	}
+	if got := svc.Language(42); got != "id" {
+		t.Errorf("expected id, got %q", got)
+	}
+	if got := svc.Language(7); got != "" {
+		t.Errorf("other chats should follow Telegram, got %q", got)
+	}
+	if _, err := svc.SetLanguage(42, "klingon"); !isValidation(err) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+	if s, err := svc.SetLanguage(42, "auto"); err != nil || s.Language != "" {
+		t.Errorf("expected auto to clear the language, got %+v, %v", s, err)
+	}
+}
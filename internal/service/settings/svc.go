@@ -0,0 +1,26 @@
+package settings
+
+import (
+	"money-tracker-bot/internal/common"
+	settings_domain "money-tracker-bot/internal/domain/settings"
+	"time"
+)
+
+type ISettings interface {
+	// Get returns the settings of a chat with the defaults filled in
+	Get(chatID int64) (settings_domain.Settings, error)
+	// SetTimezone changes the IANA timezone of a chat
+	SetTimezone(chatID int64, timezone string) (settings_domain.Settings, error)
+	// SetLocale changes the locale of a chat to one of common.Locales
+	SetLocale(chatID int64, tag string) (settings_domain.Settings, error)
+	// Location returns the timezone of a chat; it implements common.Zones
+	Location(chatID int64) *time.Location
+	// Locale returns the locale replies and reports of a chat are formatted with
+	Locale(chatID int64) common.Locale
+	// SetLanguage changes the language of a chat to one of i18n.Languages;
+	// "auto" or "" clears it
+	SetLanguage(chatID int64, lang string) (settings_domain.Settings, error)
+	// Language returns the language the chat has chosen, "" when it has not;
+	// it implements i18n.TenantLanguages
+	Language(chatID int64) string
+}
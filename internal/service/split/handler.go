@@ -0,0 +1,182 @@
+package split
+
+// Package split tracks expenses shared between household members and settles the balances.
+
+import (
+	"context"
+	"math"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	split_domain "money-tracker-bot/internal/domain/split"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Repository persists the shared expense ledger per chat
+type Repository interface {
+	List(chatID int64) ([]split_domain.Entry, error)
+	Add(entries ...split_domain.Entry) ([]split_domain.Entry, error)
+}
+
+// TransactionSaver records a transaction in the spreadsheet
+type TransactionSaver interface {
+	SaveTransaction(ctx context.Context, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error)
+}
+
+type SplitService struct {
+	Repo     Repository
+	Saver    TransactionSaver
+	Clock    common.Clock
+	Location *time.Location
+	// Zones is optional; it gives each chat its own timezone instead of Location
+	Zones common.Zones
+
+	mu sync.Mutex
+	// last is the transaction /split refers to; it is kept in memory only,
+	// so it is forgotten when the bot restarts
+	last map[int64]transaction_domain.Transaction
+}
+
+func NewSplitService(repo Repository, saver TransactionSaver, clock common.Clock, loc *time.Location) *SplitService {
+	return &SplitService{
+		Repo:     repo,
+		Saver:    saver,
+		Clock:    clock,
+		Location: loc,
+		last:     make(map[int64]transaction_domain.Transaction),
+	}
+}
+
+func (s *SplitService) Remember(chatID int64, trx transaction_domain.Transaction) {
+	// Goal contributions and transfers are not household expenses
+	if trx.Goal != "" || trx.IsTransfer() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last[chatID] = trx
+}
+
+func (s *SplitService) SplitLast(chatID int64, members []string, shares map[string]string) (split_domain.Entry, error) {
+	s.mu.Lock()
+	trx, ok := s.last[chatID]
+	s.mu.Unlock()
+	if !ok {
+		return split_domain.Entry{}, errors.NewValidationError("no recent transaction to split, send one first (the last transaction is forgotten when the bot restarts)", nil).
+			WithKey("invalid.split.no_recent").
+			WithComponent("split-service")
+	}
+
+	amount, err := transaction_domain.ParseAmount(trx.Amount)
+	if err != nil {
+		return split_domain.Entry{}, errors.NewValidationError("the last transaction has no valid amount", err).
+			WithKey("invalid.split.invalid_amount").
+			WithContext("amount", trx.Amount).
+			WithComponent("split-service")
+	}
+	description := trx.Title
+	if description == "" {
+		description = trx.Notes
+	}
+	entry := split_domain.Entry{
+		ChatID:      chatID,
+		Date:        trx.TransactionDate,
+		Description: description,
+		PaidBy:      split_domain.NormalizeMember(trx.CreatedBy),
+		Amount:      int64(math.Round(amount)),
+	}
+
+	entry.Shares, err = buildShares(entry.Amount, members, shares)
+	if err == nil {
+		err = entry.Validate()
+	}
+	if err != nil {
+		return entry, errors.NewValidationError(err.Error(), err).WithComponent("split-service")
+	}
+
+	added, err := s.Repo.Add(entry)
+	if err != nil {
+		return entry, err
+	}
+	// A transaction is split once; splitting again needs a new transaction
+	s.mu.Lock()
+	delete(s.last, chatID)
+	s.mu.Unlock()
+	return added[0], nil
+}
+
+func (s *SplitService) Balances(chatID int64) (map[string]int64, error) {
+	entries, err := s.Repo.List(chatID)
+	if err != nil {
+		return nil, err
+	}
+	return split_domain.Balances(entries), nil
+}
+
+func (s *SplitService) Settle(ctx context.Context, chatID int64) ([]split_domain.Transfer, error) {
+	balances, err := s.Balances(chatID)
+	if err != nil {
+		return nil, err
+	}
+	transfers := split_domain.Settle(balances)
+	if len(transfers) == 0 {
+		return nil, nil
+	}
+
+	// Each transfer is written to the spreadsheet before the ledger settles
+	// it, so a failed write leaves that balance open for the next /settle
+	// instead of settled without a record.
+	today := s.today(chatID).Format("2006-01-02")
+	settled := make([]split_domain.Transfer, 0, len(transfers))
+	for _, t := range transfers {
+		trx := transaction_domain.Transaction{
+			TransactionDate: today,
+			Amount:          strconv.FormatInt(t.Amount, 10),
+			Category:        transaction_domain.TransferCategory,
+			Title:           "Settlement",
+			Notes:           "Settlement from " + t.From + " to " + t.To,
+			DestinationName: t.To,
+			CreatedBy:       t.From,
+		}
+		if _, err := s.Saver.SaveTransaction(ctx, trx); err != nil {
+			return settled, err
+		}
+		if _, err := s.Repo.Add(split_domain.SettlementEntry(chatID, today, t)); err != nil {
+			return settled, err
+		}
+		settled = append(settled, t)
+	}
+	return settled, nil
+}
+
+// buildShares splits amount equally between members, or uses the custom
+// member=amount options ordered by member.
+func buildShares(amount int64, members []string, options map[string]string) ([]split_domain.Share, error) {
+	if len(options) == 0 {
+		return split_domain.EqualShares(amount, members)
+	}
+	if len(members) > 0 {
+		return nil, errors.Messagef("invalid.split.mixed_shares", "use either member names or member=amount shares, not both")
+	}
+	shares := make([]split_domain.Share, 0, len(options))
+	for member, value := range options {
+		v, err := transaction_domain.ParseAmount(value)
+		if err != nil {
+			return nil, errors.Messagef("invalid.split.invalid_share", "invalid share %q for %s", value, member)
+		}
+		shares = append(shares, split_domain.Share{
+			Member: split_domain.NormalizeMember(member),
+			Amount: int64(math.Round(v)),
+		})
+	}
+	sort.Slice(shares, func(i, j int) bool { return shares[i].Member < shares[j].Member })
+	return shares, nil
+}
+
+func (s *SplitService) today(chatID int64) time.Time {
+	return s.Clock.Now().In(common.TenantLocation(s.Zones, chatID, s.Location))
+}
@@ -0,0 +1,153 @@
+package split
+
+import (
+	"context"
+	stderrors "errors"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
+	split_domain "money-tracker-bot/internal/domain/split"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"testing"
+	"time"
+)
+
+type memoryRepo struct {
+	entries []split_domain.Entry
+}
+
+func (m *memoryRepo) List(chatID int64) ([]split_domain.Entry, error) {
+	var out []split_domain.Entry
+	for _, e := range m.entries {
+		if e.ChatID == chatID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (m *memoryRepo) Add(entries ...split_domain.Entry) ([]split_domain.Entry, error) {
+	m.entries = append(m.entries, entries...)
+	return entries, nil
+}
+
+type saverStub struct {
+	trxs []transaction_domain.Transaction
+	// failAfter makes saves fail once that many transactions are saved
+	failAfter int
+}
+
+func (s *saverStub) SaveTransaction(ctx context.Context, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
+	if s.failAfter > 0 && len(s.trxs) >= s.failAfter {
+		return spreadsheet.CategorySummary{}, errors.NewSpreadsheetError("quota exceeded", nil)
+	}
+	s.trxs = append(s.trxs, trx)
+	return spreadsheet.CategorySummary{}, nil
+}
+
+func newTestService() (*SplitService, *saverStub) {
+	saver := &saverStub{}
+	clock := &common.FixedClock{Time: time.Date(2025, 7, 20, 10, 0, 0, 0, time.UTC)}
+	return NewSplitService(&memoryRepo{}, saver, clock, time.UTC), saver
+}
+
+func TestSplitLast_Equal(t *testing.T) {
+	svc, _ := newTestService()
+	svc.Remember(1, transaction_domain.Transaction{TransactionDate: "2025-07-19", Amount: "300,000", Title: "Dinner", CreatedBy: "Alice"})
+
+	entry, err := svc.SplitLast(1, []string{"alice", "@bob", "carol"}, nil)
+	if err != nil {
+		t.Fatalf("split failed: %v", err)
+	}
+	if entry.PaidBy != "alice" || entry.Amount != 300000 || len(entry.Shares) != 3 || entry.Shares[1].Member != "bob" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+	if _, err := svc.SplitLast(1, []string{"alice", "bob"}, nil); err == nil {
+		t.Error("a transaction should only be split once")
+	}
+
+	balances, _ := svc.Balances(1)
+	if balances["alice"] != 200000 || balances["bob"] != -100000 || balances["carol"] != -100000 {
+		t.Errorf("unexpected balances: %v", balances)
+	}
+}
+
+func TestSplitLast_CustomShares(t *testing.T) {
+	svc, _ := newTestService()
+	svc.Remember(1, transaction_domain.Transaction{Amount: "100000", CreatedBy: "alice"})
+
+	if _, err := svc.SplitLast(1, nil, map[string]string{"alice": "20000", "bob": "50000"}); err == nil {
+		t.Error("expected error when shares do not add up")
+	}
+	entry, err := svc.SplitLast(1, nil, map[string]string{"bob": "70,000", "alice": "30000"})
+	if err != nil || entry.Shares[0] != (split_domain.Share{Member: "alice", Amount: 30000}) {
+		t.Errorf("unexpected entry: %+v, %v", entry, err)
+	}
+}
+
+func TestSplitLast_Validation(t *testing.T) {
+	svc, _ := newTestService()
+	if _, err := svc.SplitLast(1, []string{"bob"}, nil); err == nil {
+		t.Error("expected error without a remembered transaction")
+	}
+	svc.Remember(1, transaction_domain.Transaction{Amount: "1000", CreatedBy: "alice", Goal: "bali"})
+	if _, err := svc.SplitLast(1, []string{"bob"}, nil); err == nil {
+		t.Error("goal contributions must not be split")
+	}
+	svc.Remember(1, transaction_domain.Transaction{Amount: "1000", CreatedBy: "alice"})
+	if _, err := svc.SplitLast(1, []string{"bob"}, map[string]string{"bob": "1000"}); err == nil {
+		t.Error("expected error when mixing members and custom shares")
+	}
+	_, err := svc.SplitLast(1, nil, map[string]string{"bob": "lots"})
+	var msg *errors.Message
+	if !stderrors.As(err, &msg) || msg.Key != "invalid.split.invalid_share" {
+		t.Errorf("expected a localizable invalid share error, got %v", err)
+	}
+}
+
+func TestSettle(t *testing.T) {
+	svc, saver := newTestService()
+	svc.Remember(1, transaction_domain.Transaction{Amount: "300000", CreatedBy: "alice"})
+	svc.SplitLast(1, []string{"alice", "bob", "carol"}, nil)
+
+	transfers, err := svc.Settle(context.Background(), 1)
+	if err != nil || len(transfers) != 2 {
+		t.Fatalf("expected 2 transfers, got %+v, %v", transfers, err)
+	}
+	if len(saver.trxs) != 2 {
+		t.Fatalf("expected 2 transfer transactions, got %+v", saver.trxs)
+	}
+	trx := saver.trxs[0]
+	if !trx.IsTransfer() || trx.CreatedBy != "bob" || trx.DestinationName != "alice" || trx.Amount != "100000" || trx.TransactionDate != "2025-07-20" {
+		t.Errorf("unexpected transfer transaction: %+v", trx)
+	}
+
+	balances, _ := svc.Balances(1)
+	if len(balances) != 0 {
+		t.Errorf("balances should be settled, got %v", balances)
+	}
+	if transfers, _ := svc.Settle(context.Background(), 1); transfers != nil {
+		t.Errorf("nothing left to settle, got %+v", transfers)
+	}
+}
+
+func TestSettle_FailedWriteLeavesBalanceOpen(t *testing.T) {
+	svc, saver := newTestService()
+	svc.Remember(1, transaction_domain.Transaction{Amount: "300000", CreatedBy: "alice"})
+	svc.SplitLast(1, []string{"alice", "bob", "carol"}, nil)
+	saver.failAfter = 1
+
+	transfers, err := svc.Settle(context.Background(), 1)
+	if err == nil || len(transfers) != 1 {
+		t.Fatalf("expected the first transfer to be settled before the error, got %+v, %v", transfers, err)
+	}
+	if balances, _ := svc.Balances(1); len(balances) != 2 || balances["alice"] != 100000 {
+		t.Errorf("the unwritten transfer should stay owed, got %v", balances)
+	}
+
+	saver.failAfter = 0
+	transfers, err = svc.Settle(context.Background(), 1)
+	if err != nil || len(transfers) != 1 || len(saver.trxs) != 2 {
+		t.Errorf("expected the retry to write only the open transfer, got %+v, %v and %d rows", transfers, err, len(saver.trxs))
+	}
+}
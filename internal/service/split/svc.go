@@ -0,0 +1,19 @@
+package split
+
+import (
+	"context"
+	split_domain "money-tracker-bot/internal/domain/split"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+type ISplit interface {
+	// Remember keeps the last transaction saved in a chat so it can be split
+	Remember(chatID int64, trx transaction_domain.Transaction)
+	// SplitLast shares the last transaction of the chat equally between
+	// members, or by the custom amounts in shares (member → amount)
+	SplitLast(chatID int64, members []string, shares map[string]string) (split_domain.Entry, error)
+	// Balances returns the net position of every member of the chat
+	Balances(chatID int64) (map[string]int64, error)
+	// Settle computes the settlement transfers and records them
+	Settle(ctx context.Context, chatID int64) ([]split_domain.Transfer, error)
+}
@@ -5,14 +5,59 @@ package transactions
 import (
 	"context"
 	spreadsheet "money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
 	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
 	aiport "money-tracker-bot/internal/port/out/ai"
-	"os"
+	"money-tracker-bot/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type TransactionService struct {
 	DefaultAiPort      aiport.AiPort
 	SpreadsheetService SpreadsheetServicePort
+	// Categories is optional; without it the AI uses the default category list
+	Categories CategoryResolver
+	// Rules is optional; matching rules override what the AI extracted
+	Rules RuleApplier
+	// Learner is optional; it feeds the tenant's past corrections back into extraction
+	Learner CorrectionLearner
+	// Currencies is optional; without it amounts are saved in whatever
+	// currency they were paid in
+	Currencies CurrencyConverter
+	// SpreadsheetID is the spreadsheet transactions are saved to
+	SpreadsheetID string
+	// ReviewThreshold flags transactions with a field the AI rated below it
+	// for review; zero disables flagging
+	ReviewThreshold float64
+}
+
+// CorrectionLearner supplies the merchant→category corrections of a tenant as
+// prompt examples and applies them to the AI's answer
+type CorrectionLearner interface {
+	PromptExamples(chatID int64) ([]string, error)
+	Recall(chatID int64, trx *transaction_domain.Transaction) (bool, error)
+}
+
+// CurrencyConverter converts a transaction paid in another currency to the
+// base currency of the spreadsheet
+type CurrencyConverter interface {
+	Convert(chatID int64, trx *transaction_domain.Transaction) error
+}
+
+// RuleApplier runs the categorization rules of a tenant on an extracted
+// transaction, and counts the hit of the applied rule once it is saved
+type RuleApplier interface {
+	Apply(chatID int64, message string, trx *transaction_domain.Transaction) (bool, error)
+	RecordHit(chatID int64, id int) error
+}
+
+// CategoryResolver supplies the categories of a tenant to the AI prompt and
+// maps the category the AI answered with onto the tenant's categories
+type CategoryResolver interface {
+	PromptLines(chatID int64) ([]string, error)
+	Resolve(chatID int64, trx *transaction_domain.Transaction) error
 }
 
 // SpreadsheetServicePort abstracts spreadsheet operations for testability
@@ -21,33 +66,57 @@ type SpreadsheetServicePort interface {
 	GetCellValue(ctx context.Context, spreadsheetId string) error
 }
 
-func NewTransactionService(ai aiport.AiPort, sheets SpreadsheetServicePort) *TransactionService {
+func NewTransactionService(ai aiport.AiPort, sheets SpreadsheetServicePort, spreadsheetID string) *TransactionService {
 	return &TransactionService{
 		DefaultAiPort:      ai,
 		SpreadsheetService: sheets,
+		SpreadsheetID:      spreadsheetID,
 	}
 }
 
-func (t *TransactionService) SaveTransaction(trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
-	spreadsheetId := os.Getenv("GOOGLE_SPREADSHEET_ID")
-	summary, err := t.SpreadsheetService.AppendRow(context.Background(), spreadsheetId, trx)
+func (t *TransactionService) SaveTransaction(ctx context.Context, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
+	ctx, span := tracing.Start(ctx, "transactions.save")
+	summary, err := t.SpreadsheetService.AppendRow(ctx, t.SpreadsheetID, trx)
+	tracing.End(span, err)
 	if err != nil {
 		return spreadsheet.CategorySummary{}, err
 	}
+	t.recordRuleHit(ctx, trx)
 	return summary, nil
 }
 
+// recordRuleHit counts the hit of the rule applied to a saved transaction.
+// The transaction is saved either way; only the statistics can be lost.
+func (t *TransactionService) recordRuleHit(ctx context.Context, trx transaction_domain.Transaction) {
+	chatID, ok := common.TenantFrom(ctx)
+	if t.Rules == nil || !ok || trx.RuleID == 0 {
+		return
+	}
+	if err := t.Rules.RecordHit(chatID, trx.RuleID); err != nil {
+		errors.HandleError(err, "recording rule hit")
+	}
+}
+
 func (t *TransactionService) HandleImageInput(ctx context.Context, imagePath string, uploader string, aiPort aiport.AiPort) (*transaction_domain.Transaction, error) {
 	ai := t.DefaultAiPort
 	if aiPort != nil {
 		ai = aiPort
 	}
 
+	ctx, span := tracing.Start(ctx, "transactions.handle_image")
+	ctx = t.withExamples(t.withCategories(ctx))
 	trx, err := ai.ReadImageToTransaction(ctx, imagePath)
 	if err != nil {
+		tracing.End(span, err)
 		return nil, err
 	}
 	trx.CreatedBy = uploader
+	t.validate(ctx, "", trx)
+	if err := t.convert(ctx, trx); err != nil {
+		tracing.End(span, err)
+		return nil, err
+	}
+	tracing.End(span, nil)
 	return trx, nil
 }
 
@@ -57,10 +126,125 @@ func (t *TransactionService) HandleTextInput(ctx context.Context, imagePath stri
 		ai = aiPort
 	}
 
+	ctx, span := tracing.Start(ctx, "transactions.handle_text")
+	ctx = t.withExamples(t.withCategories(ctx))
 	trx, err := ai.TextToTransaction(ctx, imagePath)
 	if err != nil {
+		tracing.End(span, err)
 		return nil, err
 	}
 	trx.CreatedBy = uploader
+	t.validate(ctx, imagePath, trx)
+	if err := t.convert(ctx, trx); err != nil {
+		tracing.End(span, err)
+		return nil, err
+	}
+	tracing.End(span, nil)
 	return trx, nil
 }
+
+// validate maps the AI's answer onto the tenant's categories, corrections and
+// rules, and flags the fields it was unsure about
+func (t *TransactionService) validate(ctx context.Context, message string, trx *transaction_domain.Transaction) {
+	ctx, span := tracing.Start(ctx, "transactions.validate")
+	defer span.End()
+	t.resolveCategory(ctx, trx)
+	t.recallCorrections(ctx, trx)
+	t.applyRules(ctx, message, trx)
+	t.flagForReview(trx)
+	span.SetAttributes(attribute.Int("transaction.low_confidence_fields", len(trx.LowConfidence)))
+}
+
+// withCategories puts the categories of the request tenant in ctx for the AI prompt
+func (t *TransactionService) withCategories(ctx context.Context) context.Context {
+	chatID, ok := common.TenantFrom(ctx)
+	if t.Categories == nil || !ok {
+		return ctx
+	}
+	lines, err := t.Categories.PromptLines(chatID)
+	if err != nil {
+		errors.HandleError(err, "loading tenant categories")
+		return ctx
+	}
+	return common.WithPromptCategories(ctx, lines)
+}
+
+// withExamples puts the learned corrections of the request tenant in ctx for the AI prompt
+func (t *TransactionService) withExamples(ctx context.Context) context.Context {
+	chatID, ok := common.TenantFrom(ctx)
+	if t.Learner == nil || !ok {
+		return ctx
+	}
+	lines, err := t.Learner.PromptExamples(chatID)
+	if err != nil {
+		errors.HandleError(err, "loading learned corrections")
+		return ctx
+	}
+	return common.WithPromptExamples(ctx, lines)
+}
+
+// resolveCategory normalizes the AI category to the tenant's category and subcategory
+func (t *TransactionService) resolveCategory(ctx context.Context, trx *transaction_domain.Transaction) {
+	chatID, ok := common.TenantFrom(ctx)
+	if t.Categories == nil || !ok {
+		return
+	}
+	if err := t.Categories.Resolve(chatID, trx); err != nil {
+		errors.HandleError(err, "resolving transaction category")
+	}
+}
+
+// recallCorrections applies what the tenant taught by correcting the same
+// merchant before, in case the AI ignored the prompt examples
+func (t *TransactionService) recallCorrections(ctx context.Context, trx *transaction_domain.Transaction) {
+	chatID, ok := common.TenantFrom(ctx)
+	if t.Learner == nil || !ok {
+		return
+	}
+	recalled, err := t.Learner.Recall(chatID, trx)
+	if err != nil {
+		errors.HandleError(err, "applying learned corrections")
+	}
+	if recalled {
+		trx.MarkCertain("category")
+	}
+}
+
+// applyRules lets the tenant's rules override the AI's answer. They run after
+// category resolution so a rule's category is never remapped.
+func (t *TransactionService) applyRules(ctx context.Context, message string, trx *transaction_domain.Transaction) {
+	chatID, ok := common.TenantFrom(ctx)
+	if t.Rules == nil || !ok {
+		return
+	}
+	matched, err := t.Rules.Apply(chatID, message, trx)
+	if err != nil {
+		errors.HandleError(err, "applying categorization rules")
+	}
+	if matched {
+		trx.MarkCertain("category")
+	}
+}
+
+// convert turns a foreign amount into the base currency. Unlike the steps of
+// validate it can fail: a transaction without an exchange rate is not saved,
+// so a foreign amount never counts as rupiah.
+func (t *TransactionService) convert(ctx context.Context, trx *transaction_domain.Transaction) error {
+	chatID, ok := common.TenantFrom(ctx)
+	if t.Currencies == nil || !ok {
+		return nil
+	}
+	_, span := tracing.Start(ctx, "transactions.convert_currency")
+	err := t.Currencies.Convert(chatID, trx)
+	tracing.End(span, err)
+	return err
+}
+
+// flagForReview lists the fields the AI was unsure about, so the bot asks
+// the user to confirm the transaction instead of saving it right away
+func (t *TransactionService) flagForReview(trx *transaction_domain.Transaction) {
+	if t.ReviewThreshold <= 0 {
+		return
+	}
+	trx.LowConfidence = trx.LowConfidenceFields(t.ReviewThreshold)
+}
@@ -3,13 +3,21 @@ package transactions
 import (
 	"context"
 	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	"money-tracker-bot/internal/common"
 	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
 	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 type mockAiPort struct{}
 
-func (m *mockAiPort) GenerateContent(ctx context.Context, prompt string) error { return nil }
+func (m *mockAiPort) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return "", nil
+}
 func (m *mockAiPort) ReadImageToTransaction(ctx context.Context, imagePath string) (*transaction_domain.Transaction, error) {
 	return &transaction_domain.Transaction{Title: "mocked"}, nil
 }
@@ -18,25 +26,30 @@ func (m *mockAiPort) TextToTransaction(ctx context.Context, message string) (*tr
 }
 
 // DummySpreadsheetService implements only the methods needed for TransactionService
-type DummySpreadsheetService struct{}
+type DummySpreadsheetService struct {
+	LastSpreadsheetID string
+	Err               error
+}
 
 func (d *DummySpreadsheetService) AppendRow(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
-	return spreadsheet.CategorySummary{}, nil
+	d.LastSpreadsheetID = spreadsheetId
+	return spreadsheet.CategorySummary{}, d.Err
 }
 func (d *DummySpreadsheetService) GetCellValue(ctx context.Context, spreadsheetId string) error {
 	return nil
 }
 
 func TestSaveTransaction(t *testing.T) {
-	ts := &TransactionService{
-		DefaultAiPort:      &mockAiPort{},
-		SpreadsheetService: &DummySpreadsheetService{},
-	}
+	sheets := &DummySpreadsheetService{}
+	ts := NewTransactionService(&mockAiPort{}, sheets, "sheet-id")
 	trx := transaction_domain.Transaction{Title: "test"}
-	summary, err := ts.SaveTransaction(trx)
+	summary, err := ts.SaveTransaction(context.Background(), trx)
 	if err != nil {
 		t.Errorf("expected no error, got: %v", err)
 	}
+	if sheets.LastSpreadsheetID != "sheet-id" {
+		t.Errorf("expected the configured spreadsheet, got %q", sheets.LastSpreadsheetID)
+	}
 	// CategorySummary is empty in test but that's ok for this test
 	_ = summary // we don't need to validate the summary contents in this test
 }
@@ -56,3 +69,244 @@ func TestHandleTextInput(t *testing.T) {
 		t.Errorf("unexpected result: %v, %v", trx, err)
 	}
 }
+
+// promptRecorder returns the category the AI would pick from the prompt categories
+type promptRecorder struct {
+	categories []string
+	examples   []string
+}
+
+func (p *promptRecorder) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return "", nil
+}
+func (p *promptRecorder) ReadImageToTransaction(ctx context.Context, imagePath string) (*transaction_domain.Transaction, error) {
+	return p.TextToTransaction(ctx, imagePath)
+}
+func (p *promptRecorder) TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error) {
+	p.categories = common.PromptCategories(ctx)
+	p.examples = common.PromptExamples(ctx)
+	return &transaction_domain.Transaction{Category: "kopi"}, nil
+}
+
+type stubCategories struct{}
+
+func (s stubCategories) PromptLines(chatID int64) ([]string, error) {
+	return []string{"Eating Out › Coffee (also: kopi)"}, nil
+}
+
+func (s stubCategories) Resolve(chatID int64, trx *transaction_domain.Transaction) error {
+	if trx.Category == "kopi" {
+		trx.Category, trx.Subcategory = "Eating Out", "Coffee"
+	}
+	return nil
+}
+
+func TestHandleTextInput_TenantCategories(t *testing.T) {
+	ai := &promptRecorder{}
+	ts := &TransactionService{DefaultAiPort: ai, Categories: stubCategories{}}
+
+	trx, err := ts.HandleTextInput(common.WithTenant(context.Background(), 7), "kopi 25k", "user", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ai.categories) != 1 {
+		t.Errorf("tenant categories should reach the AI, got %v", ai.categories)
+	}
+	if trx.Category != "Eating Out" || trx.Subcategory != "Coffee" {
+		t.Errorf("category should be resolved, got %+v", trx)
+	}
+
+	ai.categories = nil
+	trx, _ = ts.HandleImageInput(context.Background(), "img.jpg", "user", nil)
+	if ai.categories != nil || trx.Category != "kopi" {
+		t.Errorf("without a tenant the defaults apply, got %v, %+v", ai.categories, trx)
+	}
+}
+
+type stubRules struct {
+	messages []string
+	hits     []int
+}
+
+func (s *stubRules) Apply(chatID int64, message string, trx *transaction_domain.Transaction) (bool, error) {
+	s.messages = append(s.messages, message)
+	if trx.Category == "Eating Out" {
+		trx.Category, trx.Subcategory = "Transportation", ""
+		trx.RuleID = 3
+		return true, nil
+	}
+	return false, nil
+}
+
+func (s *stubRules) RecordHit(chatID int64, id int) error {
+	s.hits = append(s.hits, id)
+	return nil
+}
+
+func TestHandleTextInput_RulesOverrideCategory(t *testing.T) {
+	rules := &stubRules{}
+	ts := &TransactionService{DefaultAiPort: &promptRecorder{}, Categories: stubCategories{}, Rules: rules}
+
+	trx, err := ts.HandleTextInput(common.WithTenant(context.Background(), 7), "grab kopi 25k", "user", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trx.Category != "Transportation" || trx.Subcategory != "" {
+		t.Errorf("rules should run after category resolution and win, got %+v", trx)
+	}
+	if len(rules.messages) != 1 || rules.messages[0] != "grab kopi 25k" {
+		t.Errorf("rules should see the original message, got %v", rules.messages)
+	}
+	if len(rules.hits) != 0 {
+		t.Errorf("a hit must not be counted before the transaction is saved, got %v", rules.hits)
+	}
+}
+
+func TestSaveTransaction_RecordsRuleHitOnceSaved(t *testing.T) {
+	rules := &stubRules{}
+	sheets := &DummySpreadsheetService{Err: errors.NewSpreadsheetError("quota exceeded", nil)}
+	ts := &TransactionService{SpreadsheetService: sheets, Rules: rules}
+	ctx := common.WithTenant(context.Background(), 7)
+	trx := transaction_domain.Transaction{Category: "Transportation", RuleID: 3}
+
+	if _, err := ts.SaveTransaction(ctx, trx); err == nil {
+		t.Fatal("expected the save to fail")
+	}
+	if len(rules.hits) != 0 {
+		t.Fatalf("a failed save must not count a hit, got %v", rules.hits)
+	}
+	sheets.Err = nil
+	if _, err := ts.SaveTransaction(ctx, trx); err != nil {
+		t.Fatal(err)
+	}
+	if len(rules.hits) != 1 || rules.hits[0] != 3 {
+		t.Errorf("expected a hit of rule 3, got %v", rules.hits)
+	}
+}
+
+type stubLearner struct{}
+
+func (s stubLearner) PromptExamples(chatID int64) ([]string, error) {
+	return []string{`"Kopi Kenangan" → Eating Out › Coffee`}, nil
+}
+
+func (s stubLearner) Recall(chatID int64, trx *transaction_domain.Transaction) (bool, error) {
+	trx.Category, trx.Subcategory = "Eating Out", "Snacks"
+	return true, nil
+}
+
+func TestHandleTextInput_LearnedCorrections(t *testing.T) {
+	ai := &promptRecorder{}
+	ts := &TransactionService{DefaultAiPort: ai, Categories: stubCategories{}, Learner: stubLearner{}}
+
+	trx, err := ts.HandleTextInput(common.WithTenant(context.Background(), 7), "kopi 25k", "user", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ai.examples) != 1 {
+		t.Errorf("learned examples should reach the AI, got %v", ai.examples)
+	}
+	if trx.Subcategory != "Snacks" {
+		t.Errorf("learned category should override the resolved one, got %+v", trx)
+	}
+
+	ts.Rules = &stubRules{}
+	trx, _ = ts.HandleImageInput(common.WithTenant(context.Background(), 7), "img.jpg", "user", nil)
+	if trx.Category != "Transportation" {
+		t.Errorf("rules should win over learned corrections, got %+v", trx)
+	}
+}
+
+// ratedAi answers with per-field confidence like a blurry receipt
+type ratedAi struct{}
+
+func (r ratedAi) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	return "", nil
+}
+func (r ratedAi) ReadImageToTransaction(ctx context.Context, imagePath string) (*transaction_domain.Transaction, error) {
+	return r.TextToTransaction(ctx, imagePath)
+}
+func (r ratedAi) TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error) {
+	return &transaction_domain.Transaction{
+		Category:   "Eating Out",
+		Confidence: map[string]float64{"amount": 0.4, "category": 0.5, "transaction_date": 0.95},
+	}, nil
+}
+
+func TestHandleImageInput_FlagsLowConfidence(t *testing.T) {
+	ts := &TransactionService{DefaultAiPort: ratedAi{}, ReviewThreshold: 0.7}
+	trx, err := ts.HandleImageInput(common.WithTenant(context.Background(), 7), "img.jpg", "user", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trx.LowConfidence) != 2 || trx.LowConfidence[0] != "amount" || trx.LowConfidence[1] != "category" {
+		t.Errorf("expected amount and category to be flagged, got %v", trx.LowConfidence)
+	}
+
+	// A category set by the tenant's rules is not in doubt
+	ts.Rules = &stubRules{}
+	trx, _ = ts.HandleTextInput(common.WithTenant(context.Background(), 7), "grab 25k", "user", nil)
+	if len(trx.LowConfidence) != 1 || trx.LowConfidence[0] != "amount" {
+		t.Errorf("expected only the amount to be flagged, got %v", trx.LowConfidence)
+	}
+
+	ts.ReviewThreshold = 0
+	if trx, _ = ts.HandleTextInput(context.Background(), "grab 25k", "user", nil); trx.NeedsReview() {
+		t.Error("a zero threshold should disable flagging")
+	}
+}
+
+type stubConverter struct {
+	chatIDs []int64
+}
+
+func (s *stubConverter) Convert(chatID int64, trx *transaction_domain.Transaction) error {
+	s.chatIDs = append(s.chatIDs, chatID)
+	if trx.Title == "mocked" {
+		return errors.NewValidationError("no exchange rate for USD", nil)
+	}
+	trx.OriginalAmount, trx.OriginalCurrency, trx.Amount = trx.Amount, "USD", "202500"
+	return nil
+}
+
+func TestHandleInput_ConvertsCurrency(t *testing.T) {
+	converter := &stubConverter{}
+	ts := &TransactionService{DefaultAiPort: &promptRecorder{}, Currencies: converter}
+	trx, err := ts.HandleTextInput(common.WithTenant(context.Background(), 7), "coffee $12.50", "user", nil)
+	if err != nil || trx.Amount != "202500" || trx.OriginalCurrency != "USD" {
+		t.Errorf("expected the amount to be converted, got %+v, %v", trx, err)
+	}
+
+	ts.DefaultAiPort = &mockAiPort{}
+	if _, err := ts.HandleImageInput(common.WithTenant(context.Background(), 7), "img.jpg", "user", nil); err == nil {
+		t.Error("expected a transaction without a rate to fail")
+	}
+	if _, err := ts.HandleTextInput(context.Background(), "coffee", "user", nil); err != nil {
+		t.Errorf("expected no conversion without a tenant, got %v", err)
+	}
+	if len(converter.chatIDs) != 2 || converter.chatIDs[0] != 7 {
+		t.Errorf("unexpected conversions %v", converter.chatIDs)
+	}
+}
+
+func TestTransactionService_RecordsSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+	ts := NewTransactionService(&mockAiPort{}, &DummySpreadsheetService{}, "sheet-id")
+
+	trx, err := ts.HandleTextInput(context.Background(), "coffee 25k", "user", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts.SaveTransaction(context.Background(), *trx)
+
+	spans := recorder.Ended()
+	if len(spans) != 3 || spans[0].Name() != "transactions.validate" || spans[1].Name() != "transactions.handle_text" || spans[2].Name() != "transactions.save" {
+		t.Fatalf("unexpected spans %v", spans)
+	}
+	if spans[0].Parent().SpanID() != spans[1].SpanContext().SpanID() {
+		t.Error("expected validation to be part of handling the text")
+	}
+}
@@ -9,7 +9,7 @@ import (
 
 type ITransaction interface {
 	// SaveTransactions saves the transactions to the database
-	SaveTransaction(trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error)
+	SaveTransaction(ctx context.Context, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error)
 	HandleImageInput(context.Context, string, string, aiport.AiPort) (*transaction_domain.Transaction, error)
 	HandleTextInput(context.Context, string, string, aiport.AiPort) (*transaction_domain.Transaction, error)
 }
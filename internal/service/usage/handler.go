@@ -0,0 +1,124 @@
+package usage
+
+// Package usage counts the AI tokens every tenant uses per day and enforces
+// optional daily token quotas.
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	usage_domain "money-tracker-bot/internal/domain/usage"
+	"money-tracker-bot/internal/errors"
+	"sync"
+	"time"
+)
+
+// Repository persists daily usage totals keyed by date (YYYY-MM-DD) and chat
+type Repository interface {
+	Add(date string, chatID int64, kind string, tokens usage_domain.Tokens) error
+	Day(date string) (map[int64]usage_domain.Totals, error)
+}
+
+type UsageService struct {
+	Repo     Repository
+	Clock    common.Clock
+	Location *time.Location
+	Prices   usage_domain.Pricing
+	// DailyQuota caps the tokens of every tenant per day, 0 for no cap
+	DailyQuota int
+	// Quotas overrides DailyQuota for single tenants; 0 lifts their cap
+	Quotas map[int64]int
+
+	// mu serializes the read-modify-write of the usage file
+	mu sync.Mutex
+}
+
+func NewUsageService(repo Repository, clock common.Clock, loc *time.Location, prices usage_domain.Pricing) *UsageService {
+	return &UsageService{
+		Repo:     repo,
+		Clock:    clock,
+		Location: loc,
+		Prices:   prices,
+	}
+}
+
+func (s *UsageService) Allow(ctx context.Context) error {
+	chatID, ok := common.TenantFrom(ctx)
+	if !ok {
+		// Scheduled jobs are not attributed to a chat and never capped
+		return nil
+	}
+	quota := s.quotaOf(chatID)
+	if quota == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	day, err := s.Repo.Day(s.date(0))
+	s.mu.Unlock()
+	if err != nil {
+		// Counting must not stop the bot from working
+		errors.HandleError(err, "checking AI quota")
+		return nil
+	}
+	used := day[chatID].Sum().Total()
+	if used >= quota {
+		return errors.NewQuotaError("daily AI token quota used up", nil).
+			WithContext("chat_id", chatID).
+			WithContext("used", used).
+			WithContext("quota", quota).
+			WithComponent("usage-service")
+	}
+	return nil
+}
+
+func (s *UsageService) Record(ctx context.Context, kind string, tokens usage_domain.Tokens) {
+	chatID, _ := common.TenantFrom(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.Repo.Add(s.date(0), chatID, kind, tokens); err != nil {
+		// The AI answer is still used; only the statistics are lost
+		errors.HandleError(err, "recording AI usage")
+	}
+}
+
+func (s *UsageService) Report(chatID int64) (usage_domain.Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report := usage_domain.Report{Quota: s.quotaOf(chatID), Week: usage_domain.Totals{}}
+	for daysAgo := 0; daysAgo < 7; daysAgo++ {
+		day, err := s.Repo.Day(s.date(daysAgo))
+		if err != nil {
+			return usage_domain.Report{}, err
+		}
+		if daysAgo == 0 {
+			report.Today = day[chatID]
+		}
+		report.Week = report.Week.Merge(day[chatID])
+	}
+	return report, nil
+}
+
+func (s *UsageService) Today() (map[int64]usage_domain.Totals, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Repo.Day(s.date(0))
+}
+
+func (s *UsageService) Pricing() usage_domain.Pricing {
+	return s.Prices
+}
+
+func (s *UsageService) quotaOf(chatID int64) int {
+	if quota, ok := s.Quotas[chatID]; ok {
+		return quota
+	}
+	return s.DailyQuota
+}
+
+// date returns the local date daysAgo days before today as YYYY-MM-DD
+func (s *UsageService) date(daysAgo int) string {
+	loc := s.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return s.Clock.Now().In(loc).AddDate(0, 0, -daysAgo).Format("2006-01-02")
+}
@@ -0,0 +1,119 @@
+package usage
+
+import (
+	"context"
+	"money-tracker-bot/internal/common"
+	usage_domain "money-tracker-bot/internal/domain/usage"
+	"money-tracker-bot/internal/errors"
+	"testing"
+	"time"
+)
+
+type memoryRepo struct {
+	days map[string]map[int64]usage_domain.Totals
+}
+
+func (m *memoryRepo) Add(date string, chatID int64, kind string, tokens usage_domain.Tokens) error {
+	if m.days == nil {
+		m.days = make(map[string]map[int64]usage_domain.Totals)
+	}
+	if m.days[date] == nil {
+		m.days[date] = make(map[int64]usage_domain.Totals)
+	}
+	m.days[date][chatID] = m.days[date][chatID].Merge(usage_domain.Totals{kind: tokens})
+	return nil
+}
+
+func (m *memoryRepo) Day(date string) (map[int64]usage_domain.Totals, error) {
+	return m.days[date], nil
+}
+
+func newTestService(repo *memoryRepo) (*UsageService, *common.FixedClock) {
+	clock := &common.FixedClock{Time: time.Date(2025, 3, 10, 20, 0, 0, 0, time.UTC)}
+	loc := time.FixedZone("UTC+7", 7*3600)
+	return NewUsageService(repo, clock, loc, usage_domain.Pricing{PromptPerMillion: 0.1, ResponsePerMillion: 0.4}), clock
+}
+
+func TestRecord_AttributesTenantAndLocalDate(t *testing.T) {
+	repo := &memoryRepo{}
+	svc, _ := newTestService(repo)
+
+	svc.Record(common.WithTenant(context.Background(), 42), usage_domain.KindImage, usage_domain.Tokens{Requests: 1, Prompt: 1200, Response: 150})
+	svc.Record(context.Background(), usage_domain.KindContent, usage_domain.Tokens{Requests: 1, Prompt: 100, Response: 300})
+
+	// 20:00 UTC is already the next day in UTC+7
+	day := repo.days["2025-03-11"]
+	if got := day[42][usage_domain.KindImage]; got.Prompt != 1200 || got.Response != 150 {
+		t.Errorf("unexpected usage of chat 42: %+v", day[42])
+	}
+	if got := day[0][usage_domain.KindContent]; got.Requests != 1 {
+		t.Errorf("expected untenanted usage under chat 0, got %+v", day[0])
+	}
+}
+
+func TestAllow_EnforcesDailyQuota(t *testing.T) {
+	repo := &memoryRepo{}
+	svc, clock := newTestService(repo)
+	svc.DailyQuota = 1000
+	svc.Quotas = map[int64]int{7: 0}
+
+	ctx := common.WithTenant(context.Background(), 42)
+	if err := svc.Allow(ctx); err != nil {
+		t.Fatalf("unexpected error before any usage: %v", err)
+	}
+	svc.Record(ctx, usage_domain.KindText, usage_domain.Tokens{Requests: 1, Prompt: 900, Response: 100})
+
+	err := svc.Allow(ctx)
+	appErr, ok := err.(*errors.AppError)
+	if !ok || appErr.Code != errors.ErrCodeQuota {
+		t.Fatalf("expected quota error, got %v", err)
+	}
+
+	unlimited := common.WithTenant(context.Background(), 7)
+	svc.Record(unlimited, usage_domain.KindText, usage_domain.Tokens{Prompt: 5000})
+	if err := svc.Allow(unlimited); err != nil {
+		t.Errorf("a 0 override should lift the quota, got %v", err)
+	}
+	if err := svc.Allow(context.Background()); err != nil {
+		t.Errorf("untenanted calls should not be capped, got %v", err)
+	}
+
+	clock.Advance(24 * time.Hour)
+	if err := svc.Allow(ctx); err != nil {
+		t.Errorf("the quota should reset the next day, got %v", err)
+	}
+}
+
+func TestReport(t *testing.T) {
+	repo := &memoryRepo{}
+	svc, clock := newTestService(repo)
+	svc.DailyQuota = 5000
+	ctx := common.WithTenant(context.Background(), 42)
+
+	clock.Time = time.Date(2025, 3, 1, 3, 0, 0, 0, time.UTC)
+	svc.Record(ctx, usage_domain.KindText, usage_domain.Tokens{Requests: 1, Prompt: 999})
+	clock.Time = time.Date(2025, 3, 8, 3, 0, 0, 0, time.UTC)
+	svc.Record(ctx, usage_domain.KindImage, usage_domain.Tokens{Requests: 1, Prompt: 1000, Response: 100})
+	clock.Time = time.Date(2025, 3, 10, 3, 0, 0, 0, time.UTC)
+	svc.Record(ctx, usage_domain.KindImage, usage_domain.Tokens{Requests: 1, Prompt: 2000, Response: 200})
+	svc.Record(common.WithTenant(context.Background(), 7), usage_domain.KindText, usage_domain.Tokens{Requests: 1, Prompt: 50})
+
+	report, err := svc.Report(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := report.Today.Sum(); got.Requests != 1 || got.Total() != 2200 {
+		t.Errorf("unexpected usage today: %+v", got)
+	}
+	if got := report.Week.Sum(); got.Requests != 2 || got.Total() != 3300 {
+		t.Errorf("usage older than seven days should be left out of the week: %+v", got)
+	}
+	if report.Quota != 5000 || report.Remaining() != 2800 {
+		t.Errorf("unexpected quota %d with %d left", report.Quota, report.Remaining())
+	}
+
+	today, err := svc.Today()
+	if err != nil || len(today) != 2 {
+		t.Errorf("expected usage of two chats today, got %+v, %v", today, err)
+	}
+}
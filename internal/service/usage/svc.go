@@ -0,0 +1,21 @@
+package usage
+
+import (
+	"context"
+	usage_domain "money-tracker-bot/internal/domain/usage"
+)
+
+type IUsage interface {
+	// Allow fails with a QUOTA_EXCEEDED error once the tenant of ctx used up
+	// its daily token quota
+	Allow(ctx context.Context) error
+	// Record adds the tokens of an AI call to the daily totals of the tenant
+	// of ctx; calls without a tenant are counted under chat 0
+	Record(ctx context.Context, kind string, tokens usage_domain.Tokens)
+	// Report returns today's and this week's usage of a chat with its quota
+	Report(chatID int64) (usage_domain.Report, error)
+	// Today returns today's usage of every chat
+	Today() (map[int64]usage_domain.Totals, error)
+	// Pricing returns the token prices costs are estimated with
+	Pricing() usage_domain.Pricing
+}
@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"context"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	aiport "money-tracker-bot/internal/port/out/ai"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedAI is an aiport.AiPort recording a span for every call of one provider
+type tracedAI struct {
+	provider string
+	next     aiport.AiPort
+}
+
+// TraceAI records an "ai.<operation>" span, such as "ai.image", for every
+// call to an AI provider
+func TraceAI(provider string, port aiport.AiPort) aiport.AiPort {
+	return &tracedAI{provider: provider, next: port}
+}
+
+func (a *tracedAI) start(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return Start(ctx, "ai."+operation, attribute.String("ai.provider", a.provider))
+}
+
+func (a *tracedAI) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	ctx, span := a.start(ctx, "generate")
+	text, err := a.next.GenerateContent(ctx, prompt)
+	End(span, err)
+	return text, err
+}
+
+func (a *tracedAI) ReadImageToTransaction(ctx context.Context, imgPath string) (*transaction_domain.Transaction, error) {
+	ctx, span := a.start(ctx, "image")
+	trx, err := a.next.ReadImageToTransaction(ctx, imgPath)
+	End(span, err)
+	return trx, err
+}
+
+func (a *tracedAI) TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error) {
+	ctx, span := a.start(ctx, "text")
+	trx, err := a.next.TextToTransaction(ctx, message)
+	End(span, err)
+	return trx, err
+}
@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// stubAI remembers the span of the last call's context
+type stubAI struct {
+	err  error
+	span trace.SpanContext
+}
+
+func (s *stubAI) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	s.span = trace.SpanContextFromContext(ctx)
+	return "", s.err
+}
+
+func (s *stubAI) ReadImageToTransaction(ctx context.Context, imgPath string) (*transaction_domain.Transaction, error) {
+	s.span = trace.SpanContextFromContext(ctx)
+	return nil, s.err
+}
+
+func (s *stubAI) TextToTransaction(ctx context.Context, message string) (*transaction_domain.Transaction, error) {
+	s.span = trace.SpanContextFromContext(ctx)
+	return &transaction_domain.Transaction{}, s.err
+}
+
+func TestTraceAI(t *testing.T) {
+	recorder := record(t)
+	stub := &stubAI{}
+	ai := TraceAI("gemini", stub)
+
+	ai.TextToTransaction(context.Background(), "coffee 25k")
+	stub.err = errors.NewGeminiTimeoutError("request timed out", nil)
+	ai.ReadImageToTransaction(context.Background(), "receipt.jpg")
+	ai.GenerateContent(context.Background(), "advice")
+
+	spans := recorder.Ended()
+	if len(spans) != 3 || spans[0].Name() != "ai.text" || spans[1].Name() != "ai.image" || spans[2].Name() != "ai.generate" {
+		t.Fatalf("expected a span per call, got %v", spans)
+	}
+	if stub.span.SpanID() != spans[2].SpanContext().SpanID() {
+		t.Error("expected the provider to get the span in its context")
+	}
+	if spans[0].Attributes()[0].Value.AsString() != "gemini" || spans[0].Status().Code != codes.Unset {
+		t.Errorf("unexpected text span %v %+v", spans[0].Attributes(), spans[0].Status())
+	}
+	if spans[1].Status().Code != codes.Error {
+		t.Errorf("expected the failed call to be marked, got %+v", spans[1].Status())
+	}
+}
@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	category_domain "money-tracker-bot/internal/domain/category"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+)
+
+// tracedSheets is a spreadsheet.Service recording a span for every call
+type tracedSheets struct {
+	next spreadsheet.Service
+}
+
+// TraceSheets records a "sheets.<operation>" span, such as
+// "sheets.append_row", for every call to Google Sheets
+func TraceSheets(service spreadsheet.Service) spreadsheet.Service {
+	return &tracedSheets{next: service}
+}
+
+func (s *tracedSheets) AppendRow(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
+	ctx, span := Start(ctx, "sheets.append_row")
+	summary, err := s.next.AppendRow(ctx, spreadsheetId, trx)
+	End(span, err)
+	return summary, err
+}
+
+func (s *tracedSheets) ListCategorySummaries(ctx context.Context, spreadsheetId string) ([]spreadsheet.CategorySummary, error) {
+	ctx, span := Start(ctx, "sheets.list_category_summaries")
+	summaries, err := s.next.ListCategorySummaries(ctx, spreadsheetId)
+	End(span, err)
+	return summaries, err
+}
+
+func (s *tracedSheets) ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error) {
+	ctx, span := Start(ctx, "sheets.list_transactions")
+	trxs, err := s.next.ListTransactions(ctx, spreadsheetId)
+	End(span, err)
+	return trxs, err
+}
+
+func (s *tracedSheets) MigrateCategory(ctx context.Context, spreadsheetId string, m category_domain.Migration) (int, error) {
+	ctx, span := Start(ctx, "sheets.migrate_category")
+	rows, err := s.next.MigrateCategory(ctx, spreadsheetId, m)
+	End(span, err)
+	return rows, err
+}
+
+func (s *tracedSheets) Recategorize(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction, to category_domain.Ref) (bool, error) {
+	ctx, span := Start(ctx, "sheets.recategorize")
+	moved, err := s.next.Recategorize(ctx, spreadsheetId, trx, to)
+	End(span, err)
+	return moved, err
+}
+
+func (s *tracedSheets) GetCellValue(ctx context.Context, spreadsheetId string) error {
+	ctx, span := Start(ctx, "sheets.get_cell_value")
+	err := s.next.GetCellValue(ctx, spreadsheetId)
+	End(span, err)
+	return err
+}
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"money-tracker-bot/internal/adapters/google/spreadsheet"
+	category_domain "money-tracker-bot/internal/domain/category"
+	transaction_domain "money-tracker-bot/internal/domain/transactions"
+	"money-tracker-bot/internal/errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+type stubSheets struct {
+	err error
+}
+
+func (s stubSheets) AppendRow(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction) (spreadsheet.CategorySummary, error) {
+	return spreadsheet.CategorySummary{}, s.err
+}
+
+func (s stubSheets) ListCategorySummaries(ctx context.Context, spreadsheetId string) ([]spreadsheet.CategorySummary, error) {
+	return nil, s.err
+}
+
+func (s stubSheets) ListTransactions(ctx context.Context, spreadsheetId string) ([]transaction_domain.Transaction, error) {
+	return nil, s.err
+}
+
+func (s stubSheets) MigrateCategory(ctx context.Context, spreadsheetId string, m category_domain.Migration) (int, error) {
+	return 0, s.err
+}
+
+func (s stubSheets) Recategorize(ctx context.Context, spreadsheetId string, trx transaction_domain.Transaction, to category_domain.Ref) (bool, error) {
+	return false, s.err
+}
+
+func (s stubSheets) GetCellValue(ctx context.Context, spreadsheetId string) error {
+	return s.err
+}
+
+func TestTraceSheets(t *testing.T) {
+	recorder := record(t)
+	sheets := TraceSheets(stubSheets{err: errors.NewCircuitOpenError("sheets is unavailable, skipping the call", nil)})
+	ctx := context.Background()
+
+	sheets.AppendRow(ctx, "sheet", transaction_domain.Transaction{})
+	sheets.ListCategorySummaries(ctx, "sheet")
+	sheets.ListTransactions(ctx, "sheet")
+	sheets.MigrateCategory(ctx, "sheet", category_domain.Migration{})
+	sheets.Recategorize(ctx, "sheet", transaction_domain.Transaction{}, category_domain.Ref{})
+	sheets.GetCellValue(ctx, "sheet")
+
+	want := []string{"sheets.append_row", "sheets.list_category_summaries", "sheets.list_transactions", "sheets.migrate_category", "sheets.recategorize", "sheets.get_cell_value"}
+	spans := recorder.Ended()
+	if len(spans) != len(want) {
+		t.Fatalf("expected %d spans, got %d", len(want), len(spans))
+	}
+	for i, span := range spans {
+		if span.Name() != want[i] || span.Status().Code != codes.Error {
+			t.Errorf("expected failed span %s, got %s %+v", want[i], span.Name(), span.Status())
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Package tracing records OpenTelemetry spans for each stage of the message
+// pipeline, such as the photo download, the AI call, validation and the
+// spreadsheet append, so a slow message shows which stage took the time.
+package tracing
+
+import (
+	"context"
+	"io"
+	"money-tracker-bot/internal/errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporters that Setup accepts
+const (
+	// ExporterNone records no spans
+	ExporterNone = "none"
+	// ExporterStdout writes every span as JSON, for local debugging
+	ExporterStdout = "stdout"
+	// ExporterOTLP sends spans over OTLP/HTTP to the collector configured by
+	// the standard OTEL_EXPORTER_OTLP_* variables
+	ExporterOTLP = "otlp"
+)
+
+// ServiceName identifies the bot in traces
+const ServiceName = "money-tracker-bot"
+
+// Setup makes a tracer provider sending spans to exporter the global one and
+// returns a function flushing and stopping it. An empty exporter is
+// ExporterNone, which leaves the no-op provider in place.
+func Setup(ctx context.Context, exporter string, w io.Writer) (func(context.Context) error, error) {
+	var exp sdktrace.SpanExporter
+	var err error
+	switch strings.ToLower(strings.TrimSpace(exporter)) {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(w))
+	case ExporterOTLP:
+		exp, err = otlptracehttp.New(ctx)
+	default:
+		return nil, errors.NewConfigError("unknown trace exporter, expected none, stdout or otlp", nil).
+			WithContext("exporter", exporter).
+			WithComponent("tracing")
+	}
+	if err != nil {
+		return nil, errors.NewConfigError("failed to create trace exporter", err).
+			WithContext("exporter", exporter).
+			WithComponent("tracing")
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", ServiceName)))
+	if err != nil {
+		return nil, errors.NewConfigError("failed to describe the service for traces", err).
+			WithComponent("tracing")
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// Start begins a span for a pipeline stage, such as "gemini.generate", as a
+// child of the span in ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(ServiceName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends span, marking it failed when err is not nil. Spans get the code
+// and message of an AppError but not its cause, which may quote URLs or
+// responses holding secrets.
+func End(span trace.Span, err error) {
+	if err != nil {
+		setError(span, err)
+	}
+	span.End()
+}
+
+// Fail marks the span in ctx failed, for errors a stage handles instead of
+// returning, such as one it replies to the user about
+func Fail(ctx context.Context, err error, attrs ...attribute.KeyValue) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attrs...)
+	setError(span, err)
+}
+
+func setError(span trace.Span, err error) {
+	message := err.Error()
+	if appErr, ok := err.(*errors.AppError); ok {
+		message = appErr.Message
+		span.SetAttributes(attribute.String("error.code", appErr.Code))
+	}
+	span.SetStatus(codes.Error, message)
+}
@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"money-tracker-bot/internal/errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// record makes a tracer provider recording finished spans the global one
+// for the duration of the test
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestStartEnd(t *testing.T) {
+	recorder := record(t)
+
+	ctx, parent := Start(context.Background(), "telegram.update")
+	_, child := Start(ctx, "sheets.append_row")
+	End(child, errors.NewSpreadsheetError("failed to insert data to sheet", fmt.Errorf("https://sheets.example/?key=secret")))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 || spans[0].Name() != "sheets.append_row" || spans[0].Parent().SpanID() != spans[1].SpanContext().SpanID() {
+		t.Fatalf("expected a child span of the update, got %v", spans)
+	}
+	if status := spans[0].Status(); status.Code != codes.Error || status.Description != "failed to insert data to sheet" {
+		t.Errorf("expected the AppError message without its cause, got %+v", status)
+	}
+	if attrs := spans[0].Attributes(); len(attrs) != 1 || attrs[0].Value.AsString() != errors.ErrCodeSpreadsheet {
+		t.Errorf("expected the error code, got %v", attrs)
+	}
+	if spans[1].Status().Code != codes.Unset {
+		t.Errorf("a successful span should not be marked failed, got %+v", spans[1].Status())
+	}
+}
+
+func TestSetup(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	if _, err := Setup(context.Background(), "zipkin", nil); err == nil {
+		t.Error("expected error for an unknown exporter")
+	}
+	shutdown, err := Setup(context.Background(), "", nil)
+	if err != nil || shutdown(context.Background()) != nil {
+		t.Errorf("expected tracing to be off by default, got %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	shutdown, err = Setup(context.Background(), ExporterStdout, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := Start(context.Background(), "gemini.generate")
+	End(span, nil)
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); !strings.Contains(out, `"Name":"gemini.generate"`) || !strings.Contains(out, ServiceName) {
+		t.Errorf("expected the span on stdout, got %s", out)
+	}
+}
+
+func TestFail(t *testing.T) {
+	recorder := record(t)
+	ctx, span := Start(context.Background(), "telegram.update")
+	Fail(ctx, errors.NewGeminiError("no transaction in response", nil), attribute.String("correlation_id", "9f2c41d0"))
+	span.End()
+	Fail(context.Background(), fmt.Errorf("without a span"))
+
+	ended := recorder.Ended()[0]
+	if ended.Status().Code != codes.Error || ended.Status().Description != "no transaction in response" {
+		t.Errorf("expected the span to be marked failed, got %+v", ended.Status())
+	}
+	if attrs := ended.Attributes(); len(attrs) != 2 || attrs[0].Value.AsString() != "9f2c41d0" {
+		t.Errorf("expected the correlation ID and error code, got %v", attrs)
+	}
+}